@@ -1,15 +1,56 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
 	"github.com/swaggo/echo-swagger"
 	"github.com/zercle/template-go-echo/docs"
+	audithandler "github.com/zercle/template-go-echo/internal/audit/handler"
+	auditrepository "github.com/zercle/template-go-echo/internal/audit/repository"
+	auditusecase "github.com/zercle/template-go-echo/internal/audit/usecase"
 	"github.com/zercle/template-go-echo/internal/config"
+	idempotencydomain "github.com/zercle/template-go-echo/internal/idempotency/domain"
+	idempotencyrepository "github.com/zercle/template-go-echo/internal/idempotency/repository"
+	idempotencyusecase "github.com/zercle/template-go-echo/internal/idempotency/usecase"
 	"github.com/zercle/template-go-echo/internal/infrastructure"
+	"github.com/zercle/template-go-echo/internal/infrastructure/cache"
+	"github.com/zercle/template-go-echo/internal/infrastructure/concurrency"
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+	"github.com/zercle/template-go-echo/internal/infrastructure/logging"
+	"github.com/zercle/template-go-echo/internal/infrastructure/mail"
+	"github.com/zercle/template-go-echo/internal/infrastructure/tracing"
+	jobsdomain "github.com/zercle/template-go-echo/internal/jobs/domain"
+	jobshandler "github.com/zercle/template-go-echo/internal/jobs/handler"
+	jobsrepository "github.com/zercle/template-go-echo/internal/jobs/repository"
+	jobsusecase "github.com/zercle/template-go-echo/internal/jobs/usecase"
 	"github.com/zercle/template-go-echo/internal/middleware"
+	outboxrepository "github.com/zercle/template-go-echo/internal/outbox/repository"
+	outboxusecase "github.com/zercle/template-go-echo/internal/outbox/usecase"
+	ratelimitdomain "github.com/zercle/template-go-echo/internal/ratelimit/domain"
+	ratelimitrepository "github.com/zercle/template-go-echo/internal/ratelimit/repository"
+	schedulerhandler "github.com/zercle/template-go-echo/internal/scheduler/handler"
+	schedulerrepository "github.com/zercle/template-go-echo/internal/scheduler/repository"
+	schedulerusecase "github.com/zercle/template-go-echo/internal/scheduler/usecase"
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/internal/user/handler"
+	"github.com/zercle/template-go-echo/internal/user/repository"
+	"github.com/zercle/template-go-echo/internal/user/usecase"
+	webhookhandler "github.com/zercle/template-go-echo/internal/webhook/handler"
+	webhookrepository "github.com/zercle/template-go-echo/internal/webhook/repository"
+	webhookusecase "github.com/zercle/template-go-echo/internal/webhook/usecase"
+	"github.com/zercle/template-go-echo/sql/migrations"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 // @title Go Echo Template API
@@ -33,12 +74,114 @@ import (
 // @description Type "Bearer" followed by a space and JWT token.
 
 func main() {
+	// Run the migrate subcommand instead of the server
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(os.Args[2:]))
+	}
+
 	// Initialize Swagger documentation
 	docs.SwaggerInfo.Host = "localhost:8080"
 
 	// Load configuration
 	cfg := config.Load()
 
+	// Log at the configured levels and format
+	if err := logging.SetDefault(&cfg.Log, os.Stderr); err != nil {
+		log.Fatalf("invalid log configuration: %v", err)
+	}
+
+	// Export traces as configured, and propagate W3C trace context and baggage
+	tracerProvider, shutdownTracing, err := tracing.New(context.Background(), &cfg.Tracing)
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+	otel.SetTracerProvider(tracerProvider)
+	propagator := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	otel.SetTextMapPropagator(propagator)
+
+	// Register metrics with a registry of our own, served at the metrics route
+	metricsRegistry := infrastructure.NewMetricsRegistry()
+
+	// Connect to database
+	db, err := database.New(&cfg.Database, database.WithQueryMetrics(database.NewQueryMetrics(metricsRegistry)))
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	defer db.Close()
+	if err := db.RegisterMetrics(metricsRegistry); err != nil {
+		log.Fatalf("failed to register database metrics: %v", err)
+	}
+
+	// Bring the schema up to date when enabled; otherwise /ready fails until
+	// `migrate up` has run. Replicas starting together take turns on a lock.
+	migrator, err := database.NewMigrator(db, migrations.FS, database.WithLockTimeout(cfg.Database.MigrationLockTimeout))
+	if err != nil {
+		log.Fatalf("failed to load migrations: %v", err)
+	}
+	if cfg.Database.MigrateOnStartup {
+		if err := migrator.Up(context.Background()); err != nil {
+			log.Fatalf("failed to migrate database: %v", err)
+		}
+	}
+
+	// Prepare the sqlc queries once the schema is current
+	if cfg.Database.PreparedStatements {
+		if err := db.PrepareQueries(context.Background()); err != nil {
+			log.Fatalf("failed to prepare queries: %v", err)
+		}
+	}
+
+	// Connect to Redis or Valkey when configured. The connection is made lazily, so an
+	// unreachable server fails cache and rate limit calls rather than startup.
+	var redisClient redis.UniversalClient
+	if cfg.Redis.Addr != "" {
+		client := redis.NewClient(&redis.Options{
+			Addr:     cfg.Redis.Addr,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+		defer client.Close()
+		redisClient = client
+	}
+
+	// Limit request rates per policy when enabled, counting in memory or, shared by
+	// all instances, in Redis; the memory store drops idle keys itself
+	policies, err := ratelimitdomain.ParsePolicies(cfg.RateLimit.Policies)
+	if err != nil {
+		log.Fatalf("invalid RATE_LIMIT_POLICIES: %v", err)
+	}
+	var rateLimitStore ratelimitdomain.Store = ratelimitrepository.NewMemoryStore()
+	if cfg.RateLimit.Store == ratelimitdomain.StoreRedis && redisClient != nil {
+		rateLimitStore = ratelimitrepository.NewRedisStore(redisClient)
+	}
+	rateLimit := func(name string) echo.MiddlewareFunc {
+		policy, ok := policies[name]
+		if !cfg.RateLimit.Enabled || !ok {
+			return func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+		}
+		policy.DryRun = policy.DryRun || cfg.RateLimit.DryRun
+		return middleware.RateLimit(rateLimitStore, policy)
+	}
+
+	// Cap the requests in flight per route class when enabled, adapting the limits to
+	// latency; health checks and admin endpoints are never shed
+	concurrencyLimiters := make(map[string]*concurrency.Limiter)
+	concurrencyMetrics := concurrency.NewMetrics(metricsRegistry)
+	if cfg.Concurrency.Enabled {
+		for _, class := range []string{middleware.RouteClassRead, middleware.RouteClassWrite} {
+			var algorithm concurrency.Algorithm = concurrency.NewGradient(cfg.Concurrency.MinLimit, cfg.Concurrency.MaxLimit)
+			if cfg.Concurrency.Algorithm == concurrency.AlgorithmAIMD {
+				algorithm = concurrency.NewAIMD(cfg.Concurrency.MinLimit, cfg.Concurrency.MaxLimit, cfg.Concurrency.LatencyTarget)
+			}
+			concurrencyLimiters[class] = concurrency.NewLimiter(class, algorithm,
+				concurrency.WithInitialLimit(cfg.Concurrency.InitialLimit),
+				concurrency.WithQueueSize(cfg.Concurrency.QueueSize),
+				concurrency.WithQueueTimeout(cfg.Concurrency.QueueTimeout),
+				concurrency.WithMetrics(concurrencyMetrics),
+			)
+		}
+	}
+
 	// Create Echo instance
 	e := echo.New()
 
@@ -47,17 +190,203 @@ func main() {
 
 	// Register middleware
 	e.Use(middleware.RequestID())
+	e.Use(middleware.Tracing(tracerProvider, propagator))
+	e.Use(middleware.RequestContext())
+	e.Use(middleware.ReadYourWrites())
 	e.Use(middleware.RequestLogger())
-	e.Use(middleware.Timeout(30 * time.Second))
+	e.Use(middleware.Metrics(metricsRegistry))
+	e.Use(middleware.ConcurrencyLimit(concurrencyLimiters, cfg.Concurrency.RetryAfter,
+		"/health", "/ready", "/live", cfg.Metrics.Path, "/swagger/", "/api/v1/admin/"))
+	e.Use(middleware.Timeout(30*time.Second, handler.StreamingRoutes...))
 	e.Use(middleware.CORS())
 	e.Use(middleware.SecurityHeaders())
+	e.Use(rateLimit(ratelimitdomain.PolicyGlobal))
+
+	// Register health check routes; /ready needs the database and the expected schema
+	infrastructure.RegisterHealthRoutes(e,
+		infrastructure.ReadyCheck{Name: "database", Check: db.Health},
+		infrastructure.ReadyCheck{Name: "schema", Check: migrator.CheckVersion},
+	)
+	if cfg.Metrics.Enabled {
+		infrastructure.RegisterMetricsRoute(e, cfg.Metrics.Path, metricsRegistry)
+	}
+
+	// Wire audit module
+	auditRepo := auditrepository.New(db.Querier(), db.DBTX())
+	auditRecorder := auditusecase.NewAsyncRecorder(auditRepo,
+		auditusecase.WithBufferSize(cfg.Audit.BufferSize),
+		auditusecase.WithBatchSize(cfg.Audit.BatchSize),
+		auditusecase.WithFlushInterval(cfg.Audit.FlushInterval),
+	)
+	audithandler.New(auditusecase.New(auditRepo)).RegisterRoutes(e, &cfg.JWT)
+
+	// Store Idempotency-Key responses in memory or, shared by all instances, in the
+	// database; the memory store drops expired keys itself
+	var idempotencyStore idempotencydomain.Store = idempotencyrepository.NewMemoryStore()
+	if cfg.Idempotency.Store == idempotencydomain.StoreDatabase {
+		idempotencyStore = idempotencyrepository.New(db.Querier())
+	}
 
-	// Register health check routes
-	infrastructure.RegisterHealthRoutes(e)
+	// Cache the user and session lookups of authenticated requests when enabled
+	userCache, err := cache.New(&cfg.Cache, redisClient)
+	if err != nil {
+		log.Fatalf("failed to create cache: %v", err)
+	}
+
+	// Wire user module
+	var userRepo domain.UserRepository = repository.New(db.Querier(), db.DBTX())
+	if userCache != nil {
+		userRepo = repository.NewCached(userRepo, userCache,
+			repository.WithCacheTTL(cfg.Cache.TTL),
+			repository.WithNegativeCacheTTL(cfg.Cache.NegativeTTL),
+		)
+	}
+	userRepo = repository.NewTraced(userRepo, tracerProvider)
+	userOpts := []usecase.Option{
+		usecase.WithDeletionGracePeriod(cfg.Account.DeletionGracePeriod),
+		usecase.WithAuditRecorder(auditRecorder),
+		usecase.WithTxManager(db.TxManager()),
+		usecase.WithMetrics(usecase.NewMetrics(metricsRegistry)),
+	}
+	// Invites for generated passwords are emailed by the job workers
+	jobRepo := jobsrepository.New(db.Querier(), db.DBTX())
+	jobUsecase := jobsusecase.New(jobRepo)
+	if cfg.Mail.SMTPAddr != "" {
+		userOpts = append(userOpts, usecase.WithInviteSender(usecase.NewQueuedInviteSender(jobUsecase)))
+	}
+	var userUsecase domain.UserUsecase = usecase.New(userRepo, cfg.JWT.TTL, userOpts...)
+	userUsecase = usecase.NewTraced(userUsecase, tracerProvider)
+	handler.New(userUsecase,
+		handler.WithIdempotency(middleware.Idempotency(idempotencyStore, &cfg.Idempotency)),
+		handler.WithRateLimit(rateLimit(ratelimitdomain.PolicyAuth)),
+	).RegisterRoutes(e, &cfg.JWT)
+
+	// Wire scheduler module; jobs run here unless a separate worker runs them
+	runRepo := schedulerrepository.New(db.Querier(), db.DBTX())
+	schedulerhandler.New(schedulerusecase.New(runRepo)).RegisterRoutes(e, &cfg.JWT)
+
+	// Wire webhook module
+	webhookRepo := webhookrepository.New(db.Querier(), db.DBTX())
+	webhookhandler.New(webhookusecase.New(webhookRepo)).RegisterRoutes(e, &cfg.JWT)
+
+	// Wire job queue module
+	jobshandler.New(jobUsecase).RegisterRoutes(e, &cfg.JWT)
+
+	// Background work stops once the server has drained. It reads from the
+	// primary, as relays and claims must not act on rows lagging behind it.
+	backgroundCtx, stopBackground := context.WithCancel(database.WithPrimary(context.Background()))
+	defer stopBackground()
+
+	// Apply the cache invalidations of other instances to the local cache
+	if broadcast, ok := userCache.(*cache.Broadcast); ok {
+		go broadcast.Run(backgroundCtx)
+	}
+
+	// Publish domain events written to the outbox and queue them as webhook deliveries
+	if cfg.Outbox.RelayEnabled {
+		publisher := outboxusecase.MultiPublisher{outboxusecase.LogPublisher{}, webhookusecase.NewDispatcher(webhookRepo)}
+		relay := outboxusecase.NewRelay(outboxrepository.New(db.Querier()), publisher,
+			outboxusecase.WithPollInterval(cfg.Outbox.PollInterval),
+			outboxusecase.WithBatchSize(cfg.Outbox.BatchSize),
+			outboxusecase.WithRetention(cfg.Outbox.Retention),
+			outboxusecase.WithLocker(schedulerrepository.NewLocker(db.GetConn(), db.Dialect())),
+		)
+		go relay.Run(backgroundCtx)
+	}
+	if cfg.Webhook.DeliveryEnabled {
+		deliverer := webhookusecase.NewDeliverer(webhookRepo,
+			webhookusecase.WithPollInterval(cfg.Webhook.PollInterval),
+			webhookusecase.WithRequestTimeout(cfg.Webhook.Timeout),
+			webhookusecase.WithConcurrency(cfg.Webhook.Concurrency),
+			webhookusecase.WithMaxAttempts(cfg.Webhook.MaxAttempts),
+			webhookusecase.WithDisableAfter(cfg.Webhook.DisableAfter),
+		)
+		go deliverer.Run(backgroundCtx)
+	}
+
+	// Run maintenance jobs such as expired session cleanup and account purge
+	var background sync.WaitGroup
+	if cfg.Scheduler.Enabled {
+		scheduler := schedulerusecase.NewScheduler(
+			schedulerusecase.WithLocker(schedulerrepository.NewLocker(db.GetConn(), db.Dialect())),
+			schedulerusecase.WithHistory(runRepo),
+		)
+		jobs := append(usecase.ScheduledJobs(userUsecase, cfg.Account.SessionCleanupSchedule, "@every "+cfg.Account.PurgeInterval.String()),
+			schedulerusecase.HistoryCleanupJob(runRepo, cfg.Scheduler.HistoryRetention),
+			jobsusecase.CleanupJob(jobRepo, cfg.Jobs.Retention))
+		if cfg.Idempotency.Store == idempotencydomain.StoreDatabase {
+			jobs = append(jobs, idempotencyusecase.CleanupJob(idempotencyStore))
+		}
+		if err := scheduler.Register(jobs...); err != nil {
+			log.Fatalf("failed to register scheduled jobs: %v", err)
+		}
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			scheduler.Run(backgroundCtx)
+		}()
+	}
+
+	// Run queued background jobs; only password invites are queued, and only
+	// when mail is configured, so the worker does not start without a handler
+	var jobHandlers []jobsdomain.Handler
+	if cfg.Mail.SMTPAddr != "" {
+		jobHandlers = append(jobHandlers, usecase.PasswordInviteHandler(userRepo, mail.NewSMTP(&cfg.Mail)))
+	}
+	if cfg.Jobs.WorkerEnabled && len(jobHandlers) == 0 {
+		log.Printf("no queued job handlers registered, job worker not started")
+	}
+	if cfg.Jobs.WorkerEnabled && len(jobHandlers) > 0 {
+		worker := jobsusecase.NewWorker(jobRepo,
+			jobsusecase.WithPollInterval(cfg.Jobs.PollInterval),
+			jobsusecase.WithConcurrency(cfg.Jobs.Concurrency),
+		)
+		if err := worker.Register(jobHandlers...); err != nil {
+			log.Fatalf("failed to register job handlers: %v", err)
+		}
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			worker.Run(backgroundCtx)
+		}()
+	}
 
 	// Register Swagger documentation route
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
 	// Start server
-	log.Fatal(e.Start(cfg.Server.Address))
+	go func() {
+		if err := e.Start(cfg.Server.Address); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	}()
+
+	// Wait for a shutdown signal, then drain in-flight requests, queued audit events and spans
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		log.Printf("failed to shut down server: %v", err)
+	}
+	stopBackground()
+	backgroundDone := make(chan struct{})
+	go func() {
+		background.Wait()
+		close(backgroundDone)
+	}()
+	select {
+	case <-backgroundDone:
+	case <-shutdownCtx.Done():
+		log.Printf("background jobs still running at shutdown")
+	}
+	if err := auditRecorder.Close(shutdownCtx); err != nil {
+		log.Printf("failed to flush audit events: %v", err)
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.Printf("failed to flush traces: %v", err)
+	}
 }
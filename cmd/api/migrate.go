@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+	"github.com/zercle/template-go-echo/sql/migrations"
+)
+
+const migrateUsage = `Usage: template-go-echo migrate <command>
+
+Commands:
+  up              apply every pending migration
+  down [N]        revert the last N migrations (default 1)
+  goto VERSION    migrate up or down to VERSION; 0 reverts every migration
+  status          print the schema version and the applied migrations
+  force VERSION   record VERSION as clean without running migrations`
+
+// runMigrate runs the migrate subcommand with the embedded migrations of the
+// configured database and returns the exit status
+func runMigrate(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 1
+	}
+
+	// Load configuration
+	cfg := config.Load()
+
+	// Connect to database
+	db, err := database.New(&cfg.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to database: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	migrator, err := database.NewMigrator(db, migrations.FS, database.WithLockTimeout(cfg.Database.MigrationLockTimeout))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load migrations: %v\n", err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	switch command, params := args[0], args[1:]; {
+	case command == "up" && len(params) == 0:
+		err = migrator.Up(ctx)
+	case command == "down" && len(params) <= 1:
+		steps := 1
+		if len(params) == 1 {
+			if steps, err = strconv.Atoi(params[0]); err != nil {
+				err = fmt.Errorf("invalid number of migrations %q", params[0])
+				break
+			}
+		}
+		err = migrator.Down(ctx, steps)
+	case (command == "goto" || command == "force") && len(params) == 1:
+		version, parseErr := strconv.ParseUint(params[0], 10, 64)
+		if parseErr != nil {
+			err = fmt.Errorf("invalid version %q", params[0])
+			break
+		}
+		if command == "goto" {
+			err = migrator.Goto(ctx, version)
+		} else {
+			err = migrator.Force(ctx, version)
+		}
+	case command == "status" && len(params) == 0:
+		err = printStatus(ctx, migrator)
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 1
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "migrate %s: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+// printStatus writes the schema version and each migration's state to stdout
+func printStatus(ctx context.Context, migrator *database.Migrator) error {
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	state := "clean"
+	if status.Dirty {
+		state = "dirty"
+	}
+	fmt.Printf("version %d (%s), latest %d\n\n", status.Version, state, status.Latest)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+	for _, m := range status.Migrations {
+		applied := "no"
+		if m.Applied {
+			applied = "yes"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", m.Version, m.Name, applied)
+	}
+	return w.Flush()
+}
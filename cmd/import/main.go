@@ -0,0 +1,142 @@
+// Command import creates users in bulk from a CSV or JSONL file and prints a
+// per-row JSON report.
+//
+// Usage:
+//
+//	import -file users.csv [-format csv|jsonl] [-dry-run] [-batch-size 100] [-report report.json]
+//
+// The exit status is 1 when the import cannot run and 2 when any row failed.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	auditrepository "github.com/zercle/template-go-echo/internal/audit/repository"
+	auditusecase "github.com/zercle/template-go-echo/internal/audit/usecase"
+	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+	"github.com/zercle/template-go-echo/internal/infrastructure/logging"
+	jobsrepository "github.com/zercle/template-go-echo/internal/jobs/repository"
+	jobsusecase "github.com/zercle/template-go-echo/internal/jobs/usecase"
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/internal/user/repository"
+	"github.com/zercle/template-go-echo/internal/user/usecase"
+)
+
+func main() {
+	file := flag.String("file", "", "path to the import file, or - for stdin")
+	format := flag.String("format", "", "input format (csv or jsonl); detected from the file extension when omitted")
+	dryRun := flag.Bool("dry-run", false, "validate rows without creating users")
+	batchSize := flag.Int("batch-size", domain.DefaultImportBatchSize, "rows inserted per transaction")
+	reportPath := flag.String("report", "", "write the JSON report to this file instead of stdout")
+	flag.Parse()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	formatHint := *format
+	if formatHint == "" {
+		formatHint = filepath.Ext(*file)
+	}
+	importFormat, ok := domain.ParseImportFormat(formatHint)
+	if !ok {
+		log.Fatalf("unsupported import format %q; use -format csv or -format jsonl", formatHint)
+	}
+
+	var src io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			log.Fatalf("failed to open import file: %v", err)
+		}
+		defer f.Close()
+		src = f
+	}
+
+	// Load configuration
+	cfg := config.Load()
+
+	// Log at the configured levels and format
+	if err := logging.SetDefault(&cfg.Log, os.Stderr); err != nil {
+		log.Fatalf("invalid log configuration: %v", err)
+	}
+
+	// Connect to database
+	db, err := database.New(&cfg.Database)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Prepare the sqlc queries when enabled
+	if cfg.Database.PreparedStatements {
+		if err := db.PrepareQueries(context.Background()); err != nil {
+			log.Fatalf("failed to prepare queries: %v", err)
+		}
+	}
+
+	auditRecorder := auditusecase.NewAsyncRecorder(auditrepository.New(db.Querier(), db.DBTX()))
+	userRepo := repository.New(db.Querier(), db.DBTX())
+	userOpts := []usecase.Option{
+		usecase.WithDeletionGracePeriod(cfg.Account.DeletionGracePeriod),
+		usecase.WithAuditRecorder(auditRecorder),
+		usecase.WithTxManager(db.TxManager()),
+	}
+
+	// Invites for generated passwords are emailed by the job workers
+	if cfg.Mail.SMTPAddr != "" {
+		queue := jobsusecase.New(jobsrepository.New(db.Querier(), db.DBTX()))
+		userOpts = append(userOpts, usecase.WithInviteSender(usecase.NewQueuedInviteSender(queue)))
+	}
+	userUsecase := usecase.New(userRepo, cfg.JWT.TTL, userOpts...)
+
+	// Stop between rows on interrupt; committed batches are kept
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Duplicate checks must see the rows the previous batches inserted
+	ctx = database.WithPrimary(ctx)
+
+	report, err := userUsecase.ImportUsers(ctx, src, importFormat, domain.ImportOptions{
+		DryRun:    *dryRun,
+		BatchSize: *batchSize,
+	})
+	if err != nil {
+		log.Fatalf("import failed: %v", err)
+	}
+	if err := auditRecorder.Close(context.Background()); err != nil {
+		log.Printf("failed to flush audit events: %v", err)
+	}
+
+	var out io.Writer = os.Stdout
+	if *reportPath != "" {
+		f, err := os.Create(*reportPath)
+		if err != nil {
+			log.Fatalf("failed to create report file: %v", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Fatalf("failed to write report: %v", err)
+	}
+
+	log.Printf("import finished: %d rows, %d created, %d valid, %d failed",
+		report.Total, report.Created, report.Valid, report.Failed)
+	if report.Failed > 0 {
+		os.Exit(2)
+	}
+}
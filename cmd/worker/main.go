@@ -0,0 +1,135 @@
+// Command worker runs scheduled maintenance jobs and queued background jobs
+// outside the API process.
+//
+// Set SCHEDULER_ENABLED=false and JOBS_WORKER_ENABLED=false on API instances
+// when running workers. Any number of workers may run: each scheduled job
+// takes a database lock so only one instance runs it at a time, and queued
+// jobs are claimed by one worker each.
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	auditrepository "github.com/zercle/template-go-echo/internal/audit/repository"
+	auditusecase "github.com/zercle/template-go-echo/internal/audit/usecase"
+	"github.com/zercle/template-go-echo/internal/config"
+	idempotencydomain "github.com/zercle/template-go-echo/internal/idempotency/domain"
+	idempotencyrepository "github.com/zercle/template-go-echo/internal/idempotency/repository"
+	idempotencyusecase "github.com/zercle/template-go-echo/internal/idempotency/usecase"
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+	"github.com/zercle/template-go-echo/internal/infrastructure/logging"
+	"github.com/zercle/template-go-echo/internal/infrastructure/mail"
+	jobsdomain "github.com/zercle/template-go-echo/internal/jobs/domain"
+	jobsrepository "github.com/zercle/template-go-echo/internal/jobs/repository"
+	jobsusecase "github.com/zercle/template-go-echo/internal/jobs/usecase"
+	schedulerrepository "github.com/zercle/template-go-echo/internal/scheduler/repository"
+	schedulerusecase "github.com/zercle/template-go-echo/internal/scheduler/usecase"
+	"github.com/zercle/template-go-echo/internal/user/repository"
+	"github.com/zercle/template-go-echo/internal/user/usecase"
+)
+
+func main() {
+	// Load configuration
+	cfg := config.Load()
+
+	// Log at the configured levels and format
+	if err := logging.SetDefault(&cfg.Log, os.Stderr); err != nil {
+		log.Fatalf("invalid log configuration: %v", err)
+	}
+
+	// Connect to database
+	db, err := database.New(&cfg.Database)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Prepare the sqlc queries when enabled
+	if cfg.Database.PreparedStatements {
+		if err := db.PrepareQueries(context.Background()); err != nil {
+			log.Fatalf("failed to prepare queries: %v", err)
+		}
+	}
+
+	// Wire modules providing jobs
+	auditRecorder := auditusecase.NewAsyncRecorder(auditrepository.New(db.Querier(), db.DBTX()),
+		auditusecase.WithBufferSize(cfg.Audit.BufferSize),
+		auditusecase.WithBatchSize(cfg.Audit.BatchSize),
+		auditusecase.WithFlushInterval(cfg.Audit.FlushInterval),
+	)
+	userRepo := repository.New(db.Querier(), db.DBTX())
+	userUsecase := usecase.New(userRepo, cfg.JWT.TTL,
+		usecase.WithDeletionGracePeriod(cfg.Account.DeletionGracePeriod),
+		usecase.WithAuditRecorder(auditRecorder),
+		usecase.WithTxManager(db.TxManager()),
+	)
+
+	// Register scheduled jobs
+	jobRepo := jobsrepository.New(db.Querier(), db.DBTX())
+	runRepo := schedulerrepository.New(db.Querier(), db.DBTX())
+	scheduler := schedulerusecase.NewScheduler(
+		schedulerusecase.WithLocker(schedulerrepository.NewLocker(db.GetConn(), db.Dialect())),
+		schedulerusecase.WithHistory(runRepo),
+	)
+	jobs := append(usecase.ScheduledJobs(userUsecase, cfg.Account.SessionCleanupSchedule, "@every "+cfg.Account.PurgeInterval.String()),
+		schedulerusecase.HistoryCleanupJob(runRepo, cfg.Scheduler.HistoryRetention),
+		jobsusecase.CleanupJob(jobRepo, cfg.Jobs.Retention))
+	if cfg.Idempotency.Store == idempotencydomain.StoreDatabase {
+		jobs = append(jobs, idempotencyusecase.CleanupJob(idempotencyrepository.New(db.Querier())))
+	}
+	if err := scheduler.Register(jobs...); err != nil {
+		log.Fatalf("failed to register scheduled jobs: %v", err)
+	}
+
+	// Register queued job handlers; password invites are the only queued jobs
+	// and need mail, so without it the worker is not started
+	var jobHandlers []jobsdomain.Handler
+	if cfg.Mail.SMTPAddr != "" {
+		jobHandlers = append(jobHandlers, usecase.PasswordInviteHandler(userRepo, mail.NewSMTP(&cfg.Mail)))
+	}
+	worker := jobsusecase.NewWorker(jobRepo,
+		jobsusecase.WithPollInterval(cfg.Jobs.PollInterval),
+		jobsusecase.WithConcurrency(cfg.Jobs.Concurrency),
+	)
+	if err := worker.Register(jobHandlers...); err != nil {
+		log.Fatalf("failed to register job handlers: %v", err)
+	}
+	if len(jobHandlers) == 0 {
+		log.Printf("no queued job handlers registered, job worker not started")
+	}
+
+	// Run until a shutdown signal, then wait for jobs in progress to stop
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Claims and run history must not act on reads lagging behind the primary
+	ctx = database.WithPrimary(ctx)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		scheduler.Run(ctx)
+	}()
+	if len(jobHandlers) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker.Run(ctx)
+		}()
+	}
+	wg.Wait()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := auditRecorder.Close(shutdownCtx); err != nil {
+		log.Printf("failed to flush audit events: %v", err)
+	}
+}
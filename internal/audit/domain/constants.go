@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+const (
+	// Pagination constraints
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+
+	// Recorder defaults
+	DefaultBufferSize    = 1024
+	DefaultBatchSize     = 100
+	DefaultFlushInterval = time.Second
+	DefaultWriteTimeout  = 5 * time.Second
+
+	// Column limits; longer values are truncated
+	MaxUserAgentLength = 500
+)
+
+// Target types
+const (
+	TargetUser = "user"
+)
+
+// Audited actions
+const (
+	ActionUserRegistered          = "user.registered"
+	ActionUserLogin               = "user.login"
+	ActionUserLoginFailed         = "user.login_failed"
+	ActionUserLogout              = "user.logout"
+	ActionUserLogoutAll           = "user.logout_all"
+	ActionUserPasswordChanged     = "user.password_changed"
+	ActionUserProfileUpdated      = "user.profile_updated"
+	ActionUserDeleted             = "user.deleted"
+	ActionUserRestored            = "user.restored"
+	ActionUserCreated             = "user.created"
+	ActionUserSuspended           = "user.suspended"
+	ActionUserReactivated         = "user.reactivated"
+	ActionUserPasswordResetForced = "user.password_reset_forced"
+	ActionUserSessionsRevoked     = "user.sessions_revoked"
+	ActionUserRoleChanged         = "user.role_changed"
+	ActionUsersImported           = "users.imported"
+	ActionUsersExported           = "users.exported"
+)
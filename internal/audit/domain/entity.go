@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+// AuditEvent records who did what to which resource, and from where
+type AuditEvent struct {
+	ID         string                 `json:"id"`
+	ActorID    string                 `json:"actor_id,omitempty"`
+	Action     string                 `json:"action"`
+	TargetType string                 `json:"target_type,omitempty"`
+	TargetID   string                 `json:"target_id,omitempty"`
+	IPAddress  string                 `json:"ip_address,omitempty"`
+	UserAgent  string                 `json:"user_agent,omitempty"`
+	RequestID  string                 `json:"request_id,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// EventFilter narrows an audit event listing; zero values are ignored
+type EventFilter struct {
+	ActorID  string
+	TargetID string
+	Action   string
+
+	// SubjectID matches events where the user is either the actor or the target
+	SubjectID string
+
+	From *time.Time
+	To   *time.Time
+}
+
+// EventQuery describes a cursor paginated audit event listing, newest first
+type EventQuery struct {
+	Filter EventFilter
+	Cursor string
+	Limit  int
+}
+
+// EventCursor is the keyset position of an audit event in a listing
+type EventCursor struct {
+	CreatedAt string `json:"t"`
+	ID        string `json:"id"`
+}
+
+// NewEventCursor returns the keyset position of event
+func NewEventCursor(event *AuditEvent) EventCursor {
+	return EventCursor{
+		CreatedAt: event.CreatedAt.UTC().Format(time.RFC3339Nano),
+		ID:        event.ID,
+	}
+}
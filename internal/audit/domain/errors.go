@@ -0,0 +1,16 @@
+package domain
+
+import "github.com/zercle/template-go-echo/pkg"
+
+// Audit domain-specific error codes
+const (
+	ErrCodeInvalidTimeRange = "INVALID_TIME_RANGE"
+)
+
+// Audit domain errors
+var (
+	ErrInvalidTimeRange = pkg.NewDomainError(
+		ErrCodeInvalidTimeRange,
+		"from must be before to",
+	)
+)
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// AuditRepository defines database operations for audit events
+type AuditRepository interface {
+	// CreateEvents inserts events in a single transaction
+	CreateEvents(ctx context.Context, events []*AuditEvent) error
+
+	// ListEvents retrieves up to limit events matching filter, newest first,
+	// starting after the given keyset position
+	ListEvents(ctx context.Context, filter EventFilter, after *EventCursor, limit int) ([]*AuditEvent, error)
+}
+
+// Recorder records audit events. Implementations must not block the caller;
+// request metadata and the actor are taken from ctx when not set on the event.
+type Recorder interface {
+	// Record queues an event for persistence
+	Record(ctx context.Context, event AuditEvent)
+}
+
+// AuditUsecase defines business logic for querying audit events
+type AuditUsecase interface {
+	// ListEvents retrieves a filtered, cursor paginated list of events, newest first
+	ListEvents(ctx context.Context, query EventQuery) (pkg.CursorPage[*AuditEvent], error)
+
+	// ListSecurityActivity retrieves the events a user performed or was subject to, newest first
+	ListSecurityActivity(ctx context.Context, userID, cursor string, limit int) (pkg.CursorPage[*AuditEvent], error)
+}
@@ -0,0 +1,24 @@
+package handler
+
+import "time"
+
+// AuditEventResponse is the response body for an audit event
+type AuditEventResponse struct {
+	ID         string                 `json:"id"`
+	ActorID    string                 `json:"actor_id,omitempty"`
+	Action     string                 `json:"action"`
+	TargetType string                 `json:"target_type,omitempty"`
+	TargetID   string                 `json:"target_id,omitempty"`
+	IPAddress  string                 `json:"ip_address,omitempty"`
+	UserAgent  string                 `json:"user_agent,omitempty"`
+	RequestID  string                 `json:"request_id,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// AuditEventPageResponse is the response body for a cursor paginated audit event listing
+type AuditEventPageResponse struct {
+	Events     []*AuditEventResponse `json:"events"`
+	NextCursor string                `json:"next_cursor,omitempty"`
+	HasMore    bool                  `json:"has_more"`
+}
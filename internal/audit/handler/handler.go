@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zercle/template-go-echo/internal/audit/domain"
+	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/middleware"
+	userdomain "github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// Handler handles audit HTTP requests
+type Handler struct {
+	usecase domain.AuditUsecase
+}
+
+// New creates a new audit handler
+func New(usecase domain.AuditUsecase) *Handler {
+	return &Handler{
+		usecase: usecase,
+	}
+}
+
+// RegisterRoutes registers audit routes
+func (h *Handler) RegisterRoutes(e *echo.Echo, jwtCfg *config.JWTConfig) {
+	e.GET("/api/v1/admin/audit-events", h.ListEvents,
+		middleware.JWTAuth(jwtCfg), middleware.RequireRole(userdomain.RoleAdmin))
+	e.GET("/api/v1/users/me/security-activity", h.ListSecurityActivity, middleware.JWTAuth(jwtCfg))
+}
+
+// ListEvents retrieves a filtered, cursor paginated list of audit events
+// @Summary List audit events
+// @Description Retrieve audit events newest first, filtered by actor, target, action and time range
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param actor_id query string false "Filter by acting user ID"
+// @Param target_id query string false "Filter by target resource ID"
+// @Param action query string false "Filter by action, such as user.login"
+// @Param from query string false "Only events at or after this RFC3339 timestamp"
+// @Param to query string false "Only events before this RFC3339 timestamp"
+// @Param limit query int false "Page limit (default: 20, max: 100)"
+// @Param cursor query string false "Opaque cursor from a previous page"
+// @Success 200 {object} pkg.JSendResponse{data=AuditEventPageResponse}
+// @Failure 400 {object} pkg.JSendResponse
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 500 {object} pkg.JSendResponse
+// @Router /api/v1/admin/audit-events [get]
+func (h *Handler) ListEvents(c echo.Context) error {
+	query := domain.EventQuery{
+		Filter: domain.EventFilter{
+			ActorID:  c.QueryParam("actor_id"),
+			TargetID: c.QueryParam("target_id"),
+			Action:   c.QueryParam("action"),
+		},
+		Cursor: c.QueryParam("cursor"),
+		Limit:  parseLimit(c),
+	}
+
+	if v := c.QueryParam("from"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return pkg.Fail(c, http.StatusBadRequest, nil, "from must be an RFC3339 timestamp")
+		}
+		query.Filter.From = &from
+	}
+
+	if v := c.QueryParam("to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return pkg.Fail(c, http.StatusBadRequest, nil, "to must be an RFC3339 timestamp")
+		}
+		query.Filter.To = &to
+	}
+
+	page, err := h.usecase.ListEvents(c.Request().Context(), query)
+	if err != nil {
+		return listError(c, err)
+	}
+
+	return pkg.Success(c, http.StatusOK, toEventPageResponse(page))
+}
+
+// ListSecurityActivity retrieves the current user's security activity
+// @Summary List my security activity
+// @Description Retrieve audit events performed by or affecting the current user, newest first
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Page limit (default: 20, max: 100)"
+// @Param cursor query string false "Opaque cursor from a previous page"
+// @Success 200 {object} pkg.JSendResponse{data=AuditEventPageResponse}
+// @Failure 400 {object} pkg.JSendResponse
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 500 {object} pkg.JSendResponse
+// @Router /api/v1/users/me/security-activity [get]
+func (h *Handler) ListSecurityActivity(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return pkg.Error(c, http.StatusUnauthorized, "unauthorized", pkg.ErrCodeUnauthorized)
+	}
+
+	page, err := h.usecase.ListSecurityActivity(c.Request().Context(), userID, c.QueryParam("cursor"), parseLimit(c))
+	if err != nil {
+		return listError(c, err)
+	}
+
+	return pkg.Success(c, http.StatusOK, toEventPageResponse(page))
+}
+
+// parseLimit reads the optional page limit; invalid values fall back to the default
+func parseLimit(c echo.Context) int {
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	return limit
+}
+
+// listError maps listing errors to responses; internal errors are left to the
+// Echo error handler, which never exposes their details
+func listError(c echo.Context, err error) error {
+	if domainErr, ok := err.(*pkg.DomainError); ok && domainErr.Code != pkg.ErrCodeInternalError {
+		return pkg.Error(c, http.StatusBadRequest, domainErr.Message, domainErr.Code)
+	}
+	return err
+}
+
+// toEventPageResponse converts a page of domain events to its response representation
+func toEventPageResponse(page pkg.CursorPage[*domain.AuditEvent]) *AuditEventPageResponse {
+	events := make([]*AuditEventResponse, len(page.Items))
+	for i, event := range page.Items {
+		events[i] = &AuditEventResponse{
+			ID:         event.ID,
+			ActorID:    event.ActorID,
+			Action:     event.Action,
+			TargetType: event.TargetType,
+			TargetID:   event.TargetID,
+			IPAddress:  event.IPAddress,
+			UserAgent:  event.UserAgent,
+			RequestID:  event.RequestID,
+			Metadata:   event.Metadata,
+			CreatedAt:  event.CreatedAt,
+		}
+	}
+
+	return &AuditEventPageResponse{
+		Events:     events,
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/audit/domain"
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+	"github.com/zercle/template-go-echo/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// auditEventColumns lists audit event columns in sqlc.AuditEvents scan order
+const auditEventColumns = "id, actor_id, action, target_type, target_id, ip_address, user_agent, request_id, metadata, created_at"
+
+// AuditRepository implements domain.AuditRepository using sqlc generated code
+type AuditRepository struct {
+	q  sqlc.Querier
+	db sqlc.DBTX
+	tx *database.TxManager
+}
+
+// New creates a new audit repository with sqlc querier. The db connection
+// backs batched inserts and filtered listings.
+func New(q sqlc.Querier, db sqlc.DBTX) *AuditRepository {
+	return &AuditRepository{q: q, db: db, tx: database.NewTxManager(db)}
+}
+
+// CreateEvents inserts events in a single transaction
+func (r *AuditRepository) CreateEvents(ctx context.Context, events []*domain.AuditEvent) error {
+	return r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		q := database.Queries(ctx, r.q)
+		for _, event := range events {
+			params, err := createAuditEventParams(event)
+			if err != nil {
+				return err
+			}
+			if err := q.CreateAuditEvent(ctx, params); err != nil {
+				slog.Error("failed to create audit event", slog.String("error", err.Error()))
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListEvents retrieves up to limit events matching filter, newest first,
+// starting after the given keyset position
+func (r *AuditRepository) ListEvents(ctx context.Context, filter domain.EventFilter, after *domain.EventCursor, limit int) ([]*domain.AuditEvent, error) {
+	where, args := eventFilterClause(filter)
+	if after != nil {
+		createdAt, err := time.Parse(time.RFC3339Nano, after.CreatedAt)
+		if err != nil {
+			return nil, pkg.ErrInvalidCursor
+		}
+		where += " AND (created_at < ? OR (created_at = ? AND id < ?))"
+		args = append(args, createdAt, createdAt, after.ID)
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf("-- name: ListAuditEventsPage :many\nSELECT %s FROM audit_events WHERE %s ORDER BY created_at DESC, id DESC LIMIT ?",
+		auditEventColumns, where)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		slog.Error("failed to list audit events", slog.String("error", err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*domain.AuditEvent
+	for rows.Next() {
+		var i sqlc.AuditEvents
+		if err := rows.Scan(
+			&i.ID,
+			&i.ActorID,
+			&i.Action,
+			&i.TargetType,
+			&i.TargetID,
+			&i.IpAddress,
+			&i.UserAgent,
+			&i.RequestID,
+			&i.Metadata,
+			&i.CreatedAt,
+		); err != nil {
+			slog.Error("failed to scan audit event", slog.String("error", err.Error()))
+			return nil, err
+		}
+		events = append(events, sqlcAuditEventToDomain(&i))
+	}
+	if err := rows.Err(); err != nil {
+		slog.Error("failed to list audit events", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// eventFilterClause builds the WHERE clause and arguments for an event filter
+func eventFilterClause(filter domain.EventFilter) (string, []interface{}) {
+	conditions := []string{"1 = 1"}
+	var args []interface{}
+
+	if filter.ActorID != "" {
+		conditions = append(conditions, "actor_id = ?")
+		args = append(args, filter.ActorID)
+	}
+	if filter.TargetID != "" {
+		conditions = append(conditions, "target_id = ?")
+		args = append(args, filter.TargetID)
+	}
+	if filter.SubjectID != "" {
+		conditions = append(conditions, "(actor_id = ? OR target_id = ?)")
+		args = append(args, filter.SubjectID, filter.SubjectID)
+	}
+	if filter.Action != "" {
+		conditions = append(conditions, "action = ?")
+		args = append(args, filter.Action)
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, *filter.To)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// createAuditEventParams converts a domain event to sqlc insert parameters
+func createAuditEventParams(event *domain.AuditEvent) (sqlc.CreateAuditEventParams, error) {
+	var metadata json.RawMessage
+	if len(event.Metadata) > 0 {
+		encoded, err := json.Marshal(event.Metadata)
+		if err != nil {
+			return sqlc.CreateAuditEventParams{}, fmt.Errorf("failed to encode audit metadata: %w", err)
+		}
+		metadata = encoded
+	}
+
+	return sqlc.CreateAuditEventParams{
+		ID:         event.ID,
+		ActorID:    nullString(event.ActorID),
+		Action:     event.Action,
+		TargetType: nullString(event.TargetType),
+		TargetID:   nullString(event.TargetID),
+		IpAddress:  nullString(event.IPAddress),
+		UserAgent:  nullString(event.UserAgent),
+		RequestID:  nullString(event.RequestID),
+		Metadata:   metadata,
+		CreatedAt:  event.CreatedAt,
+	}, nil
+}
+
+// sqlcAuditEventToDomain converts a sqlc audit event to a domain event
+func sqlcAuditEventToDomain(e *sqlc.AuditEvents) *domain.AuditEvent {
+	event := &domain.AuditEvent{
+		ID:         e.ID,
+		ActorID:    e.ActorID.String,
+		Action:     e.Action,
+		TargetType: e.TargetType.String,
+		TargetID:   e.TargetID.String,
+		IPAddress:  e.IpAddress.String,
+		UserAgent:  e.UserAgent.String,
+		RequestID:  e.RequestID.String,
+		CreatedAt:  e.CreatedAt,
+	}
+
+	if len(e.Metadata) > 0 {
+		if err := json.Unmarshal(e.Metadata, &event.Metadata); err != nil {
+			slog.Warn("failed to decode audit metadata", slog.String("event_id", e.ID), slog.String("error", err.Error()))
+		}
+	}
+
+	return event
+}
+
+// nullString maps empty strings to NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,105 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/audit/domain"
+	"github.com/zercle/template-go-echo/internal/audit/test/mocks"
+	"github.com/zercle/template-go-echo/internal/audit/usecase"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+func TestAsyncRecorderBatchesEvents(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	recorder := usecase.NewAsyncRecorder(repo, usecase.WithBatchSize(10), usecase.WithFlushInterval(time.Hour))
+
+	ctx := pkg.WithRequestInfo(context.Background(), pkg.RequestInfo{
+		RequestID: "req-1",
+		IPAddress: "203.0.113.7",
+		UserAgent: "test-agent",
+		UserID:    "admin-1",
+	})
+	for i := 0; i < 25; i++ {
+		recorder.Record(ctx, domain.AuditEvent{Action: domain.ActionUserLogin, TargetID: "user-1"})
+	}
+
+	if err := recorder.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events := repo.Events()
+	if len(events) != 25 {
+		t.Fatalf("expected 25 events after close, got %d", len(events))
+	}
+	if repo.Batches() != 3 {
+		t.Errorf("expected 3 batches, got %d", repo.Batches())
+	}
+
+	// Request metadata and the actor are taken from the context
+	event := events[0]
+	if event.ID == "" || event.CreatedAt.IsZero() {
+		t.Error("expected ID and timestamp to be generated")
+	}
+	if event.ActorID != "admin-1" || event.IPAddress != "203.0.113.7" || event.UserAgent != "test-agent" || event.RequestID != "req-1" {
+		t.Errorf("expected request metadata from context, got %+v", event)
+	}
+}
+
+func TestAsyncRecorderFlushesOnInterval(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	recorder := usecase.NewAsyncRecorder(repo, usecase.WithFlushInterval(10*time.Millisecond))
+	defer recorder.Close(context.Background())
+
+	recorder.Record(context.Background(), domain.AuditEvent{Action: domain.ActionUserLogout})
+
+	deadline := time.Now().Add(time.Second)
+	for len(repo.Events()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if len(repo.Events()) != 1 {
+		t.Error("expected event to be written after the flush interval")
+	}
+}
+
+func TestAsyncRecorderNeverBlocks(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	recorder := usecase.NewAsyncRecorder(repo, usecase.WithBufferSize(1), usecase.WithBatchSize(1000), usecase.WithFlushInterval(time.Hour))
+
+	start := time.Now()
+	for i := 0; i < 1000; i++ {
+		recorder.Record(context.Background(), domain.AuditEvent{Action: domain.ActionUserLogin})
+	}
+	if time.Since(start) > time.Second {
+		t.Error("expected Record not to block when the buffer is full")
+	}
+	if recorder.Dropped() == 0 {
+		t.Error("expected events to be dropped when the buffer is full")
+	}
+
+	_ = recorder.Close(context.Background())
+
+	// Events recorded after close are dropped rather than panicking
+	dropped := recorder.Dropped()
+	recorder.Record(context.Background(), domain.AuditEvent{Action: domain.ActionUserLogin})
+	if recorder.Dropped() != dropped+1 {
+		t.Error("expected event recorded after close to be dropped")
+	}
+}
+
+func TestAsyncRecorderWriteFailure(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	repo.SetFailing(true)
+	recorder := usecase.NewAsyncRecorder(repo, usecase.WithFlushInterval(time.Hour))
+
+	recorder.Record(context.Background(), domain.AuditEvent{Action: domain.ActionUserLogin})
+	recorder.Record(context.Background(), domain.AuditEvent{Action: domain.ActionUserLogin})
+
+	if err := recorder.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recorder.Dropped() != 2 {
+		t.Errorf("expected failed batch to be counted as dropped, got %d", recorder.Dropped())
+	}
+}
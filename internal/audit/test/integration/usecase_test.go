@@ -0,0 +1,132 @@
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/audit/domain"
+	"github.com/zercle/template-go-echo/internal/audit/test/mocks"
+	"github.com/zercle/template-go-echo/internal/audit/usecase"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// seedEvents stores events one second apart, oldest first
+func seedEvents(t *testing.T, repo *mocks.MockAuditRepository, events ...domain.AuditEvent) {
+	t.Helper()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	batch := make([]*domain.AuditEvent, len(events))
+	for i := range events {
+		event := events[i]
+		event.ID = fmt.Sprintf("event-%02d", i)
+		event.CreatedAt = base.Add(time.Duration(i) * time.Second)
+		batch[i] = &event
+	}
+	if err := repo.CreateEvents(context.Background(), batch); err != nil {
+		t.Fatalf("failed to seed events: %v", err)
+	}
+}
+
+func TestListEventsPagination(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo)
+
+	var events []domain.AuditEvent
+	for i := 0; i < 5; i++ {
+		events = append(events, domain.AuditEvent{Action: domain.ActionUserLogin, ActorID: "user-1"})
+	}
+	seedEvents(t, repo, events...)
+
+	var ids []string
+	query := domain.EventQuery{Limit: 2}
+	for pages := 0; pages < 5; pages++ {
+		page, err := uc.ListEvents(context.Background(), query)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, event := range page.Items {
+			ids = append(ids, event.ID)
+		}
+		if !page.HasMore {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	expected := []string{"event-04", "event-03", "event-02", "event-01", "event-00"}
+	if fmt.Sprint(ids) != fmt.Sprint(expected) {
+		t.Errorf("expected newest first %v, got %v", expected, ids)
+	}
+}
+
+func TestListEventsFilters(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo)
+
+	seedEvents(t, repo,
+		domain.AuditEvent{Action: domain.ActionUserLogin, ActorID: "user-1", TargetID: "user-1"},
+		domain.AuditEvent{Action: domain.ActionUserSuspended, ActorID: "admin-1", TargetID: "user-1"},
+		domain.AuditEvent{Action: domain.ActionUserLogin, ActorID: "user-2", TargetID: "user-2"},
+	)
+
+	page, _ := uc.ListEvents(context.Background(), domain.EventQuery{Filter: domain.EventFilter{Action: domain.ActionUserLogin}})
+	if len(page.Items) != 2 {
+		t.Errorf("expected 2 login events, got %d", len(page.Items))
+	}
+
+	page, _ = uc.ListEvents(context.Background(), domain.EventQuery{Filter: domain.EventFilter{ActorID: "admin-1"}})
+	if len(page.Items) != 1 || page.Items[0].Action != domain.ActionUserSuspended {
+		t.Errorf("expected the admin's suspension, got %d events", len(page.Items))
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 1, 0, time.UTC)
+	page, _ = uc.ListEvents(context.Background(), domain.EventQuery{Filter: domain.EventFilter{From: &from}})
+	if len(page.Items) != 2 {
+		t.Errorf("expected 2 events from the second second, got %d", len(page.Items))
+	}
+
+	to := from
+	if _, err := uc.ListEvents(context.Background(), domain.EventQuery{Filter: domain.EventFilter{From: &from, To: &to}}); err != domain.ErrInvalidTimeRange {
+		t.Errorf("expected ErrInvalidTimeRange, got %v", err)
+	}
+
+	if _, err := uc.ListEvents(context.Background(), domain.EventQuery{Cursor: "garbage!"}); err != pkg.ErrInvalidCursor {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestListSecurityActivity(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo)
+
+	seedEvents(t, repo,
+		domain.AuditEvent{Action: domain.ActionUserLogin, ActorID: "user-1", TargetID: "user-1", IPAddress: "198.51.100.1"},
+		domain.AuditEvent{Action: domain.ActionUserSuspended, ActorID: "admin-1", TargetID: "user-1", IPAddress: "203.0.113.9"},
+		domain.AuditEvent{Action: domain.ActionUserLogin, ActorID: "user-2", TargetID: "user-2"},
+	)
+
+	page, err := uc.ListSecurityActivity(context.Background(), "user-1", "", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(page.Items) != 2 {
+		t.Fatalf("expected 2 events for user-1, got %d", len(page.Items))
+	}
+
+	// Details of other actors are withheld from the user
+	suspension := page.Items[0]
+	if suspension.Action != domain.ActionUserSuspended || suspension.ActorID != "" || suspension.IPAddress != "" {
+		t.Errorf("expected admin details to be withheld, got %+v", suspension)
+	}
+	if page.Items[1].IPAddress != "198.51.100.1" {
+		t.Errorf("expected the user's own IP address, got %q", page.Items[1].IPAddress)
+	}
+
+	// Redaction does not leak into the stored events
+	stored, _ := uc.ListEvents(context.Background(), domain.EventQuery{Filter: domain.EventFilter{ActorID: "admin-1"}})
+	if len(stored.Items) != 1 || stored.Items[0].IPAddress == "" {
+		t.Error("expected stored admin event to keep its IP address")
+	}
+}
@@ -0,0 +1,116 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/audit/domain"
+)
+
+// MockAuditRepository is a simple in-memory audit repository for testing. It is
+// safe for concurrent use because recorders write from a background goroutine.
+type MockAuditRepository struct {
+	mu      sync.Mutex
+	events  []*domain.AuditEvent
+	batches int
+	fail    bool
+}
+
+// NewMockRepository creates a new mock repository
+func NewMockRepository() *MockAuditRepository {
+	return &MockAuditRepository{}
+}
+
+// SetFailing makes subsequent writes fail
+func (m *MockAuditRepository) SetFailing(fail bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fail = fail
+}
+
+// Events returns a copy of the stored events in insertion order
+func (m *MockAuditRepository) Events() []*domain.AuditEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*domain.AuditEvent(nil), m.events...)
+}
+
+// Batches returns the number of successful CreateEvents calls
+func (m *MockAuditRepository) Batches() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.batches
+}
+
+func (m *MockAuditRepository) CreateEvents(ctx context.Context, events []*domain.AuditEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.fail {
+		return errors.New("database unavailable")
+	}
+	m.events = append(m.events, events...)
+	m.batches++
+	return nil
+}
+
+func (m *MockAuditRepository) ListEvents(ctx context.Context, filter domain.EventFilter, after *domain.EventCursor, limit int) ([]*domain.AuditEvent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var events []*domain.AuditEvent
+	for _, event := range m.events {
+		if matchesFilter(event, filter) {
+			events = append(events, event)
+		}
+	}
+
+	// Newest first, breaking ties by ID
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].CreatedAt.Equal(events[j].CreatedAt) {
+			return events[i].CreatedAt.After(events[j].CreatedAt)
+		}
+		return events[i].ID > events[j].ID
+	})
+
+	var page []*domain.AuditEvent
+	for _, event := range events {
+		if after != nil {
+			createdAt, _ := time.Parse(time.RFC3339Nano, after.CreatedAt)
+			if event.CreatedAt.After(createdAt) || (event.CreatedAt.Equal(createdAt) && event.ID >= after.ID) {
+				continue
+			}
+		}
+		if len(page) == limit {
+			break
+		}
+		copied := *event
+		page = append(page, &copied)
+	}
+	return page, nil
+}
+
+// matchesFilter applies an event filter the way the SQL repository does
+func matchesFilter(event *domain.AuditEvent, filter domain.EventFilter) bool {
+	if filter.ActorID != "" && event.ActorID != filter.ActorID {
+		return false
+	}
+	if filter.TargetID != "" && event.TargetID != filter.TargetID {
+		return false
+	}
+	if filter.SubjectID != "" && event.ActorID != filter.SubjectID && event.TargetID != filter.SubjectID {
+		return false
+	}
+	if filter.Action != "" && event.Action != filter.Action {
+		return false
+	}
+	if filter.From != nil && event.CreatedAt.Before(*filter.From) {
+		return false
+	}
+	if filter.To != nil && !event.CreatedAt.Before(*filter.To) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zercle/template-go-echo/internal/audit/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// AsyncRecorder implements domain.Recorder by queueing events in memory and
+// writing them in batches from a background goroutine. When the queue is full
+// events are dropped and counted rather than blocking the request path.
+type AsyncRecorder struct {
+	repo          domain.AuditRepository
+	bufferSize    int
+	batchSize     int
+	flushInterval time.Duration
+	writeTimeout  time.Duration
+
+	mu      sync.RWMutex
+	closed  bool
+	events  chan *domain.AuditEvent
+	done    chan struct{}
+	dropped atomic.Int64
+}
+
+// RecorderOption configures optional AsyncRecorder settings
+type RecorderOption func(*AsyncRecorder)
+
+// WithBufferSize sets how many events may be queued before new ones are dropped
+func WithBufferSize(size int) RecorderOption {
+	return func(r *AsyncRecorder) {
+		r.bufferSize = size
+	}
+}
+
+// WithBatchSize sets the maximum number of events written per transaction
+func WithBatchSize(size int) RecorderOption {
+	return func(r *AsyncRecorder) {
+		r.batchSize = size
+	}
+}
+
+// WithFlushInterval sets how long queued events may wait before being written
+func WithFlushInterval(interval time.Duration) RecorderOption {
+	return func(r *AsyncRecorder) {
+		r.flushInterval = interval
+	}
+}
+
+// NewAsyncRecorder creates a recorder and starts its background writer. Call
+// Close on shutdown to write the events still queued.
+func NewAsyncRecorder(repo domain.AuditRepository, opts ...RecorderOption) *AsyncRecorder {
+	r := &AsyncRecorder{
+		repo:          repo,
+		bufferSize:    domain.DefaultBufferSize,
+		batchSize:     domain.DefaultBatchSize,
+		flushInterval: domain.DefaultFlushInterval,
+		writeTimeout:  domain.DefaultWriteTimeout,
+		done:          make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.events = make(chan *domain.AuditEvent, r.bufferSize)
+
+	go r.run()
+	return r
+}
+
+// Record queues an event without blocking, filling in the ID, timestamp,
+// request metadata and actor from ctx where the event leaves them empty
+func (r *AsyncRecorder) Record(ctx context.Context, event domain.AuditEvent) {
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	info := pkg.RequestInfoFromContext(ctx)
+	if event.ActorID == "" {
+		event.ActorID = info.UserID
+	}
+	if event.IPAddress == "" {
+		event.IPAddress = info.IPAddress
+	}
+	if event.UserAgent == "" {
+		event.UserAgent = info.UserAgent
+	}
+	if event.RequestID == "" {
+		event.RequestID = info.RequestID
+	}
+	if len(event.UserAgent) > domain.MaxUserAgentLength {
+		event.UserAgent = event.UserAgent[:domain.MaxUserAgentLength]
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		r.drop(&event, "recorder closed")
+		return
+	}
+
+	select {
+	case r.events <- &event:
+	default:
+		r.drop(&event, "buffer full")
+	}
+}
+
+// Dropped returns the number of events discarded because the queue was full or closed
+func (r *AsyncRecorder) Dropped() int64 {
+	return r.dropped.Load()
+}
+
+// Close stops accepting events and waits until the queued ones are written or ctx ends
+func (r *AsyncRecorder) Close(ctx context.Context) error {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.events)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// drop discards an event that could not be queued
+func (r *AsyncRecorder) drop(event *domain.AuditEvent, reason string) {
+	r.dropped.Add(1)
+	slog.Warn("audit event dropped",
+		slog.String("reason", reason),
+		slog.String("action", event.Action),
+		slog.String("event_id", event.ID),
+	)
+}
+
+// run writes queued events whenever a batch fills up or the flush interval elapses
+func (r *AsyncRecorder) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*domain.AuditEvent, 0, r.batchSize)
+	for {
+		select {
+		case event, ok := <-r.events:
+			if !ok {
+				r.write(batch)
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) >= r.batchSize {
+				r.write(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			r.write(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+// write persists a batch; failed batches are logged and discarded so that a
+// database outage cannot grow memory without bound
+func (r *AsyncRecorder) write(batch []*domain.AuditEvent) {
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.writeTimeout)
+	defer cancel()
+
+	if err := r.repo.CreateEvents(ctx, batch); err != nil {
+		r.dropped.Add(int64(len(batch)))
+		slog.Error("failed to write audit events",
+			slog.Int("count", len(batch)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
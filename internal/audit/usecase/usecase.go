@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zercle/template-go-echo/internal/audit/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// AuditUsecase implements domain.AuditUsecase
+type AuditUsecase struct {
+	repo domain.AuditRepository
+}
+
+// New creates a new audit usecase
+func New(repo domain.AuditRepository) *AuditUsecase {
+	return &AuditUsecase{repo: repo}
+}
+
+// ListEvents retrieves a filtered, cursor paginated list of events, newest first
+func (u *AuditUsecase) ListEvents(ctx context.Context, query domain.EventQuery) (pkg.CursorPage[*domain.AuditEvent], error) {
+	filter := query.Filter
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return pkg.CursorPage[*domain.AuditEvent]{}, domain.ErrInvalidTimeRange
+	}
+
+	var after *domain.EventCursor
+	if query.Cursor != "" {
+		cursor, err := pkg.DecodeCursor[domain.EventCursor](query.Cursor)
+		if err != nil || cursor.ID == "" {
+			return pkg.CursorPage[*domain.AuditEvent]{}, pkg.ErrInvalidCursor
+		}
+		after = &cursor
+	}
+
+	// Fetch one extra row to detect whether another page exists
+	limit := pkg.ClampLimit(query.Limit, domain.DefaultPageSize, domain.MaxPageSize)
+	events, err := u.repo.ListEvents(ctx, filter, after, limit+1)
+	if err != nil {
+		if domainErr, ok := err.(*pkg.DomainError); ok {
+			return pkg.CursorPage[*domain.AuditEvent]{}, domainErr
+		}
+		slog.Error("failed to list audit events", slog.String("error", err.Error()))
+		return pkg.CursorPage[*domain.AuditEvent]{}, pkg.ErrInternalError
+	}
+
+	return pkg.NewCursorPage(events, limit, func(event *domain.AuditEvent) any {
+		return domain.NewEventCursor(event)
+	}), nil
+}
+
+// ListSecurityActivity retrieves the events a user performed or was subject to, newest first
+// Client details of other actors, such as administrators, are withheld.
+func (u *AuditUsecase) ListSecurityActivity(ctx context.Context, userID, cursor string, limit int) (pkg.CursorPage[*domain.AuditEvent], error) {
+	page, err := u.ListEvents(ctx, domain.EventQuery{
+		Filter: domain.EventFilter{SubjectID: userID},
+		Cursor: cursor,
+		Limit:  limit,
+	})
+	if err != nil {
+		return page, err
+	}
+
+	for _, event := range page.Items {
+		if event.ActorID != "" && event.ActorID != userID {
+			event.ActorID = ""
+			event.IPAddress = ""
+			event.UserAgent = ""
+			event.RequestID = ""
+		}
+	}
+	return page, nil
+}
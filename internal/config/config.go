@@ -3,15 +3,32 @@ package config
 import (
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 // Config holds the application configuration
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	JWT         JWTConfig
+	Account     AccountConfig
+	Audit       AuditConfig
+	Outbox      OutboxConfig
+	Webhook     WebhookConfig
+	Scheduler   SchedulerConfig
+	Jobs        JobsConfig
+	Mail        MailConfig
+	Idempotency IdempotencyConfig
+	Cache       CacheConfig
+	Redis       RedisConfig
+	RateLimit   RateLimitConfig
+	Concurrency ConcurrencyConfig
+	Metrics     MetricsConfig
+	Tracing     TracingConfig
+	Log         LogConfig
 }
 
 // ServerConfig holds the server configuration
@@ -23,9 +40,17 @@ type ServerConfig struct {
 
 // DatabaseConfig holds the database configuration
 type DatabaseConfig struct {
-	Driver   string
-	DSN      string
-	MaxConns int
+	Driver                string
+	DSN                   string
+	ReplicaDSNs           []string
+	ReplicaPolicy         string
+	ReplicaHealthInterval time.Duration
+	MaxConns              int
+	MigrateOnStartup      bool
+	MigrationLockTimeout  time.Duration
+	SlowQueryThreshold    time.Duration
+	QueryComments         bool
+	PreparedStatements    bool
 }
 
 // JWTConfig holds JWT configuration
@@ -34,6 +59,141 @@ type JWTConfig struct {
 	TTL    int
 }
 
+// AccountConfig holds account lifecycle configuration
+type AccountConfig struct {
+	DeletionGracePeriod    time.Duration
+	PurgeInterval          time.Duration
+	SessionCleanupSchedule string
+}
+
+// AuditConfig holds audit recorder configuration
+type AuditConfig struct {
+	BufferSize    int
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// OutboxConfig holds outbox relay configuration
+type OutboxConfig struct {
+	RelayEnabled bool
+	PollInterval time.Duration
+	BatchSize    int
+	Retention    time.Duration
+}
+
+// SchedulerConfig holds scheduled job configuration
+type SchedulerConfig struct {
+	Enabled          bool
+	HistoryRetention time.Duration
+}
+
+// JobsConfig holds background job queue configuration
+type JobsConfig struct {
+	WorkerEnabled bool
+	PollInterval  time.Duration
+	Concurrency   int
+	Retention     time.Duration
+}
+
+// MailConfig holds outgoing email configuration; mail is disabled when
+// SMTPAddr is empty
+type MailConfig struct {
+	SMTPAddr string
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration
+}
+
+// IdempotencyConfig holds Idempotency-Key middleware configuration
+type IdempotencyConfig struct {
+	Store       string
+	TTL         time.Duration
+	LockTimeout time.Duration
+	// Secret keys the fingerprints of stored requests, so that request
+	// bodies cannot be guessed from them offline, and encrypts stored
+	// responses
+	Secret string
+}
+
+// CacheConfig holds user repository cache configuration
+type CacheConfig struct {
+	Backend             string
+	Size                int
+	TTL                 time.Duration
+	NegativeTTL         time.Duration
+	InvalidationChannel string
+}
+
+// RedisConfig holds the Redis connection shared by the cache and rate limits
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
+}
+
+// RateLimitConfig holds rate limit configuration
+type RateLimitConfig struct {
+	Enabled  bool
+	Store    string
+	Policies string
+	DryRun   bool
+}
+
+// ConcurrencyConfig holds adaptive concurrency limit configuration
+type ConcurrencyConfig struct {
+	Enabled       bool
+	Algorithm     string
+	InitialLimit  int
+	MinLimit      int
+	MaxLimit      int
+	QueueSize     int
+	QueueTimeout  time.Duration
+	LatencyTarget time.Duration
+	RetryAfter    time.Duration
+}
+
+// MetricsConfig holds Prometheus metrics configuration
+type MetricsConfig struct {
+	Enabled bool
+	Path    string
+}
+
+// TracingConfig holds OpenTelemetry tracing configuration
+type TracingConfig struct {
+	Exporter    string
+	Endpoint    string
+	Insecure    bool
+	SampleRatio float64
+	ServiceName string
+}
+
+// LogConfig holds logging configuration
+type LogConfig struct {
+	Level  string
+	Format string
+	// Levels holds comma-separated package=level overrides of Level
+	Levels string
+	// RedactMode is how sensitive values are redacted: mask, hash or partial
+	RedactMode string
+	// RedactKeys are attribute keys whose values are always redacted
+	RedactKeys []string
+	// RedactPatterns name the built-in patterns redacted in any string value
+	RedactPatterns []string
+	// RedactHashKey keys the hashes of the hash redaction mode
+	RedactHashKey string
+}
+
+// WebhookConfig holds webhook delivery configuration
+type WebhookConfig struct {
+	DeliveryEnabled bool
+	PollInterval    time.Duration
+	Timeout         time.Duration
+	Concurrency     int
+	MaxAttempts     int
+	DisableAfter    int
+}
+
 // Load loads configuration from environment variables
 func Load() *Config {
 	// Set default values
@@ -42,9 +202,83 @@ func Load() *Config {
 	viper.SetDefault("SERVER_DEBUG", false)
 	viper.SetDefault("DB_DRIVER", "mysql")
 	viper.SetDefault("DB_DSN", "root:password@tcp(localhost:3306)/template_go_echo")
+	viper.SetDefault("DB_REPLICA_DSNS", "")
+	viper.SetDefault("DB_REPLICA_POLICY", "round_robin")
+	viper.SetDefault("DB_REPLICA_HEALTH_INTERVAL", "5s")
 	viper.SetDefault("DB_MAX_CONNS", 10)
+	viper.SetDefault("DB_MIGRATE_ON_STARTUP", false)
+	viper.SetDefault("DB_MIGRATION_LOCK_TIMEOUT", "1m")
+	viper.SetDefault("DB_SLOW_QUERY_THRESHOLD", "200ms")
+	viper.SetDefault("DB_QUERY_COMMENTS", true)
+	viper.SetDefault("DB_PREPARED_STATEMENTS", false)
 	viper.SetDefault("JWT_SECRET", "your-secret-key")
 	viper.SetDefault("JWT_TTL", 3600)
+	viper.SetDefault("ACCOUNT_DELETION_GRACE_PERIOD", "720h")
+	viper.SetDefault("ACCOUNT_PURGE_INTERVAL", "1h")
+	viper.SetDefault("SESSION_CLEANUP_SCHEDULE", "*/15 * * * *")
+	viper.SetDefault("AUDIT_BUFFER_SIZE", 1024)
+	viper.SetDefault("AUDIT_BATCH_SIZE", 100)
+	viper.SetDefault("AUDIT_FLUSH_INTERVAL", "1s")
+	viper.SetDefault("OUTBOX_RELAY_ENABLED", true)
+	viper.SetDefault("OUTBOX_POLL_INTERVAL", "1s")
+	viper.SetDefault("OUTBOX_BATCH_SIZE", 100)
+	viper.SetDefault("OUTBOX_RETENTION", "168h")
+	viper.SetDefault("WEBHOOK_DELIVERY_ENABLED", true)
+	viper.SetDefault("WEBHOOK_POLL_INTERVAL", "1s")
+	viper.SetDefault("WEBHOOK_TIMEOUT", "10s")
+	viper.SetDefault("WEBHOOK_CONCURRENCY", 4)
+	viper.SetDefault("WEBHOOK_MAX_ATTEMPTS", 10)
+	viper.SetDefault("WEBHOOK_DISABLE_AFTER", 20)
+	viper.SetDefault("SCHEDULER_ENABLED", true)
+	viper.SetDefault("SCHEDULER_HISTORY_RETENTION", "720h")
+	viper.SetDefault("JOBS_WORKER_ENABLED", true)
+	viper.SetDefault("JOBS_POLL_INTERVAL", "1s")
+	viper.SetDefault("JOBS_CONCURRENCY", 4)
+	viper.SetDefault("JOBS_RETENTION", "168h")
+	viper.SetDefault("MAIL_SMTP_ADDR", "")
+	viper.SetDefault("MAIL_USERNAME", "")
+	viper.SetDefault("MAIL_PASSWORD", "")
+	viper.SetDefault("MAIL_FROM", "")
+	viper.SetDefault("MAIL_TIMEOUT", "30s")
+	viper.SetDefault("IDEMPOTENCY_STORE", "database")
+	viper.SetDefault("IDEMPOTENCY_TTL", "24h")
+	viper.SetDefault("IDEMPOTENCY_LOCK_TIMEOUT", "1m")
+	viper.SetDefault("IDEMPOTENCY_SECRET", "")
+	viper.SetDefault("CACHE_BACKEND", "none")
+	viper.SetDefault("CACHE_SIZE", 10000)
+	viper.SetDefault("CACHE_TTL", "1m")
+	viper.SetDefault("CACHE_NEGATIVE_TTL", "10s")
+	viper.SetDefault("CACHE_INVALIDATION_CHANNEL", "template-go-echo:cache:invalidate")
+	viper.SetDefault("REDIS_ADDR", "")
+	viper.SetDefault("REDIS_PASSWORD", "")
+	viper.SetDefault("REDIS_DB", 0)
+	viper.SetDefault("RATE_LIMIT_ENABLED", false)
+	viper.SetDefault("RATE_LIMIT_STORE", "memory")
+	viper.SetDefault("RATE_LIMIT_POLICIES", "global=ip:100/1s:burst=200,auth=ip:10/1m:sliding_window")
+	viper.SetDefault("RATE_LIMIT_DRY_RUN", false)
+	viper.SetDefault("CONCURRENCY_LIMIT_ENABLED", false)
+	viper.SetDefault("CONCURRENCY_ALGORITHM", "gradient")
+	viper.SetDefault("CONCURRENCY_INITIAL_LIMIT", 20)
+	viper.SetDefault("CONCURRENCY_MIN_LIMIT", 4)
+	viper.SetDefault("CONCURRENCY_MAX_LIMIT", 200)
+	viper.SetDefault("CONCURRENCY_QUEUE_SIZE", 50)
+	viper.SetDefault("CONCURRENCY_QUEUE_TIMEOUT", "100ms")
+	viper.SetDefault("CONCURRENCY_LATENCY_TARGET", "500ms")
+	viper.SetDefault("CONCURRENCY_RETRY_AFTER", "1s")
+	viper.SetDefault("METRICS_ENABLED", true)
+	viper.SetDefault("METRICS_PATH", "/metrics")
+	viper.SetDefault("TRACING_EXPORTER", "none")
+	viper.SetDefault("TRACING_ENDPOINT", "")
+	viper.SetDefault("TRACING_INSECURE", false)
+	viper.SetDefault("TRACING_SAMPLE_RATIO", 1.0)
+	viper.SetDefault("TRACING_SERVICE_NAME", "template-go-echo")
+	viper.SetDefault("LOG_LEVEL", "info")
+	viper.SetDefault("LOG_FORMAT", "text")
+	viper.SetDefault("LOG_LEVELS", "")
+	viper.SetDefault("LOG_REDACT_MODE", "mask")
+	viper.SetDefault("LOG_REDACT_KEYS", "email,password,token,access_token,refresh_token,authorization,secret,remote_ip,ip_address")
+	viper.SetDefault("LOG_REDACT_PATTERNS", "email,ipv4,jwt,bearer")
+	viper.SetDefault("LOG_REDACT_HASH_KEY", "")
 
 	// Read environment variables
 	viper.AutomaticEnv()
@@ -65,16 +299,119 @@ func Load() *Config {
 			Debug:   viper.GetBool("SERVER_DEBUG"),
 		},
 		Database: DatabaseConfig{
-			Driver:   viper.GetString("DB_DRIVER"),
-			DSN:      viper.GetString("DB_DSN"),
-			MaxConns: viper.GetInt("DB_MAX_CONNS"),
+			Driver:                viper.GetString("DB_DRIVER"),
+			DSN:                   viper.GetString("DB_DSN"),
+			ReplicaDSNs:           splitList(viper.GetString("DB_REPLICA_DSNS")),
+			ReplicaPolicy:         viper.GetString("DB_REPLICA_POLICY"),
+			ReplicaHealthInterval: viper.GetDuration("DB_REPLICA_HEALTH_INTERVAL"),
+			MaxConns:              viper.GetInt("DB_MAX_CONNS"),
+			MigrateOnStartup:      viper.GetBool("DB_MIGRATE_ON_STARTUP"),
+			MigrationLockTimeout:  viper.GetDuration("DB_MIGRATION_LOCK_TIMEOUT"),
+			SlowQueryThreshold:    viper.GetDuration("DB_SLOW_QUERY_THRESHOLD"),
+			QueryComments:         viper.GetBool("DB_QUERY_COMMENTS"),
+			PreparedStatements:    viper.GetBool("DB_PREPARED_STATEMENTS"),
 		},
 		JWT: JWTConfig{
 			Secret: viper.GetString("JWT_SECRET"),
 			TTL:    viper.GetInt("JWT_TTL"),
 		},
+		Account: AccountConfig{
+			DeletionGracePeriod:    viper.GetDuration("ACCOUNT_DELETION_GRACE_PERIOD"),
+			PurgeInterval:          viper.GetDuration("ACCOUNT_PURGE_INTERVAL"),
+			SessionCleanupSchedule: viper.GetString("SESSION_CLEANUP_SCHEDULE"),
+		},
+		Audit: AuditConfig{
+			BufferSize:    viper.GetInt("AUDIT_BUFFER_SIZE"),
+			BatchSize:     viper.GetInt("AUDIT_BATCH_SIZE"),
+			FlushInterval: viper.GetDuration("AUDIT_FLUSH_INTERVAL"),
+		},
+		Outbox: OutboxConfig{
+			RelayEnabled: viper.GetBool("OUTBOX_RELAY_ENABLED"),
+			PollInterval: viper.GetDuration("OUTBOX_POLL_INTERVAL"),
+			BatchSize:    viper.GetInt("OUTBOX_BATCH_SIZE"),
+			Retention:    viper.GetDuration("OUTBOX_RETENTION"),
+		},
+		Webhook: WebhookConfig{
+			DeliveryEnabled: viper.GetBool("WEBHOOK_DELIVERY_ENABLED"),
+			PollInterval:    viper.GetDuration("WEBHOOK_POLL_INTERVAL"),
+			Timeout:         viper.GetDuration("WEBHOOK_TIMEOUT"),
+			Concurrency:     viper.GetInt("WEBHOOK_CONCURRENCY"),
+			MaxAttempts:     viper.GetInt("WEBHOOK_MAX_ATTEMPTS"),
+			DisableAfter:    viper.GetInt("WEBHOOK_DISABLE_AFTER"),
+		},
+		Scheduler: SchedulerConfig{
+			Enabled:          viper.GetBool("SCHEDULER_ENABLED"),
+			HistoryRetention: viper.GetDuration("SCHEDULER_HISTORY_RETENTION"),
+		},
+		Jobs: JobsConfig{
+			WorkerEnabled: viper.GetBool("JOBS_WORKER_ENABLED"),
+			PollInterval:  viper.GetDuration("JOBS_POLL_INTERVAL"),
+			Concurrency:   viper.GetInt("JOBS_CONCURRENCY"),
+			Retention:     viper.GetDuration("JOBS_RETENTION"),
+		},
+		Mail: MailConfig{
+			SMTPAddr: viper.GetString("MAIL_SMTP_ADDR"),
+			Username: viper.GetString("MAIL_USERNAME"),
+			Password: viper.GetString("MAIL_PASSWORD"),
+			From:     viper.GetString("MAIL_FROM"),
+			Timeout:  viper.GetDuration("MAIL_TIMEOUT"),
+		},
+		Idempotency: IdempotencyConfig{
+			Store:       viper.GetString("IDEMPOTENCY_STORE"),
+			TTL:         viper.GetDuration("IDEMPOTENCY_TTL"),
+			LockTimeout: viper.GetDuration("IDEMPOTENCY_LOCK_TIMEOUT"),
+			Secret:      viper.GetString("IDEMPOTENCY_SECRET"),
+		},
+		Cache: CacheConfig{
+			Backend:             viper.GetString("CACHE_BACKEND"),
+			Size:                viper.GetInt("CACHE_SIZE"),
+			TTL:                 viper.GetDuration("CACHE_TTL"),
+			NegativeTTL:         viper.GetDuration("CACHE_NEGATIVE_TTL"),
+			InvalidationChannel: viper.GetString("CACHE_INVALIDATION_CHANNEL"),
+		},
+		Redis: RedisConfig{
+			Addr:     viper.GetString("REDIS_ADDR"),
+			Password: viper.GetString("REDIS_PASSWORD"),
+			DB:       viper.GetInt("REDIS_DB"),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:  viper.GetBool("RATE_LIMIT_ENABLED"),
+			Store:    viper.GetString("RATE_LIMIT_STORE"),
+			Policies: viper.GetString("RATE_LIMIT_POLICIES"),
+			DryRun:   viper.GetBool("RATE_LIMIT_DRY_RUN"),
+		},
+		Concurrency: ConcurrencyConfig{
+			Enabled:       viper.GetBool("CONCURRENCY_LIMIT_ENABLED"),
+			Algorithm:     viper.GetString("CONCURRENCY_ALGORITHM"),
+			InitialLimit:  viper.GetInt("CONCURRENCY_INITIAL_LIMIT"),
+			MinLimit:      viper.GetInt("CONCURRENCY_MIN_LIMIT"),
+			MaxLimit:      viper.GetInt("CONCURRENCY_MAX_LIMIT"),
+			QueueSize:     viper.GetInt("CONCURRENCY_QUEUE_SIZE"),
+			QueueTimeout:  viper.GetDuration("CONCURRENCY_QUEUE_TIMEOUT"),
+			LatencyTarget: viper.GetDuration("CONCURRENCY_LATENCY_TARGET"),
+			RetryAfter:    viper.GetDuration("CONCURRENCY_RETRY_AFTER"),
+		},
+		Metrics: MetricsConfig{
+			Enabled: viper.GetBool("METRICS_ENABLED"),
+			Path:    viper.GetString("METRICS_PATH"),
+		},
+		Tracing: TracingConfig{
+			Exporter:    viper.GetString("TRACING_EXPORTER"),
+			Endpoint:    viper.GetString("TRACING_ENDPOINT"),
+			Insecure:    viper.GetBool("TRACING_INSECURE"),
+			SampleRatio: viper.GetFloat64("TRACING_SAMPLE_RATIO"),
+			ServiceName: viper.GetString("TRACING_SERVICE_NAME"),
+		},
+		Log: LogConfig{
+			Level:          viper.GetString("LOG_LEVEL"),
+			Format:         viper.GetString("LOG_FORMAT"),
+			Levels:         viper.GetString("LOG_LEVELS"),
+			RedactMode:     viper.GetString("LOG_REDACT_MODE"),
+			RedactKeys:     splitList(viper.GetString("LOG_REDACT_KEYS")),
+			RedactPatterns: splitList(viper.GetString("LOG_REDACT_PATTERNS")),
+			RedactHashKey:  viper.GetString("LOG_REDACT_HASH_KEY"),
+		},
 	}
-
 	cfg.Validate()
 	return cfg
 }
@@ -87,12 +424,27 @@ func (c *Config) Validate() {
 	if c.Database.Driver == "" {
 		log.Fatal("DB_DRIVER is required")
 	}
+	if c.Database.Driver != "mysql" && c.Database.Driver != "postgres" && c.Database.Driver != "sqlite" {
+		log.Fatal("DB_DRIVER must be mysql, postgres or sqlite")
+	}
 	if c.Database.DSN == "" {
 		log.Fatal("DB_DSN is required")
 	}
 	if c.Database.MaxConns <= 0 {
 		log.Fatal("DB_MAX_CONNS must be greater than 0")
 	}
+	if c.Database.ReplicaPolicy != "round_robin" && c.Database.ReplicaPolicy != "least_connections" {
+		log.Fatal("DB_REPLICA_POLICY must be round_robin or least_connections")
+	}
+	if c.Database.ReplicaHealthInterval <= 0 {
+		log.Fatal("DB_REPLICA_HEALTH_INTERVAL must be greater than 0")
+	}
+	if c.Database.MigrationLockTimeout <= 0 {
+		log.Fatal("DB_MIGRATION_LOCK_TIMEOUT must be greater than 0")
+	}
+	if c.Database.SlowQueryThreshold < 0 {
+		log.Fatal("DB_SLOW_QUERY_THRESHOLD must not be negative")
+	}
 	if c.JWT.Secret == "" {
 		log.Fatal("JWT_SECRET is required")
 	}
@@ -102,4 +454,134 @@ func (c *Config) Validate() {
 	if c.JWT.TTL <= 0 {
 		log.Fatal("JWT_TTL must be greater than 0")
 	}
+	if c.Account.DeletionGracePeriod < 0 {
+		log.Fatal("ACCOUNT_DELETION_GRACE_PERIOD must not be negative")
+	}
+	if c.Account.PurgeInterval <= 0 {
+		log.Fatal("ACCOUNT_PURGE_INTERVAL must be greater than 0")
+	}
+	if c.Account.SessionCleanupSchedule == "" {
+		log.Fatal("SESSION_CLEANUP_SCHEDULE is required")
+	}
+	if c.Audit.BufferSize <= 0 || c.Audit.BatchSize <= 0 {
+		log.Fatal("AUDIT_BUFFER_SIZE and AUDIT_BATCH_SIZE must be greater than 0")
+	}
+	if c.Audit.FlushInterval <= 0 {
+		log.Fatal("AUDIT_FLUSH_INTERVAL must be greater than 0")
+	}
+	if c.Outbox.PollInterval <= 0 || c.Outbox.BatchSize <= 0 {
+		log.Fatal("OUTBOX_POLL_INTERVAL and OUTBOX_BATCH_SIZE must be greater than 0")
+	}
+	if c.Outbox.Retention < 0 {
+		log.Fatal("OUTBOX_RETENTION must not be negative")
+	}
+	if c.Webhook.PollInterval <= 0 || c.Webhook.Timeout <= 0 {
+		log.Fatal("WEBHOOK_POLL_INTERVAL and WEBHOOK_TIMEOUT must be greater than 0")
+	}
+	if c.Webhook.Concurrency <= 0 || c.Webhook.MaxAttempts <= 0 {
+		log.Fatal("WEBHOOK_CONCURRENCY and WEBHOOK_MAX_ATTEMPTS must be greater than 0")
+	}
+	if c.Webhook.DisableAfter < 0 {
+		log.Fatal("WEBHOOK_DISABLE_AFTER must not be negative")
+	}
+	if c.Scheduler.HistoryRetention <= 0 {
+		log.Fatal("SCHEDULER_HISTORY_RETENTION must be greater than 0")
+	}
+	if c.Jobs.PollInterval <= 0 || c.Jobs.Concurrency <= 0 {
+		log.Fatal("JOBS_POLL_INTERVAL and JOBS_CONCURRENCY must be greater than 0")
+	}
+	if c.Jobs.Retention <= 0 {
+		log.Fatal("JOBS_RETENTION must be greater than 0")
+	}
+	if c.Mail.SMTPAddr != "" && c.Mail.From == "" {
+		log.Fatal("MAIL_FROM is required when MAIL_SMTP_ADDR is set")
+	}
+	if c.Mail.Timeout <= 0 {
+		log.Fatal("MAIL_TIMEOUT must be greater than 0")
+	}
+	if c.Idempotency.Store != "memory" && c.Idempotency.Store != "database" {
+		log.Fatal("IDEMPOTENCY_STORE must be memory or database")
+	}
+	if c.Idempotency.TTL <= 0 || c.Idempotency.LockTimeout <= 0 {
+		log.Fatal("IDEMPOTENCY_TTL and IDEMPOTENCY_LOCK_TIMEOUT must be greater than 0")
+	}
+	if c.Idempotency.LockTimeout > c.Idempotency.TTL {
+		log.Fatal("IDEMPOTENCY_LOCK_TIMEOUT must not exceed IDEMPOTENCY_TTL")
+	}
+	if c.Idempotency.Secret == "" {
+		log.Fatal("IDEMPOTENCY_SECRET is required")
+	}
+	if c.Idempotency.Secret == c.JWT.Secret {
+		log.Fatal("IDEMPOTENCY_SECRET must differ from JWT_SECRET")
+	}
+	if c.Cache.Backend != "none" && c.Cache.Backend != "memory" && c.Cache.Backend != "redis" {
+		log.Fatal("CACHE_BACKEND must be none, memory or redis")
+	}
+	if c.Cache.Backend == "redis" && c.Redis.Addr == "" {
+		log.Fatal("REDIS_ADDR is required for the redis cache backend")
+	}
+	if c.Cache.Size <= 0 || c.Cache.TTL <= 0 {
+		log.Fatal("CACHE_SIZE and CACHE_TTL must be greater than 0")
+	}
+	if c.Cache.NegativeTTL < 0 {
+		log.Fatal("CACHE_NEGATIVE_TTL must not be negative")
+	}
+	if c.Cache.InvalidationChannel == "" {
+		log.Fatal("CACHE_INVALIDATION_CHANNEL is required")
+	}
+	if c.RateLimit.Store != "memory" && c.RateLimit.Store != "redis" {
+		log.Fatal("RATE_LIMIT_STORE must be memory or redis")
+	}
+	if c.RateLimit.Enabled && c.RateLimit.Store == "redis" && c.Redis.Addr == "" {
+		log.Fatal("REDIS_ADDR is required for the redis rate limit store")
+	}
+	if c.Concurrency.Algorithm != "aimd" && c.Concurrency.Algorithm != "gradient" {
+		log.Fatal("CONCURRENCY_ALGORITHM must be aimd or gradient")
+	}
+	if c.Concurrency.MinLimit <= 0 || c.Concurrency.MaxLimit < c.Concurrency.MinLimit {
+		log.Fatal("CONCURRENCY_MIN_LIMIT must be greater than 0 and not exceed CONCURRENCY_MAX_LIMIT")
+	}
+	if c.Concurrency.InitialLimit < c.Concurrency.MinLimit || c.Concurrency.InitialLimit > c.Concurrency.MaxLimit {
+		log.Fatal("CONCURRENCY_INITIAL_LIMIT must be between CONCURRENCY_MIN_LIMIT and CONCURRENCY_MAX_LIMIT")
+	}
+	if c.Concurrency.QueueSize < 0 {
+		log.Fatal("CONCURRENCY_QUEUE_SIZE must not be negative")
+	}
+	if c.Concurrency.QueueTimeout <= 0 || c.Concurrency.LatencyTarget <= 0 || c.Concurrency.RetryAfter <= 0 {
+		log.Fatal("CONCURRENCY_QUEUE_TIMEOUT, CONCURRENCY_LATENCY_TARGET and CONCURRENCY_RETRY_AFTER must be greater than 0")
+	}
+	if !strings.HasPrefix(c.Metrics.Path, "/") {
+		log.Fatal("METRICS_PATH must start with /")
+	}
+	switch c.Tracing.Exporter {
+	case "none", "stdout", "otlp-grpc", "otlp-http":
+	default:
+		log.Fatal("TRACING_EXPORTER must be none, stdout, otlp-grpc or otlp-http")
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		log.Fatal("TRACING_SAMPLE_RATIO must be between 0 and 1")
+	}
+	if c.Tracing.ServiceName == "" {
+		log.Fatal("TRACING_SERVICE_NAME is required")
+	}
+	if c.Log.Format != "text" && c.Log.Format != "json" {
+		log.Fatal("LOG_FORMAT must be text or json")
+	}
+	if c.Log.RedactMode != "mask" && c.Log.RedactMode != "hash" && c.Log.RedactMode != "partial" {
+		log.Fatal("LOG_REDACT_MODE must be mask, hash or partial")
+	}
+	if c.Log.RedactMode == "hash" && c.Log.RedactHashKey == "" {
+		log.Fatal("LOG_REDACT_HASH_KEY is required when LOG_REDACT_MODE is hash")
+	}
+}
+
+// splitList splits a comma-separated value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
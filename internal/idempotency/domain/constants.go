@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+const (
+	// Request and response headers
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	// Key constraints
+	MaxKeyLength = 255
+
+	// Responses larger than this are not stored, and retries run the request again
+	MaxResponseBytes = 1 << 20
+
+	// Store defaults
+	DefaultTTL         = 24 * time.Hour
+	DefaultLockTimeout = time.Minute
+)
+
+// Record statuses
+const (
+	StatusProcessing = "processing"
+	StatusCompleted  = "completed"
+)
+
+// Store types selected by configuration
+const (
+	StoreMemory   = "memory"
+	StoreDatabase = "database"
+)
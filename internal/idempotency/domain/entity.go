@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"net/http"
+	"time"
+)
+
+// Record is a claimed idempotency key and, once its request finished, the response
+// replayed to retries
+type Record struct {
+	Key         string
+	RequestHash string
+	Status      string
+	Response    *Response
+	LockedUntil time.Time
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
+
+// IsCompleted checks if the request owning the key finished and its response was stored
+func (r *Record) IsCompleted() bool {
+	return r.Status == StatusCompleted
+}
+
+// Response is a captured HTTP response
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// ReplayedHeaders lists the response headers stored and replayed with a response;
+// headers such as X-Request-Id describe the original request and are not replayed
+var ReplayedHeaders = []string{"Content-Type", "Location", "ETag"}
+
+// IsValidStore checks if a store type is recognized
+func IsValidStore(store string) bool {
+	return store == StoreMemory || store == StoreDatabase
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "github.com/zercle/template-go-echo/pkg"
+
+// Idempotency domain-specific error codes
+const (
+	ErrCodeInvalidKey        = "INVALID_IDEMPOTENCY_KEY"
+	ErrCodeRequestInProgress = "IDEMPOTENCY_REQUEST_IN_PROGRESS"
+	ErrCodeKeyReused         = "IDEMPOTENCY_KEY_REUSED"
+)
+
+// Idempotency domain errors
+var (
+	ErrInvalidKey = pkg.NewDomainError(
+		ErrCodeInvalidKey,
+		"Idempotency-Key must be between 1 and 255 characters",
+	)
+
+	ErrRequestInProgress = pkg.NewDomainError(
+		ErrCodeRequestInProgress,
+		"a request with this Idempotency-Key is still being processed; retry later",
+	)
+
+	ErrKeyReused = pkg.NewDomainError(
+		ErrCodeKeyReused,
+		"Idempotency-Key was already used for a different request",
+	)
+)
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Store persists idempotency keys and the responses of the requests that claimed them.
+// Implementations must make Reserve atomic across all API instances sharing the store.
+type Store interface {
+	// Reserve claims key for a request with the given fingerprint, locked until lockedUntil
+	// and kept until expiresAt. It returns nil when the caller now owns the key, or the live
+	// record holding it otherwise. Records that expired or whose lock lapsed at now are
+	// taken over.
+	Reserve(ctx context.Context, key, requestHash string, now, lockedUntil, expiresAt time.Time) (*Record, error)
+
+	// Complete stores the response of the request owning key
+	Complete(ctx context.Context, key string, response *Response) error
+
+	// Release deletes an unfinished key so that its request can be retried
+	Release(ctx context.Context, key string) error
+
+	// DeleteExpired deletes keys that expired at or before the cutoff and returns how many were removed
+	DeleteExpired(ctx context.Context, before time.Time) (int, error)
+}
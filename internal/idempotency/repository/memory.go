@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/idempotency/domain"
+)
+
+// memorySweepInterval is how often Reserve drops expired keys from a MemoryStore
+const memorySweepInterval = time.Minute
+
+// MemoryStore implements domain.Store in process memory. Keys are not shared
+// between API instances and are lost on restart, so it suits single instance
+// deployments and tests.
+type MemoryStore struct {
+	mu        sync.Mutex
+	records   map[string]*domain.Record
+	lastSweep time.Time
+}
+
+// NewMemoryStore creates an empty in-memory idempotency store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string]*domain.Record)}
+}
+
+// Reserve claims key unless a live record holds it
+func (s *MemoryStore) Reserve(ctx context.Context, key, requestHash string, now, lockedUntil, expiresAt time.Time) (*domain.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= memorySweepInterval {
+		s.deleteExpired(now)
+		s.lastSweep = now
+	}
+
+	if record, ok := s.records[key]; ok && !reclaimable(record, now) {
+		return copyRecord(record), nil
+	}
+
+	s.records[key] = &domain.Record{
+		Key:         key,
+		RequestHash: requestHash,
+		Status:      domain.StatusProcessing,
+		LockedUntil: lockedUntil,
+		ExpiresAt:   expiresAt,
+		CreatedAt:   now,
+	}
+	return nil, nil
+}
+
+// Complete stores the response of the request owning key
+func (s *MemoryStore) Complete(ctx context.Context, key string, response *domain.Response) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok || record.IsCompleted() {
+		return nil
+	}
+	record.Status = domain.StatusCompleted
+	record.Response = &domain.Response{
+		StatusCode: response.StatusCode,
+		Header:     response.Header.Clone(),
+		Body:       append([]byte(nil), response.Body...),
+	}
+	return nil
+}
+
+// Release deletes an unfinished key so that its request can be retried
+func (s *MemoryStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[key]; ok && !record.IsCompleted() {
+		delete(s.records, key)
+	}
+	return nil
+}
+
+// DeleteExpired deletes keys that expired at or before the cutoff
+func (s *MemoryStore) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.deleteExpired(before), nil
+}
+
+// deleteExpired deletes expired keys; the caller must hold the lock
+func (s *MemoryStore) deleteExpired(before time.Time) int {
+	deleted := 0
+	for key, record := range s.records {
+		if !record.ExpiresAt.After(before) {
+			delete(s.records, key)
+			deleted++
+		}
+	}
+	return deleted
+}
+
+// reclaimable reports whether a record expired or its unfinished request stopped responding
+func reclaimable(record *domain.Record, now time.Time) bool {
+	if !record.ExpiresAt.After(now) {
+		return true
+	}
+	return !record.IsCompleted() && !record.LockedUntil.After(now)
+}
+
+// copyRecord returns a copy of record that callers may keep after the lock is released
+func copyRecord(record *domain.Record) *domain.Record {
+	clone := *record
+	if record.Response != nil {
+		clone.Response = &domain.Response{
+			StatusCode: record.Response.StatusCode,
+			Header:     record.Response.Header.Clone(),
+			Body:       record.Response.Body,
+		}
+	}
+	return &clone
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/idempotency/domain"
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+	"github.com/zercle/template-go-echo/internal/infrastructure/sqlc"
+)
+
+// maxReserveAttempts bounds the insert, reclaim and read cycle when a key is
+// released by its owner while another request tries to reserve it
+const maxReserveAttempts = 3
+
+// Store implements domain.Store on the idempotency_keys table, so that keys
+// are shared by every API instance
+type Store struct {
+	q sqlc.Querier
+}
+
+// New creates a new database idempotency store with sqlc querier
+func New(q sqlc.Querier) *Store {
+	return &Store{q: q}
+}
+
+// Reserve claims key by inserting it; the primary key settles concurrent reservations
+func (s *Store) Reserve(ctx context.Context, key, requestHash string, now, lockedUntil, expiresAt time.Time) (*domain.Record, error) {
+	// A key reserved a moment ago may not have reached the read replicas yet
+	ctx = database.WithPrimary(ctx)
+
+	for attempt := 0; attempt < maxReserveAttempts; attempt++ {
+		err := s.q.CreateIdempotencyKey(ctx, sqlc.CreateIdempotencyKeyParams{
+			ID:          key,
+			RequestHash: requestHash,
+			LockedUntil: lockedUntil,
+			ExpiresAt:   expiresAt,
+			CreatedAt:   now,
+		})
+		if err == nil {
+			return nil, nil
+		}
+		if !database.IsDuplicateKey(err) {
+			slog.Error("failed to create idempotency key", slog.String("error", err.Error()))
+			return nil, err
+		}
+
+		reclaimed, err := s.q.ReclaimIdempotencyKey(ctx, sqlc.ReclaimIdempotencyKeyParams{
+			RequestHash: requestHash,
+			LockedUntil: lockedUntil,
+			ExpiresAt:   expiresAt,
+			Now:         now,
+			ID:          key,
+		})
+		if err != nil {
+			slog.Error("failed to reclaim idempotency key", slog.String("error", err.Error()))
+			return nil, err
+		}
+		if reclaimed > 0 {
+			return nil, nil
+		}
+
+		row, err := s.q.GetIdempotencyKey(ctx, key)
+		if err == sql.ErrNoRows {
+			// Released by its owner in the meantime
+			continue
+		}
+		if err != nil {
+			slog.Error("failed to get idempotency key", slog.String("error", err.Error()))
+			return nil, err
+		}
+		return sqlcRecordToDomain(&row)
+	}
+
+	return nil, domain.ErrRequestInProgress
+}
+
+// Complete stores the response of the request owning key
+func (s *Store) Complete(ctx context.Context, key string, response *domain.Response) error {
+	header, err := json.Marshal(response.Header)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.q.CompleteIdempotencyKey(ctx, sqlc.CompleteIdempotencyKeyParams{
+		ResponseStatus:  int32(response.StatusCode),
+		ResponseHeaders: header,
+		ResponseBody:    sql.NullString{String: string(response.Body), Valid: true},
+		ID:              key,
+	})
+	if err != nil {
+		slog.Error("failed to complete idempotency key", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// Release deletes an unfinished key so that its request can be retried
+func (s *Store) Release(ctx context.Context, key string) error {
+	if err := s.q.DeleteIdempotencyKey(ctx, key); err != nil {
+		slog.Error("failed to release idempotency key", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// DeleteExpired deletes keys that expired at or before the cutoff
+func (s *Store) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	deleted, err := s.q.DeleteExpiredIdempotencyKeys(ctx, before)
+	if err != nil {
+		slog.Error("failed to delete expired idempotency keys", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
+
+// sqlcRecordToDomain converts an sqlc idempotency key to a domain record
+func sqlcRecordToDomain(row *sqlc.IdempotencyKeys) (*domain.Record, error) {
+	record := &domain.Record{
+		Key:         row.ID,
+		RequestHash: row.RequestHash,
+		Status:      row.Status,
+		LockedUntil: row.LockedUntil,
+		ExpiresAt:   row.ExpiresAt,
+		CreatedAt:   row.CreatedAt,
+	}
+
+	if record.IsCompleted() {
+		response := &domain.Response{
+			StatusCode: int(row.ResponseStatus),
+			Header:     http.Header{},
+			Body:       []byte(row.ResponseBody.String),
+		}
+		if len(row.ResponseHeaders) > 0 {
+			if err := json.Unmarshal(row.ResponseHeaders, &response.Header); err != nil {
+				slog.Error("failed to decode idempotency response headers", slog.String("error", err.Error()))
+				return nil, err
+			}
+		}
+		record.Response = response
+	}
+
+	return record, nil
+}
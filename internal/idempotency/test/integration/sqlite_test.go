@@ -0,0 +1,115 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/idempotency/domain"
+	"github.com/zercle/template-go-echo/internal/idempotency/repository"
+	"github.com/zercle/template-go-echo/internal/infrastructure/test/sqlitetest"
+)
+
+func TestSQLiteStoreReplaysCompletedResponse(t *testing.T) {
+	store := repository.New(sqlitetest.Open(t).Querier())
+	ctx := context.Background()
+	now := time.Now()
+
+	record, err := store.Reserve(ctx, "key", "hash", now, now.Add(time.Minute), now.Add(time.Hour))
+	if err != nil || record != nil {
+		t.Fatalf("expected key to be reserved, got %v, %v", record, err)
+	}
+
+	// A retry while the first request runs sees the processing record
+	record, err = store.Reserve(ctx, "key", "hash", now, now.Add(time.Minute), now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record == nil || record.IsCompleted() || record.RequestHash != "hash" {
+		t.Fatalf("expected processing record, got %+v", record)
+	}
+
+	response := &domain.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Location": {"/users/1"}},
+		Body:       []byte(`{"id":"1"}`),
+	}
+	if err := store.Complete(ctx, "key", response); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	record, err = store.Reserve(ctx, "key", "hash", now, now.Add(time.Minute), now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record == nil || !record.IsCompleted() {
+		t.Fatalf("expected completed record, got %+v", record)
+	}
+	if record.Response.StatusCode != http.StatusCreated ||
+		record.Response.Header.Get("Location") != "/users/1" ||
+		string(record.Response.Body) != `{"id":"1"}` {
+		t.Errorf("unexpected stored response %+v", record.Response)
+	}
+
+	// Completed keys are not released
+	if err := store.Release(ctx, "key"); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if record, _ := store.Reserve(ctx, "key", "hash", now, now.Add(time.Minute), now.Add(time.Hour)); record == nil || !record.IsCompleted() {
+		t.Error("expected completed key to survive release")
+	}
+}
+
+func TestSQLiteStoreReclaimsLapsedKeys(t *testing.T) {
+	store := repository.New(sqlitetest.Open(t).Querier())
+	ctx := context.Background()
+	now := time.Now()
+
+	// A request that stopped responding no longer blocks retries
+	_, _ = store.Reserve(ctx, "stuck", "hash", now, now.Add(time.Minute), now.Add(time.Hour))
+	later := now.Add(2 * time.Minute)
+	record, err := store.Reserve(ctx, "stuck", "other-hash", later, later.Add(time.Minute), later.Add(time.Hour))
+	if err != nil || record != nil {
+		t.Fatalf("expected a key whose lock lapsed to be taken over, got %+v, %v", record, err)
+	}
+
+	// An expired completed key may be reused for a different request
+	_, _ = store.Reserve(ctx, "done", "hash", now, now.Add(time.Minute), now.Add(time.Hour))
+	_ = store.Complete(ctx, "done", &domain.Response{StatusCode: http.StatusOK, Header: http.Header{}})
+	if record, _ := store.Reserve(ctx, "done", "hash", later, later.Add(time.Minute), later.Add(time.Hour)); record == nil {
+		t.Fatal("expected an unexpired completed key to be kept")
+	}
+	expired := now.Add(2 * time.Hour)
+	record, err = store.Reserve(ctx, "done", "other-hash", expired, expired.Add(time.Minute), expired.Add(time.Hour))
+	if err != nil || record != nil {
+		t.Fatalf("expected an expired key to be taken over, got %+v, %v", record, err)
+	}
+}
+
+func TestSQLiteStoreReleaseAndDeleteExpired(t *testing.T) {
+	store := repository.New(sqlitetest.Open(t).Querier())
+	ctx := context.Background()
+	now := time.Now()
+
+	// Released keys may be reserved again right away
+	_, _ = store.Reserve(ctx, "failed", "hash", now, now.Add(time.Minute), now.Add(time.Hour))
+	if err := store.Release(ctx, "failed"); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if record, err := store.Reserve(ctx, "failed", "hash", now, now.Add(time.Minute), now.Add(time.Hour)); err != nil || record != nil {
+		t.Fatalf("expected a released key to be reserved again, got %+v, %v", record, err)
+	}
+
+	_, _ = store.Reserve(ctx, "short", "hash", now, now.Add(time.Minute), now.Add(10*time.Minute))
+	deleted, err := store.DeleteExpired(ctx, now.Add(30*time.Minute))
+	if err != nil {
+		t.Fatalf("DeleteExpired failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected 1 expired key deleted, got %d", deleted)
+	}
+	if record, _ := store.Reserve(ctx, "failed", "hash", now, now.Add(time.Minute), now.Add(time.Hour)); record == nil {
+		t.Error("expected the unexpired key to be kept")
+	}
+}
@@ -0,0 +1,86 @@
+package unit_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/idempotency/domain"
+	"github.com/zercle/template-go-echo/internal/idempotency/repository"
+)
+
+func TestMemoryStoreReserve(t *testing.T) {
+	store := repository.NewMemoryStore()
+	ctx := context.Background()
+	now := time.Now()
+
+	record, err := store.Reserve(ctx, "key", "hash", now, now.Add(time.Minute), now.Add(time.Hour))
+	if err != nil || record != nil {
+		t.Fatalf("expected key to be reserved, got %v, %v", record, err)
+	}
+
+	record, _ = store.Reserve(ctx, "key", "hash", now, now.Add(time.Minute), now.Add(time.Hour))
+	if record == nil || record.IsCompleted() {
+		t.Fatalf("expected processing record, got %v", record)
+	}
+
+	response := &domain.Response{StatusCode: http.StatusCreated, Header: http.Header{"Location": {"/users/1"}}, Body: []byte("{}")}
+	if err := store.Complete(ctx, "key", response); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	record, _ = store.Reserve(ctx, "key", "hash", now, now.Add(time.Minute), now.Add(time.Hour))
+	if record == nil || !record.IsCompleted() {
+		t.Fatalf("expected completed record, got %v", record)
+	}
+	if record.Response.StatusCode != http.StatusCreated || record.Response.Header.Get("Location") != "/users/1" {
+		t.Errorf("unexpected stored response %+v", record.Response)
+	}
+
+	// Completed keys are not released
+	_ = store.Release(ctx, "key")
+	if record, _ := store.Reserve(ctx, "key", "hash", now, now.Add(time.Minute), now.Add(time.Hour)); record == nil {
+		t.Error("expected completed key to survive release")
+	}
+}
+
+func TestMemoryStoreReclaimsLapsedKeys(t *testing.T) {
+	store := repository.NewMemoryStore()
+	ctx := context.Background()
+	now := time.Now()
+
+	_, _ = store.Reserve(ctx, "stuck", "hash", now, now.Add(time.Minute), now.Add(time.Hour))
+	later := now.Add(2 * time.Minute)
+	if record, _ := store.Reserve(ctx, "stuck", "hash", later, later.Add(time.Minute), later.Add(time.Hour)); record != nil {
+		t.Error("expected a key whose lock lapsed to be taken over")
+	}
+
+	_, _ = store.Reserve(ctx, "done", "hash", now, now.Add(time.Minute), now.Add(time.Hour))
+	_ = store.Complete(ctx, "done", &domain.Response{StatusCode: http.StatusOK})
+	if record, _ := store.Reserve(ctx, "done", "hash", later, later.Add(time.Minute), later.Add(time.Hour)); record == nil {
+		t.Error("expected a completed key to be kept until it expires")
+	}
+
+	expired := now.Add(2 * time.Hour)
+	if record, _ := store.Reserve(ctx, "done", "other", expired, expired.Add(time.Minute), expired.Add(time.Hour)); record != nil {
+		t.Error("expected an expired key to be reusable")
+	}
+}
+
+func TestMemoryStoreDeleteExpired(t *testing.T) {
+	store := repository.NewMemoryStore()
+	ctx := context.Background()
+	now := time.Now()
+
+	_, _ = store.Reserve(ctx, "short", "hash", now, now.Add(time.Minute), now.Add(time.Minute))
+	_, _ = store.Reserve(ctx, "long", "hash", now, now.Add(time.Minute), now.Add(time.Hour))
+
+	deleted, err := store.DeleteExpired(ctx, now.Add(30*time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected 1 expired key, got %d", deleted)
+	}
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/idempotency/domain"
+	schedulerdomain "github.com/zercle/template-go-echo/internal/scheduler/domain"
+)
+
+// CleanupJobName is the name of the scheduled job removing expired idempotency keys
+const CleanupJobName = "idempotency.cleanup"
+
+// CleanupJob returns an hourly scheduled job deleting expired idempotency keys and
+// their stored responses
+func CleanupJob(store domain.Store) schedulerdomain.Job {
+	return schedulerdomain.Job{
+		Name:     CleanupJobName,
+		Schedule: "@hourly",
+		Jitter:   5 * time.Minute,
+		Timeout:  5 * time.Minute,
+		Run: func(ctx context.Context) error {
+			deleted, err := store.DeleteExpired(ctx, time.Now())
+			if err != nil {
+				return err
+			}
+			if deleted > 0 {
+				slog.Info("deleted expired idempotency keys", slog.Int("count", deleted))
+			}
+			return nil
+		},
+	}
+}
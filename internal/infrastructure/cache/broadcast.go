@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultInvalidationChannel is the pub/sub channel invalidations are published on
+const DefaultInvalidationChannel = "template-go-echo:cache:invalidate"
+
+// resubscribeDelay is how long Run waits before receiving again after an error
+const resubscribeDelay = time.Second
+
+// invalidation is the message announcing keys deleted by an instance
+type invalidation struct {
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys"`
+}
+
+// Broadcast wraps the LRU of an instance so that keys deleted by one instance
+// are deleted from the LRU of every instance, through a Redis pub/sub channel.
+//
+// Pub/sub does not keep messages for subscribers that are disconnected, so
+// the LRU is cleared whenever the subscription is established again; the
+// entries cached meanwhile may have missed their invalidation.
+type Broadcast struct {
+	*LRU
+	client  redis.UniversalClient
+	channel string
+	origin  string
+}
+
+// NewBroadcast wraps local, publishing deletions on channel of client. Run
+// must be running for the instance to apply the deletions of the others.
+func NewBroadcast(local *LRU, client redis.UniversalClient, channel string) *Broadcast {
+	return &Broadcast{
+		LRU:     local,
+		client:  client,
+		channel: channel,
+		origin:  uuid.NewString(),
+	}
+}
+
+// Delete removes the keys locally and publishes them to the other instances
+func (b *Broadcast) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	if err := b.LRU.Delete(ctx, keys...); err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(invalidation{Origin: b.origin, Keys: keys})
+	if err != nil {
+		return err
+	}
+	return b.client.Publish(ctx, b.channel, payload).Err()
+}
+
+// Run deletes the keys published by other instances until ctx is done
+func (b *Broadcast) Run(ctx context.Context) {
+	sub := b.client.Subscribe(ctx, b.channel)
+	defer sub.Close()
+
+	for {
+		msg, err := sub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Warn("failed to receive cache invalidations", slog.String("error", err.Error()))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(resubscribeDelay):
+			}
+			continue
+		}
+
+		switch msg := msg.(type) {
+		case *redis.Subscription:
+			// Invalidations published while unsubscribed are lost
+			if msg.Kind == "subscribe" {
+				b.LRU.Clear()
+			}
+		case *redis.Message:
+			b.apply(ctx, msg.Payload)
+		}
+	}
+}
+
+// apply deletes the keys of an invalidation published by another instance
+func (b *Broadcast) apply(ctx context.Context, payload string) {
+	var inv invalidation
+	if err := json.Unmarshal([]byte(payload), &inv); err != nil {
+		slog.Warn("failed to decode cache invalidation", slog.String("error", err.Error()))
+		return
+	}
+	if inv.Origin == b.origin {
+		return
+	}
+	_ = b.LRU.Delete(ctx, inv.Keys...)
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zercle/template-go-echo/internal/config"
+)
+
+// Cache backends selectable by configuration
+const (
+	BackendNone   = "none"
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+// ErrMiss is returned by Get when the key is not cached
+var ErrMiss = errors.New("cache miss")
+
+// Cache stores byte values under string keys for a limited time. Values may
+// be evicted before they expire, so a Cache only ever spares work that can be
+// done again.
+type Cache interface {
+	// Get returns the value stored under key, or ErrMiss
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set stores value under key until ttl elapses
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the keys; missing keys are ignored
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// New returns the cache selected by cfg, or nil for BackendNone. client is
+// the Redis connection, or nil without one; the memory backend broadcasts its
+// invalidations to the other instances through it, and the returned
+// *Broadcast must then be Run.
+func New(cfg *config.CacheConfig, client redis.UniversalClient) (Cache, error) {
+	switch cfg.Backend {
+	case BackendNone:
+		return nil, nil
+	case BackendMemory:
+		local := NewLRU(cfg.Size)
+		if client == nil {
+			return local, nil
+		}
+		return NewBroadcast(local, client, cfg.InvalidationChannel), nil
+	case BackendRedis:
+		if client == nil {
+			return nil, errors.New("redis cache backend requires a redis address")
+		}
+		return NewRedis(client), nil
+	}
+	return nil, errors.New("unknown cache backend " + cfg.Backend)
+}
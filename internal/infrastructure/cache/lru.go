@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultLRUSize is the number of entries an LRU holds unless set otherwise
+const DefaultLRUSize = 10000
+
+// lruEntry is a cached value and when it expires
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// LRU is an in-process Cache holding at most size entries. Once full, storing
+// a key evicts the least recently used one. Expired entries are dropped when
+// read or evicted. It is safe for concurrent use.
+type LRU struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// NewLRU creates an LRU holding at most size entries, or DefaultLRUSize when
+// size is not positive
+func NewLRU(size int) *LRU {
+	if size <= 0 {
+		size = DefaultLRUSize
+	}
+	return &LRU{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element, size),
+	}
+}
+
+// Get returns the value stored under key, or ErrMiss
+func (c *LRU) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, ErrMiss
+	}
+	entry := elem.Value.(*lruEntry)
+	if !time.Now().Before(entry.expiresAt) {
+		c.remove(elem)
+		return nil, ErrMiss
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, nil
+}
+
+// Set stores value under key until ttl elapses, evicting the least recently
+// used entry when the cache is full
+func (c *LRU) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*lruEntry)
+		entry.value, entry.expiresAt = value, expiresAt
+		c.order.MoveToFront(elem)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	if c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+	return nil
+}
+
+// Delete removes the keys
+func (c *LRU) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if elem, ok := c.entries[key]; ok {
+			c.remove(elem)
+		}
+	}
+	return nil
+}
+
+// Clear removes every entry
+func (c *LRU) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	clear(c.entries)
+}
+
+// Len returns the number of entries held, including expired ones not yet dropped
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// remove drops elem; the caller holds the lock
+func (c *LRU) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*lruEntry).key)
+}
@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultKeyPrefix namespaces the keys the application stores in Redis
+const DefaultKeyPrefix = "template-go-echo:"
+
+// Redis is a Cache shared by every instance connected to the same Redis or
+// Valkey server, so a key deleted by one instance is gone for all of them
+type Redis struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// RedisOption configures optional Redis settings
+type RedisOption func(*Redis)
+
+// WithKeyPrefix sets the prefix of every key, so that several applications
+// can share a server
+func WithKeyPrefix(prefix string) RedisOption {
+	return func(c *Redis) {
+		c.prefix = prefix
+	}
+}
+
+// NewRedis creates a Cache storing its keys on client under DefaultKeyPrefix
+// unless set by options
+func NewRedis(client redis.UniversalClient, opts ...RedisOption) *Redis {
+	c := &Redis{client: client, prefix: DefaultKeyPrefix}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Get returns the value stored under key, or ErrMiss
+func (c *Redis) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrMiss
+	}
+	return value, err
+}
+
+// Set stores value under key until ttl elapses
+func (c *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, c.prefix+key, value, ttl).Err()
+}
+
+// Delete removes the keys
+func (c *Redis) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = c.prefix + key
+	}
+	return c.client.Del(ctx, prefixed...).Err()
+}
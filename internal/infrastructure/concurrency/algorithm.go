@@ -0,0 +1,112 @@
+package concurrency
+
+import (
+	"math"
+	"time"
+)
+
+// Algorithms selectable by configuration
+const (
+	AlgorithmAIMD     = "aimd"
+	AlgorithmGradient = "gradient"
+)
+
+// Sample describes a request that completed under a limit
+type Sample struct {
+	// RTT is how long the request ran, not counting the time it was queued
+	RTT time.Duration
+
+	// InFlight is how many requests were running when it completed, itself included
+	InFlight int
+
+	// Dropped is set when the request timed out or was rejected as overloaded
+	Dropped bool
+}
+
+// Algorithm adjusts a concurrency limit from the requests completing under it.
+// Limits are fractional so that small adjustments add up; the limiter admits
+// the whole part. Calls are serialized by the limiter.
+type Algorithm interface {
+	// Update returns the limit after sample completed under limit
+	Update(limit float64, sample Sample) float64
+}
+
+// AIMD raises the limit additively while requests complete within a latency
+// target and cuts it multiplicatively when one is slower or dropped, as TCP
+// congestion control does
+type AIMD struct {
+	min, max      float64
+	latencyTarget time.Duration
+	backoffRatio  float64
+}
+
+// NewAIMD creates an AIMD algorithm keeping the limit within min and max and
+// backing off when a request takes longer than latencyTarget
+func NewAIMD(min, max int, latencyTarget time.Duration) *AIMD {
+	return &AIMD{min: float64(min), max: float64(max), latencyTarget: latencyTarget, backoffRatio: 0.9}
+}
+
+// Update implements Algorithm
+func (a *AIMD) Update(limit float64, sample Sample) float64 {
+	switch {
+	case sample.Dropped || sample.RTT > a.latencyTarget:
+		limit *= a.backoffRatio
+	case float64(sample.InFlight)*2 >= limit:
+		// Grow by about one per limit's worth of requests, and only while
+		// the limit is in use, so that an idle limit does not drift upwards
+		limit += 1 / limit
+	}
+	return clamp(limit, a.min, a.max)
+}
+
+// Gradient compares the latency of each request with a long-term average.
+// While requests are about as fast as usual the limit grows by a queue of
+// √limit; as latency rises above the average the limit shrinks in
+// proportion. It needs no latency target, so it adapts as the workload
+// changes, and suits most deployments.
+type Gradient struct {
+	min, max  float64
+	tolerance float64
+	smoothing float64
+	window    float64
+
+	// longRTT is the exponential moving average of RTT in seconds
+	longRTT float64
+}
+
+// NewGradient creates a gradient algorithm keeping the limit within min and max
+func NewGradient(min, max int) *Gradient {
+	return &Gradient{min: float64(min), max: float64(max), tolerance: 1.5, smoothing: 0.2, window: 600}
+}
+
+// Update implements Algorithm
+func (g *Gradient) Update(limit float64, sample Sample) float64 {
+	rtt := sample.RTT.Seconds()
+	if sample.Dropped {
+		// A dropped request says little about latency but much about load
+		return clamp(limit*0.9, g.min, g.max)
+	}
+	if rtt <= 0 {
+		return limit
+	}
+
+	if g.longRTT == 0 {
+		g.longRTT = rtt
+	} else {
+		g.longRTT += (rtt - g.longRTT) / g.window
+	}
+
+	// Do not grow while the limit is not in use
+	if float64(sample.InFlight)*2 < limit {
+		return limit
+	}
+
+	gradient := clamp(g.tolerance*g.longRTT/rtt, 0.5, 1)
+	next := limit*gradient + math.Sqrt(limit)
+	return clamp(limit*(1-g.smoothing)+next*g.smoothing, g.min, g.max)
+}
+
+// clamp limits value to the range from low to high
+func clamp(value, low, high float64) float64 {
+	return math.Min(math.Max(value, low), high)
+}
@@ -0,0 +1,203 @@
+package concurrency
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ErrCodeOverloaded is the error code of requests shed by a limiter
+const ErrCodeOverloaded = "SERVER_OVERLOADED"
+
+// Defaults of limiters created without options
+const (
+	DefaultInitialLimit = 20
+	DefaultQueueSize    = 50
+	DefaultQueueTimeout = 100 * time.Millisecond
+)
+
+// ErrOverloaded is returned by Acquire when a request is shed
+var ErrOverloaded = errors.New("concurrency limit exceeded")
+
+// Limiter caps the requests running at once at a limit its Algorithm adjusts
+// as they complete. Requests over the limit wait in a FIFO queue until one
+// completes, and are shed when the queue is full or they waited too long.
+type Limiter struct {
+	name         string
+	algorithm    Algorithm
+	queueSize    int
+	queueTimeout time.Duration
+	metrics      *Metrics
+
+	mu       sync.Mutex
+	limit    float64
+	inFlight int
+	waiters  list.List
+}
+
+// LimiterOption configures optional Limiter settings
+type LimiterOption func(*Limiter)
+
+// WithInitialLimit sets the limit before the algorithm first adjusts it
+func WithInitialLimit(limit int) LimiterOption {
+	return func(l *Limiter) {
+		l.limit = float64(limit)
+	}
+}
+
+// WithQueueSize sets how many requests may wait for the limit; zero sheds
+// every request over it
+func WithQueueSize(size int) LimiterOption {
+	return func(l *Limiter) {
+		l.queueSize = size
+	}
+}
+
+// WithQueueTimeout sets how long a request may wait for the limit
+func WithQueueTimeout(timeout time.Duration) LimiterOption {
+	return func(l *Limiter) {
+		l.queueTimeout = timeout
+	}
+}
+
+// WithMetrics sets the metrics the limiter state is exposed in
+func WithMetrics(metrics *Metrics) LimiterOption {
+	return func(l *Limiter) {
+		l.metrics = metrics
+	}
+}
+
+// NewLimiter creates a limiter adjusted by algorithm. Its metrics are labeled
+// with name and registered with the default Prometheus registry unless set
+// by options.
+func NewLimiter(name string, algorithm Algorithm, opts ...LimiterOption) *Limiter {
+	l := &Limiter{
+		name:         name,
+		algorithm:    algorithm,
+		queueSize:    DefaultQueueSize,
+		queueTimeout: DefaultQueueTimeout,
+		limit:        DefaultInitialLimit,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	if l.metrics == nil {
+		l.metrics = NewMetrics(prometheus.DefaultRegisterer)
+	}
+	l.observe()
+	return l
+}
+
+// Token is a request admitted by a Limiter. It must be released exactly once,
+// when the request completes.
+type Token struct {
+	limiter *Limiter
+	start   time.Time
+}
+
+// Acquire admits a request, waiting in the queue while the limit is reached.
+// It returns ErrOverloaded when the request is shed, or the context error
+// when ctx ends while it waits.
+func (l *Limiter) Acquire(ctx context.Context) (*Token, error) {
+	l.mu.Lock()
+	if l.inFlight < l.admitted() && l.waiters.Len() == 0 {
+		l.inFlight++
+		l.observe()
+		l.mu.Unlock()
+		return &Token{limiter: l, start: time.Now()}, nil
+	}
+	if l.waiters.Len() >= l.queueSize {
+		l.mu.Unlock()
+		l.metrics.shed.WithLabelValues(l.name, ShedQueueFull).Inc()
+		return nil, ErrOverloaded
+	}
+	ready := make(chan struct{})
+	waiter := l.waiters.PushBack(ready)
+	l.observe()
+	l.mu.Unlock()
+
+	timer := time.NewTimer(l.queueTimeout)
+	defer timer.Stop()
+
+	err := ErrOverloaded
+	select {
+	case <-ready:
+		return &Token{limiter: l, start: time.Now()}, nil
+	case <-timer.C:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	l.mu.Lock()
+	select {
+	case <-ready:
+		// Admitted while giving up; run rather than waste the slot
+		l.mu.Unlock()
+		return &Token{limiter: l, start: time.Now()}, nil
+	default:
+	}
+	l.waiters.Remove(waiter)
+	l.observe()
+	l.mu.Unlock()
+
+	if errors.Is(err, ErrOverloaded) {
+		l.metrics.shed.WithLabelValues(l.name, ShedQueueTimeout).Inc()
+	}
+	return nil, err
+}
+
+// Release completes the request, adjusting the limit and admitting waiting
+// requests. dropped is set when the request timed out or failed from overload.
+func (t *Token) Release(dropped bool) {
+	l := t.limiter
+	rtt := time.Since(t.start)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.limit = l.algorithm.Update(l.limit, Sample{RTT: rtt, InFlight: l.inFlight, Dropped: dropped})
+	l.inFlight--
+	for l.inFlight < l.admitted() && l.waiters.Len() > 0 {
+		ready := l.waiters.Remove(l.waiters.Front()).(chan struct{})
+		l.inFlight++
+		close(ready)
+	}
+	l.observe()
+}
+
+// Limit returns how many requests the limiter currently admits at once
+func (l *Limiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.admitted()
+}
+
+// InFlight returns how many admitted requests are running
+func (l *Limiter) InFlight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inFlight
+}
+
+// Queued returns how many requests wait for the limit
+func (l *Limiter) Queued() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.waiters.Len()
+}
+
+// admitted returns the whole part of the limit, at least one; the caller holds the lock
+func (l *Limiter) admitted() int {
+	return max(int(l.limit), 1)
+}
+
+// observe updates the gauges; the caller holds the lock
+func (l *Limiter) observe() {
+	l.metrics.limit.WithLabelValues(l.name).Set(float64(l.admitted()))
+	l.metrics.inFlight.WithLabelValues(l.name).Set(float64(l.inFlight))
+	l.metrics.queued.WithLabelValues(l.name).Set(float64(l.waiters.Len()))
+}
@@ -0,0 +1,43 @@
+package concurrency
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// Reasons a request is shed, as recorded in concurrency_limit_shed_total
+const (
+	ShedQueueFull    = "queue_full"
+	ShedQueueTimeout = "queue_timeout"
+)
+
+// Metrics exposes the state of limiters, labeled by limiter name
+type Metrics struct {
+	limit    *prometheus.GaugeVec
+	inFlight *prometheus.GaugeVec
+	queued   *prometheus.GaugeVec
+	shed     *prometheus.CounterVec
+}
+
+// NewMetrics creates the concurrency_limit gauges and counters and registers
+// them with reg. When reg already holds them, the registered ones are shared.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	return &Metrics{
+		limit: pkg.RegisterCollector(reg, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "concurrency_limit",
+			Help: "Current adaptive limit on requests in flight.",
+		}, []string{"class"})),
+		inFlight: pkg.RegisterCollector(reg, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "concurrency_limit_in_flight",
+			Help: "Requests running under the concurrency limit.",
+		}, []string{"class"})),
+		queued: pkg.RegisterCollector(reg, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "concurrency_limit_queued",
+			Help: "Requests waiting for the concurrency limit.",
+		}, []string{"class"})),
+		shed: pkg.RegisterCollector(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "concurrency_limit_shed_total",
+			Help: "Requests rejected by the concurrency limit, by reason.",
+		}, []string{"class", "reason"})),
+	}
+}
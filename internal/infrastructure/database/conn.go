@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/zercle/template-go-echo/internal/infrastructure/sqlc"
+)
+
+// dialectConn runs dynamic queries written with ? placeholders on a
+// connection of the given dialect
+type dialectConn struct {
+	db      sqlc.DBTX
+	dialect Dialect
+}
+
+// NewDialectConn wraps db so that repositories building SQL at runtime can
+// write ? placeholders on every dialect and look the dialect up with DialectOf
+func NewDialectConn(db sqlc.DBTX, dialect Dialect) sqlc.DBTX {
+	return &dialectConn{db: db, dialect: dialect}
+}
+
+// DialectOf returns the dialect of a connection created by NewDialectConn,
+// or DialectMySQL for any other connection
+func DialectOf(db sqlc.DBTX) Dialect {
+	if conn, ok := db.(*dialectConn); ok {
+		return conn.dialect
+	}
+	return DialectMySQL
+}
+
+func (c *dialectConn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return c.db.ExecContext(ctx, c.dialect.Rebind(query), args...)
+}
+
+func (c *dialectConn) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return c.db.PrepareContext(ctx, c.dialect.Rebind(query))
+}
+
+func (c *dialectConn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.db.QueryContext(ctx, c.dialect.Rebind(query), args...)
+}
+
+func (c *dialectConn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return c.db.QueryRowContext(ctx, c.dialect.Rebind(query), args...)
+}
+
+// BeginTx starts a transaction when the wrapped connection can, so that a
+// TxManager works on the wrapper
+func (c *dialectConn) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	db, ok := c.db.(beginner)
+	if !ok {
+		return nil, ErrTxNotSupported
+	}
+	return db.BeginTx(ctx, opts)
+}
@@ -5,30 +5,62 @@ import (
 	"database/sql"
 	"fmt"
 	"log/slog"
+	"net/url"
+	"strings"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-echo/internal/infrastructure/sqlc/postgres"
+	"github.com/zercle/template-go-echo/internal/infrastructure/sqlc/sqlite"
+	_ "modernc.org/sqlite"
 )
 
+// sqliteDSNDefaults are the connection parameters SQLite is opened with unless
+// the DSN sets them. The schema stores timestamps as Unix microseconds read back
+// as time.Time, foreign keys are off by default in SQLite, and taking the write
+// lock when a transaction begins avoids deadlocks between transactions
+// upgrading read locks.
+var sqliteDSNDefaults = []struct{ key, pragma, value string }{
+	{key: "_time_integer_format", value: "unix_micro"},
+	{key: "_inttotime", value: "1"},
+	{key: "_txlock", value: "immediate"},
+	{pragma: "foreign_keys", value: "foreign_keys(1)"},
+	{pragma: "busy_timeout", value: "busy_timeout(5000)"},
+	{pragma: "journal_mode", value: "journal_mode(WAL)"},
+}
+
 // Database represents the database connection
 type Database struct {
-	conn *sql.DB
+	conn         *sql.DB
+	dialect      Dialect
+	router       *Router
+	instrumented *InstrumentedDB
+	prepared     *PreparedQuerier
 }
 
-// New creates a new database connection
-func New(cfg *config.DatabaseConfig) (*Database, error) {
-	db, err := sql.Open(cfg.Driver, cfg.DSN)
+// New creates a new database connection. opts configure the instrumentation
+// of its queries on top of the configured settings.
+func New(cfg *config.DatabaseConfig, opts ...InstrumentOption) (*Database, error) {
+	if !IsValidDialect(cfg.Driver) {
+		return nil, fmt.Errorf("unsupported database driver %q", cfg.Driver)
+	}
+	dialect := Dialect(cfg.Driver)
+
+	dsn := cfg.DSN
+	if dialect == DialectSQLite {
+		dsn = sqliteDSN(dsn)
+	}
+
+	db, err := open(dialect, dsn, cfg.MaxConns)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	// Configure connection pool
-	db.SetMaxOpenConns(cfg.MaxConns)
-	db.SetMaxIdleConns(cfg.MaxConns / 2)
-	db.SetConnMaxLifetime(time.Hour)
-	db.SetConnMaxIdleTime(10 * time.Minute)
-
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -37,12 +69,92 @@ func New(cfg *config.DatabaseConfig) (*Database, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	d := &Database{conn: db, dialect: dialect}
+
+	// Route reads to the replicas; an unreachable replica does not stop
+	// startup but serves no reads until a health check re-admits it
+	if len(cfg.ReplicaDSNs) > 0 {
+		replicas := make([]*sql.DB, 0, len(cfg.ReplicaDSNs))
+		for i, replicaDSN := range cfg.ReplicaDSNs {
+			if dialect == DialectSQLite {
+				replicaDSN = sqliteDSN(replicaDSN)
+			}
+			replica, err := open(dialect, replicaDSN, cfg.MaxConns)
+			if err != nil {
+				for _, r := range replicas {
+					_ = r.Close()
+				}
+				_ = db.Close()
+				return nil, fmt.Errorf("failed to open replica %d: %w", i+1, err)
+			}
+			replicas = append(replicas, replica)
+		}
+
+		d.router = NewRouter(db, replicas,
+			WithPolicy(cfg.ReplicaPolicy),
+			WithHealthInterval(cfg.ReplicaHealthInterval),
+		)
+		d.router.CheckHealth(ctx)
+	}
+
+	d.instrumented = NewInstrumentedDB(d.dbtx(), dialect, append([]InstrumentOption{
+		WithSlowQueryThreshold(cfg.SlowQueryThreshold),
+		WithQueryComments(cfg.QueryComments),
+	}, opts...)...)
+
 	slog.Info("database connection established",
-		slog.String("driver", cfg.Driver),
+		slog.String("dialect", string(dialect)),
 		slog.Int("max_conns", cfg.MaxConns),
+		slog.Int("replicas", len(cfg.ReplicaDSNs)),
 	)
 
-	return &Database{conn: db}, nil
+	return d, nil
+}
+
+// open opens a connection pool of maxConns connections
+func open(dialect Dialect, dsn string, maxConns int) (*sql.DB, error) {
+	db, err := sql.Open(dialect.driverName(), dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Configure connection pool
+	db.SetMaxOpenConns(maxConns)
+	db.SetMaxIdleConns(maxConns / 2)
+	db.SetConnMaxLifetime(time.Hour)
+	db.SetConnMaxIdleTime(10 * time.Minute)
+
+	return db, nil
+}
+
+// sqliteDSN adds the parameters of sqliteDSNDefaults the DSN does not set
+func sqliteDSN(dsn string) string {
+	path, rawQuery, _ := strings.Cut(dsn, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return dsn
+	}
+
+	for _, param := range sqliteDSNDefaults {
+		if param.key != "" {
+			if !query.Has(param.key) {
+				query.Set(param.key, param.value)
+			}
+			continue
+		}
+		set := false
+		for _, pragma := range query["_pragma"] {
+			if strings.HasPrefix(strings.ToLower(pragma), param.pragma) {
+				set = true
+				break
+			}
+		}
+		if !set {
+			query.Add("_pragma", param.value)
+		}
+	}
+
+	return path + "?" + query.Encode()
 }
 
 // GetConn returns the underlying database connection
@@ -50,14 +162,104 @@ func (d *Database) GetConn() *sql.DB {
 	return d.conn
 }
 
-// Close closes the database connection
+// Dialect returns the SQL dialect of the connection
+func (d *Database) Dialect() Dialect {
+	return d.dialect
+}
+
+// PrepareQueries prepares every sqlc query, after which Querier returns them
+// as prepared statements running on the primary. Call it once the schema is
+// migrated and before building repositories; Close closes the statements.
+func (d *Database) PrepareQueries(ctx context.Context) error {
+	prepared, err := NewPreparedQuerier(ctx, d.instrumented, d.dialect)
+	if err != nil {
+		return fmt.Errorf("failed to prepare queries: %w", err)
+	}
+	d.prepared = prepared
+	return nil
+}
+
+// Querier returns the sqlc queries generated for the connection's dialect,
+// reading from the replicas when there are any, or the prepared queries after
+// PrepareQueries. Its queries are instrumented, including those bound to a
+// transaction by Queries.
+func (d *Database) Querier() sqlc.Querier {
+	if d.prepared != nil {
+		return d.prepared
+	}
+	return &instrumentedQuerier{
+		Querier: d.newQuerier(d.instrumented),
+		db:      d.instrumented,
+		build:   d.newQuerier,
+	}
+}
+
+// newQuerier returns the sqlc queries of the connection's dialect running on db
+func (d *Database) newQuerier(db sqlc.DBTX) sqlc.Querier {
+	switch d.dialect {
+	case DialectPostgres:
+		return postgres.NewQuerier(db)
+	case DialectSQLite:
+		return sqlite.NewQuerier(db)
+	}
+	return sqlc.New(db)
+}
+
+// DBTX returns the connection repositories run dynamic queries on, which
+// rewrites their ? placeholders for the dialect, is instrumented and reads
+// from the replicas when there are any
+func (d *Database) DBTX() sqlc.DBTX {
+	return NewDialectConn(d.instrumented, d.dialect)
+}
+
+// Router returns the read replica router, nil without replicas
+func (d *Database) Router() *Router {
+	return d.router
+}
+
+// dbtx returns the router when there are replicas and the primary otherwise
+func (d *Database) dbtx() sqlc.DBTX {
+	if d.router != nil {
+		return d.router
+	}
+	return d.conn
+}
+
+// Close closes the prepared statements, the database connection and the
+// replica connections
 func (d *Database) Close() error {
+	if d.prepared != nil {
+		if err := d.prepared.Close(); err != nil {
+			slog.Error("failed to close prepared statements", slog.String("error", err.Error()))
+		}
+	}
+	if d.router != nil {
+		if err := d.router.Close(); err != nil {
+			slog.Error("failed to close read replicas", slog.String("error", err.Error()))
+		}
+	}
 	if d.conn != nil {
 		return d.conn.Close()
 	}
 	return nil
 }
 
+// RegisterMetrics registers the connection pool statistics of the primary and
+// the replicas with reg, as the go_sql_* metrics labeled by db_name
+func (d *Database) RegisterMetrics(reg prometheus.Registerer) error {
+	if err := reg.Register(collectors.NewDBStatsCollector(d.conn, "primary")); err != nil {
+		return err
+	}
+	if d.router != nil {
+		for _, r := range d.router.replicas {
+			if err := reg.Register(collectors.NewDBStatsCollector(r.db, r.name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // Health checks the database health
 func (d *Database) Health(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
@@ -70,3 +272,8 @@ func (d *Database) Health(ctx context.Context) error {
 func (d *Database) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
 	return d.conn.BeginTx(ctx, opts)
 }
+
+// TxManager returns a transaction manager for the connection
+func (d *Database) TxManager(opts ...TxOption) *TxManager {
+	return NewTxManager(d.conn, opts...)
+}
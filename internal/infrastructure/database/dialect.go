@@ -0,0 +1,180 @@
+package database
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Dialect identifies the SQL engine behind a connection
+type Dialect string
+
+// Supported dialects, selected with DB_DRIVER
+const (
+	// DialectMySQL covers MariaDB 10.6+ and MySQL 8.0+
+	DialectMySQL    Dialect = "mysql"
+	DialectPostgres Dialect = "postgres"
+	DialectSQLite   Dialect = "sqlite"
+)
+
+// MySQL/MariaDB error numbers
+const (
+	mysqlErrDuplicateEntry  = 1062
+	mysqlErrLockWaitTimeout = 1205
+	mysqlErrDeadlock        = 1213
+	mysqlErrUnknownStmt     = 1243
+	mysqlErrNeedReprepare   = 1615
+)
+
+// PostgreSQL SQLSTATE codes
+const (
+	pgErrUniqueViolation      = "23505"
+	pgErrSerializationFailure = "40001"
+	pgErrDeadlockDetected     = "40P01"
+	pgErrInvalidStatementName = "26000"
+	pgErrFeatureNotSupported  = "0A000"
+)
+
+// IsValidDialect reports whether d is a supported dialect
+func IsValidDialect(d string) bool {
+	switch Dialect(d) {
+	case DialectMySQL, DialectPostgres, DialectSQLite:
+		return true
+	}
+	return false
+}
+
+// driverName returns the database/sql driver registered for the dialect
+func (d Dialect) driverName() string {
+	switch d {
+	case DialectPostgres:
+		return "pgx"
+	case DialectSQLite:
+		return "sqlite"
+	}
+	return "mysql"
+}
+
+// systemName returns the OpenTelemetry db.system.name of the dialect
+func (d Dialect) systemName() string {
+	switch d {
+	case DialectPostgres:
+		return "postgresql"
+	case DialectSQLite:
+		return "sqlite"
+	}
+	return "mysql"
+}
+
+// Rebind rewrites the ? placeholders of query into the dialect's bind
+// syntax. Dynamic queries are written with ? placeholders, which MySQL and
+// SQLite accept as is and PostgreSQL numbers as $1, $2 and so on.
+func (d Dialect) Rebind(query string) string {
+	if d != DialectPostgres || !strings.Contains(query, "?") {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	n := 0
+	quoted := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'':
+			quoted = !quoted
+		case c == '?' && !quoted:
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// ForUpdateSkipLocked returns the clause locking selected rows while skipping
+// rows locked by other transactions. SQLite has no row locks and serializes
+// writing transactions instead, so the clause is empty there.
+func (d Dialect) ForUpdateSkipLocked() string {
+	if d == DialectSQLite {
+		return ""
+	}
+	return " FOR UPDATE SKIP LOCKED"
+}
+
+// Like returns a case-insensitive LIKE condition matching column against a ?
+// pattern whose wildcards are escaped with a backslash
+func (d Dialect) Like(column string) string {
+	switch d {
+	case DialectPostgres:
+		return column + " ILIKE ?"
+	case DialectSQLite:
+		return column + ` LIKE ? ESCAPE '\'`
+	}
+	return column + " LIKE ?"
+}
+
+// IsDuplicateKey reports whether err is a unique key violation on any supported dialect
+func IsDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlErrDuplicateEntry
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgErrUniqueViolation
+	}
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		code := sqliteErr.Code()
+		return code == sqlite3.SQLITE_CONSTRAINT_UNIQUE || code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY
+	}
+	return false
+}
+
+// IsRetryable reports whether err aborted a transaction that may succeed when
+// run again, such as a deadlock
+func IsRetryable(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlErrDeadlock || mysqlErr.Number == mysqlErrLockWaitTimeout
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgErrDeadlockDetected || pgErr.Code == pgErrSerializationFailure
+	}
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		// Extended result codes keep the primary code in the low byte
+		code := sqliteErr.Code() & 0xff
+		return code == sqlite3.SQLITE_BUSY || code == sqlite3.SQLITE_LOCKED
+	}
+	return false
+}
+
+// IsStatementInvalid reports whether err means the server no longer knows or
+// can no longer run a prepared statement, which must be prepared again, as
+// after a connection reset by a pooler or a change of the tables it reads
+func IsStatementInvalid(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlErrUnknownStmt || mysqlErr.Number == mysqlErrNeedReprepare
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgErrInvalidStatementName ||
+			pgErr.Code == pgErrFeatureNotSupported && strings.Contains(pgErr.Message, "cached plan must not change result type")
+	}
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code()&0xff == sqlite3.SQLITE_SCHEMA
+	}
+	return false
+}
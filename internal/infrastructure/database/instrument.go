@@ -0,0 +1,296 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/zercle/template-go-echo/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-echo/pkg"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultSlowQueryThreshold is the latency above which a query is logged as slow
+const DefaultSlowQueryThreshold = 200 * time.Millisecond
+
+// UnnamedQuery is the name recorded for queries without a sqlc name comment
+const UnnamedQuery = "unnamed"
+
+// tracerName is the instrumentation scope of the query spans
+const tracerName = "github.com/zercle/template-go-echo/internal/infrastructure/database"
+
+// queryDurationBuckets are the query latency histogram buckets in seconds
+var queryDurationBuckets = []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5}
+
+// QueryMetrics records query latency histograms labeled by sqlc query name
+// and by status, success or error
+type QueryMetrics struct {
+	duration *prometheus.HistogramVec
+}
+
+// NewQueryMetrics creates the db_query_duration_seconds histograms and
+// registers them with reg. When reg already holds them, as when several
+// databases are opened in one process, the registered histograms are shared.
+func NewQueryMetrics(reg prometheus.Registerer) *QueryMetrics {
+	return &QueryMetrics{duration: pkg.RegisterCollector(reg, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Latency of database queries by sqlc query name.",
+		Buckets: queryDurationBuckets,
+	}, []string{"query", "status"}))}
+}
+
+// observe records one query
+func (m *QueryMetrics) observe(name string, elapsed time.Duration, err error) {
+	status := "success"
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		status = "error"
+	}
+	m.duration.WithLabelValues(name, status).Observe(elapsed.Seconds())
+}
+
+// instrumentation holds the settings an InstrumentedDB shares with the
+// connections it wraps for transactions
+type instrumentation struct {
+	system   string
+	slow     time.Duration
+	comments bool
+	metrics  *QueryMetrics
+	tracer   trace.Tracer
+}
+
+// InstrumentOption configures optional InstrumentedDB settings
+type InstrumentOption func(*instrumentation)
+
+// WithSlowQueryThreshold sets the latency above which a query is logged as
+// slow; zero disables the log
+func WithSlowQueryThreshold(threshold time.Duration) InstrumentOption {
+	return func(i *instrumentation) {
+		i.slow = threshold
+	}
+}
+
+// WithQueryComments sets whether sqlcommenter comments are appended to queries
+func WithQueryComments(enabled bool) InstrumentOption {
+	return func(i *instrumentation) {
+		i.comments = enabled
+	}
+}
+
+// WithQueryMetrics sets the histograms query latencies are recorded in
+func WithQueryMetrics(metrics *QueryMetrics) InstrumentOption {
+	return func(i *instrumentation) {
+		i.metrics = metrics
+	}
+}
+
+// WithTracerProvider sets the provider of the query spans
+func WithTracerProvider(provider trace.TracerProvider) InstrumentOption {
+	return func(i *instrumentation) {
+		i.tracer = provider.Tracer(tracerName)
+	}
+}
+
+// InstrumentedDB wraps a connection to observe every query run through it.
+// Each query gets a client span carrying the statement and the types of its
+// arguments but never their values, and its latency is recorded in a
+// histogram under the sqlc query name and logged when slower than the slow
+// query threshold. With comments enabled, the request ID, route and
+// traceparent of the context are appended to the statement as a
+// sqlcommenter comment, so that database logs lead back to the request.
+//
+// Statements prepared through the wrapper run outside it; a PreparedQuerier
+// created on the wrapper observes them under their query name instead.
+type InstrumentedDB struct {
+	db sqlc.DBTX
+	*instrumentation
+}
+
+// NewInstrumentedDB wraps db, a connection of the given dialect. Spans come
+// from the global OpenTelemetry tracer provider and histograms are registered
+// with the default Prometheus registry unless set by options.
+func NewInstrumentedDB(db sqlc.DBTX, dialect Dialect, opts ...InstrumentOption) *InstrumentedDB {
+	i := &instrumentation{
+		system:   dialect.systemName(),
+		slow:     DefaultSlowQueryThreshold,
+		comments: true,
+		tracer:   otel.Tracer(tracerName),
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	if i.metrics == nil {
+		i.metrics = NewQueryMetrics(prometheus.DefaultRegisterer)
+	}
+	return &InstrumentedDB{db: db, instrumentation: i}
+}
+
+// withConn returns a wrapper of db with the same settings, used to observe
+// the queries of a transaction
+func (d *InstrumentedDB) withConn(db sqlc.DBTX) *InstrumentedDB {
+	return &InstrumentedDB{db: db, instrumentation: d.instrumentation}
+}
+
+func (d *InstrumentedDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, done := d.start(ctx, QueryName(query), query, args)
+	result, err := d.db.ExecContext(ctx, d.annotate(ctx, query), args...)
+	done(err)
+	return result, err
+}
+
+func (d *InstrumentedDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return d.db.PrepareContext(ctx, query)
+}
+
+func (d *InstrumentedDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, done := d.start(ctx, QueryName(query), query, args)
+	rows, err := d.db.QueryContext(ctx, d.annotate(ctx, query), args...)
+	done(err)
+	return rows, err
+}
+
+func (d *InstrumentedDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, done := d.start(ctx, QueryName(query), query, args)
+	row := d.db.QueryRowContext(ctx, d.annotate(ctx, query), args...)
+	done(row.Err())
+	return row
+}
+
+// BeginTx starts a transaction when the wrapped connection can, so that a
+// TxManager works on the wrapper
+func (d *InstrumentedDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	db, ok := d.db.(beginner)
+	if !ok {
+		return nil, ErrTxNotSupported
+	}
+	return db.BeginTx(ctx, opts)
+}
+
+// start opens the span of the query called name and returns its context and
+// the function recording the outcome once the query returns. The statement
+// and its arguments are left out of the span when query is empty, as for
+// prepared statements.
+func (i *instrumentation) start(ctx context.Context, name, query string, args []interface{}) (context.Context, func(err error)) {
+	began := time.Now()
+
+	ctx, span := i.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+	if span.IsRecording() {
+		span.SetAttributes(
+			attribute.String("db.system.name", i.system),
+			attribute.String("db.query.summary", name),
+		)
+		if query != "" {
+			span.SetAttributes(
+				attribute.String("db.query.text", query),
+				attribute.StringSlice("db.query.args", redactArgs(args)),
+			)
+		}
+	}
+
+	return ctx, func(err error) {
+		elapsed := time.Since(began)
+
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+		i.metrics.observe(name, elapsed, err)
+
+		if i.slow > 0 && elapsed >= i.slow {
+			info := pkg.RequestInfoFromContext(ctx)
+			slog.WarnContext(ctx, "slow query",
+				slog.String("query", name),
+				slog.Duration("duration", elapsed),
+				slog.Duration("threshold", i.slow),
+				slog.String("request_id", info.RequestID),
+				slog.String("route", info.Route),
+			)
+		}
+	}
+}
+
+// annotate appends the sqlcommenter comment of ctx to query. Statements that
+// already hold a comment other than the sqlc name are left as they are.
+func (d *InstrumentedDB) annotate(ctx context.Context, query string) string {
+	if !d.comments || strings.Contains(query, "/*") {
+		return query
+	}
+
+	// Keys are sorted, as sqlcommenter requires
+	info := pkg.RequestInfoFromContext(ctx)
+	pairs := make([]string, 0, 3)
+	if info.RequestID != "" {
+		pairs = append(pairs, commentPair("request_id", info.RequestID))
+	}
+	if info.Route != "" {
+		pairs = append(pairs, commentPair("route", info.Route))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		traceparent := fmt.Sprintf("00-%s-%s-%s", sc.TraceID(), sc.SpanID(), sc.TraceFlags())
+		pairs = append(pairs, commentPair("traceparent", traceparent))
+	}
+	if len(pairs) == 0 {
+		return query
+	}
+
+	// The comment goes before a trailing semicolon
+	trimmed := strings.TrimRight(query, " \t\r\n;")
+	return trimmed + " /*" + strings.Join(pairs, ",") + "*/" + query[len(trimmed):]
+}
+
+// commentPair formats a sqlcommenter key='value' pair. Escaping the value
+// also escapes ' and *, so it can neither end the quote nor the comment.
+func commentPair(key, value string) string {
+	return key + "='" + url.PathEscape(value) + "'"
+}
+
+// QueryName returns the name sqlc gives a query in its leading
+// "-- name: Name :kind" comment, or UnnamedQuery when there is none
+func QueryName(query string) string {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(query), "-- name: ")
+	if !ok {
+		return UnnamedQuery
+	}
+	if name, _, _ := strings.Cut(rest, " "); name != "" && !strings.Contains(name, "\n") {
+		return name
+	}
+	return UnnamedQuery
+}
+
+// redactArgs describes query arguments by type only, so that spans never
+// carry values such as emails or password hashes
+func redactArgs(args []interface{}) []string {
+	types := make([]string, len(args))
+	for i, arg := range args {
+		if arg == nil {
+			types[i] = "nil"
+			continue
+		}
+		types[i] = fmt.Sprintf("%T", arg)
+	}
+	return types
+}
+
+// instrumentedQuerier runs the sqlc queries of a dialect on an InstrumentedDB
+// and binds them to transactions through the same instrumentation, so that
+// Queries keeps observing the queries of a transaction
+type instrumentedQuerier struct {
+	sqlc.Querier
+	db    *InstrumentedDB
+	build func(db sqlc.DBTX) sqlc.Querier
+}
+
+// WithTx returns the queries running in tx
+func (q *instrumentedQuerier) WithTx(tx *sql.Tx) sqlc.Querier {
+	return q.build(q.db.withConn(tx))
+}
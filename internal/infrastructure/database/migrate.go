@@ -0,0 +1,544 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMigrationLockTimeout bounds waiting for a migration run by another replica
+const DefaultMigrationLockTimeout = time.Minute
+
+// migrationLockName names the advisory lock serializing migrations between replicas
+const migrationLockName = "template-go-echo:schema_migrations"
+
+// migrationLockPollInterval is how often a waiting migrator retries the lock
+const migrationLockPollInterval = 500 * time.Millisecond
+
+// Schema version table statements. The table has the layout golang-migrate
+// uses, so databases migrated with the migrate CLI carry on from their version.
+const (
+	createSchemaTableQuery = "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)"
+	selectSchemaQuery      = "SELECT version, dirty FROM schema_migrations LIMIT 1"
+	deleteSchemaQuery      = "DELETE FROM schema_migrations"
+	insertSchemaQuery      = "INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)"
+)
+
+// Migration lock statements per dialect, selecting 1 when the lock was taken
+// without waiting. SQLite has no locks shared between processes.
+const (
+	mysqlMigrationLockQuery      = "SELECT GET_LOCK(?, 0)"
+	mysqlMigrationUnlockQuery    = "DO RELEASE_LOCK(?)"
+	postgresMigrationLockQuery   = "SELECT CASE WHEN pg_try_advisory_lock(hashtextextended($1, 0)) THEN 1 ELSE 0 END"
+	postgresMigrationUnlockQuery = "SELECT pg_advisory_unlock(hashtextextended($1, 0))"
+)
+
+// migrationFilePattern matches <version>_<name>.up.sql and <version>_<name>.down.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+var (
+	// ErrDirtySchema is returned when a migration failed part way and left the schema unknown
+	ErrDirtySchema = errors.New("schema is dirty; repair it and run migrate force")
+	// ErrUnknownVersion is returned for a schema version without a migration in this build
+	ErrUnknownVersion = errors.New("no migration with this version")
+	// ErrSchemaOutdated is returned when the schema is not at the latest migration
+	ErrSchemaOutdated = errors.New("schema is not at the expected version")
+	// ErrMigrationLocked is returned when another migration held the lock for the whole lock timeout
+	ErrMigrationLocked = errors.New("timed out waiting for the migration lock")
+)
+
+// Migration is one versioned schema change
+type Migration struct {
+	Version uint64
+	Name    string
+	up      string
+	down    string
+}
+
+// MigrationState reports whether a migration has been applied
+type MigrationState struct {
+	Version uint64
+	Name    string
+	Applied bool
+}
+
+// SchemaStatus describes the schema version of a database
+type SchemaStatus struct {
+	Version    uint64
+	Dirty      bool
+	Latest     uint64
+	Migrations []MigrationState
+}
+
+// Migrator applies the embedded migrations of the connection's dialect
+type Migrator struct {
+	db          *sql.DB
+	dialect     Dialect
+	migrations  []Migration
+	lockTimeout time.Duration
+}
+
+// MigratorOption configures a Migrator
+type MigratorOption func(*Migrator)
+
+// WithLockTimeout sets how long to wait for a migration run by another replica
+func WithLockTimeout(timeout time.Duration) MigratorOption {
+	return func(m *Migrator) {
+		if timeout > 0 {
+			m.lockTimeout = timeout
+		}
+	}
+}
+
+// NewMigrator creates a migrator for db from fsys, which holds one directory
+// of migrations per dialect
+func NewMigrator(db *Database, fsys fs.FS, opts ...MigratorOption) (*Migrator, error) {
+	dir, err := fs.Sub(fsys, string(db.dialect))
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := loadMigrations(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(migrations) == 0 {
+		return nil, fmt.Errorf("no %s migrations found", db.dialect)
+	}
+
+	m := &Migrator{
+		db:          db.conn,
+		dialect:     db.dialect,
+		migrations:  migrations,
+		lockTimeout: DefaultMigrationLockTimeout,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m, nil
+}
+
+// loadMigrations reads the migrations in dir ordered by version
+func loadMigrations(dir fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(dir, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint64]*Migration)
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+		script, err := fs.ReadFile(dir, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d is used by %s and %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.up = string(script)
+		} else {
+			m.down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Latest returns the version of the newest migration
+func (m *Migrator) Latest() uint64 {
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies every pending migration
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.Goto(ctx, m.Latest())
+}
+
+// Down reverts the given number of applied migrations
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("cannot revert %d migrations", steps)
+	}
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		version, err := m.cleanVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		i := m.index(version)
+		if i-steps+1 < 0 {
+			return fmt.Errorf("cannot revert %d migrations from version %d", steps, version)
+		}
+		target := uint64(0)
+		if i-steps >= 0 {
+			target = m.migrations[i-steps].Version
+		}
+		return m.migrate(ctx, conn, version, target)
+	})
+}
+
+// Goto migrates up or down to the given version; version 0 reverts every migration
+func (m *Migrator) Goto(ctx context.Context, target uint64) error {
+	if target != 0 && m.index(target) < 0 {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, target)
+	}
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		version, err := m.cleanVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		return m.migrate(ctx, conn, version, target)
+	})
+}
+
+// Force records version as applied and clean without running any migration,
+// to recover once a failed migration has been repaired by hand
+func (m *Migrator) Force(ctx context.Context, version uint64) error {
+	if version != 0 && m.index(version) < 0 {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		if err := m.setVersion(ctx, conn, version, false); err != nil {
+			return err
+		}
+		slog.Info("forced schema version", slog.Uint64("version", version))
+		return nil
+	})
+}
+
+// Status returns the schema version and which migrations have been applied
+func (m *Migrator) Status(ctx context.Context) (*SchemaStatus, error) {
+	if _, err := m.db.ExecContext(ctx, createSchemaTableQuery); err != nil {
+		return nil, fmt.Errorf("failed to create schema version table: %w", err)
+	}
+	version, dirty, err := m.version(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &SchemaStatus{Version: version, Dirty: dirty, Latest: m.Latest()}
+	for _, mig := range m.migrations {
+		status.Migrations = append(status.Migrations, MigrationState{
+			Version: mig.Version,
+			Name:    mig.Name,
+			Applied: mig.Version <= version,
+		})
+	}
+	return status, nil
+}
+
+// CheckVersion returns an error unless the schema is clean and at the latest
+// version, for readiness checks
+func (m *Migrator) CheckVersion(ctx context.Context) error {
+	version, dirty, err := m.version(ctx, m.db)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("%w at version %d", ErrDirtySchema, version)
+	}
+	if version != m.Latest() {
+		return fmt.Errorf("%w: at %d, expected %d", ErrSchemaOutdated, version, m.Latest())
+	}
+	return nil
+}
+
+// index returns the position of version in the migrations, or -1 for version 0
+// and versions without a migration
+func (m *Migrator) index(version uint64) int {
+	i := sort.Search(len(m.migrations), func(i int) bool { return m.migrations[i].Version >= version })
+	if i < len(m.migrations) && m.migrations[i].Version == version {
+		return i
+	}
+	return -1
+}
+
+// cleanVersion returns the current version, failing when it is dirty or unknown
+func (m *Migrator) cleanVersion(ctx context.Context, conn *sql.Conn) (uint64, error) {
+	version, dirty, err := m.version(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, fmt.Errorf("%w at version %d", ErrDirtySchema, version)
+	}
+	if version != 0 && m.index(version) < 0 {
+		return 0, fmt.Errorf("%w: database is at %d", ErrUnknownVersion, version)
+	}
+	return version, nil
+}
+
+// migrate applies the up scripts after version through target, or the down
+// scripts from version back to target, one migration at a time
+func (m *Migrator) migrate(ctx context.Context, conn *sql.Conn, version, target uint64) error {
+	if target > version {
+		for _, mig := range m.migrations {
+			if mig.Version <= version || mig.Version > target {
+				continue
+			}
+			if err := m.apply(ctx, conn, mig, mig.up, mig.Version, "up"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		mig := m.migrations[i]
+		if mig.Version > version || mig.Version <= target {
+			continue
+		}
+		if mig.down == "" {
+			return fmt.Errorf("migration %d_%s has no down script", mig.Version, mig.Name)
+		}
+		previous := uint64(0)
+		if i > 0 {
+			previous = m.migrations[i-1].Version
+		}
+		if err := m.apply(ctx, conn, mig, mig.down, previous, "down"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply runs one migration script and records the version it leaves the
+// schema at. Where DDL is transactional the script and the version change
+// commit together; elsewhere the version stays dirty if the script fails.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, mig Migration, script string, result uint64, direction string) error {
+	start := time.Now()
+	fail := func(err error) error {
+		slog.Error("failed to apply migration",
+			slog.Uint64("version", mig.Version),
+			slog.String("name", mig.Name),
+			slog.String("direction", direction),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("migration %d_%s %s: %w", mig.Version, mig.Name, direction, err)
+	}
+
+	if m.dialect.transactionalDDL() {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return fail(err)
+		}
+		defer func() { _ = tx.Rollback() }()
+		if _, err := tx.ExecContext(ctx, script); err != nil {
+			return fail(err)
+		}
+		if err := m.setVersion(ctx, tx, result, false); err != nil {
+			return fail(err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fail(err)
+		}
+	} else {
+		if err := m.setVersion(ctx, conn, mig.Version, true); err != nil {
+			return fail(err)
+		}
+		for _, stmt := range splitStatements(script) {
+			if _, err := conn.ExecContext(ctx, stmt); err != nil {
+				return fail(err)
+			}
+		}
+		if err := m.setVersion(ctx, conn, result, false); err != nil {
+			return fail(err)
+		}
+	}
+
+	slog.Info("applied migration",
+		slog.Uint64("version", mig.Version),
+		slog.String("name", mig.Name),
+		slog.String("direction", direction),
+		slog.Duration("duration", time.Since(start)),
+	)
+	return nil
+}
+
+// execer runs statements on a connection or transaction
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// version reads the recorded schema version, 0 when no migration has been applied
+func (m *Migrator) version(ctx context.Context, db execer) (uint64, bool, error) {
+	var version int64
+	var dirty bool
+	err := db.QueryRowContext(ctx, selectSchemaQuery).Scan(&version, &dirty)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return uint64(version), dirty, nil
+}
+
+// setVersion replaces the recorded schema version
+func (m *Migrator) setVersion(ctx context.Context, db execer, version uint64, dirty bool) error {
+	if _, err := db.ExecContext(ctx, deleteSchemaQuery); err != nil {
+		return fmt.Errorf("failed to clear schema version: %w", err)
+	}
+	if version == 0 && !dirty {
+		return nil
+	}
+	if _, err := db.ExecContext(ctx, m.dialect.Rebind(insertSchemaQuery), int64(version), dirty); err != nil {
+		return fmt.Errorf("failed to record schema version: %w", err)
+	}
+	return nil
+}
+
+// withLock runs fn on a connection holding the migration lock, so replicas
+// starting together migrate one after another
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	lockQuery, unlockQuery := m.dialect.migrationLockQueries()
+	if lockQuery != "" {
+		if err := m.lock(ctx, conn, lockQuery); err != nil {
+			return err
+		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if _, err := conn.ExecContext(ctx, unlockQuery, migrationLockName); err != nil {
+				slog.Error("failed to release migration lock", slog.String("error", err.Error()))
+			}
+		}()
+	}
+
+	if _, err := conn.ExecContext(ctx, createSchemaTableQuery); err != nil {
+		return fmt.Errorf("failed to create schema version table: %w", err)
+	}
+	return fn(conn)
+}
+
+// lock takes the migration lock on conn, polling until the lock timeout
+func (m *Migrator) lock(ctx context.Context, conn *sql.Conn, lockQuery string) error {
+	deadline := time.Now().Add(m.lockTimeout)
+	for {
+		var acquired sql.NullInt64
+		if err := conn.QueryRowContext(ctx, lockQuery, migrationLockName).Scan(&acquired); err != nil {
+			return fmt.Errorf("failed to take migration lock: %w", err)
+		}
+		if acquired.Valid && acquired.Int64 == 1 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return ErrMigrationLocked
+		}
+
+		slog.Info("waiting for migration lock held by another process")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(migrationLockPollInterval):
+		}
+	}
+}
+
+// migrationLockQueries returns the statements taking and releasing the
+// migration lock, empty where the dialect has none
+func (d Dialect) migrationLockQueries() (string, string) {
+	switch d {
+	case DialectMySQL:
+		return mysqlMigrationLockQuery, mysqlMigrationUnlockQuery
+	case DialectPostgres:
+		return postgresMigrationLockQuery, postgresMigrationUnlockQuery
+	}
+	return "", ""
+}
+
+// transactionalDDL reports whether schema changes can run inside a
+// transaction. MySQL commits implicitly around DDL statements.
+func (d Dialect) transactionalDDL() bool {
+	return d != DialectMySQL
+}
+
+// splitStatements splits a script into statements at semicolons outside
+// quotes and comments, as the MySQL driver runs one statement per call
+func splitStatements(script string) []string {
+	var stmts []string
+	var b strings.Builder
+	flush := func() {
+		if stmt := strings.TrimSpace(b.String()); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+		b.Reset()
+	}
+
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			// Copy the quoted text; a backslash or doubled quote escapes the quote
+			b.WriteByte(c)
+			for i++; i < len(script); i++ {
+				b.WriteByte(script[i])
+				if script[i] == '\\' && i+1 < len(script) {
+					i++
+					b.WriteByte(script[i])
+				} else if script[i] == c {
+					if i+1 < len(script) && script[i+1] == c {
+						i++
+						b.WriteByte(script[i])
+						continue
+					}
+					break
+				}
+			}
+		case c == '-' && strings.HasPrefix(script[i:], "--"), c == '#':
+			// Skip line comments
+			for i < len(script) && script[i] != '\n' {
+				i++
+			}
+			b.WriteByte('\n')
+		case c == '/' && strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				i = len(script)
+			} else {
+				i += end + 3
+			}
+			b.WriteByte(' ')
+		case c == ';':
+			flush()
+		default:
+			b.WriteByte(c)
+		}
+	}
+	flush()
+	return stmts
+}
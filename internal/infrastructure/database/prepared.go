@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"sync"
+
+	"github.com/zercle/template-go-echo/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-echo/internal/infrastructure/sqlc/postgres"
+	"github.com/zercle/template-go-echo/internal/infrastructure/sqlc/sqlite"
+)
+
+// preparedQueries are sqlc queries whose statements are prepared
+type preparedQueries interface {
+	sqlc.Querier
+	Close() error
+}
+
+// PreparedQuerier runs the sqlc queries of a dialect as statements prepared
+// once with the generated Prepare, sparing the database from parsing and
+// planning them on every call.
+//
+// database/sql prepares a statement again on each pooled connection it runs
+// on, including connections replacing broken ones. When the server has
+// forgotten a statement on a connection it kept, as after a reset by a
+// connection pooler in front of it or a change of the tables the statement
+// reads, every statement is prepared again and the call retried once. In a
+// transaction the error is returned instead, since the transaction may
+// already be aborted.
+//
+// Statements are prepared through the connection the querier was created
+// on, so with replicas they run on the primary.
+type PreparedQuerier struct {
+	querierRunner
+	db           sqlc.DBTX
+	dialect      Dialect
+	instrumented *InstrumentedDB
+
+	mu      sync.RWMutex
+	queries preparedQueries
+	version uint64
+}
+
+// NewPreparedQuerier prepares every sqlc query of the dialect on db. When db
+// is an InstrumentedDB, each call is observed under its query name. Close
+// closes the statements.
+func NewPreparedQuerier(ctx context.Context, db sqlc.DBTX, dialect Dialect) (*PreparedQuerier, error) {
+	p := &PreparedQuerier{db: db, dialect: dialect}
+	p.instrumented, _ = db.(*InstrumentedDB)
+	p.querierRunner.run = p.run
+
+	queries, err := p.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.queries = queries
+	return p, nil
+}
+
+// prepare prepares the statements of every query
+func (p *PreparedQuerier) prepare(ctx context.Context) (preparedQueries, error) {
+	switch p.dialect {
+	case DialectPostgres:
+		return postgres.PrepareQuerier(ctx, p.db)
+	case DialectSQLite:
+		return sqlite.PrepareQuerier(ctx, p.db)
+	}
+	return sqlc.Prepare(ctx, p.db)
+}
+
+// run invokes call on the prepared queries, preparing them again and
+// retrying once when the server no longer knows the statement
+func (p *PreparedQuerier) run(ctx context.Context, name string, call func(ctx context.Context, q sqlc.Querier) error) error {
+	return p.observe(ctx, name, func(ctx context.Context) error {
+		version, err := p.call(ctx, call)
+		if !IsStatementInvalid(err) {
+			return err
+		}
+
+		slog.Warn("preparing statements again",
+			slog.String("query", name),
+			slog.String("error", err.Error()))
+		if reErr := p.reprepare(ctx, version); reErr != nil {
+			return err
+		}
+		_, err = p.call(ctx, call)
+		return err
+	})
+}
+
+// call invokes call on the current prepared queries, which are not closed
+// while it runs, and returns the version of the queries and its error
+func (p *PreparedQuerier) call(ctx context.Context, call func(ctx context.Context, q sqlc.Querier) error) (uint64, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.version, call(ctx, p.queries)
+}
+
+// reprepare replaces the queries of the given version with newly prepared
+// ones. Calls that failed together prepare them once: the later ones find a
+// newer version and reuse it.
+func (p *PreparedQuerier) reprepare(ctx context.Context, version uint64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.version != version {
+		return nil
+	}
+
+	queries, err := p.prepare(ctx)
+	if err != nil {
+		slog.Error("failed to prepare statements", slog.String("error", err.Error()))
+		return err
+	}
+	if err := p.queries.Close(); err != nil {
+		slog.Error("failed to close stale statements", slog.String("error", err.Error()))
+	}
+	p.queries = queries
+	p.version++
+	return nil
+}
+
+// observe runs fn under the instrumentation of the querier's connection, if any
+func (p *PreparedQuerier) observe(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	if p.instrumented == nil {
+		return fn(ctx)
+	}
+	ctx, done := p.instrumented.start(ctx, name, "", nil)
+	err := fn(ctx)
+	done(err)
+	return err
+}
+
+// WithTx returns the queries running in tx on the current prepared statements
+func (p *PreparedQuerier) WithTx(tx *sql.Tx) sqlc.Querier {
+	p.mu.RLock()
+	queries := bindTx(p.queries, tx)
+	p.mu.RUnlock()
+
+	return &querierRunner{run: func(ctx context.Context, name string, call func(ctx context.Context, q sqlc.Querier) error) error {
+		return p.observe(ctx, name, func(ctx context.Context) error {
+			return call(ctx, queries)
+		})
+	}}
+}
+
+// Close closes the prepared statements
+func (p *PreparedQuerier) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.queries.Close()
+}
@@ -0,0 +1,295 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Replica selection policies, selected with DB_REPLICA_POLICY
+const (
+	PolicyRoundRobin       = "round_robin"
+	PolicyLeastConnections = "least_connections"
+)
+
+// DefaultReplicaHealthInterval is how often replicas are pinged
+const DefaultReplicaHealthInterval = 5 * time.Second
+
+// replicaPingTimeout bounds one replica health check
+const replicaPingTimeout = 2 * time.Second
+
+// routeKey is the context key of the routing hint
+type routeKey struct{}
+
+// route records whether the reads of a context must see the primary
+type route struct {
+	primary atomic.Bool
+}
+
+// WithReadYourWrites returns a context whose reads move to the primary once a
+// write has run with it, so that it sees its own changes despite replica lag
+func WithReadYourWrites(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(routeKey{}).(*route); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, routeKey{}, &route{})
+}
+
+// WithPrimary returns a context whose reads always run on the primary, for
+// work that cannot tolerate replica lag
+func WithPrimary(ctx context.Context) context.Context {
+	r := &route{}
+	r.primary.Store(true)
+	return context.WithValue(ctx, routeKey{}, r)
+}
+
+// markWritten moves the later reads of a read-your-writes context to the primary
+func markWritten(ctx context.Context) {
+	if r, ok := ctx.Value(routeKey{}).(*route); ok {
+		r.primary.Store(true)
+	}
+}
+
+// readsFromPrimary reports whether the reads of ctx must run on the primary
+func readsFromPrimary(ctx context.Context) bool {
+	r, ok := ctx.Value(routeKey{}).(*route)
+	return ok && r.primary.Load()
+}
+
+// replica is a read replica and its health
+type replica struct {
+	name    string
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// Router runs reads on healthy read replicas and everything else on the
+// primary. Only plain SELECT statements are reads; statements locking rows,
+// writes and transactions always run on the primary. When no replica is
+// healthy, reads fall back to the primary.
+type Router struct {
+	primary  *sql.DB
+	replicas []*replica
+	policy   string
+	next     atomic.Uint64
+	interval time.Duration
+	cancel   context.CancelFunc
+	done     sync.WaitGroup
+}
+
+// RouterOption configures optional Router settings
+type RouterOption func(*Router)
+
+// WithPolicy sets how a replica is chosen for each read
+func WithPolicy(policy string) RouterOption {
+	return func(r *Router) {
+		r.policy = policy
+	}
+}
+
+// WithHealthInterval sets how often replicas are pinged to eject unreachable
+// ones and re-admit recovered ones; zero disables the periodic checks
+func WithHealthInterval(interval time.Duration) RouterOption {
+	return func(r *Router) {
+		r.interval = interval
+	}
+}
+
+// NewRouter creates a router over primary and its replicas, which start out
+// healthy. It pings the replicas in the background until Close.
+func NewRouter(primary *sql.DB, replicas []*sql.DB, opts ...RouterOption) *Router {
+	r := &Router{
+		primary:  primary,
+		policy:   PolicyRoundRobin,
+		interval: DefaultReplicaHealthInterval,
+		cancel:   func() {},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	for i, db := range replicas {
+		rep := &replica{name: fmt.Sprintf("replica-%d", i+1), db: db}
+		rep.healthy.Store(true)
+		r.replicas = append(r.replicas, rep)
+	}
+
+	if r.interval > 0 && len(r.replicas) > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		r.cancel = cancel
+		r.done.Add(1)
+		go r.monitor(ctx)
+	}
+	return r
+}
+
+// ExecContext runs a write on the primary
+func (r *Router) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	markWritten(ctx)
+	return r.primary.ExecContext(ctx, query, args...)
+}
+
+// PrepareContext prepares the statement on the primary
+func (r *Router) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return r.primary.PrepareContext(ctx, query)
+}
+
+// QueryContext runs a read on a replica and any other statement on the primary
+func (r *Router) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return r.route(ctx, query).QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext runs a read on a replica and any other statement on the primary
+func (r *Router) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return r.route(ctx, query).QueryRowContext(ctx, query, args...)
+}
+
+// BeginTx starts a transaction on the primary
+func (r *Router) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return r.primary.BeginTx(ctx, opts)
+}
+
+// route returns the database a statement runs on
+func (r *Router) route(ctx context.Context, query string) *sql.DB {
+	if !isReadQuery(query) {
+		markWritten(ctx)
+		return r.primary
+	}
+	if readsFromPrimary(ctx) {
+		return r.primary
+	}
+	if rep := r.pick(); rep != nil {
+		return rep.db
+	}
+	return r.primary
+}
+
+// pick chooses a healthy replica by the router's policy, or nil when none is healthy
+func (r *Router) pick() *replica {
+	healthy := make([]*replica, 0, len(r.replicas))
+	for _, rep := range r.replicas {
+		if rep.healthy.Load() {
+			healthy = append(healthy, rep)
+		}
+	}
+	if len(healthy) == 0 {
+		return nil
+	}
+
+	if r.policy == PolicyLeastConnections {
+		least := healthy[0]
+		for _, rep := range healthy[1:] {
+			if rep.db.Stats().InUse < least.db.Stats().InUse {
+				least = rep
+			}
+		}
+		return least
+	}
+
+	return healthy[(r.next.Add(1)-1)%uint64(len(healthy))]
+}
+
+// CheckHealth pings every replica, ejecting those that fail and re-admitting
+// those that answer again
+func (r *Router) CheckHealth(ctx context.Context) {
+	for _, rep := range r.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, replicaPingTimeout)
+		err := rep.db.PingContext(pingCtx)
+		cancel()
+
+		if err != nil {
+			if rep.healthy.Swap(false) {
+				slog.Warn("read replica ejected", slog.String("replica", rep.name), slog.String("error", err.Error()))
+			}
+			continue
+		}
+		if !rep.healthy.Swap(true) {
+			slog.Info("read replica re-admitted", slog.String("replica", rep.name))
+		}
+	}
+}
+
+// HealthyReplicas returns the number of replicas currently serving reads
+func (r *Router) HealthyReplicas() int {
+	n := 0
+	for _, rep := range r.replicas {
+		if rep.healthy.Load() {
+			n++
+		}
+	}
+	return n
+}
+
+// monitor checks the replicas every interval until Close
+func (r *Router) monitor(ctx context.Context) {
+	defer r.done.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.CheckHealth(ctx)
+		}
+	}
+}
+
+// Close stops the health checks and closes the replica connections; the
+// primary stays open
+func (r *Router) Close() error {
+	r.cancel()
+	r.done.Wait()
+
+	var firstErr error
+	for _, rep := range r.replicas {
+		if err := rep.db.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// isReadQuery reports whether query is a SELECT that can run on a replica.
+// Leading comments, such as the name comment sqlc adds, are skipped, and
+// SELECTs taking row locks or advisory locks count as writes.
+func isReadQuery(query string) bool {
+	q := strings.TrimSpace(query)
+	for {
+		switch {
+		case strings.HasPrefix(q, "--"):
+			end := strings.IndexByte(q, '\n')
+			if end < 0 {
+				return false
+			}
+			q = strings.TrimSpace(q[end+1:])
+			continue
+		case strings.HasPrefix(q, "/*"):
+			end := strings.Index(q, "*/")
+			if end < 0 {
+				return false
+			}
+			q = strings.TrimSpace(q[end+2:])
+			continue
+		}
+		break
+	}
+
+	if len(q) < 6 || !strings.EqualFold(q[:6], "SELECT") {
+		return false
+	}
+	upper := strings.ToUpper(q)
+	for _, locking := range []string{"FOR UPDATE", "FOR SHARE", "LOCK IN SHARE MODE", "GET_LOCK", "ADVISORY_LOCK"} {
+		if strings.Contains(upper, locking) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,269 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/infrastructure/sqlc"
+)
+
+// Transaction retry defaults
+const (
+	DefaultTxMaxRetries   = 3
+	DefaultTxRetryBackoff = 20 * time.Millisecond
+)
+
+// ErrTxNotSupported is returned when the connection cannot start transactions
+var ErrTxNotSupported = errors.New("database connection does not support transactions")
+
+// txKey is the context key of the transaction in progress
+type txKey struct{}
+
+// txState is the transaction carried by a context and how deeply it is nested
+type txState struct {
+	tx    *sql.Tx
+	depth int
+	hooks *[]func()
+}
+
+// beginner is implemented by connections that can start transactions, such as *sql.DB
+type beginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// TxManager implements pkg.TxManager on a database connection. The transaction
+// travels in the context, so repositories resolving their querier with Queries
+// or Conn join it without being passed a *sql.Tx.
+type TxManager struct {
+	db           sqlc.DBTX
+	txOptions    *sql.TxOptions
+	maxRetries   int
+	retryBackoff time.Duration
+}
+
+// TxOption configures optional TxManager settings
+type TxOption func(*TxManager)
+
+// WithTxOptions sets the isolation level and read-only flag of new transactions
+func WithTxOptions(opts *sql.TxOptions) TxOption {
+	return func(m *TxManager) {
+		m.txOptions = opts
+	}
+}
+
+// WithMaxRetries sets how many times a transaction aborted by a deadlock or
+// lock wait timeout is run again; zero disables retries
+func WithMaxRetries(retries int) TxOption {
+	return func(m *TxManager) {
+		m.maxRetries = retries
+	}
+}
+
+// WithRetryBackoff sets the base delay before a retry, which grows with each attempt
+func WithRetryBackoff(backoff time.Duration) TxOption {
+	return func(m *TxManager) {
+		m.retryBackoff = backoff
+	}
+}
+
+// NewTxManager creates a transaction manager for db, which must be able to
+// begin transactions, such as *sql.DB
+func NewTxManager(db sqlc.DBTX, opts ...TxOption) *TxManager {
+	m := &TxManager{
+		db:           db,
+		maxRetries:   DefaultTxMaxRetries,
+		retryBackoff: DefaultTxRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// WithinTx runs fn in a transaction, committing when fn returns nil and
+// rolling back when it returns an error or panics.
+//
+// When ctx already carries a transaction, fn runs inside a savepoint of it
+// instead: an error rolls back to the savepoint only and is returned to the
+// caller, who decides whether the outer transaction fails.
+//
+// An outermost transaction aborted by a deadlock or lock wait timeout is run
+// again, so fn must not have side effects outside the database. The
+// transaction must not be used from several goroutines at once.
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return withinSavepoint(ctx, state, fn)
+	}
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = m.run(ctx, fn)
+		if err == nil || !IsRetryable(err) || attempt >= m.maxRetries {
+			return err
+		}
+
+		backoff := m.retryBackoff * time.Duration(attempt+1)
+		backoff += rand.N(backoff/2 + 1)
+		slog.Warn("retrying transaction",
+			slog.Int("attempt", attempt+1),
+			slog.Duration("backoff", backoff),
+			slog.String("error", err.Error()))
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// run executes fn in a new transaction
+func (m *TxManager) run(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	db, ok := m.db.(beginner)
+	if !ok {
+		return ErrTxNotSupported
+	}
+
+	tx, err := db.BeginTx(ctx, m.txOptions)
+	if err != nil {
+		slog.Error("failed to begin transaction", slog.String("error", err.Error()))
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	var hooks []func()
+	if err := fn(context.WithValue(ctx, txKey{}, &txState{tx: tx, hooks: &hooks})); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			slog.Error("failed to roll back transaction", slog.String("error", rbErr.Error()))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.Error("failed to commit transaction", slog.String("error", err.Error()))
+		return err
+	}
+
+	// Later reads of a read-your-writes context must see the committed changes
+	markWritten(ctx)
+	for _, hook := range hooks {
+		hook()
+	}
+	return nil
+}
+
+// withinSavepoint executes fn inside a savepoint of the transaction in state
+func withinSavepoint(ctx context.Context, state *txState, fn func(ctx context.Context) error) (err error) {
+	nested := &txState{tx: state.tx, depth: state.depth + 1, hooks: state.hooks}
+	name := fmt.Sprintf("sp_%d", nested.depth)
+
+	if _, err := state.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		slog.Error("failed to create savepoint", slog.String("error", err.Error()))
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_, _ = state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+			panic(r)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, nested)); err != nil {
+		// After a deadlock the server has already rolled back the whole
+		// transaction and the savepoint is gone; the outermost call retries
+		if _, rbErr := state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil && !IsRetryable(err) {
+			slog.Error("failed to roll back to savepoint", slog.String("error", rbErr.Error()))
+		}
+		return err
+	}
+
+	if _, err := state.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		slog.Error("failed to release savepoint", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// TxFromContext returns the transaction carried by ctx, if any
+func TxFromContext(ctx context.Context) (*sql.Tx, bool) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		return nil, false
+	}
+	return state.tx, true
+}
+
+// AfterCommit runs fn once the transaction carried by ctx commits, or right
+// away outside a transaction. Functions registered in a savepoint run with the
+// outermost transaction even when the savepoint is rolled back, and are
+// dropped when the transaction is rolled back or retried.
+func AfterCommit(ctx context.Context, fn func()) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		fn()
+		return
+	}
+	*state.hooks = append(*state.hooks, fn)
+}
+
+// txQuerier is implemented by the queriers adapted from other engines' sqlc packages
+type txQuerier interface {
+	WithTx(tx *sql.Tx) sqlc.Querier
+}
+
+// Queries returns q bound to the transaction carried by ctx, or q itself
+// outside a transaction. Queriers other than *sqlc.Queries and the engine
+// adapters, such as test doubles, are returned unchanged.
+func Queries(ctx context.Context, q sqlc.Querier) sqlc.Querier {
+	tx, ok := TxFromContext(ctx)
+	if !ok {
+		return q
+	}
+	return bindTx(q, tx)
+}
+
+// bindTx returns q running its queries in tx, or q itself when it cannot
+func bindTx(q sqlc.Querier, tx *sql.Tx) sqlc.Querier {
+	switch queries := q.(type) {
+	case *sqlc.Queries:
+		return queries.WithTx(tx)
+	case txQuerier:
+		return queries.WithTx(tx)
+	}
+	return q
+}
+
+// Conn returns the transaction carried by ctx, or db outside a transaction.
+// A transaction keeps the dialect of a db created by NewDialectConn and the
+// instrumentation of an InstrumentedDB.
+func Conn(ctx context.Context, db sqlc.DBTX) sqlc.DBTX {
+	tx, ok := TxFromContext(ctx)
+	if !ok {
+		return db
+	}
+	if conn, ok := db.(*dialectConn); ok {
+		return &dialectConn{db: txConn(conn.db, tx), dialect: conn.dialect}
+	}
+	return txConn(db, tx)
+}
+
+// txConn returns tx, instrumented like db when db is an InstrumentedDB
+func txConn(db sqlc.DBTX, tx *sql.Tx) sqlc.DBTX {
+	if instrumented, ok := db.(*InstrumentedDB); ok {
+		return instrumented.withConn(tx)
+	}
+	return tx
+}
@@ -1,22 +1,38 @@
 package infrastructure
 
 import (
+	"context"
+	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/zercle/template-go-echo/pkg"
 )
 
+// ErrCodeNotReady is the error code of a failed readiness check
+const ErrCodeNotReady = "NOT_READY"
+
+// readyCheckTimeout bounds all readiness checks of one request
+const readyCheckTimeout = 3 * time.Second
+
 // HealthResponse represents the health check response
 type HealthResponse struct {
 	Status  string `json:"status"`
 	Service string `json:"service"`
 }
 
-// RegisterHealthRoutes registers health check routes
-func RegisterHealthRoutes(e *echo.Echo) {
+// ReadyCheck is a dependency the service needs before it accepts requests
+type ReadyCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// RegisterHealthRoutes registers health check routes; /ready fails while any
+// of the checks fails
+func RegisterHealthRoutes(e *echo.Echo, checks ...ReadyCheck) {
 	e.GET("/health", Health)
-	e.GET("/ready", Ready)
+	e.GET("/ready", Ready(checks...))
 	e.GET("/live", Live)
 }
 
@@ -33,17 +49,30 @@ func Health(c echo.Context) error {
 	})
 }
 
-// Ready is the readiness check endpoint
+// Ready returns the readiness check endpoint running the given checks
 // @Summary Readiness check
-// @Description Check if service is ready to accept requests
+// @Description Check if service is ready to accept requests, such as the database being reachable and its schema up to date
 // @Produce json
 // @Success 200 {object} HealthResponse
+// @Failure 503 {object} pkg.JSendResponse
 // @Router /ready [get]
-func Ready(c echo.Context) error {
-	return pkg.Success(c, http.StatusOK, HealthResponse{
-		Status:  "ready",
-		Service: "template-go-echo",
-	})
+func Ready(checks ...ReadyCheck) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, cancel := context.WithTimeout(c.Request().Context(), readyCheckTimeout)
+		defer cancel()
+
+		for _, check := range checks {
+			if err := check.Check(ctx); err != nil {
+				slog.Warn("readiness check failed", slog.String("check", check.Name), slog.String("error", err.Error()))
+				return pkg.Error(c, http.StatusServiceUnavailable, check.Name+" is not ready", ErrCodeNotReady)
+			}
+		}
+
+		return pkg.Success(c, http.StatusOK, HealthResponse{
+			Status:  "ready",
+			Service: "template-go-echo",
+		})
+	}
 }
 
 // Live is the liveness check endpoint
@@ -0,0 +1,209 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/infrastructure/tracing"
+)
+
+// Log formats selectable by configuration
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// NewHandler returns the handler writing records to w in the format and at
+// the levels set by cfg. Records of packages with a level override in
+// cfg.Levels are filtered at that level rather than cfg.Level. The values of
+// cfg.RedactKeys and the matches of cfg.RedactPatterns are redacted.
+func NewHandler(cfg *config.LogConfig, w io.Writer) (slog.Handler, error) {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := ParseLevels(cfg.Levels)
+	if err != nil {
+		return nil, err
+	}
+
+	// The output handler lets the lowest level through; PackageLevelHandler
+	// filters each record at the level of its package
+	lowest := level
+	for _, l := range overrides {
+		lowest = min(lowest, l)
+	}
+	opts := &slog.HandlerOptions{Level: lowest}
+
+	var handler slog.Handler
+	switch cfg.Format {
+	case FormatText:
+		handler = slog.NewTextHandler(w, opts)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+	if len(overrides) > 0 {
+		handler = NewPackageLevelHandler(handler, level, overrides)
+	}
+
+	patterns := make([]Pattern, 0, len(cfg.RedactPatterns))
+	for _, name := range cfg.RedactPatterns {
+		pattern, ok := Patterns[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown redaction pattern %q", name)
+		}
+		patterns = append(patterns, pattern)
+	}
+	if len(cfg.RedactKeys) == 0 && len(patterns) == 0 {
+		return handler, nil
+	}
+	redactor, err := NewRedactor(cfg.RedactMode, cfg.RedactKeys, patterns, cfg.RedactHashKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewRedactHandler(handler, redactor), nil
+}
+
+// SetDefault makes the handler NewHandler builds from cfg, writing to w, the
+// default of slog and of the standard log package, so that every binary logs
+// at the same levels and format and with the same redaction. Records logged
+// with a context carry the trace and span IDs of its span.
+func SetDefault(cfg *config.LogConfig, w io.Writer) error {
+	handler, err := NewHandler(cfg, w)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(slog.New(tracing.NewLogHandler(handler)))
+	return nil
+}
+
+// ParseLevel parses a level name, debug, info, warn or error, in any case
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return 0, fmt.Errorf("invalid log level %q", name)
+	}
+	return level, nil
+}
+
+// ParseLevels parses comma-separated package=level overrides, such as
+// "internal/infrastructure/database=warn,internal/user=debug". An override
+// applies to the packages whose import path holds it as whole path elements,
+// so internal/user also covers internal/user/usecase.
+func ParseLevels(value string) (map[string]slog.Level, error) {
+	overrides := make(map[string]slog.Level)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		pkg, name, ok := strings.Cut(item, "=")
+		pkg = strings.Trim(strings.TrimSpace(pkg), "/")
+		if !ok || pkg == "" {
+			return nil, fmt.Errorf("invalid log level override %q, expected package=level", item)
+		}
+		level, err := ParseLevel(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		overrides[pkg] = level
+	}
+	return overrides, nil
+}
+
+// PackageLevelHandler filters records at the level of the package logging
+// them, found from the record's program counter. The most specific override
+// matching the package applies; other packages, and records without a program
+// counter, are filtered at the default level.
+type PackageLevelHandler struct {
+	handler   slog.Handler
+	level     slog.Level
+	lowest    slog.Level
+	overrides map[string]slog.Level
+	// levels caches the level of each program counter seen
+	levels *sync.Map
+}
+
+// NewPackageLevelHandler wraps handler to filter records at level, or at the
+// level overrides set for their package. handler must itself let the lowest
+// of these levels through.
+func NewPackageLevelHandler(handler slog.Handler, level slog.Level, overrides map[string]slog.Level) *PackageLevelHandler {
+	lowest := level
+	for _, l := range overrides {
+		lowest = min(lowest, l)
+	}
+	return &PackageLevelHandler{
+		handler:   handler,
+		level:     level,
+		lowest:    lowest,
+		overrides: overrides,
+		levels:    &sync.Map{},
+	}
+}
+
+// Enabled implements slog.Handler. The package is unknown until the record
+// is handled, so any level an override lets through is enabled here.
+func (h *PackageLevelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.lowest && h.handler.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler
+func (h *PackageLevelHandler) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level < h.levelOf(record.PC) {
+		return nil
+	}
+	return h.handler.Handle(ctx, record)
+}
+
+// WithAttrs implements slog.Handler
+func (h *PackageLevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.handler = h.handler.WithAttrs(attrs)
+	return &clone
+}
+
+// WithGroup implements slog.Handler
+func (h *PackageLevelHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.handler = h.handler.WithGroup(name)
+	return &clone
+}
+
+// levelOf returns the level applying to the package of the function at pc
+func (h *PackageLevelHandler) levelOf(pc uintptr) slog.Level {
+	if pc == 0 {
+		return h.level
+	}
+	if level, ok := h.levels.Load(pc); ok {
+		return level.(slog.Level)
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	path := "/" + packagePath(frame.Function) + "/"
+	level, matched := h.level, ""
+	for pkg, l := range h.overrides {
+		if len(pkg) > len(matched) && strings.Contains(path, "/"+pkg+"/") {
+			level, matched = l, pkg
+		}
+	}
+	h.levels.Store(pc, level)
+	return level
+}
+
+// packagePath returns the import path of the package of a function named as
+// by runtime.Frame, such as example.com/mod/pkg.(*Type).Method
+func packagePath(function string) string {
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+	return function
+}
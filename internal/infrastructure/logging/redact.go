@@ -0,0 +1,260 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// Redaction modes selectable by configuration
+const (
+	// RedactMask replaces values with [REDACTED]
+	RedactMask = "mask"
+	// RedactHash replaces values with a keyed hash, so that records about the
+	// same value can still be correlated
+	RedactHash = "hash"
+	// RedactPartial keeps the shape of emails and IPv4 addresses, such as
+	// a***@example.com and 192.168.*.*, and masks other values
+	RedactPartial = "partial"
+)
+
+// redactedValue replaces masked values
+const redactedValue = "[REDACTED]"
+
+// Pattern finds sensitive values inside strings. Partial, if set, masks a
+// match partially; matches of patterns without it are masked fully.
+type Pattern struct {
+	Name    string
+	Regexp  *regexp.Regexp
+	Partial func(match string) string
+}
+
+// Patterns are the built-in patterns, selectable by name in configuration
+var Patterns = map[string]Pattern{
+	"email": {
+		Name:    "email",
+		Regexp:  regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+		Partial: partialEmail,
+	},
+	"ipv4": {
+		Name:    "ipv4",
+		Regexp:  regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`),
+		Partial: partialIPv4,
+	},
+	"jwt": {
+		Name:   "jwt",
+		Regexp: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	},
+	"bearer": {
+		Name:   "bearer",
+		Regexp: regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/=-]+`),
+	},
+}
+
+// Redactor masks the values of sensitive attribute keys, whatever their
+// content, and the matches of sensitive patterns in any string value
+type Redactor struct {
+	mode     string
+	keys     map[string]bool
+	patterns []Pattern
+	hashKey  []byte
+}
+
+// NewRedactor returns a redactor masking in mode the values of keys, matched
+// regardless of case, and the matches of patterns. hashKey keys the hashes
+// of RedactHash, so that they cannot be reversed by hashing guesses without
+// it, and is required in that mode.
+func NewRedactor(mode string, keys []string, patterns []Pattern, hashKey string) (*Redactor, error) {
+	switch mode {
+	case RedactMask, RedactPartial:
+	case RedactHash:
+		if hashKey == "" {
+			return nil, fmt.Errorf("redaction mode %q requires a hash key", mode)
+		}
+	default:
+		return nil, fmt.Errorf("unknown redaction mode %q", mode)
+	}
+	r := &Redactor{mode: mode, keys: make(map[string]bool), patterns: patterns, hashKey: []byte(hashKey)}
+	for _, key := range keys {
+		r.keys[strings.ToLower(key)] = true
+	}
+	return r, nil
+}
+
+// String redacts the pattern matches in s
+func (r *Redactor) String(s string) string {
+	for _, p := range r.patterns {
+		s = p.Regexp.ReplaceAllStringFunc(s, func(match string) string {
+			return r.replace(match, p.Partial)
+		})
+	}
+	return s
+}
+
+// Attr redacts attr: its whole value when its key is sensitive, otherwise
+// the pattern matches in its string values, within groups too. Other values
+// held as any are redacted as described by Any.
+func (r *Redactor) Attr(attr slog.Attr) slog.Attr {
+	value := attr.Value.Resolve()
+	if r.keys[strings.ToLower(attr.Key)] {
+		return slog.String(attr.Key, r.whole(value.String()))
+	}
+
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, r.String(value.String()))
+	case slog.KindGroup:
+		group := value.Group()
+		attrs := make([]slog.Attr, len(group))
+		for i, a := range group {
+			attrs[i] = r.Attr(a)
+		}
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(attrs...)}
+	case slog.KindAny:
+		return slog.Attr{Key: attr.Key, Value: r.Any(value.Any())}
+	}
+	return slog.Attr{Key: attr.Key, Value: value}
+}
+
+// Any redacts a value held as any. Errors and fmt.Stringers are redacted as
+// their text. Other values, such as structs, maps and slices, are redacted
+// as their JSON form: the fields and keys that are sensitive lose their
+// whole value and strings lose their pattern matches. Values that cannot be
+// encoded as JSON are masked, as their content cannot be inspected.
+func (r *Redactor) Any(v any) slog.Value {
+	switch v := v.(type) {
+	case nil:
+		return slog.AnyValue(nil)
+	case error:
+		return slog.StringValue(r.String(v.Error()))
+	case fmt.Stringer:
+		return slog.StringValue(r.String(v.String()))
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return slog.StringValue(redactedValue)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return slog.StringValue(redactedValue)
+	}
+	return slog.AnyValue(r.json(decoded))
+}
+
+// json redacts a value decoded from JSON
+func (r *Redactor) json(v any) any {
+	switch v := v.(type) {
+	case string:
+		return r.String(v)
+	case map[string]any:
+		for key, value := range v {
+			if r.keys[strings.ToLower(key)] {
+				if value != nil {
+					v[key] = r.whole(fmt.Sprint(value))
+				}
+				continue
+			}
+			v[key] = r.json(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = r.json(value)
+		}
+	}
+	return v
+}
+
+// whole redacts a value of a sensitive key. In partial mode a value that is
+// entirely one match of a pattern keeps that pattern's partial shape.
+func (r *Redactor) whole(s string) string {
+	if s == "" {
+		return s
+	}
+	for _, p := range r.patterns {
+		if loc := p.Regexp.FindStringIndex(s); loc != nil && loc[0] == 0 && loc[1] == len(s) {
+			return r.replace(s, p.Partial)
+		}
+	}
+	return r.replace(s, nil)
+}
+
+// replace redacts one sensitive value in the redactor's mode
+func (r *Redactor) replace(s string, partial func(string) string) string {
+	switch r.mode {
+	case RedactHash:
+		mac := hmac.New(sha256.New, r.hashKey)
+		mac.Write([]byte(s))
+		return "sha256:" + hex.EncodeToString(mac.Sum(nil)[:8])
+	case RedactPartial:
+		if partial != nil {
+			return partial(s)
+		}
+	}
+	return redactedValue
+}
+
+// partialEmail keeps the first character of the local part and the domain
+func partialEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return redactedValue
+	}
+	return email[:1] + "***" + email[at:]
+}
+
+// partialIPv4 keeps the first two octets, the network of most allocations
+func partialIPv4(ip string) string {
+	octets := strings.Split(ip, ".")
+	return octets[0] + "." + octets[1] + ".*.*"
+}
+
+// RedactHandler redacts the message and attributes of records, including
+// attributes added through WithAttrs, before passing them on
+type RedactHandler struct {
+	handler  slog.Handler
+	redactor *Redactor
+}
+
+// NewRedactHandler wraps handler to redact records with redactor
+func NewRedactHandler(handler slog.Handler, redactor *Redactor) *RedactHandler {
+	return &RedactHandler{handler: handler, redactor: redactor}
+}
+
+// Enabled implements slog.Handler
+func (h *RedactHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler
+func (h *RedactHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, h.redactor.String(record.Message), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		redacted.AddAttrs(h.redactor.Attr(attr))
+		return true
+	})
+	return h.handler.Handle(ctx, redacted)
+}
+
+// WithAttrs implements slog.Handler
+func (h *RedactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		redacted[i] = h.redactor.Attr(attr)
+	}
+	return &RedactHandler{handler: h.handler.WithAttrs(redacted), redactor: h.redactor}
+}
+
+// WithGroup implements slog.Handler
+func (h *RedactHandler) WithGroup(name string) slog.Handler {
+	return &RedactHandler{handler: h.handler.WithGroup(name), redactor: h.redactor}
+}
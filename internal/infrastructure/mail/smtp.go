@@ -0,0 +1,117 @@
+// Package mail sends email through an SMTP relay
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/config"
+)
+
+// ErrInvalidHeader is returned for recipients or subjects spanning several lines
+var ErrInvalidHeader = errors.New("mail header values must not contain line breaks")
+
+// SMTP sends plain text emails through an SMTP relay, upgrading the
+// connection with STARTTLS whenever the relay offers it
+type SMTP struct {
+	addr     string
+	username string
+	password string
+	from     string
+	timeout  time.Duration
+}
+
+// NewSMTP creates a mailer sending through the relay of cfg
+func NewSMTP(cfg *config.MailConfig) *SMTP {
+	return &SMTP{
+		addr:     cfg.SMTPAddr,
+		username: cfg.Username,
+		password: cfg.Password,
+		from:     cfg.From,
+		timeout:  cfg.Timeout,
+	}
+}
+
+// Send delivers a plain text email to a single recipient, giving up once ctx
+// is done or the configured timeout elapses
+func (m *SMTP) Send(ctx context.Context, to, subject, body string) error {
+	if strings.ContainsAny(to+subject, "\r\n") {
+		return ErrInvalidHeader
+	}
+	host, _, err := net.SplitHostPort(m.addr)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Abort the exchange once ctx is done
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	// PlainAuth refuses to send credentials over unencrypted connections to other hosts than localhost
+	if m.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.username, m.password, host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(m.from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(m.message(to, subject, body)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// message builds the headers and body of an email
+func (m *SMTP) message(to, subject, body string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("From: " + m.from + "\r\n")
+	buf.WriteString("To: " + to + "\r\n")
+	buf.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	buf.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(body)
+	return buf.Bytes()
+}
@@ -0,0 +1,28 @@
+package infrastructure
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// NewMetricsRegistry creates the registry the API's metrics are registered
+// with, holding the Go runtime metrics, including garbage collector, memory
+// and scheduler metrics, and the process metrics
+func NewMetricsRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(collectors.WithGoCollectorRuntimeMetrics(
+			collectors.MetricsGC, collectors.MetricsMemory, collectors.MetricsScheduler,
+		)),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registry
+}
+
+// RegisterMetricsRoute registers a route at path serving the metrics of
+// gatherer in the Prometheus exposition format
+func RegisterMetricsRoute(e *echo.Echo, path string, gatherer prometheus.Gatherer) {
+	e.GET(path, echo.WrapHandler(promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})))
+}
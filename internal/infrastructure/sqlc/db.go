@@ -24,21 +24,99 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.claimJobStmt, err = db.PrepareContext(ctx, claimJob); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimJob: %w", err)
+	}
+	if q.claimWebhookDeliveryStmt, err = db.PrepareContext(ctx, claimWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimWebhookDelivery: %w", err)
+	}
+	if q.completeIdempotencyKeyStmt, err = db.PrepareContext(ctx, completeIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query CompleteIdempotencyKey: %w", err)
+	}
+	if q.completeJobStmt, err = db.PrepareContext(ctx, completeJob); err != nil {
+		return nil, fmt.Errorf("error preparing query CompleteJob: %w", err)
+	}
+	if q.createAuditEventStmt, err = db.PrepareContext(ctx, createAuditEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateAuditEvent: %w", err)
+	}
+	if q.createIdempotencyKeyStmt, err = db.PrepareContext(ctx, createIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateIdempotencyKey: %w", err)
+	}
+	if q.createJobStmt, err = db.PrepareContext(ctx, createJob); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateJob: %w", err)
+	}
+	if q.createOutboxMessageStmt, err = db.PrepareContext(ctx, createOutboxMessage); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateOutboxMessage: %w", err)
+	}
+	if q.createSchedulerRunStmt, err = db.PrepareContext(ctx, createSchedulerRun); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateSchedulerRun: %w", err)
+	}
 	if q.createSessionStmt, err = db.PrepareContext(ctx, createSession); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateSession: %w", err)
 	}
 	if q.createUserStmt, err = db.PrepareContext(ctx, createUser); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateUser: %w", err)
 	}
+	if q.createWebhookDeliveryStmt, err = db.PrepareContext(ctx, createWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWebhookDelivery: %w", err)
+	}
+	if q.createWebhookSubscriptionStmt, err = db.PrepareContext(ctx, createWebhookSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWebhookSubscription: %w", err)
+	}
+	if q.deadLetterExpiredJobsStmt, err = db.PrepareContext(ctx, deadLetterExpiredJobs); err != nil {
+		return nil, fmt.Errorf("error preparing query DeadLetterExpiredJobs: %w", err)
+	}
+	if q.deadLetterJobStmt, err = db.PrepareContext(ctx, deadLetterJob); err != nil {
+		return nil, fmt.Errorf("error preparing query DeadLetterJob: %w", err)
+	}
+	if q.deleteExpiredIdempotencyKeysStmt, err = db.PrepareContext(ctx, deleteExpiredIdempotencyKeys); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredIdempotencyKeys: %w", err)
+	}
 	if q.deleteExpiredSessionsStmt, err = db.PrepareContext(ctx, deleteExpiredSessions); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteExpiredSessions: %w", err)
 	}
+	if q.deleteIdempotencyKeyStmt, err = db.PrepareContext(ctx, deleteIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteIdempotencyKey: %w", err)
+	}
+	if q.deletePublishedOutboxMessagesStmt, err = db.PrepareContext(ctx, deletePublishedOutboxMessages); err != nil {
+		return nil, fmt.Errorf("error preparing query DeletePublishedOutboxMessages: %w", err)
+	}
+	if q.deleteSchedulerRunsBeforeStmt, err = db.PrepareContext(ctx, deleteSchedulerRunsBefore); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSchedulerRunsBefore: %w", err)
+	}
 	if q.deleteSessionStmt, err = db.PrepareContext(ctx, deleteSession); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteSession: %w", err)
 	}
+	if q.deleteSessionsByUserIDStmt, err = db.PrepareContext(ctx, deleteSessionsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSessionsByUserID: %w", err)
+	}
+	if q.deleteSucceededJobsStmt, err = db.PrepareContext(ctx, deleteSucceededJobs); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSucceededJobs: %w", err)
+	}
 	if q.deleteUserStmt, err = db.PrepareContext(ctx, deleteUser); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteUser: %w", err)
 	}
+	if q.deleteWebhookSubscriptionStmt, err = db.PrepareContext(ctx, deleteWebhookSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteWebhookSubscription: %w", err)
+	}
+	if q.disableWebhookSubscriptionStmt, err = db.PrepareContext(ctx, disableWebhookSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query DisableWebhookSubscription: %w", err)
+	}
+	if q.finishSchedulerRunStmt, err = db.PrepareContext(ctx, finishSchedulerRun); err != nil {
+		return nil, fmt.Errorf("error preparing query FinishSchedulerRun: %w", err)
+	}
+	if q.getDeletedUserByEmailStmt, err = db.PrepareContext(ctx, getDeletedUserByEmail); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDeletedUserByEmail: %w", err)
+	}
+	if q.getDeletedUserCountStmt, err = db.PrepareContext(ctx, getDeletedUserCount); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDeletedUserCount: %w", err)
+	}
+	if q.getIdempotencyKeyStmt, err = db.PrepareContext(ctx, getIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query GetIdempotencyKey: %w", err)
+	}
+	if q.getJobStmt, err = db.PrepareContext(ctx, getJob); err != nil {
+		return nil, fmt.Errorf("error preparing query GetJob: %w", err)
+	}
 	if q.getSessionByIDStmt, err = db.PrepareContext(ctx, getSessionByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetSessionByID: %w", err)
 	}
@@ -57,17 +135,140 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getUserCountStmt, err = db.PrepareContext(ctx, getUserCount); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserCount: %w", err)
 	}
+	if q.getWebhookDeliveryStmt, err = db.PrepareContext(ctx, getWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebhookDelivery: %w", err)
+	}
+	if q.getWebhookSubscriptionStmt, err = db.PrepareContext(ctx, getWebhookSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebhookSubscription: %w", err)
+	}
+	if q.listDeletedUsersStmt, err = db.PrepareContext(ctx, listDeletedUsers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDeletedUsers: %w", err)
+	}
+	if q.listDueWebhookDeliveriesStmt, err = db.PrepareContext(ctx, listDueWebhookDeliveries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDueWebhookDeliveries: %w", err)
+	}
+	if q.listEnabledWebhookSubscriptionsStmt, err = db.PrepareContext(ctx, listEnabledWebhookSubscriptions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListEnabledWebhookSubscriptions: %w", err)
+	}
+	if q.listPendingOutboxMessagesStmt, err = db.PrepareContext(ctx, listPendingOutboxMessages); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPendingOutboxMessages: %w", err)
+	}
 	if q.listUsersStmt, err = db.PrepareContext(ctx, listUsers); err != nil {
 		return nil, fmt.Errorf("error preparing query ListUsers: %w", err)
 	}
+	if q.listWebhookSubscriptionsStmt, err = db.PrepareContext(ctx, listWebhookSubscriptions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWebhookSubscriptions: %w", err)
+	}
+	if q.markOutboxMessageFailedStmt, err = db.PrepareContext(ctx, markOutboxMessageFailed); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkOutboxMessageFailed: %w", err)
+	}
+	if q.markOutboxMessagePublishedStmt, err = db.PrepareContext(ctx, markOutboxMessagePublished); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkOutboxMessagePublished: %w", err)
+	}
+	if q.markWebhookDeliveryFailedStmt, err = db.PrepareContext(ctx, markWebhookDeliveryFailed); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkWebhookDeliveryFailed: %w", err)
+	}
+	if q.markWebhookDeliverySucceededStmt, err = db.PrepareContext(ctx, markWebhookDeliverySucceeded); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkWebhookDeliverySucceeded: %w", err)
+	}
+	if q.purgeDeletedUserStmt, err = db.PrepareContext(ctx, purgeDeletedUser); err != nil {
+		return nil, fmt.Errorf("error preparing query PurgeDeletedUser: %w", err)
+	}
+	if q.purgeDeletedUsersStmt, err = db.PrepareContext(ctx, purgeDeletedUsers); err != nil {
+		return nil, fmt.Errorf("error preparing query PurgeDeletedUsers: %w", err)
+	}
+	if q.reactivateUserStmt, err = db.PrepareContext(ctx, reactivateUser); err != nil {
+		return nil, fmt.Errorf("error preparing query ReactivateUser: %w", err)
+	}
+	if q.reclaimIdempotencyKeyStmt, err = db.PrepareContext(ctx, reclaimIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query ReclaimIdempotencyKey: %w", err)
+	}
+	if q.recordWebhookFailureStmt, err = db.PrepareContext(ctx, recordWebhookFailure); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordWebhookFailure: %w", err)
+	}
+	if q.recordWebhookSuccessStmt, err = db.PrepareContext(ctx, recordWebhookSuccess); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordWebhookSuccess: %w", err)
+	}
+	if q.releaseExpiredJobsStmt, err = db.PrepareContext(ctx, releaseExpiredJobs); err != nil {
+		return nil, fmt.Errorf("error preparing query ReleaseExpiredJobs: %w", err)
+	}
+	if q.rescheduleJobStmt, err = db.PrepareContext(ctx, rescheduleJob); err != nil {
+		return nil, fmt.Errorf("error preparing query RescheduleJob: %w", err)
+	}
+	if q.resetWebhookDeliveryStmt, err = db.PrepareContext(ctx, resetWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query ResetWebhookDelivery: %w", err)
+	}
+	if q.restoreUserStmt, err = db.PrepareContext(ctx, restoreUser); err != nil {
+		return nil, fmt.Errorf("error preparing query RestoreUser: %w", err)
+	}
+	if q.retryJobStmt, err = db.PrepareContext(ctx, retryJob); err != nil {
+		return nil, fmt.Errorf("error preparing query RetryJob: %w", err)
+	}
+	if q.suspendUserStmt, err = db.PrepareContext(ctx, suspendUser); err != nil {
+		return nil, fmt.Errorf("error preparing query SuspendUser: %w", err)
+	}
+	if q.updatePasswordStmt, err = db.PrepareContext(ctx, updatePassword); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdatePassword: %w", err)
+	}
 	if q.updateUserStmt, err = db.PrepareContext(ctx, updateUser); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateUser: %w", err)
 	}
+	if q.updateUserRoleStmt, err = db.PrepareContext(ctx, updateUserRole); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateUserRole: %w", err)
+	}
+	if q.updateWebhookSubscriptionStmt, err = db.PrepareContext(ctx, updateWebhookSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateWebhookSubscription: %w", err)
+	}
 	return &q, nil
 }
 
 func (q *Queries) Close() error {
 	var err error
+	if q.claimJobStmt != nil {
+		if cerr := q.claimJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimJobStmt: %w", cerr)
+		}
+	}
+	if q.claimWebhookDeliveryStmt != nil {
+		if cerr := q.claimWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.completeIdempotencyKeyStmt != nil {
+		if cerr := q.completeIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing completeIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.completeJobStmt != nil {
+		if cerr := q.completeJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing completeJobStmt: %w", cerr)
+		}
+	}
+	if q.createAuditEventStmt != nil {
+		if cerr := q.createAuditEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createAuditEventStmt: %w", cerr)
+		}
+	}
+	if q.createIdempotencyKeyStmt != nil {
+		if cerr := q.createIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.createJobStmt != nil {
+		if cerr := q.createJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createJobStmt: %w", cerr)
+		}
+	}
+	if q.createOutboxMessageStmt != nil {
+		if cerr := q.createOutboxMessageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createOutboxMessageStmt: %w", cerr)
+		}
+	}
+	if q.createSchedulerRunStmt != nil {
+		if cerr := q.createSchedulerRunStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createSchedulerRunStmt: %w", cerr)
+		}
+	}
 	if q.createSessionStmt != nil {
 		if cerr := q.createSessionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createSessionStmt: %w", cerr)
@@ -78,21 +279,106 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createUserStmt: %w", cerr)
 		}
 	}
+	if q.createWebhookDeliveryStmt != nil {
+		if cerr := q.createWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.createWebhookSubscriptionStmt != nil {
+		if cerr := q.createWebhookSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWebhookSubscriptionStmt: %w", cerr)
+		}
+	}
+	if q.deadLetterExpiredJobsStmt != nil {
+		if cerr := q.deadLetterExpiredJobsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deadLetterExpiredJobsStmt: %w", cerr)
+		}
+	}
+	if q.deadLetterJobStmt != nil {
+		if cerr := q.deadLetterJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deadLetterJobStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredIdempotencyKeysStmt != nil {
+		if cerr := q.deleteExpiredIdempotencyKeysStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredIdempotencyKeysStmt: %w", cerr)
+		}
+	}
 	if q.deleteExpiredSessionsStmt != nil {
 		if cerr := q.deleteExpiredSessionsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteExpiredSessionsStmt: %w", cerr)
 		}
 	}
+	if q.deleteIdempotencyKeyStmt != nil {
+		if cerr := q.deleteIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.deletePublishedOutboxMessagesStmt != nil {
+		if cerr := q.deletePublishedOutboxMessagesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deletePublishedOutboxMessagesStmt: %w", cerr)
+		}
+	}
+	if q.deleteSchedulerRunsBeforeStmt != nil {
+		if cerr := q.deleteSchedulerRunsBeforeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSchedulerRunsBeforeStmt: %w", cerr)
+		}
+	}
 	if q.deleteSessionStmt != nil {
 		if cerr := q.deleteSessionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteSessionStmt: %w", cerr)
 		}
 	}
+	if q.deleteSessionsByUserIDStmt != nil {
+		if cerr := q.deleteSessionsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSessionsByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.deleteSucceededJobsStmt != nil {
+		if cerr := q.deleteSucceededJobsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSucceededJobsStmt: %w", cerr)
+		}
+	}
 	if q.deleteUserStmt != nil {
 		if cerr := q.deleteUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteUserStmt: %w", cerr)
 		}
 	}
+	if q.deleteWebhookSubscriptionStmt != nil {
+		if cerr := q.deleteWebhookSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteWebhookSubscriptionStmt: %w", cerr)
+		}
+	}
+	if q.disableWebhookSubscriptionStmt != nil {
+		if cerr := q.disableWebhookSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing disableWebhookSubscriptionStmt: %w", cerr)
+		}
+	}
+	if q.finishSchedulerRunStmt != nil {
+		if cerr := q.finishSchedulerRunStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing finishSchedulerRunStmt: %w", cerr)
+		}
+	}
+	if q.getDeletedUserByEmailStmt != nil {
+		if cerr := q.getDeletedUserByEmailStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDeletedUserByEmailStmt: %w", cerr)
+		}
+	}
+	if q.getDeletedUserCountStmt != nil {
+		if cerr := q.getDeletedUserCountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDeletedUserCountStmt: %w", cerr)
+		}
+	}
+	if q.getIdempotencyKeyStmt != nil {
+		if cerr := q.getIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.getJobStmt != nil {
+		if cerr := q.getJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getJobStmt: %w", cerr)
+		}
+	}
 	if q.getSessionByIDStmt != nil {
 		if cerr := q.getSessionByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getSessionByIDStmt: %w", cerr)
@@ -123,16 +409,146 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getUserCountStmt: %w", cerr)
 		}
 	}
+	if q.getWebhookDeliveryStmt != nil {
+		if cerr := q.getWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.getWebhookSubscriptionStmt != nil {
+		if cerr := q.getWebhookSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebhookSubscriptionStmt: %w", cerr)
+		}
+	}
+	if q.listDeletedUsersStmt != nil {
+		if cerr := q.listDeletedUsersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDeletedUsersStmt: %w", cerr)
+		}
+	}
+	if q.listDueWebhookDeliveriesStmt != nil {
+		if cerr := q.listDueWebhookDeliveriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDueWebhookDeliveriesStmt: %w", cerr)
+		}
+	}
+	if q.listEnabledWebhookSubscriptionsStmt != nil {
+		if cerr := q.listEnabledWebhookSubscriptionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listEnabledWebhookSubscriptionsStmt: %w", cerr)
+		}
+	}
+	if q.listPendingOutboxMessagesStmt != nil {
+		if cerr := q.listPendingOutboxMessagesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPendingOutboxMessagesStmt: %w", cerr)
+		}
+	}
 	if q.listUsersStmt != nil {
 		if cerr := q.listUsersStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listUsersStmt: %w", cerr)
 		}
 	}
+	if q.listWebhookSubscriptionsStmt != nil {
+		if cerr := q.listWebhookSubscriptionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWebhookSubscriptionsStmt: %w", cerr)
+		}
+	}
+	if q.markOutboxMessageFailedStmt != nil {
+		if cerr := q.markOutboxMessageFailedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markOutboxMessageFailedStmt: %w", cerr)
+		}
+	}
+	if q.markOutboxMessagePublishedStmt != nil {
+		if cerr := q.markOutboxMessagePublishedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markOutboxMessagePublishedStmt: %w", cerr)
+		}
+	}
+	if q.markWebhookDeliveryFailedStmt != nil {
+		if cerr := q.markWebhookDeliveryFailedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markWebhookDeliveryFailedStmt: %w", cerr)
+		}
+	}
+	if q.markWebhookDeliverySucceededStmt != nil {
+		if cerr := q.markWebhookDeliverySucceededStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markWebhookDeliverySucceededStmt: %w", cerr)
+		}
+	}
+	if q.purgeDeletedUserStmt != nil {
+		if cerr := q.purgeDeletedUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing purgeDeletedUserStmt: %w", cerr)
+		}
+	}
+	if q.purgeDeletedUsersStmt != nil {
+		if cerr := q.purgeDeletedUsersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing purgeDeletedUsersStmt: %w", cerr)
+		}
+	}
+	if q.reactivateUserStmt != nil {
+		if cerr := q.reactivateUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reactivateUserStmt: %w", cerr)
+		}
+	}
+	if q.reclaimIdempotencyKeyStmt != nil {
+		if cerr := q.reclaimIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reclaimIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.recordWebhookFailureStmt != nil {
+		if cerr := q.recordWebhookFailureStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordWebhookFailureStmt: %w", cerr)
+		}
+	}
+	if q.recordWebhookSuccessStmt != nil {
+		if cerr := q.recordWebhookSuccessStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordWebhookSuccessStmt: %w", cerr)
+		}
+	}
+	if q.releaseExpiredJobsStmt != nil {
+		if cerr := q.releaseExpiredJobsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing releaseExpiredJobsStmt: %w", cerr)
+		}
+	}
+	if q.rescheduleJobStmt != nil {
+		if cerr := q.rescheduleJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing rescheduleJobStmt: %w", cerr)
+		}
+	}
+	if q.resetWebhookDeliveryStmt != nil {
+		if cerr := q.resetWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing resetWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.restoreUserStmt != nil {
+		if cerr := q.restoreUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing restoreUserStmt: %w", cerr)
+		}
+	}
+	if q.retryJobStmt != nil {
+		if cerr := q.retryJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing retryJobStmt: %w", cerr)
+		}
+	}
+	if q.suspendUserStmt != nil {
+		if cerr := q.suspendUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing suspendUserStmt: %w", cerr)
+		}
+	}
+	if q.updatePasswordStmt != nil {
+		if cerr := q.updatePasswordStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updatePasswordStmt: %w", cerr)
+		}
+	}
 	if q.updateUserStmt != nil {
 		if cerr := q.updateUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateUserStmt: %w", cerr)
 		}
 	}
+	if q.updateUserRoleStmt != nil {
+		if cerr := q.updateUserRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateUserRoleStmt: %w", cerr)
+		}
+	}
+	if q.updateWebhookSubscriptionStmt != nil {
+		if cerr := q.updateWebhookSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateWebhookSubscriptionStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -170,39 +586,143 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                        DBTX
-	tx                        *sql.Tx
-	createSessionStmt         *sql.Stmt
-	createUserStmt            *sql.Stmt
-	deleteExpiredSessionsStmt *sql.Stmt
-	deleteSessionStmt         *sql.Stmt
-	deleteUserStmt            *sql.Stmt
-	getSessionByIDStmt        *sql.Stmt
-	getSessionByTokenHashStmt *sql.Stmt
-	getSessionByUserIDStmt    *sql.Stmt
-	getUserByEmailStmt        *sql.Stmt
-	getUserByIDStmt           *sql.Stmt
-	getUserCountStmt          *sql.Stmt
-	listUsersStmt             *sql.Stmt
-	updateUserStmt            *sql.Stmt
+	db                                  DBTX
+	tx                                  *sql.Tx
+	claimJobStmt                        *sql.Stmt
+	claimWebhookDeliveryStmt            *sql.Stmt
+	completeIdempotencyKeyStmt          *sql.Stmt
+	completeJobStmt                     *sql.Stmt
+	createAuditEventStmt                *sql.Stmt
+	createIdempotencyKeyStmt            *sql.Stmt
+	createJobStmt                       *sql.Stmt
+	createOutboxMessageStmt             *sql.Stmt
+	createSchedulerRunStmt              *sql.Stmt
+	createSessionStmt                   *sql.Stmt
+	createUserStmt                      *sql.Stmt
+	createWebhookDeliveryStmt           *sql.Stmt
+	createWebhookSubscriptionStmt       *sql.Stmt
+	deadLetterExpiredJobsStmt           *sql.Stmt
+	deadLetterJobStmt                   *sql.Stmt
+	deleteExpiredIdempotencyKeysStmt    *sql.Stmt
+	deleteExpiredSessionsStmt           *sql.Stmt
+	deleteIdempotencyKeyStmt            *sql.Stmt
+	deletePublishedOutboxMessagesStmt   *sql.Stmt
+	deleteSchedulerRunsBeforeStmt       *sql.Stmt
+	deleteSessionStmt                   *sql.Stmt
+	deleteSessionsByUserIDStmt          *sql.Stmt
+	deleteSucceededJobsStmt             *sql.Stmt
+	deleteUserStmt                      *sql.Stmt
+	deleteWebhookSubscriptionStmt       *sql.Stmt
+	disableWebhookSubscriptionStmt      *sql.Stmt
+	finishSchedulerRunStmt              *sql.Stmt
+	getDeletedUserByEmailStmt           *sql.Stmt
+	getDeletedUserCountStmt             *sql.Stmt
+	getIdempotencyKeyStmt               *sql.Stmt
+	getJobStmt                          *sql.Stmt
+	getSessionByIDStmt                  *sql.Stmt
+	getSessionByTokenHashStmt           *sql.Stmt
+	getSessionByUserIDStmt              *sql.Stmt
+	getUserByEmailStmt                  *sql.Stmt
+	getUserByIDStmt                     *sql.Stmt
+	getUserCountStmt                    *sql.Stmt
+	getWebhookDeliveryStmt              *sql.Stmt
+	getWebhookSubscriptionStmt          *sql.Stmt
+	listDeletedUsersStmt                *sql.Stmt
+	listDueWebhookDeliveriesStmt        *sql.Stmt
+	listEnabledWebhookSubscriptionsStmt *sql.Stmt
+	listPendingOutboxMessagesStmt       *sql.Stmt
+	listUsersStmt                       *sql.Stmt
+	listWebhookSubscriptionsStmt        *sql.Stmt
+	markOutboxMessageFailedStmt         *sql.Stmt
+	markOutboxMessagePublishedStmt      *sql.Stmt
+	markWebhookDeliveryFailedStmt       *sql.Stmt
+	markWebhookDeliverySucceededStmt    *sql.Stmt
+	purgeDeletedUserStmt                *sql.Stmt
+	purgeDeletedUsersStmt               *sql.Stmt
+	reactivateUserStmt                  *sql.Stmt
+	reclaimIdempotencyKeyStmt           *sql.Stmt
+	recordWebhookFailureStmt            *sql.Stmt
+	recordWebhookSuccessStmt            *sql.Stmt
+	releaseExpiredJobsStmt              *sql.Stmt
+	rescheduleJobStmt                   *sql.Stmt
+	resetWebhookDeliveryStmt            *sql.Stmt
+	restoreUserStmt                     *sql.Stmt
+	retryJobStmt                        *sql.Stmt
+	suspendUserStmt                     *sql.Stmt
+	updatePasswordStmt                  *sql.Stmt
+	updateUserStmt                      *sql.Stmt
+	updateUserRoleStmt                  *sql.Stmt
+	updateWebhookSubscriptionStmt       *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                        tx,
-		tx:                        tx,
-		createSessionStmt:         q.createSessionStmt,
-		createUserStmt:            q.createUserStmt,
-		deleteExpiredSessionsStmt: q.deleteExpiredSessionsStmt,
-		deleteSessionStmt:         q.deleteSessionStmt,
-		deleteUserStmt:            q.deleteUserStmt,
-		getSessionByIDStmt:        q.getSessionByIDStmt,
-		getSessionByTokenHashStmt: q.getSessionByTokenHashStmt,
-		getSessionByUserIDStmt:    q.getSessionByUserIDStmt,
-		getUserByEmailStmt:        q.getUserByEmailStmt,
-		getUserByIDStmt:           q.getUserByIDStmt,
-		getUserCountStmt:          q.getUserCountStmt,
-		listUsersStmt:             q.listUsersStmt,
-		updateUserStmt:            q.updateUserStmt,
+		db:                                  tx,
+		tx:                                  tx,
+		claimJobStmt:                        q.claimJobStmt,
+		claimWebhookDeliveryStmt:            q.claimWebhookDeliveryStmt,
+		completeIdempotencyKeyStmt:          q.completeIdempotencyKeyStmt,
+		completeJobStmt:                     q.completeJobStmt,
+		createAuditEventStmt:                q.createAuditEventStmt,
+		createIdempotencyKeyStmt:            q.createIdempotencyKeyStmt,
+		createJobStmt:                       q.createJobStmt,
+		createOutboxMessageStmt:             q.createOutboxMessageStmt,
+		createSchedulerRunStmt:              q.createSchedulerRunStmt,
+		createSessionStmt:                   q.createSessionStmt,
+		createUserStmt:                      q.createUserStmt,
+		createWebhookDeliveryStmt:           q.createWebhookDeliveryStmt,
+		createWebhookSubscriptionStmt:       q.createWebhookSubscriptionStmt,
+		deadLetterExpiredJobsStmt:           q.deadLetterExpiredJobsStmt,
+		deadLetterJobStmt:                   q.deadLetterJobStmt,
+		deleteExpiredIdempotencyKeysStmt:    q.deleteExpiredIdempotencyKeysStmt,
+		deleteExpiredSessionsStmt:           q.deleteExpiredSessionsStmt,
+		deleteIdempotencyKeyStmt:            q.deleteIdempotencyKeyStmt,
+		deletePublishedOutboxMessagesStmt:   q.deletePublishedOutboxMessagesStmt,
+		deleteSchedulerRunsBeforeStmt:       q.deleteSchedulerRunsBeforeStmt,
+		deleteSessionStmt:                   q.deleteSessionStmt,
+		deleteSessionsByUserIDStmt:          q.deleteSessionsByUserIDStmt,
+		deleteSucceededJobsStmt:             q.deleteSucceededJobsStmt,
+		deleteUserStmt:                      q.deleteUserStmt,
+		deleteWebhookSubscriptionStmt:       q.deleteWebhookSubscriptionStmt,
+		disableWebhookSubscriptionStmt:      q.disableWebhookSubscriptionStmt,
+		finishSchedulerRunStmt:              q.finishSchedulerRunStmt,
+		getDeletedUserByEmailStmt:           q.getDeletedUserByEmailStmt,
+		getDeletedUserCountStmt:             q.getDeletedUserCountStmt,
+		getIdempotencyKeyStmt:               q.getIdempotencyKeyStmt,
+		getJobStmt:                          q.getJobStmt,
+		getSessionByIDStmt:                  q.getSessionByIDStmt,
+		getSessionByTokenHashStmt:           q.getSessionByTokenHashStmt,
+		getSessionByUserIDStmt:              q.getSessionByUserIDStmt,
+		getUserByEmailStmt:                  q.getUserByEmailStmt,
+		getUserByIDStmt:                     q.getUserByIDStmt,
+		getUserCountStmt:                    q.getUserCountStmt,
+		getWebhookDeliveryStmt:              q.getWebhookDeliveryStmt,
+		getWebhookSubscriptionStmt:          q.getWebhookSubscriptionStmt,
+		listDeletedUsersStmt:                q.listDeletedUsersStmt,
+		listDueWebhookDeliveriesStmt:        q.listDueWebhookDeliveriesStmt,
+		listEnabledWebhookSubscriptionsStmt: q.listEnabledWebhookSubscriptionsStmt,
+		listPendingOutboxMessagesStmt:       q.listPendingOutboxMessagesStmt,
+		listUsersStmt:                       q.listUsersStmt,
+		listWebhookSubscriptionsStmt:        q.listWebhookSubscriptionsStmt,
+		markOutboxMessageFailedStmt:         q.markOutboxMessageFailedStmt,
+		markOutboxMessagePublishedStmt:      q.markOutboxMessagePublishedStmt,
+		markWebhookDeliveryFailedStmt:       q.markWebhookDeliveryFailedStmt,
+		markWebhookDeliverySucceededStmt:    q.markWebhookDeliverySucceededStmt,
+		purgeDeletedUserStmt:                q.purgeDeletedUserStmt,
+		purgeDeletedUsersStmt:               q.purgeDeletedUsersStmt,
+		reactivateUserStmt:                  q.reactivateUserStmt,
+		reclaimIdempotencyKeyStmt:           q.reclaimIdempotencyKeyStmt,
+		recordWebhookFailureStmt:            q.recordWebhookFailureStmt,
+		recordWebhookSuccessStmt:            q.recordWebhookSuccessStmt,
+		releaseExpiredJobsStmt:              q.releaseExpiredJobsStmt,
+		rescheduleJobStmt:                   q.rescheduleJobStmt,
+		resetWebhookDeliveryStmt:            q.resetWebhookDeliveryStmt,
+		restoreUserStmt:                     q.restoreUserStmt,
+		retryJobStmt:                        q.retryJobStmt,
+		suspendUserStmt:                     q.suspendUserStmt,
+		updatePasswordStmt:                  q.updatePasswordStmt,
+		updateUserStmt:                      q.updateUserStmt,
+		updateUserRoleStmt:                  q.updateUserRoleStmt,
+		updateWebhookSubscriptionStmt:       q.updateWebhookSubscriptionStmt,
 	}
 }
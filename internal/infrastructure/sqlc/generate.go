@@ -0,0 +1,11 @@
+package sqlc
+
+// The postgres and sqlite packages hold the queries sqlc generates for those
+// engines from sql/queries/postgres and sql/queries/sqlite. Their adapters
+// expose them as Querier, so repositories run unchanged on every engine.
+// Regenerate after running sqlc generate. The runner in package database
+// wraps every query of the prepared-statement mode.
+
+//go:generate go run ./querieradapter -pkg postgres
+//go:generate go run ./querieradapter -pkg sqlite
+//go:generate go run ./querieradapter -pkg . -runner ../database/querier_runner.go
@@ -6,9 +6,134 @@ package sqlc
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
+// Audit log of security and admin actions
+type AuditEvents struct {
+	// UUID unique identifier
+	ID string `db:"id" json:"id"`
+	// User who performed the action, NULL for anonymous or system actions
+	ActorID sql.NullString `db:"actor_id" json:"actor_id"`
+	// Action name such as user.login
+	Action string `db:"action" json:"action"`
+	// Kind of resource acted upon
+	TargetType sql.NullString `db:"target_type" json:"target_type"`
+	// Identifier of the resource acted upon
+	TargetID sql.NullString `db:"target_id" json:"target_id"`
+	// Client IP address
+	IpAddress sql.NullString `db:"ip_address" json:"ip_address"`
+	// Client user agent
+	UserAgent sql.NullString `db:"user_agent" json:"user_agent"`
+	// Request identifier
+	RequestID sql.NullString `db:"request_id" json:"request_id"`
+	// Action specific details
+	Metadata json.RawMessage `db:"metadata" json:"metadata"`
+	// Time the action happened
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// Idempotency keys and stored responses
+type IdempotencyKeys struct {
+	// HMAC-SHA256 of the request scope and Idempotency-Key header
+	ID string `db:"id" json:"id"`
+	// HMAC-SHA256 fingerprint of the request method, path and body
+	RequestHash string `db:"request_hash" json:"request_hash"`
+	// processing or completed
+	Status string `db:"status" json:"status"`
+	// HTTP status of the stored response
+	ResponseStatus int32 `db:"response_status" json:"response_status"`
+	// Replayed response headers
+	ResponseHeaders json.RawMessage `db:"response_headers" json:"response_headers"`
+	// Encrypted response body
+	ResponseBody sql.NullString `db:"response_body" json:"response_body"`
+	// Time after which an unfinished request may be retried
+	LockedUntil time.Time `db:"locked_until" json:"locked_until"`
+	// Time after which the key may be reused
+	ExpiresAt time.Time `db:"expires_at" json:"expires_at"`
+	// Time the key was first used
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// Background job queue
+type Jobs struct {
+	// UUID unique identifier
+	ID string `db:"id" json:"id"`
+	// Handler name such as user.send_welcome_email
+	JobType string `db:"job_type" json:"job_type"`
+	// Handler input
+	Payload json.RawMessage `db:"payload" json:"payload"`
+	// Higher priorities are claimed first
+	Priority int32 `db:"priority" json:"priority"`
+	// pending, running, succeeded or dead
+	Status string `db:"status" json:"status"`
+	// Number of attempts started
+	Attempts uint32 `db:"attempts" json:"attempts"`
+	// Attempts before the job is dead-lettered
+	MaxAttempts uint32 `db:"max_attempts" json:"max_attempts"`
+	// Earliest time the job may run while pending
+	RunAt time.Time `db:"run_at" json:"run_at"`
+	// Worker running the job
+	LockedBy sql.NullString `db:"locked_by" json:"locked_by"`
+	// Visibility timeout after which a running job is released
+	LockedUntil sql.NullTime `db:"locked_until" json:"locked_until"`
+	// Error of the last failed attempt
+	LastError sql.NullString `db:"last_error" json:"last_error"`
+	// Time the job was enqueued
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	// Record update time
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+	// Time the job succeeded or was dead-lettered
+	CompletedAt sql.NullTime `db:"completed_at" json:"completed_at"`
+}
+
+// Outbox of domain events
+type Outbox struct {
+	// Sequence number defining delivery order
+	ID uint64 `db:"id" json:"id"`
+	// UUID of the event, stable across redeliveries
+	EventID string `db:"event_id" json:"event_id"`
+	// Kind of entity the event belongs to
+	AggregateType string `db:"aggregate_type" json:"aggregate_type"`
+	// Identifier of the entity the event belongs to
+	AggregateID string `db:"aggregate_id" json:"aggregate_id"`
+	// Event name such as user.registered
+	EventType string `db:"event_type" json:"event_type"`
+	// Event body
+	Payload json.RawMessage `db:"payload" json:"payload"`
+	// Time the state change happened
+	OccurredAt time.Time `db:"occurred_at" json:"occurred_at"`
+	// Number of failed delivery attempts
+	Attempts uint32 `db:"attempts" json:"attempts"`
+	// Error of the last failed delivery attempt
+	LastError sql.NullString `db:"last_error" json:"last_error"`
+	// Earliest time of the next delivery attempt
+	NextAttemptAt time.Time `db:"next_attempt_at" json:"next_attempt_at"`
+	// Time the event was delivered, NULL while pending
+	PublishedAt sql.NullTime `db:"published_at" json:"published_at"`
+}
+
+// Run history of scheduled jobs
+type SchedulerRuns struct {
+	// UUID unique identifier
+	ID string `db:"id" json:"id"`
+	// Name of the scheduled job
+	JobName string `db:"job_name" json:"job_name"`
+	// Process that ran the job
+	Instance string `db:"instance" json:"instance"`
+	// running, succeeded or failed
+	Status string `db:"status" json:"status"`
+	// Error of a failed run
+	Error sql.NullString `db:"error" json:"error"`
+	// Time the run started
+	StartedAt time.Time `db:"started_at" json:"started_at"`
+	// Time the run finished, NULL while running
+	FinishedAt sql.NullTime `db:"finished_at" json:"finished_at"`
+	// Run time in milliseconds
+	DurationMs sql.NullInt64 `db:"duration_ms" json:"duration_ms"`
+}
+
 // User session tokens
 type UserSessions struct {
 	// UUIDv7 unique identifier
@@ -45,4 +170,70 @@ type Users struct {
 	UpdatedAt sql.NullTime `db:"updated_at" json:"updated_at"`
 	// Soft delete timestamp
 	DeletedAt sql.NullTime `db:"deleted_at" json:"deleted_at"`
+	// Authorization role
+	Role string `db:"role" json:"role"`
+	// Suspension timestamp
+	SuspendedAt sql.NullTime `db:"suspended_at" json:"suspended_at"`
+	// Suspension expiry, NULL for indefinite
+	SuspendedUntil sql.NullTime `db:"suspended_until" json:"suspended_until"`
+	// Reason given for suspension
+	SuspensionReason sql.NullString `db:"suspension_reason" json:"suspension_reason"`
+	// Password must be changed before further use
+	PasswordResetRequired bool `db:"password_reset_required" json:"password_reset_required"`
+	// Optimistic concurrency version, incremented on every update
+	Version int64 `db:"version" json:"version"`
+}
+
+// Webhook delivery log
+type WebhookDeliveries struct {
+	// UUID unique identifier
+	ID string `db:"id" json:"id"`
+	// Foreign key to webhook_subscriptions
+	SubscriptionID string `db:"subscription_id" json:"subscription_id"`
+	// Outbox event delivered
+	EventID string `db:"event_id" json:"event_id"`
+	// Event name such as user.registered
+	EventType string `db:"event_type" json:"event_type"`
+	// Request body sent to the endpoint
+	Payload json.RawMessage `db:"payload" json:"payload"`
+	// pending, succeeded or failed
+	Status string `db:"status" json:"status"`
+	// Number of delivery attempts
+	Attempts uint32 `db:"attempts" json:"attempts"`
+	// Earliest time of the next attempt while pending
+	NextAttemptAt time.Time `db:"next_attempt_at" json:"next_attempt_at"`
+	// HTTP status of the last attempt
+	LastStatusCode sql.NullInt32 `db:"last_status_code" json:"last_status_code"`
+	// Truncated response body of the last attempt
+	LastResponse sql.NullString `db:"last_response" json:"last_response"`
+	// Error of the last failed attempt
+	LastError sql.NullString `db:"last_error" json:"last_error"`
+	// Duration of the last attempt in milliseconds
+	LastDurationMs sql.NullInt32 `db:"last_duration_ms" json:"last_duration_ms"`
+	// Time of the successful attempt
+	DeliveredAt sql.NullTime `db:"delivered_at" json:"delivered_at"`
+	// Record creation time
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// Webhook subscriptions
+type WebhookSubscriptions struct {
+	// UUID unique identifier
+	ID string `db:"id" json:"id"`
+	// Endpoint receiving deliveries
+	Url string `db:"url" json:"url"`
+	// Subscribed event types, * for all
+	EventTypes json.RawMessage `db:"event_types" json:"event_types"`
+	// Shared secret signing deliveries
+	Secret string `db:"secret" json:"secret"`
+	// Whether deliveries are sent
+	Enabled bool `db:"enabled" json:"enabled"`
+	// Failed attempts since the last success
+	ConsecutiveFailures uint32 `db:"consecutive_failures" json:"consecutive_failures"`
+	// Time the subscription was disabled automatically
+	DisabledAt sql.NullTime `db:"disabled_at" json:"disabled_at"`
+	// Record creation time
+	CreatedAt sql.NullTime `db:"created_at" json:"created_at"`
+	// Record update time
+	UpdatedAt sql.NullTime `db:"updated_at" json:"updated_at"`
 }
@@ -6,24 +6,93 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 type Querier interface {
+	// Marks a job selected with FOR UPDATE SKIP LOCKED as running; must run in the same transaction
+	ClaimJob(ctx context.Context, arg ClaimJobParams) error
+	// Pushes the next attempt past the lease so other workers skip the delivery while it is sent
+	ClaimWebhookDelivery(ctx context.Context, arg ClaimWebhookDeliveryParams) (int64, error)
+	CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) (int64, error)
+	CompleteJob(ctx context.Context, arg CompleteJobParams) (int64, error)
+	// SQL queries for audit domain
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) error
+	// SQL queries for idempotency domain
+	// Headers start as an empty object, as the JSON column cannot be scanned when NULL
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) error
+	// SQL queries for jobs domain
+	CreateJob(ctx context.Context, arg CreateJobParams) error
+	// SQL queries for outbox domain
+	CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessageParams) error
+	// SQL queries for scheduler domain
+	CreateSchedulerRun(ctx context.Context, arg CreateSchedulerRunParams) error
 	// SQL queries for user session domain
 	CreateSession(ctx context.Context, arg CreateSessionParams) error
 	// SQL queries for user domain
 	CreateUser(ctx context.Context, arg CreateUserParams) error
-	DeleteExpiredSessions(ctx context.Context) error
+	// Deliveries are unique per subscription and event, so redelivered outbox events are ignored
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (int64, error)
+	// SQL queries for webhook domain
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) error
+	// Dead-letters running jobs past their visibility timeout that have no attempts left
+	DeadLetterExpiredJobs(ctx context.Context, arg DeadLetterExpiredJobsParams) (int64, error)
+	DeadLetterJob(ctx context.Context, arg DeadLetterJobParams) (int64, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteExpiredSessions(ctx context.Context) (int64, error)
+	// Releases an unfinished key so that the request can be retried
+	DeleteIdempotencyKey(ctx context.Context, id string) error
+	DeletePublishedOutboxMessages(ctx context.Context, publishedAt sql.NullTime) (int64, error)
+	DeleteSchedulerRunsBefore(ctx context.Context, startedAt time.Time) (int64, error)
 	DeleteSession(ctx context.Context, id string) error
+	DeleteSessionsByUserID(ctx context.Context, userID string) (int64, error)
+	DeleteSucceededJobs(ctx context.Context, completedAt sql.NullTime) (int64, error)
 	DeleteUser(ctx context.Context, id string) error
+	DeleteWebhookSubscription(ctx context.Context, id string) (int64, error)
+	DisableWebhookSubscription(ctx context.Context, arg DisableWebhookSubscriptionParams) (int64, error)
+	FinishSchedulerRun(ctx context.Context, arg FinishSchedulerRunParams) error
+	GetDeletedUserByEmail(ctx context.Context, email string) (Users, error)
+	GetDeletedUserCount(ctx context.Context) (int64, error)
+	GetIdempotencyKey(ctx context.Context, id string) (IdempotencyKeys, error)
+	GetJob(ctx context.Context, id string) (Jobs, error)
 	GetSessionByID(ctx context.Context, id string) (UserSessions, error)
 	GetSessionByTokenHash(ctx context.Context, refreshTokenHash string) (UserSessions, error)
 	GetSessionByUserID(ctx context.Context, userID string) ([]UserSessions, error)
 	GetUserByEmail(ctx context.Context, email string) (Users, error)
 	GetUserByID(ctx context.Context, id string) (Users, error)
 	GetUserCount(ctx context.Context) (int64, error)
+	GetWebhookDelivery(ctx context.Context, id string) (WebhookDeliveries, error)
+	GetWebhookSubscription(ctx context.Context, id string) (WebhookSubscriptions, error)
+	ListDeletedUsers(ctx context.Context, arg ListDeletedUsersParams) ([]Users, error)
+	ListDueWebhookDeliveries(ctx context.Context, arg ListDueWebhookDeliveriesParams) ([]ListDueWebhookDeliveriesRow, error)
+	ListEnabledWebhookSubscriptions(ctx context.Context) ([]WebhookSubscriptions, error)
+	// Messages are held back while an earlier message of the same aggregate waits for a retry
+	ListPendingOutboxMessages(ctx context.Context, arg ListPendingOutboxMessagesParams) ([]Outbox, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]Users, error)
-	UpdateUser(ctx context.Context, arg UpdateUserParams) error
+	ListWebhookSubscriptions(ctx context.Context) ([]WebhookSubscriptions, error)
+	MarkOutboxMessageFailed(ctx context.Context, arg MarkOutboxMessageFailedParams) error
+	MarkOutboxMessagePublished(ctx context.Context, arg MarkOutboxMessagePublishedParams) error
+	MarkWebhookDeliveryFailed(ctx context.Context, arg MarkWebhookDeliveryFailedParams) error
+	MarkWebhookDeliverySucceeded(ctx context.Context, arg MarkWebhookDeliverySucceededParams) error
+	PurgeDeletedUser(ctx context.Context, arg PurgeDeletedUserParams) (int64, error)
+	PurgeDeletedUsers(ctx context.Context, deletedAt sql.NullTime) (int64, error)
+	ReactivateUser(ctx context.Context, id string) error
+	// Takes over a key whose record expired or whose request stopped responding
+	ReclaimIdempotencyKey(ctx context.Context, arg ReclaimIdempotencyKeyParams) (int64, error)
+	RecordWebhookFailure(ctx context.Context, id string) error
+	RecordWebhookSuccess(ctx context.Context, id string) error
+	// Returns running jobs past their visibility timeout to the queue while attempts remain
+	ReleaseExpiredJobs(ctx context.Context, lockedUntil sql.NullTime) (int64, error)
+	RescheduleJob(ctx context.Context, arg RescheduleJobParams) (int64, error)
+	ResetWebhookDelivery(ctx context.Context, arg ResetWebhookDeliveryParams) error
+	RestoreUser(ctx context.Context, id string) error
+	RetryJob(ctx context.Context, arg RetryJobParams) (int64, error)
+	SuspendUser(ctx context.Context, arg SuspendUserParams) error
+	UpdatePassword(ctx context.Context, arg UpdatePasswordParams) error
+	UpdateUser(ctx context.Context, arg UpdateUserParams) (int64, error)
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) error
+	UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) error
 }
 
 var _ Querier = (*Queries)(nil)
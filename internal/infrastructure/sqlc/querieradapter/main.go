@@ -0,0 +1,338 @@
+// Command querieradapter generates an adapter exposing the queries sqlc
+// generated for another database engine as sqlc.Querier.
+//
+// The engine packages are generated from queries written for their own SQL
+// dialect, but sqlc.yaml overrides their column types so every model, params
+// and row struct matches the one in package sqlc field for field. The adapter
+// therefore only converts between the identical struct types.
+//
+// With -runner it instead generates, into the given file of another package,
+// a Querier passing every call of the package's Querier to a run function
+// together with the query name, so that callers can wrap each query.
+//
+// Usage, from internal/infrastructure/sqlc:
+//
+//	go run ./querieradapter -pkg postgres
+//	go run ./querieradapter -pkg . -runner ../database/querier_runner.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// sqlcImportPath is the package defining the Querier every engine is adapted to
+const sqlcImportPath = "github.com/zercle/template-go-echo/internal/infrastructure/sqlc"
+
+// outputFile is the name of the generated file in the engine package
+const outputFile = "querier_adapter.go"
+
+// sqlType matches a reference to a database/sql type, such as sql.NullString
+var sqlType = regexp.MustCompile(`\bsql\.`)
+
+// method is a Querier method and how its values convert to the shared types
+type method struct {
+	name    string
+	params  []param
+	result  string // shared result type, empty for methods returning only error
+	convert string // "struct" or "slice" when the result needs converting
+	elem    string // element type of a converted slice result
+}
+
+// param is a method parameter after the context
+type param struct {
+	name    string
+	typ     string // shared type
+	convert bool   // whether the engine package declares its own copy of the type
+}
+
+func main() {
+	pkg := flag.String("pkg", "", "engine package directory to generate the adapter for")
+	runner := flag.String("runner", "", "file to generate a runner for the package's Querier into")
+	flag.Parse()
+	if *pkg == "" {
+		log.Fatal("-pkg is required")
+	}
+
+	structs, querier, err := parsePackage(*pkg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	methods, err := collectMethods(querier, structs)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *runner != "" {
+		dir, err := filepath.Abs(filepath.Dir(*runner))
+		if err != nil {
+			log.Fatal(err)
+		}
+		src, err := renderRunner(filepath.Base(dir), methods)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(*runner, src, 0o644); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	src, err := render(filepath.Base(*pkg), methods)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(*pkg, outputFile), src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parsePackage returns the struct types declared by the package in dir and its Querier interface
+func parsePackage(dir string) (map[string]bool, *ast.InterfaceType, error) {
+	fset := token.NewFileSet()
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	structs := make(map[string]bool)
+	var querier *ast.InterfaceType
+	for _, path := range files {
+		if filepath.Base(path) == outputFile {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				switch t := ts.Type.(type) {
+				case *ast.StructType:
+					structs[ts.Name.Name] = true
+				case *ast.InterfaceType:
+					if ts.Name.Name == "Querier" {
+						querier = t
+					}
+				}
+			}
+		}
+	}
+
+	if querier == nil {
+		return nil, nil, fmt.Errorf("no Querier interface in %s", dir)
+	}
+	return structs, querier, nil
+}
+
+// collectMethods describes the methods of the Querier interface
+func collectMethods(querier *ast.InterfaceType, structs map[string]bool) ([]method, error) {
+	var methods []method
+	for _, field := range querier.Methods.List {
+		fn, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) != 1 {
+			continue
+		}
+		m := method{name: field.Names[0].Name}
+
+		// The first parameter is always the context
+		for _, p := range fn.Params.List[1:] {
+			typ, local := sharedType(p.Type, structs)
+			for _, name := range p.Names {
+				m.params = append(m.params, param{name: name.Name, typ: typ, convert: local})
+			}
+		}
+
+		results := fn.Results.List
+		switch len(results) {
+		case 1:
+		case 2:
+			m.result, _ = sharedType(results[0].Type, structs)
+			switch t := results[0].Type.(type) {
+			case *ast.Ident:
+				if structs[t.Name] {
+					m.convert = "struct"
+				}
+			case *ast.ArrayType:
+				if ident, ok := t.Elt.(*ast.Ident); ok && structs[ident.Name] {
+					m.convert = "slice"
+					m.elem = "sqlc." + ident.Name
+				}
+			}
+		default:
+			return nil, fmt.Errorf("method %s: unexpected results", m.name)
+		}
+
+		methods = append(methods, m)
+	}
+
+	sort.Slice(methods, func(i, j int) bool { return methods[i].name < methods[j].name })
+	return methods, nil
+}
+
+// sharedType returns the source of expr with the package's own struct types
+// qualified by package sqlc, and whether expr is one of those structs
+func sharedType(expr ast.Expr, structs map[string]bool) (string, bool) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if structs[t.Name] {
+			return "sqlc." + t.Name, true
+		}
+		return t.Name, false
+	case *ast.ArrayType:
+		elem, _ := sharedType(t.Elt, structs)
+		return "[]" + elem, false
+	case *ast.StarExpr:
+		elem, _ := sharedType(t.X, structs)
+		return "*" + elem, false
+	case *ast.SelectorExpr:
+		return t.X.(*ast.Ident).Name + "." + t.Sel.Name, false
+	case *ast.InterfaceType:
+		return "interface{}", false
+	}
+	panic(fmt.Sprintf("unsupported type %T", expr))
+}
+
+// render produces the formatted adapter source
+func render(pkg string, methods []method) ([]byte, error) {
+	var body bytes.Buffer
+	for _, m := range methods {
+		writeMethod(&body, m)
+	}
+	imports := stdImports(body.String(), `"context"`, `"database/sql"`)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by querieradapter. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	fmt.Fprintf(&buf, "import (\n%s\n\n%q\n)\n\n", strings.Join(imports, "\n"), sqlcImportPath)
+	fmt.Fprintf(&buf, "var _ sqlc.Querier = (*Adapter)(nil)\n\n")
+	fmt.Fprintf(&buf, "// Adapter exposes Queries as sqlc.Querier\n")
+	fmt.Fprintf(&buf, "type Adapter struct {\n\tq *Queries\n}\n\n")
+	fmt.Fprintf(&buf, "// NewQuerier returns the queries run on db as sqlc.Querier\n")
+	fmt.Fprintf(&buf, "func NewQuerier(db DBTX) *Adapter {\n\treturn &Adapter{q: New(db)}\n}\n\n")
+	fmt.Fprintf(&buf, "// PrepareQuerier prepares every query on db and returns them as sqlc.Querier\n")
+	fmt.Fprintf(&buf, "func PrepareQuerier(ctx context.Context, db DBTX) (*Adapter, error) {\n")
+	fmt.Fprintf(&buf, "\tq, err := Prepare(ctx, db)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\treturn &Adapter{q: q}, nil\n}\n\n")
+	fmt.Fprintf(&buf, "// WithTx returns the adapter running its queries in tx\n")
+	fmt.Fprintf(&buf, "func (a *Adapter) WithTx(tx *sql.Tx) sqlc.Querier {\n\treturn &Adapter{q: a.q.WithTx(tx)}\n}\n\n")
+	fmt.Fprintf(&buf, "// Close closes the statements prepared by PrepareQuerier\n")
+	fmt.Fprintf(&buf, "func (a *Adapter) Close() error {\n\treturn a.q.Close()\n}\n\n")
+	buf.Write(body.Bytes())
+
+	return format.Source(buf.Bytes())
+}
+
+// renderRunner produces the formatted runner source for package pkg
+func renderRunner(pkg string, methods []method) ([]byte, error) {
+	var body bytes.Buffer
+	for _, m := range methods {
+		writeRunnerMethod(&body, m)
+	}
+	imports := []string{`"context"`}
+	if sqlType.MatchString(body.String()) {
+		imports = append(imports, `"database/sql"`)
+	}
+	imports = stdImports(body.String(), imports...)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by querieradapter. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	fmt.Fprintf(&buf, "import (\n%s\n\n%q\n)\n\n", strings.Join(imports, "\n"), sqlcImportPath)
+	fmt.Fprintf(&buf, "var _ sqlc.Querier = (*querierRunner)(nil)\n\n")
+	fmt.Fprintf(&buf, "// querierRunner implements sqlc.Querier by passing each call, with the name\n")
+	fmt.Fprintf(&buf, "// of its query, to run, which invokes it with a context and querier of its choice\n")
+	fmt.Fprintf(&buf, "type querierRunner struct {\n")
+	fmt.Fprintf(&buf, "\trun func(ctx context.Context, name string, call func(ctx context.Context, q sqlc.Querier) error) error\n}\n\n")
+	buf.Write(body.Bytes())
+
+	return format.Source(buf.Bytes())
+}
+
+// stdImports returns the given imports and those of the standard packages
+// body refers to, sorted
+func stdImports(body string, imports ...string) []string {
+	for prefix, path := range map[string]string{
+		"json.": `"encoding/json"`,
+		"time.": `"time"`,
+	} {
+		if strings.Contains(body, prefix) {
+			imports = append(imports, path)
+		}
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+// writeMethod writes the adapter method for m
+func writeMethod(w *bytes.Buffer, m method) {
+	params := []string{"ctx context.Context"}
+	args := []string{"ctx"}
+	for _, p := range m.params {
+		params = append(params, p.name+" "+p.typ)
+		if p.convert {
+			args = append(args, fmt.Sprintf("%s(%s)", strings.TrimPrefix(p.typ, "sqlc."), p.name))
+		} else {
+			args = append(args, p.name)
+		}
+	}
+
+	results := "error"
+	if m.result != "" {
+		results = "(" + m.result + ", error)"
+	}
+
+	call := fmt.Sprintf("a.q.%s(%s)", m.name, strings.Join(args, ", "))
+	fmt.Fprintf(w, "func (a *Adapter) %s(%s) %s {\n", m.name, strings.Join(params, ", "), results)
+	switch m.convert {
+	case "struct":
+		fmt.Fprintf(w, "\trow, err := %s\n\treturn %s(row), err\n", call, m.result)
+	case "slice":
+		fmt.Fprintf(w, "\trows, err := %s\n\tif err != nil {\n\t\treturn nil, err\n\t}\n", call)
+		fmt.Fprintf(w, "\titems := make(%s, len(rows))\n", m.result)
+		fmt.Fprintf(w, "\tfor i, row := range rows {\n\t\titems[i] = %s(row)\n\t}\n\treturn items, nil\n", m.elem)
+	default:
+		fmt.Fprintf(w, "\treturn %s\n", call)
+	}
+	fmt.Fprintf(w, "}\n\n")
+}
+
+// writeRunnerMethod writes the runner method for m
+func writeRunnerMethod(w *bytes.Buffer, m method) {
+	params := []string{"ctx context.Context"}
+	args := []string{"ctx"}
+	for _, p := range m.params {
+		params = append(params, p.name+" "+p.typ)
+		args = append(args, p.name)
+	}
+	call := fmt.Sprintf("q.%s(%s)", m.name, strings.Join(args, ", "))
+
+	if m.result == "" {
+		fmt.Fprintf(w, "func (r *querierRunner) %s(%s) error {\n", m.name, strings.Join(params, ", "))
+		fmt.Fprintf(w, "\treturn r.run(ctx, %q, func(ctx context.Context, q sqlc.Querier) error {\n\t\treturn %s\n\t})\n}\n\n", m.name, call)
+		return
+	}
+
+	fmt.Fprintf(w, "func (r *querierRunner) %s(%s) (%s, error) {\n", m.name, strings.Join(params, ", "), m.result)
+	fmt.Fprintf(w, "\tvar result %s\n", m.result)
+	fmt.Fprintf(w, "\terr := r.run(ctx, %q, func(ctx context.Context, q sqlc.Querier) (err error) {\n", m.name)
+	fmt.Fprintf(w, "\t\tresult, err = %s\n\t\treturn err\n\t})\n", call)
+	fmt.Fprintf(w, "\treturn result, err\n}\n\n")
+}
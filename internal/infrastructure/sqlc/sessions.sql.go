@@ -39,14 +39,17 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) er
 	return err
 }
 
-const deleteExpiredSessions = `-- name: DeleteExpiredSessions :exec
+const deleteExpiredSessions = `-- name: DeleteExpiredSessions :execrows
 DELETE FROM user_sessions
 WHERE expires_at <= NOW()
 `
 
-func (q *Queries) DeleteExpiredSessions(ctx context.Context) error {
-	_, err := q.exec(ctx, q.deleteExpiredSessionsStmt, deleteExpiredSessions)
-	return err
+func (q *Queries) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	result, err := q.exec(ctx, q.deleteExpiredSessionsStmt, deleteExpiredSessions)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const deleteSession = `-- name: DeleteSession :exec
@@ -59,6 +62,19 @@ func (q *Queries) DeleteSession(ctx context.Context, id string) error {
 	return err
 }
 
+const deleteSessionsByUserID = `-- name: DeleteSessionsByUserID :execrows
+DELETE FROM user_sessions
+WHERE user_id = ?
+`
+
+func (q *Queries) DeleteSessionsByUserID(ctx context.Context, userID string) (int64, error) {
+	result, err := q.exec(ctx, q.deleteSessionsByUserIDStmt, deleteSessionsByUserID, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getSessionByID = `-- name: GetSessionByID :one
 SELECT id, user_id, refresh_token_hash, ip_address, user_agent, expires_at, created_at
 FROM user_sessions
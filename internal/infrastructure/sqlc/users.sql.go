@@ -12,16 +12,18 @@ import (
 
 const createUser = `-- name: CreateUser :exec
 
-INSERT INTO users (id, email, name, password_hash, is_active, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, NOW(), NOW())
+INSERT INTO users (id, email, name, password_hash, is_active, role, password_reset_required, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
 `
 
 type CreateUserParams struct {
-	ID           string       `db:"id" json:"id"`
-	Email        string       `db:"email" json:"email"`
-	Name         string       `db:"name" json:"name"`
-	PasswordHash string       `db:"password_hash" json:"password_hash"`
-	IsActive     sql.NullBool `db:"is_active" json:"is_active"`
+	ID                    string       `db:"id" json:"id"`
+	Email                 string       `db:"email" json:"email"`
+	Name                  string       `db:"name" json:"name"`
+	PasswordHash          string       `db:"password_hash" json:"password_hash"`
+	IsActive              sql.NullBool `db:"is_active" json:"is_active"`
+	Role                  string       `db:"role" json:"role"`
+	PasswordResetRequired bool         `db:"password_reset_required" json:"password_reset_required"`
 }
 
 // SQL queries for user domain
@@ -32,13 +34,15 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) error {
 		arg.Name,
 		arg.PasswordHash,
 		arg.IsActive,
+		arg.Role,
+		arg.PasswordResetRequired,
 	)
 	return err
 }
 
 const deleteUser = `-- name: DeleteUser :exec
 UPDATE users
-SET deleted_at = NOW(), updated_at = NOW()
+SET deleted_at = NOW(), version = version + 1, updated_at = NOW()
 WHERE id = ? AND deleted_at IS NULL
 `
 
@@ -47,8 +51,51 @@ func (q *Queries) DeleteUser(ctx context.Context, id string) error {
 	return err
 }
 
+const getDeletedUserByEmail = `-- name: GetDeletedUserByEmail :one
+SELECT id, email, name, password_hash, is_active, created_at, updated_at, deleted_at, role, suspended_at, suspended_until, suspension_reason, password_reset_required, version
+FROM users
+WHERE email = ? AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetDeletedUserByEmail(ctx context.Context, email string) (Users, error) {
+	row := q.queryRow(ctx, q.getDeletedUserByEmailStmt, getDeletedUserByEmail, email)
+	var i Users
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.PasswordHash,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Role,
+		&i.SuspendedAt,
+		&i.SuspendedUntil,
+		&i.SuspensionReason,
+		&i.PasswordResetRequired,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getDeletedUserCount = `-- name: GetDeletedUserCount :one
+SELECT COUNT(*) as count
+FROM users
+WHERE deleted_at IS NOT NULL
+`
+
+func (q *Queries) GetDeletedUserCount(ctx context.Context) (int64, error) {
+	row := q.queryRow(ctx, q.getDeletedUserCountStmt, getDeletedUserCount)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, name, password_hash, is_active, created_at, updated_at, deleted_at
+SELECT id, email, name, password_hash, is_active, created_at, updated_at, deleted_at, role, suspended_at, suspended_until, suspension_reason, password_reset_required, version
 FROM users
 WHERE email = ? AND deleted_at IS NULL
 `
@@ -65,12 +112,18 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (Users, erro
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Role,
+		&i.SuspendedAt,
+		&i.SuspendedUntil,
+		&i.SuspensionReason,
+		&i.PasswordResetRequired,
+		&i.Version,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, name, password_hash, is_active, created_at, updated_at, deleted_at
+SELECT id, email, name, password_hash, is_active, created_at, updated_at, deleted_at, role, suspended_at, suspended_until, suspension_reason, password_reset_required, version
 FROM users
 WHERE id = ? AND deleted_at IS NULL
 `
@@ -87,6 +140,12 @@ func (q *Queries) GetUserByID(ctx context.Context, id string) (Users, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Role,
+		&i.SuspendedAt,
+		&i.SuspendedUntil,
+		&i.SuspensionReason,
+		&i.PasswordResetRequired,
+		&i.Version,
 	)
 	return i, err
 }
@@ -104,8 +163,59 @@ func (q *Queries) GetUserCount(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const listDeletedUsers = `-- name: ListDeletedUsers :many
+SELECT id, email, name, password_hash, is_active, created_at, updated_at, deleted_at, role, suspended_at, suspended_until, suspension_reason, password_reset_required, version
+FROM users
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at ASC
+LIMIT ? OFFSET ?
+`
+
+type ListDeletedUsersParams struct {
+	Limit  int32 `db:"limit" json:"limit"`
+	Offset int32 `db:"offset" json:"offset"`
+}
+
+func (q *Queries) ListDeletedUsers(ctx context.Context, arg ListDeletedUsersParams) ([]Users, error) {
+	rows, err := q.query(ctx, q.listDeletedUsersStmt, listDeletedUsers, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Users
+	for rows.Next() {
+		var i Users
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.Name,
+			&i.PasswordHash,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Role,
+			&i.SuspendedAt,
+			&i.SuspendedUntil,
+			&i.SuspensionReason,
+			&i.PasswordResetRequired,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUsers = `-- name: ListUsers :many
-SELECT id, email, name, password_hash, is_active, created_at, updated_at, deleted_at
+SELECT id, email, name, password_hash, is_active, created_at, updated_at, deleted_at, role, suspended_at, suspended_until, suspension_reason, password_reset_required, version
 FROM users
 WHERE deleted_at IS NULL
 ORDER BY created_at DESC
@@ -135,6 +245,12 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]Users,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Role,
+			&i.SuspendedAt,
+			&i.SuspendedUntil,
+			&i.SuspensionReason,
+			&i.PasswordResetRequired,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -149,19 +265,131 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]Users,
 	return items, nil
 }
 
-const updateUser = `-- name: UpdateUser :exec
+const purgeDeletedUser = `-- name: PurgeDeletedUser :execrows
+DELETE FROM users
+WHERE id = ? AND deleted_at IS NOT NULL AND deleted_at < ?
+`
+
+type PurgeDeletedUserParams struct {
+	ID        string       `db:"id" json:"id"`
+	DeletedAt sql.NullTime `db:"deleted_at" json:"deleted_at"`
+}
+
+func (q *Queries) PurgeDeletedUser(ctx context.Context, arg PurgeDeletedUserParams) (int64, error) {
+	result, err := q.exec(ctx, q.purgeDeletedUserStmt, purgeDeletedUser, arg.ID, arg.DeletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeDeletedUsers = `-- name: PurgeDeletedUsers :execrows
+DELETE FROM users
+WHERE deleted_at IS NOT NULL AND deleted_at < ?
+`
+
+func (q *Queries) PurgeDeletedUsers(ctx context.Context, deletedAt sql.NullTime) (int64, error) {
+	result, err := q.exec(ctx, q.purgeDeletedUsersStmt, purgeDeletedUsers, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const reactivateUser = `-- name: ReactivateUser :exec
 UPDATE users
-SET name = ?, email = ?, updated_at = NOW()
+SET is_active = TRUE, suspended_at = NULL, suspended_until = NULL, suspension_reason = NULL, version = version + 1, updated_at = NOW()
 WHERE id = ? AND deleted_at IS NULL
 `
 
+func (q *Queries) ReactivateUser(ctx context.Context, id string) error {
+	_, err := q.exec(ctx, q.reactivateUserStmt, reactivateUser, id)
+	return err
+}
+
+const restoreUser = `-- name: RestoreUser :exec
+UPDATE users
+SET deleted_at = NULL, version = version + 1, updated_at = NOW()
+WHERE id = ? AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) RestoreUser(ctx context.Context, id string) error {
+	_, err := q.exec(ctx, q.restoreUserStmt, restoreUser, id)
+	return err
+}
+
+const suspendUser = `-- name: SuspendUser :exec
+UPDATE users
+SET is_active = FALSE, suspended_at = NOW(), suspended_until = ?, suspension_reason = ?, version = version + 1, updated_at = NOW()
+WHERE id = ? AND deleted_at IS NULL
+`
+
+type SuspendUserParams struct {
+	SuspendedUntil   sql.NullTime   `db:"suspended_until" json:"suspended_until"`
+	SuspensionReason sql.NullString `db:"suspension_reason" json:"suspension_reason"`
+	ID               string         `db:"id" json:"id"`
+}
+
+func (q *Queries) SuspendUser(ctx context.Context, arg SuspendUserParams) error {
+	_, err := q.exec(ctx, q.suspendUserStmt, suspendUser, arg.SuspendedUntil, arg.SuspensionReason, arg.ID)
+	return err
+}
+
+const updatePassword = `-- name: UpdatePassword :exec
+UPDATE users
+SET password_hash = ?, password_reset_required = ?, version = version + 1, updated_at = NOW()
+WHERE id = ? AND deleted_at IS NULL
+`
+
+type UpdatePasswordParams struct {
+	PasswordHash          string `db:"password_hash" json:"password_hash"`
+	PasswordResetRequired bool   `db:"password_reset_required" json:"password_reset_required"`
+	ID                    string `db:"id" json:"id"`
+}
+
+func (q *Queries) UpdatePassword(ctx context.Context, arg UpdatePasswordParams) error {
+	_, err := q.exec(ctx, q.updatePasswordStmt, updatePassword, arg.PasswordHash, arg.PasswordResetRequired, arg.ID)
+	return err
+}
+
+const updateUser = `-- name: UpdateUser :execrows
+UPDATE users
+SET name = ?, email = ?, version = version + 1, updated_at = NOW()
+WHERE id = ? AND version = ? AND deleted_at IS NULL
+`
+
 type UpdateUserParams struct {
-	Name  string `db:"name" json:"name"`
-	Email string `db:"email" json:"email"`
-	ID    string `db:"id" json:"id"`
+	Name    string `db:"name" json:"name"`
+	Email   string `db:"email" json:"email"`
+	ID      string `db:"id" json:"id"`
+	Version int64  `db:"version" json:"version"`
+}
+
+func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (int64, error) {
+	result, err := q.exec(ctx, q.updateUserStmt, updateUser,
+		arg.Name,
+		arg.Email,
+		arg.ID,
+		arg.Version,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateUserRole = `-- name: UpdateUserRole :exec
+UPDATE users
+SET role = ?, version = version + 1, updated_at = NOW()
+WHERE id = ? AND deleted_at IS NULL
+`
+
+type UpdateUserRoleParams struct {
+	Role string `db:"role" json:"role"`
+	ID   string `db:"id" json:"id"`
 }
 
-func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
-	_, err := q.exec(ctx, q.updateUserStmt, updateUser, arg.Name, arg.Email, arg.ID)
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) error {
+	_, err := q.exec(ctx, q.updateUserRoleStmt, updateUserRole, arg.Role, arg.ID)
 	return err
 }
@@ -0,0 +1,116 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/zercle/template-go-echo/internal/infrastructure/cache"
+)
+
+// newRedisClient returns a client of an in-process Redis server
+func newRedisClient(t *testing.T) (*redis.Client, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return client, server
+}
+
+func TestRedisCache(t *testing.T) {
+	client, server := newRedisClient(t)
+	c := cache.NewRedis(client, cache.WithKeyPrefix("test:"))
+	ctx := context.Background()
+
+	if _, err := c.Get(ctx, "user"); !errors.Is(err, cache.ErrMiss) {
+		t.Fatalf("expected a miss, got %v", err)
+	}
+	if err := c.Set(ctx, "user", []byte("cached"), time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if value, err := c.Get(ctx, "user"); err != nil || string(value) != "cached" {
+		t.Fatalf("expected the cached value, got %q, %v", value, err)
+	}
+	if !server.Exists("test:user") || server.TTL("test:user") != time.Minute {
+		t.Errorf("expected the prefixed key stored with its TTL, got %v", server.Keys())
+	}
+
+	// Empty values are kept, as negative entries
+	_ = c.Set(ctx, "missing", nil, time.Minute)
+	if value, err := c.Get(ctx, "missing"); err != nil || len(value) != 0 {
+		t.Errorf("expected the empty value, got %q, %v", value, err)
+	}
+
+	server.FastForward(time.Minute)
+	if _, err := c.Get(ctx, "user"); !errors.Is(err, cache.ErrMiss) {
+		t.Errorf("expected the value expired, got %v", err)
+	}
+
+	_ = c.Set(ctx, "user", []byte("cached"), time.Minute)
+	if err := c.Delete(ctx, "user", "missing"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := c.Get(ctx, "user"); !errors.Is(err, cache.ErrMiss) {
+		t.Errorf("expected the value deleted, got %v", err)
+	}
+
+	server.SetError("server unavailable")
+	if _, err := c.Get(ctx, "user"); err == nil || errors.Is(err, cache.ErrMiss) {
+		t.Errorf("expected the server error, got %v", err)
+	}
+}
+
+// eventually polls cond until it holds or a second elapses
+func eventually(t *testing.T, cond func() bool) bool {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return false
+}
+
+func TestBroadcastInvalidatesOtherInstances(t *testing.T) {
+	client, _ := newRedisClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first := cache.NewBroadcast(cache.NewLRU(10), client, cache.DefaultInvalidationChannel)
+	second := cache.NewBroadcast(cache.NewLRU(10), client, cache.DefaultInvalidationChannel)
+
+	// Entries cached before subscribing may have missed invalidations and are dropped
+	for _, instance := range []*cache.Broadcast{first, second} {
+		_ = instance.Set(ctx, "stale", []byte("cached"), time.Minute)
+		go instance.Run(ctx)
+	}
+	subscribed := func() bool { return first.Len() == 0 && second.Len() == 0 }
+	if !eventually(t, subscribed) {
+		t.Fatal("expected both instances subscribed and cleared")
+	}
+
+	for _, instance := range []*cache.Broadcast{first, second} {
+		_ = instance.Set(ctx, "user:1", []byte("cached"), time.Minute)
+		_ = instance.Set(ctx, "user:2", []byte("cached"), time.Minute)
+	}
+
+	if err := first.Delete(ctx, "user:1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := first.Get(ctx, "user:1"); !errors.Is(err, cache.ErrMiss) {
+		t.Errorf("expected the key deleted locally, got %v", err)
+	}
+	if !eventually(t, func() bool {
+		_, err := second.Get(ctx, "user:1")
+		return errors.Is(err, cache.ErrMiss)
+	}) {
+		t.Error("expected the key deleted by the other instance")
+	}
+	if _, err := second.Get(ctx, "user:2"); err != nil {
+		t.Errorf("expected other keys kept, got %v", err)
+	}
+}
@@ -0,0 +1,234 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+	"github.com/zercle/template-go-echo/internal/infrastructure/test/sqlitetest"
+	"github.com/zercle/template-go-echo/pkg"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordingDB records the statements sent to the database
+type recordingDB struct {
+	*sql.DB
+	queries []string
+}
+
+func (r *recordingDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	r.queries = append(r.queries, query)
+	return r.DB.QueryContext(ctx, query, args...)
+}
+
+func (r *recordingDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	r.queries = append(r.queries, query)
+	return r.DB.QueryRowContext(ctx, query, args...)
+}
+
+// queryCount returns how many queries named name the registry has observed
+func queryCount(t *testing.T, gatherer prometheus.Gatherer, name string) uint64 {
+	families, err := gatherer.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	var count uint64
+	for _, family := range families {
+		if family.GetName() != "db_query_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "query" && label.GetValue() == name {
+					count += metric.GetHistogram().GetSampleCount()
+				}
+			}
+		}
+	}
+	return count
+}
+
+func TestInstrumentedDBMetricsAndSpans(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	recorder := tracetest.NewSpanRecorder()
+	db := database.NewInstrumentedDB(openNamedDB(t, "primary"), database.DialectSQLite,
+		database.WithQueryMetrics(database.NewQueryMetrics(registry)),
+		database.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+		database.WithQueryComments(false))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if got := servedBy(t, ctx, db); got != "primary" {
+			t.Fatalf("unexpected row %q", got)
+		}
+	}
+	_, err := db.ExecContext(ctx, "-- name: RenameNode :exec\nUPDATE node SET name = ? WHERE name = ?", "secret@example.com", "primary")
+	if err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, "UPDATE missing SET name = 'x'"); err == nil {
+		t.Fatal("expected the unnamed statement to fail")
+	}
+
+	if n := queryCount(t, registry, "GetNode"); n != 2 {
+		t.Errorf("expected 2 GetNode observations, got %d", n)
+	}
+	if n := queryCount(t, registry, "RenameNode"); n != 1 {
+		t.Errorf("expected 1 RenameNode observation, got %d", n)
+	}
+	if n := queryCount(t, registry, database.UnnamedQuery); n != 1 {
+		t.Errorf("expected 1 unnamed observation, got %d", n)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 4 {
+		t.Fatalf("expected 4 spans, got %d", len(spans))
+	}
+	rename := spans[2]
+	if rename.Name() != "RenameNode" {
+		t.Fatalf("expected the RenameNode span, got %s", rename.Name())
+	}
+	for _, attr := range rename.Attributes() {
+		if strings.Contains(attr.Value.Emit(), "secret@example.com") {
+			t.Errorf("expected arguments redacted, %s carries %s", attr.Key, attr.Value.Emit())
+		}
+		if attr.Key == "db.query.args" {
+			if got := attr.Value.AsStringSlice(); len(got) != 2 || got[0] != "string" {
+				t.Errorf("expected argument types, got %v", got)
+			}
+		}
+	}
+	if status := spans[3].Status(); status.Code.String() != "Error" {
+		t.Errorf("expected the failed statement's span to be an error, got %v", status)
+	}
+	if !hasAttribute(spans[0].Attributes(), attribute.String("db.system.name", "sqlite")) {
+		t.Errorf("expected the db.system.name attribute, got %v", spans[0].Attributes())
+	}
+}
+
+// hasAttribute reports whether attrs holds want
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, attr := range attrs {
+		if attr == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestInstrumentedDBSlowQueryLog(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	primary := openNamedDB(t, "primary")
+	ctx := pkg.WithRequestInfo(context.Background(), pkg.RequestInfo{RequestID: "req-1", Route: "/api/v1/nodes"})
+
+	fast := database.NewInstrumentedDB(primary, database.DialectSQLite,
+		database.WithQueryMetrics(database.NewQueryMetrics(prometheus.NewRegistry())),
+		database.WithSlowQueryThreshold(time.Hour))
+	servedBy(t, ctx, fast)
+	if strings.Contains(logs.String(), "slow query") {
+		t.Fatalf("expected no slow query log, got %s", logs.String())
+	}
+
+	slow := database.NewInstrumentedDB(primary, database.DialectSQLite,
+		database.WithQueryMetrics(database.NewQueryMetrics(prometheus.NewRegistry())),
+		database.WithSlowQueryThreshold(time.Nanosecond))
+	servedBy(t, ctx, slow)
+	for _, want := range []string{`"msg":"slow query"`, `"query":"GetNode"`, `"request_id":"req-1"`, `"route":"/api/v1/nodes"`} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("expected %s in the slow query log, got %s", want, logs.String())
+		}
+	}
+}
+
+func TestInstrumentedDBComments(t *testing.T) {
+	primary := &recordingDB{DB: openNamedDB(t, "primary")}
+	recorder := tracetest.NewSpanRecorder()
+	db := database.NewInstrumentedDB(primary, database.DialectSQLite,
+		database.WithQueryMetrics(database.NewQueryMetrics(prometheus.NewRegistry())),
+		database.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))))
+
+	ctx := pkg.WithRequestInfo(context.Background(), pkg.RequestInfo{RequestID: "req-1", Route: "/api/v1/users/:id"})
+	servedBy(t, ctx, db)
+
+	comment := regexp.MustCompile(`^-- name: GetNode :one\nSELECT name FROM node LIMIT 1 ` +
+		`/\*request_id='req-1',route='%2Fapi%2Fv1%2Fusers%2F:id',traceparent='00-([0-9a-f]{32})-([0-9a-f]{16})-01'\*/$`)
+	match := comment.FindStringSubmatch(primary.queries[0])
+	if match == nil {
+		t.Fatalf("unexpected annotated query %q", primary.queries[0])
+	}
+	span := recorder.Ended()[0].SpanContext()
+	if match[1] != span.TraceID().String() || match[2] != span.SpanID().String() {
+		t.Errorf("expected the traceparent of the query span, got %s-%s", match[1], match[2])
+	}
+
+	// Values cannot close the quote or the comment
+	ctx = pkg.WithRequestInfo(context.Background(), pkg.RequestInfo{RequestID: "x'*/ DROP TABLE node; --"})
+	servedBy(t, ctx, db)
+	if got := primary.queries[1]; !strings.Contains(got, "/*request_id='x%27%2A%2F%20DROP%20TABLE%20node%3B%20--',") || strings.Count(got, "*/") != 1 {
+		t.Errorf("expected the request ID escaped, got %q", got)
+	}
+
+	// Without request details or a span there is nothing to append
+	plain := database.NewInstrumentedDB(primary, database.DialectSQLite,
+		database.WithQueryMetrics(database.NewQueryMetrics(prometheus.NewRegistry())))
+	servedBy(t, context.Background(), plain)
+	if got := primary.queries[2]; strings.Contains(got, "/*") {
+		t.Errorf("expected the query unchanged, got %q", got)
+	}
+}
+
+func TestInstrumentedDBTransactions(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	db := database.NewInstrumentedDB(openNamedDB(t, "primary"), database.DialectSQLite,
+		database.WithQueryMetrics(database.NewQueryMetrics(registry)))
+	tx := database.NewTxManager(database.NewDialectConn(db, database.DialectSQLite))
+
+	err := tx.WithinTx(context.Background(), func(ctx context.Context) error {
+		conn := database.Conn(ctx, database.NewDialectConn(db, database.DialectSQLite))
+		if database.DialectOf(conn) != database.DialectSQLite {
+			t.Error("expected the transaction to keep the dialect")
+		}
+		servedBy(t, ctx, conn)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("transaction failed: %v", err)
+	}
+	if n := queryCount(t, registry, "GetNode"); n != 1 {
+		t.Errorf("expected the query in the transaction observed, got %d", n)
+	}
+}
+
+func TestDatabaseQuerierInstrumentsTransactions(t *testing.T) {
+	db := sqlitetest.Open(t)
+	q := db.Querier()
+	before := queryCount(t, prometheus.DefaultGatherer, "GetUserByEmail")
+
+	err := db.TxManager().WithinTx(context.Background(), func(ctx context.Context) error {
+		_, err := database.Queries(ctx, q).GetUserByEmail(ctx, "nobody@example.com")
+		return err
+	})
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+	if _, err := q.GetUserByEmail(context.Background(), "nobody@example.com"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+	if n := queryCount(t, prometheus.DefaultGatherer, "GetUserByEmail") - before; n != 2 {
+		t.Errorf("expected both queries observed, got %d", n)
+	}
+}
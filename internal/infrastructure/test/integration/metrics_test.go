@@ -0,0 +1,42 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zercle/template-go-echo/internal/infrastructure"
+	"github.com/zercle/template-go-echo/internal/infrastructure/test/sqlitetest"
+)
+
+func TestMetricsRoute(t *testing.T) {
+	registry := infrastructure.NewMetricsRegistry()
+	db := sqlitetest.Open(t)
+	if err := db.RegisterMetrics(registry); err != nil {
+		t.Fatalf("failed to register database metrics: %v", err)
+	}
+	if err := db.RegisterMetrics(registry); err == nil {
+		t.Error("expected registering the pool twice to fail")
+	}
+
+	e := echo.New()
+	infrastructure.RegisterMetricsRoute(e, "/metrics", registry)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	for _, series := range []string{
+		`go_sql_max_open_connections{db_name="primary"}`,
+		"go_goroutines",
+		"go_gc_heap_allocs_bytes_total",
+		"go_sched_goroutines_goroutines",
+	} {
+		if !strings.Contains(rec.Body.String(), series) {
+			t.Errorf("expected %s to be served", series)
+		}
+	}
+}
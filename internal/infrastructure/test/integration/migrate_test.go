@@ -0,0 +1,148 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+	"github.com/zercle/template-go-echo/sql/migrations"
+)
+
+// openSQLite opens an empty SQLite database in a temporary file
+func openSQLite(t *testing.T) *database.Database {
+	db, err := database.New(&config.DatabaseConfig{
+		Driver:   "sqlite",
+		DSN:      filepath.Join(t.TempDir(), "test.db"),
+		MaxConns: 2,
+	})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// countTables counts the application tables in a SQLite database
+func countTables(t *testing.T, db *database.Database) int {
+	var n int
+	err := db.GetConn().QueryRow(
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name <> 'schema_migrations'",
+	).Scan(&n)
+	if err != nil {
+		t.Fatalf("failed to count tables: %v", err)
+	}
+	return n
+}
+
+func TestMigrateUpDownAndGoto(t *testing.T) {
+	db := openSQLite(t)
+	ctx := context.Background()
+
+	migrator, err := database.NewMigrator(db, migrations.FS)
+	if err != nil {
+		t.Fatalf("NewMigrator failed: %v", err)
+	}
+	if err := migrator.CheckVersion(ctx); err == nil {
+		t.Fatal("expected an unmigrated database to fail the version check")
+	}
+
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	if err := migrator.CheckVersion(ctx); err != nil {
+		t.Fatalf("expected the latest version, got %v", err)
+	}
+	// Running again is a no-op
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("second Up failed: %v", err)
+	}
+
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if status.Version != migrator.Latest() || status.Dirty || len(status.Migrations) < 2 {
+		t.Fatalf("unexpected status %+v", status)
+	}
+	for _, m := range status.Migrations {
+		if !m.Applied {
+			t.Errorf("expected migration %d_%s applied", m.Version, m.Name)
+		}
+	}
+
+	if err := migrator.Down(ctx, 1); err != nil {
+		t.Fatalf("Down failed: %v", err)
+	}
+	status, _ = migrator.Status(ctx)
+	previous := status.Migrations[len(status.Migrations)-2].Version
+	if status.Version != previous || status.Migrations[len(status.Migrations)-1].Applied {
+		t.Fatalf("expected version %d after Down, got %+v", previous, status)
+	}
+	if err := migrator.CheckVersion(ctx); !errors.Is(err, database.ErrSchemaOutdated) {
+		t.Errorf("expected ErrSchemaOutdated, got %v", err)
+	}
+
+	if err := migrator.Goto(ctx, 0); err != nil {
+		t.Fatalf("Goto 0 failed: %v", err)
+	}
+	if n := countTables(t, db); n != 0 {
+		t.Errorf("expected every table dropped, %d left", n)
+	}
+
+	if err := migrator.Goto(ctx, migrator.Latest()); err != nil {
+		t.Fatalf("Goto latest failed: %v", err)
+	}
+	if err := migrator.Goto(ctx, migrator.Latest()+1000); !errors.Is(err, database.ErrUnknownVersion) {
+		t.Errorf("expected ErrUnknownVersion, got %v", err)
+	}
+}
+
+func TestMigrateFailureAndForce(t *testing.T) {
+	db := openSQLite(t)
+	ctx := context.Background()
+
+	fsys := fstest.MapFS{
+		"sqlite/001_widgets.up.sql":   {Data: []byte("CREATE TABLE widgets (id TEXT PRIMARY KEY);")},
+		"sqlite/001_widgets.down.sql": {Data: []byte("DROP TABLE widgets;")},
+		"sqlite/002_broken.up.sql":    {Data: []byte("ALTER TABLE widgets ADD COLUMN name TEXT; ALTER TABLE missing ADD COLUMN x TEXT;")},
+		"sqlite/002_broken.down.sql":  {Data: []byte("ALTER TABLE widgets DROP COLUMN name;")},
+	}
+	migrator, err := database.NewMigrator(db, fsys)
+	if err != nil {
+		t.Fatalf("NewMigrator failed: %v", err)
+	}
+
+	if err := migrator.Up(ctx); err == nil {
+		t.Fatal("expected the broken migration to fail")
+	}
+
+	// SQLite rolls the failed migration back, leaving the schema clean at the previous version
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if status.Version != 1 || status.Dirty {
+		t.Fatalf("expected clean version 1, got %+v", status)
+	}
+	if _, err := db.GetConn().Exec("INSERT INTO widgets (id, name) VALUES ('a', 'b')"); err == nil {
+		t.Error("expected the partial migration rolled back")
+	}
+
+	// After repairing the schema by hand, force records the version
+	if _, err := db.GetConn().Exec("ALTER TABLE widgets ADD COLUMN name TEXT"); err != nil {
+		t.Fatalf("failed to repair schema: %v", err)
+	}
+	if err := migrator.Force(ctx, 2); err != nil {
+		t.Fatalf("Force failed: %v", err)
+	}
+	if err := migrator.CheckVersion(ctx); err != nil {
+		t.Errorf("expected the forced version to be current, got %v", err)
+	}
+	if err := migrator.Force(ctx, 7); !errors.Is(err, database.ErrUnknownVersion) {
+		t.Errorf("expected ErrUnknownVersion, got %v", err)
+	}
+}
@@ -0,0 +1,250 @@
+package integration_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+	"github.com/zercle/template-go-echo/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-echo/internal/infrastructure/test/sqlitetest"
+	"github.com/zercle/template-go-echo/sql/migrations"
+)
+
+// forgetfulDriver wraps the SQLite driver to count prepared statements and to
+// fail statement executions as a server that forgot the statement would
+type forgetfulDriver struct {
+	base     driver.Driver
+	prepared atomic.Int64
+	forget   atomic.Int64
+}
+
+var (
+	forgetful     = &forgetfulDriver{}
+	registerOnce  sync.Once
+	errForgotStmt = &mysql.MySQLError{Number: 1243, Message: "Unknown prepared statement handler"}
+)
+
+func (d *forgetfulDriver) Open(dsn string) (driver.Conn, error) {
+	conn, err := d.base.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &forgetfulConn{Conn: conn, driver: d}, nil
+}
+
+type forgetfulConn struct {
+	driver.Conn
+	driver *forgetfulDriver
+}
+
+func (c *forgetfulConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *forgetfulConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	c.driver.prepared.Add(1)
+	return &forgetfulStmt{Stmt: stmt, driver: c.driver}, nil
+}
+
+func (c *forgetfulConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+type forgetfulStmt struct {
+	driver.Stmt
+	driver *forgetfulDriver
+}
+
+// forgot consumes one pending failure
+func (s *forgetfulStmt) forgot() bool {
+	for {
+		n := s.driver.forget.Load()
+		if n <= 0 {
+			return false
+		}
+		if s.driver.forget.CompareAndSwap(n, n-1) {
+			return true
+		}
+	}
+}
+
+func (s *forgetfulStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if s.forgot() {
+		return nil, errForgotStmt
+	}
+	return s.Stmt.(driver.StmtExecContext).ExecContext(ctx, args)
+}
+
+func (s *forgetfulStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if s.forgot() {
+		return nil, errForgotStmt
+	}
+	return s.Stmt.(driver.StmtQueryContext).QueryContext(ctx, args)
+}
+
+// openForgetful opens a migrated SQLite database through forgetfulDriver
+func openForgetful(t *testing.T) *sql.DB {
+	registerOnce.Do(func() {
+		probe, err := sql.Open("sqlite", ":memory:")
+		if err != nil {
+			t.Fatalf("failed to open sqlite: %v", err)
+		}
+		forgetful.base = probe.Driver()
+		_ = probe.Close()
+		sql.Register("forgetful-sqlite", forgetful)
+	})
+
+	path := filepath.Join(t.TempDir(), "test.db")
+	migrated, err := database.New(&config.DatabaseConfig{Driver: "sqlite", DSN: path, MaxConns: 2})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	migrator, err := database.NewMigrator(migrated, migrations.FS)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	_ = migrated.Close()
+
+	db, err := sql.Open("forgetful-sqlite", path+"?_time_integer_format=unix_micro&_inttotime=1")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// newUser returns the parameters of an active user with the given email
+func newUser(id, email string) sqlc.CreateUserParams {
+	return sqlc.CreateUserParams{
+		ID:           id,
+		Email:        email,
+		Name:         "Prepared",
+		PasswordHash: "hash",
+		IsActive:     sql.NullBool{Bool: true, Valid: true},
+		Role:         "user",
+	}
+}
+
+func TestDatabasePrepareQueries(t *testing.T) {
+	db := sqlitetest.Open(t)
+	ctx := context.Background()
+
+	if err := db.PrepareQueries(ctx); err != nil {
+		t.Fatalf("PrepareQueries failed: %v", err)
+	}
+	q := db.Querier()
+	if _, ok := q.(*database.PreparedQuerier); !ok {
+		t.Fatalf("expected the prepared querier, got %T", q)
+	}
+
+	if err := q.CreateUser(ctx, newUser("u-1", "prepared@example.com")); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	user, err := q.GetUserByEmail(ctx, "prepared@example.com")
+	if err != nil || user.ID != "u-1" {
+		t.Fatalf("GetUserByEmail returned %+v, %v", user, err)
+	}
+
+	// A rolled back transaction leaves no trace, a committed one does
+	rollback := errors.New("rollback")
+	err = db.TxManager().WithinTx(ctx, func(ctx context.Context) error {
+		if err := database.Queries(ctx, q).CreateUser(ctx, newUser("u-2", "rolled-back@example.com")); err != nil {
+			return err
+		}
+		return rollback
+	})
+	if !errors.Is(err, rollback) {
+		t.Fatalf("expected the rollback error, got %v", err)
+	}
+	if _, err := q.GetUserByEmail(ctx, "rolled-back@example.com"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected the user rolled back, got %v", err)
+	}
+	err = db.TxManager().WithinTx(ctx, func(ctx context.Context) error {
+		return database.Queries(ctx, q).CreateUser(ctx, newUser("u-3", "committed@example.com"))
+	})
+	if err != nil {
+		t.Fatalf("transaction failed: %v", err)
+	}
+	if _, err := q.GetUserByEmail(ctx, "committed@example.com"); err != nil {
+		t.Errorf("expected the committed user, got %v", err)
+	}
+}
+
+func TestPreparedQuerierPreparesAgain(t *testing.T) {
+	conn := openForgetful(t)
+	ctx := context.Background()
+
+	q, err := database.NewPreparedQuerier(ctx, conn, database.DialectSQLite)
+	if err != nil {
+		t.Fatalf("NewPreparedQuerier failed: %v", err)
+	}
+	defer q.Close()
+	if err := q.CreateUser(ctx, newUser("u-1", "forgotten@example.com")); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+
+	// The call that finds the statement forgotten succeeds on newly prepared statements
+	prepared := forgetful.prepared.Load()
+	forgetful.forget.Store(1)
+	if _, err := q.GetUserByEmail(ctx, "forgotten@example.com"); err != nil {
+		t.Fatalf("expected the call retried, got %v", err)
+	}
+	if forgetful.prepared.Load() == prepared {
+		t.Error("expected the statements prepared again")
+	}
+
+	// A statement still unknown after preparing again fails the call
+	forgetful.forget.Store(2)
+	if _, err := q.GetUserByEmail(ctx, "forgotten@example.com"); !database.IsStatementInvalid(err) {
+		t.Errorf("expected the statement error, got %v", err)
+	}
+	forgetful.forget.Store(0)
+}
+
+// BenchmarkGetUserByEmail compares the login lookup with and without prepared statements
+func BenchmarkGetUserByEmail(b *testing.B) {
+	for _, mode := range []struct {
+		name     string
+		prepared bool
+	}{
+		{name: "unprepared"},
+		{name: "prepared", prepared: true},
+	} {
+		b.Run(mode.name, func(b *testing.B) {
+			db := sqlitetest.Open(b)
+			ctx := context.Background()
+			if mode.prepared {
+				if err := db.PrepareQueries(ctx); err != nil {
+					b.Fatalf("PrepareQueries failed: %v", err)
+				}
+			}
+			q := db.Querier()
+			if err := q.CreateUser(ctx, newUser("u-1", "bench@example.com")); err != nil {
+				b.Fatalf("CreateUser failed: %v", err)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := q.GetUserByEmail(ctx, "bench@example.com"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package integration_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+)
+
+// openNamedDB opens a SQLite database holding one row naming it
+func openNamedDB(t *testing.T, name string) *sql.DB {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), name+".db"))
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", name, err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	if _, err := db.Exec("CREATE TABLE node (name TEXT NOT NULL)"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO node (name) VALUES (?)", name); err != nil {
+		t.Fatalf("failed to insert row: %v", err)
+	}
+	return db
+}
+
+// servedBy returns the database that answered a read through db
+func servedBy(t *testing.T, ctx context.Context, db interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}) string {
+	var name string
+	if err := db.QueryRowContext(ctx, "-- name: GetNode :one\nSELECT name FROM node LIMIT 1").Scan(&name); err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	return name
+}
+
+func TestRouterRoundRobin(t *testing.T) {
+	primary := openNamedDB(t, "primary")
+	router := database.NewRouter(primary, []*sql.DB{openNamedDB(t, "replica-1"), openNamedDB(t, "replica-2")},
+		database.WithHealthInterval(0))
+	ctx := context.Background()
+
+	got := []string{servedBy(t, ctx, router), servedBy(t, ctx, router), servedBy(t, ctx, router)}
+	if got[0] != "replica-1" || got[1] != "replica-2" || got[2] != "replica-1" {
+		t.Errorf("expected reads to alternate between replicas, got %v", got)
+	}
+
+	// Writes run on the primary
+	if _, err := router.ExecContext(ctx, "UPDATE node SET name = ?", "primary-updated"); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	var name string
+	if err := primary.QueryRow("SELECT name FROM node").Scan(&name); err != nil || name != "primary-updated" {
+		t.Errorf("expected the write on the primary, got %q %v", name, err)
+	}
+}
+
+func TestRouterLeastConnections(t *testing.T) {
+	busy := openNamedDB(t, "replica-1")
+	router := database.NewRouter(openNamedDB(t, "primary"), []*sql.DB{busy, openNamedDB(t, "replica-2")},
+		database.WithPolicy(database.PolicyLeastConnections), database.WithHealthInterval(0))
+	ctx := context.Background()
+
+	conn, err := busy.Conn(ctx)
+	if err != nil {
+		t.Fatalf("failed to hold connection: %v", err)
+	}
+	defer conn.Close()
+
+	for i := 0; i < 3; i++ {
+		if got := servedBy(t, ctx, router); got != "replica-2" {
+			t.Errorf("expected the idle replica, got %s", got)
+		}
+	}
+}
+
+func TestRouterReadYourWrites(t *testing.T) {
+	router := database.NewRouter(openNamedDB(t, "primary"), []*sql.DB{openNamedDB(t, "replica-1")},
+		database.WithHealthInterval(0))
+
+	ctx := database.WithReadYourWrites(context.Background())
+	if got := servedBy(t, ctx, router); got != "replica-1" {
+		t.Errorf("expected reads on the replica before writing, got %s", got)
+	}
+	if _, err := router.ExecContext(ctx, "INSERT INTO node (name) VALUES ('other')"); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	if got := servedBy(t, ctx, router); got != "primary" {
+		t.Errorf("expected reads on the primary after writing, got %s", got)
+	}
+
+	// Other contexts keep reading from the replica
+	if got := servedBy(t, context.Background(), router); got != "replica-1" {
+		t.Errorf("expected an unrelated read on the replica, got %s", got)
+	}
+	if got := servedBy(t, database.WithPrimary(context.Background()), router); got != "primary" {
+		t.Errorf("expected a forced primary read, got %s", got)
+	}
+}
+
+func TestRouterTransactionsUsePrimary(t *testing.T) {
+	router := database.NewRouter(openNamedDB(t, "primary"), []*sql.DB{openNamedDB(t, "replica-1")},
+		database.WithHealthInterval(0))
+	tx := database.NewTxManager(router)
+
+	ctx := database.WithReadYourWrites(context.Background())
+	err := tx.WithinTx(ctx, func(ctx context.Context) error {
+		conn := database.Conn(ctx, router)
+		if got := servedBy(t, ctx, conn); got != "primary" {
+			t.Errorf("expected reads in a transaction on the primary, got %s", got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("transaction failed: %v", err)
+	}
+
+	// A committed transaction counts as a write
+	if got := servedBy(t, ctx, router); got != "primary" {
+		t.Errorf("expected reads on the primary after a transaction, got %s", got)
+	}
+}
+
+func TestRouterEjectsUnhealthyReplica(t *testing.T) {
+	broken := openNamedDB(t, "replica-1")
+	router := database.NewRouter(openNamedDB(t, "primary"), []*sql.DB{broken, openNamedDB(t, "replica-2")},
+		database.WithHealthInterval(0))
+	ctx := context.Background()
+
+	_ = broken.Close()
+	router.CheckHealth(ctx)
+	if n := router.HealthyReplicas(); n != 1 {
+		t.Fatalf("expected 1 healthy replica, got %d", n)
+	}
+	for i := 0; i < 3; i++ {
+		if got := servedBy(t, ctx, router); got != "replica-2" {
+			t.Errorf("expected the healthy replica, got %s", got)
+		}
+	}
+}
+
+func TestDatabaseWithReplicas(t *testing.T) {
+	dir := t.TempDir()
+	replica, err := sql.Open("sqlite", filepath.Join(dir, "replica.db"))
+	if err != nil {
+		t.Fatalf("failed to open replica: %v", err)
+	}
+	if _, err := replica.Exec("CREATE TABLE node (name TEXT NOT NULL); INSERT INTO node (name) VALUES ('replica-1')"); err != nil {
+		t.Fatalf("failed to seed replica: %v", err)
+	}
+	_ = replica.Close()
+
+	db, err := database.New(&config.DatabaseConfig{
+		Driver:                "sqlite",
+		DSN:                   filepath.Join(dir, "primary.db"),
+		ReplicaDSNs:           []string{filepath.Join(dir, "replica.db")},
+		ReplicaPolicy:         database.PolicyRoundRobin,
+		ReplicaHealthInterval: time.Minute,
+		MaxConns:              2,
+	})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	if db.Router() == nil || db.Router().HealthyReplicas() != 1 {
+		t.Fatal("expected one healthy replica")
+	}
+	if got := servedBy(t, context.Background(), db.DBTX()); got != "replica-1" {
+		t.Errorf("expected dynamic reads on the replica, got %s", got)
+	}
+}
@@ -0,0 +1,240 @@
+package integration_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+)
+
+// statementLog records the statements a fake connection receives
+type statementLog struct {
+	mu         sync.Mutex
+	statements []string
+}
+
+func (l *statementLog) add(statement string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.statements = append(l.statements, statement)
+}
+
+func (l *statementLog) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return strings.Join(l.statements, "; ")
+}
+
+// fakeConnector opens connections that log transaction statements instead of running them
+type fakeConnector struct{ log *statementLog }
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{log: c.log}, nil
+}
+func (c *fakeConnector) Driver() driver.Driver { return nil }
+
+type fakeConn struct{ log *statementLog }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.log.add("BEGIN")
+	return &fakeTx{log: c.log}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.log.add(query)
+	return driver.RowsAffected(0), nil
+}
+
+type fakeTx struct{ log *statementLog }
+
+func (t *fakeTx) Commit() error   { t.log.add("COMMIT"); return nil }
+func (t *fakeTx) Rollback() error { t.log.add("ROLLBACK"); return nil }
+
+func newTxManager(opts ...database.TxOption) (*database.TxManager, *statementLog) {
+	log := &statementLog{}
+	db := sql.OpenDB(&fakeConnector{log: log})
+	opts = append([]database.TxOption{database.WithRetryBackoff(time.Millisecond)}, opts...)
+	return database.NewTxManager(db, opts...), log
+}
+
+func TestWithinTxCommits(t *testing.T) {
+	tm, log := newTxManager()
+
+	err := tm.WithinTx(context.Background(), func(ctx context.Context) error {
+		if _, ok := database.TxFromContext(ctx); !ok {
+			t.Error("expected transaction in context")
+		}
+		if _, ok := database.Conn(ctx, nil).(*sql.Tx); !ok {
+			t.Error("expected Conn to return the transaction")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithinTx failed: %v", err)
+	}
+	if log.String() != "BEGIN; COMMIT" {
+		t.Errorf("unexpected statements %q", log)
+	}
+	if _, ok := database.TxFromContext(context.Background()); ok {
+		t.Error("expected no transaction outside WithinTx")
+	}
+}
+
+func TestWithinTxRollsBackOnError(t *testing.T) {
+	tm, log := newTxManager()
+	failure := errors.New("insert failed")
+
+	err := tm.WithinTx(context.Background(), func(ctx context.Context) error {
+		return failure
+	})
+	if err != failure {
+		t.Errorf("expected fn error, got %v", err)
+	}
+	if log.String() != "BEGIN; ROLLBACK" {
+		t.Errorf("unexpected statements %q", log)
+	}
+}
+
+func TestWithinTxRollsBackOnPanic(t *testing.T) {
+	tm, log := newTxManager()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic to propagate")
+		}
+		if log.String() != "BEGIN; ROLLBACK" {
+			t.Errorf("unexpected statements %q", log)
+		}
+	}()
+	_ = tm.WithinTx(context.Background(), func(ctx context.Context) error {
+		panic("boom")
+	})
+}
+
+func TestWithinTxNestsSavepoints(t *testing.T) {
+	tm, log := newTxManager()
+	failure := errors.New("optional step failed")
+
+	err := tm.WithinTx(context.Background(), func(ctx context.Context) error {
+		if err := tm.WithinTx(ctx, func(ctx context.Context) error {
+			return tm.WithinTx(ctx, func(ctx context.Context) error { return nil })
+		}); err != nil {
+			return err
+		}
+		// The outer transaction survives a failed inner step it chooses to ignore
+		if err := tm.WithinTx(ctx, func(ctx context.Context) error { return failure }); err != failure {
+			t.Errorf("expected inner error, got %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithinTx failed: %v", err)
+	}
+
+	expected := "BEGIN; SAVEPOINT sp_1; SAVEPOINT sp_2; RELEASE SAVEPOINT sp_2; RELEASE SAVEPOINT sp_1; " +
+		"SAVEPOINT sp_1; ROLLBACK TO SAVEPOINT sp_1; COMMIT"
+	if log.String() != expected {
+		t.Errorf("unexpected statements\n got %q\nwant %q", log, expected)
+	}
+}
+
+func TestWithinTxRetriesDeadlocks(t *testing.T) {
+	tm, log := newTxManager()
+
+	attempts := 0
+	err := tm.WithinTx(context.Background(), func(ctx context.Context) error {
+		attempts++
+		if attempts == 1 {
+			return &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithinTx failed: %v", err)
+	}
+	if attempts != 2 || log.String() != "BEGIN; ROLLBACK; BEGIN; COMMIT" {
+		t.Errorf("expected one retry, got %d attempts and %q", attempts, log)
+	}
+}
+
+func TestWithinTxGivesUpAfterMaxRetries(t *testing.T) {
+	tm, _ := newTxManager(database.WithMaxRetries(2))
+	deadlock := &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+
+	attempts := 0
+	err := tm.WithinTx(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return deadlock
+	})
+	if err != deadlock || attempts != 3 {
+		t.Errorf("expected deadlock after 3 attempts, got %v after %d", err, attempts)
+	}
+
+	// Other errors are not retried
+	attempts = 0
+	_ = tm.WithinTx(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+	})
+	if attempts != 1 {
+		t.Errorf("expected no retry for duplicate entry, got %d attempts", attempts)
+	}
+}
+
+func TestAfterCommit(t *testing.T) {
+	tm, log := newTxManager()
+	var ran []string
+
+	database.AfterCommit(context.Background(), func() { ran = append(ran, "outside") })
+	if len(ran) != 1 {
+		t.Fatalf("expected the hook run outside a transaction, got %v", ran)
+	}
+
+	// Hooks wait for the commit, including those of a rolled back savepoint
+	err := tm.WithinTx(context.Background(), func(ctx context.Context) error {
+		database.AfterCommit(ctx, func() { ran = append(ran, "outer") })
+		_ = tm.WithinTx(ctx, func(ctx context.Context) error {
+			database.AfterCommit(ctx, func() { ran = append(ran, "savepoint") })
+			return errors.New("optional step failed")
+		})
+		if len(ran) != 1 {
+			t.Errorf("expected no hook run before the commit, got %v", ran)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithinTx failed: %v", err)
+	}
+	if strings.Join(ran, ",") != "outside,outer,savepoint" || !strings.HasSuffix(log.String(), "COMMIT") {
+		t.Errorf("expected the hooks run after the commit, got %v and %q", ran, log)
+	}
+
+	// A rolled back transaction drops its hooks
+	ran = nil
+	_ = tm.WithinTx(context.Background(), func(ctx context.Context) error {
+		database.AfterCommit(ctx, func() { ran = append(ran, "rolled back") })
+		return errors.New("insert failed")
+	})
+	if len(ran) != 0 {
+		t.Errorf("expected no hook run after a rollback, got %v", ran)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	if !database.IsRetryable(&mysql.MySQLError{Number: 1205}) {
+		t.Error("expected lock wait timeout to be retryable")
+	}
+	if database.IsRetryable(errors.New("connection refused")) {
+		t.Error("expected plain error not to be retryable")
+	}
+}
@@ -0,0 +1,41 @@
+// Package sqlitetest opens migrated SQLite databases for integration tests,
+// so repositories run against real SQL without a database server.
+package sqlitetest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+	"github.com/zercle/template-go-echo/sql/migrations"
+)
+
+// Open creates a database in a temporary file, applies the SQLite migrations
+// and closes it when the test ends. A file is used rather than :memory:
+// because every pooled connection to :memory: opens a separate database.
+// Options instrument the queries, as database.New does.
+func Open(t testing.TB, opts ...database.InstrumentOption) *database.Database {
+	t.Helper()
+
+	db, err := database.New(&config.DatabaseConfig{
+		Driver:   string(database.DialectSQLite),
+		DSN:      filepath.Join(t.TempDir(), "test.db"),
+		MaxConns: 4,
+	}, opts...)
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	migrator, err := database.NewMigrator(db, migrations.FS)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	return db
+}
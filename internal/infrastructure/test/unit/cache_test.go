@@ -0,0 +1,64 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/infrastructure/cache"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := cache.NewLRU(2)
+	ctx := context.Background()
+
+	_ = c.Set(ctx, "a", []byte("1"), time.Minute)
+	_ = c.Set(ctx, "b", []byte("2"), time.Minute)
+	if _, err := c.Get(ctx, "a"); err != nil {
+		t.Fatalf("expected a cached, got %v", err)
+	}
+
+	// b is now the least recently used entry
+	_ = c.Set(ctx, "c", []byte("3"), time.Minute)
+	if _, err := c.Get(ctx, "b"); !errors.Is(err, cache.ErrMiss) {
+		t.Errorf("expected b evicted, got %v", err)
+	}
+	if value, err := c.Get(ctx, "a"); err != nil || string(value) != "1" {
+		t.Errorf("expected a kept, got %q, %v", value, err)
+	}
+	if c.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", c.Len())
+	}
+
+	// Storing a key again replaces its value without growing the cache
+	_ = c.Set(ctx, "c", []byte("4"), time.Minute)
+	if value, _ := c.Get(ctx, "c"); string(value) != "4" || c.Len() != 2 {
+		t.Errorf("expected c replaced, got %q with %d entries", value, c.Len())
+	}
+}
+
+func TestLRUExpiresAndDeletes(t *testing.T) {
+	c := cache.NewLRU(0)
+	ctx := context.Background()
+
+	_ = c.Set(ctx, "short", []byte("1"), time.Millisecond)
+	_ = c.Set(ctx, "long", []byte("2"), time.Minute)
+	_ = c.Set(ctx, "other", []byte("3"), time.Minute)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := c.Get(ctx, "short"); !errors.Is(err, cache.ErrMiss) {
+		t.Errorf("expected the entry expired, got %v", err)
+	}
+	if err := c.Delete(ctx, "long", "missing"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := c.Get(ctx, "long"); !errors.Is(err, cache.ErrMiss) {
+		t.Errorf("expected the entry deleted, got %v", err)
+	}
+
+	c.Clear()
+	if _, err := c.Get(ctx, "other"); !errors.Is(err, cache.ErrMiss) || c.Len() != 0 {
+		t.Errorf("expected the cache cleared, got %v with %d entries", err, c.Len())
+	}
+}
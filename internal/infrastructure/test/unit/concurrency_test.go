@@ -0,0 +1,148 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/zercle/template-go-echo/internal/infrastructure/concurrency"
+)
+
+// fixedAlgorithm never changes the limit
+type fixedAlgorithm struct{}
+
+func (fixedAlgorithm) Update(limit float64, _ concurrency.Sample) float64 { return limit }
+
+func TestAIMD(t *testing.T) {
+	aimd := concurrency.NewAIMD(2, 10, 100*time.Millisecond)
+
+	limit := 4.0
+	for i := 0; i < 4; i++ {
+		limit = aimd.Update(limit, concurrency.Sample{RTT: 10 * time.Millisecond, InFlight: 4})
+	}
+	if limit < 4.9 || limit > 5 {
+		t.Errorf("expected a limit's worth of fast requests to add about one, got %v", limit)
+	}
+	if idle := aimd.Update(4, concurrency.Sample{RTT: 10 * time.Millisecond, InFlight: 1}); idle != 4 {
+		t.Errorf("expected an unused limit to stay, got %v", idle)
+	}
+	if slow := aimd.Update(5, concurrency.Sample{RTT: time.Second, InFlight: 5}); slow != 4.5 {
+		t.Errorf("expected a slow request to cut the limit to 4.5, got %v", slow)
+	}
+	if dropped := aimd.Update(2, concurrency.Sample{RTT: time.Millisecond, InFlight: 2, Dropped: true}); dropped != 2 {
+		t.Errorf("expected the limit to stay at the minimum, got %v", dropped)
+	}
+	if grown := aimd.Update(10, concurrency.Sample{RTT: time.Millisecond, InFlight: 10}); grown != 10 {
+		t.Errorf("expected the limit to stay at the maximum, got %v", grown)
+	}
+}
+
+func TestGradient(t *testing.T) {
+	gradient := concurrency.NewGradient(4, 100)
+
+	limit := 20.0
+	for i := 0; i < 20; i++ {
+		limit = gradient.Update(limit, concurrency.Sample{RTT: 10 * time.Millisecond, InFlight: int(limit)})
+	}
+	if limit <= 20 {
+		t.Fatalf("expected steady latency to raise the limit, got %v", limit)
+	}
+
+	raised := limit
+	for i := 0; i < 20; i++ {
+		limit = gradient.Update(limit, concurrency.Sample{RTT: 100 * time.Millisecond, InFlight: int(limit)})
+	}
+	if limit >= raised {
+		t.Errorf("expected rising latency to lower the limit from %v, got %v", raised, limit)
+	}
+	if idle := gradient.Update(limit, concurrency.Sample{RTT: 10 * time.Millisecond, InFlight: 1}); idle != limit {
+		t.Errorf("expected an unused limit to stay at %v, got %v", limit, idle)
+	}
+}
+
+func TestLimiterQueuesAndSheds(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	limiter := concurrency.NewLimiter("read", fixedAlgorithm{},
+		concurrency.WithInitialLimit(1),
+		concurrency.WithQueueSize(1),
+		concurrency.WithQueueTimeout(time.Minute),
+		concurrency.WithMetrics(concurrency.NewMetrics(registry)),
+	)
+	ctx := context.Background()
+
+	first, err := limiter.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("expected the first request to be admitted, got %v", err)
+	}
+
+	admitted := make(chan *concurrency.Token)
+	go func() {
+		token, _ := limiter.Acquire(ctx)
+		admitted <- token
+	}()
+	for limiter.Queued() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	if _, err := limiter.Acquire(ctx); !errors.Is(err, concurrency.ErrOverloaded) {
+		t.Fatalf("expected a request beyond the queue to be shed, got %v", err)
+	}
+
+	first.Release(false)
+	second := <-admitted
+	if second == nil || limiter.InFlight() != 1 || limiter.Queued() != 0 {
+		t.Fatalf("expected the queued request to be admitted, got %d in flight and %d queued", limiter.InFlight(), limiter.Queued())
+	}
+	second.Release(false)
+
+	if got := metricValue(t, registry, "concurrency_limit_shed_total"); got != 1 {
+		t.Errorf("expected 1 shed request, got %v", got)
+	}
+	if got := metricValue(t, registry, "concurrency_limit"); got != 1 {
+		t.Errorf("expected a limit of 1, got %v", got)
+	}
+}
+
+func TestLimiterShedsAfterQueueTimeout(t *testing.T) {
+	limiter := concurrency.NewLimiter("write", fixedAlgorithm{},
+		concurrency.WithInitialLimit(1),
+		concurrency.WithQueueTimeout(10*time.Millisecond),
+		concurrency.WithMetrics(concurrency.NewMetrics(prometheus.NewRegistry())),
+	)
+	token, _ := limiter.Acquire(context.Background())
+	defer token.Release(false)
+
+	if _, err := limiter.Acquire(context.Background()); !errors.Is(err, concurrency.ErrOverloaded) {
+		t.Fatalf("expected the queued request to be shed, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := limiter.Acquire(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a canceled request to stop waiting, got %v", err)
+	}
+	if limiter.Queued() != 0 {
+		t.Errorf("expected the queue to be empty, got %d", limiter.Queued())
+	}
+}
+
+// metricValue returns the sum of the gauge or counter series named name
+func metricValue(t *testing.T, gatherer prometheus.Gatherer, name string) float64 {
+	t.Helper()
+	families, err := gatherer.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	var value float64
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			value += metric.GetGauge().GetValue() + metric.GetCounter().GetValue()
+		}
+	}
+	return value
+}
@@ -0,0 +1,81 @@
+package unit_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+)
+
+func TestRebind(t *testing.T) {
+	query := "SELECT id FROM users WHERE email LIKE ? ESCAPE '\\' AND note = 'why?' AND id > ? LIMIT ?"
+
+	if got := database.DialectMySQL.Rebind(query); got != query {
+		t.Errorf("expected mysql query unchanged, got %s", got)
+	}
+	if got := database.DialectSQLite.Rebind(query); got != query {
+		t.Errorf("expected sqlite query unchanged, got %s", got)
+	}
+
+	want := "SELECT id FROM users WHERE email LIKE $1 ESCAPE '\\' AND note = 'why?' AND id > $2 LIMIT $3"
+	if got := database.DialectPostgres.Rebind(query); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestDialectClauses(t *testing.T) {
+	tests := []struct {
+		dialect    database.Dialect
+		like       string
+		skipLocked string
+	}{
+		{database.DialectMySQL, "email LIKE ?", " FOR UPDATE SKIP LOCKED"},
+		{database.DialectPostgres, "email ILIKE ?", " FOR UPDATE SKIP LOCKED"},
+		{database.DialectSQLite, `email LIKE ? ESCAPE '\'`, ""},
+	}
+
+	for _, tt := range tests {
+		if got := tt.dialect.Like("email"); got != tt.like {
+			t.Errorf("%s: expected %q, got %q", tt.dialect, tt.like, got)
+		}
+		if got := tt.dialect.ForUpdateSkipLocked(); got != tt.skipLocked {
+			t.Errorf("%s: expected %q, got %q", tt.dialect, tt.skipLocked, got)
+		}
+	}
+}
+
+func TestIsValidDialect(t *testing.T) {
+	for _, d := range []string{"mysql", "postgres", "sqlite"} {
+		if !database.IsValidDialect(d) {
+			t.Errorf("expected %s to be valid", d)
+		}
+	}
+	if database.IsValidDialect("oracle") {
+		t.Error("expected oracle to be invalid")
+	}
+}
+
+func TestIsStatementInvalid(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: &mysql.MySQLError{Number: 1243}, want: true},
+		{err: &mysql.MySQLError{Number: 1615}, want: true},
+		{err: &mysql.MySQLError{Number: 1062}, want: false},
+		{err: &pgconn.PgError{Code: "26000"}, want: true},
+		{err: &pgconn.PgError{Code: "0A000", Message: "cached plan must not change result type"}, want: true},
+		{err: &pgconn.PgError{Code: "0A000", Message: "unsupported feature"}, want: false},
+		{err: fmt.Errorf("query failed: %w", &pgconn.PgError{Code: "26000"}), want: true},
+		{err: errors.New("connection refused"), want: false},
+		{err: nil, want: false},
+	}
+	for _, tt := range tests {
+		if got := database.IsStatementInvalid(tt.err); got != tt.want {
+			t.Errorf("IsStatementInvalid(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
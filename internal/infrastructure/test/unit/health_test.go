@@ -1,8 +1,11 @@
 package unit_test
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/labstack/echo/v4"
@@ -30,7 +33,7 @@ func TestReady(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	if err := infrastructure.Ready(c); err != nil {
+	if err := infrastructure.Ready()(c); err != nil {
 		t.Errorf("Ready() returned error: %v", err)
 	}
 
@@ -39,6 +42,28 @@ func TestReady(t *testing.T) {
 	}
 }
 
+func TestReadyFailingCheck(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	ready := infrastructure.Ready(
+		infrastructure.ReadyCheck{Name: "database", Check: func(context.Context) error { return nil }},
+		infrastructure.ReadyCheck{Name: "schema", Check: func(context.Context) error { return errors.New("schema is at version 3") }},
+	)
+	if err := ready(c); err != nil {
+		t.Errorf("Ready() returned error: %v", err)
+	}
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d, got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "schema is not ready") || strings.Contains(rec.Body.String(), "version 3") {
+		t.Errorf("unexpected body %s", rec.Body.String())
+	}
+}
+
 func TestLive(t *testing.T) {
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/live", nil)
@@ -0,0 +1,109 @@
+package unit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/infrastructure/logging"
+)
+
+func TestLogHandlerFormats(t *testing.T) {
+	var buf bytes.Buffer
+	handler, err := logging.NewHandler(&config.LogConfig{Level: "info", Format: logging.FormatJSON}, &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	logger := slog.New(handler)
+	logger.Debug("hidden")
+	logger.Info("shown", slog.String("key", "value"))
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected a single JSON record, got %q: %v", buf.String(), err)
+	}
+	if record["msg"] != "shown" || record["key"] != "value" {
+		t.Errorf("unexpected record %v", record)
+	}
+
+	buf.Reset()
+	handler, err = logging.NewHandler(&config.LogConfig{Level: "WARN", Format: logging.FormatText}, &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	slog.New(handler).Info("hidden")
+	slog.New(handler).Warn("shown")
+	if got := buf.String(); !strings.Contains(got, "level=WARN msg=shown") || strings.Contains(got, "hidden") {
+		t.Errorf("expected only the warning as text, got %q", got)
+	}
+}
+
+func TestLogHandlerPackageLevels(t *testing.T) {
+	tests := []struct {
+		name   string
+		levels string
+		debug  bool
+	}{
+		{"no override", "", false},
+		{"this package", "internal/infrastructure/test=debug", true},
+		{"most specific wins", "internal/infrastructure=debug,internal/infrastructure/test=error", false},
+		{"another package", "internal/user=debug", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			handler, err := logging.NewHandler(&config.LogConfig{Level: "info", Format: logging.FormatText, Levels: tt.levels}, &buf)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			logger := slog.New(handler).With(slog.String("component", "test"))
+			logger.Debug("debug record")
+
+			if got := strings.Contains(buf.String(), "debug record"); got != tt.debug {
+				t.Errorf("expected the debug record logged to be %v, got %q", tt.debug, buf.String())
+			}
+		})
+	}
+}
+
+func TestLogHandlerRejectsInvalidConfig(t *testing.T) {
+	for _, cfg := range []config.LogConfig{
+		{Level: "verbose", Format: logging.FormatText},
+		{Level: "info", Format: "xml"},
+		{Level: "info", Format: logging.FormatText, Levels: "internal/user"},
+		{Level: "info", Format: logging.FormatText, Levels: "internal/user=loud"},
+	} {
+		if _, err := logging.NewHandler(&cfg, &bytes.Buffer{}); err == nil {
+			t.Errorf("expected %+v to be rejected", cfg)
+		}
+	}
+}
+
+func TestSetDefaultConfiguresStandardLoggers(t *testing.T) {
+	previous := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	var buf bytes.Buffer
+	if err := logging.SetDefault(&config.LogConfig{Level: "warn", Format: logging.FormatJSON}, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	slog.Info("hidden")
+	log.Printf("hidden too")
+	slog.Warn("shown")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected a single JSON record, got %q: %v", buf.String(), err)
+	}
+	if record["msg"] != "shown" {
+		t.Errorf("unexpected record %v", record)
+	}
+
+	if err := logging.SetDefault(&config.LogConfig{Level: "info", Format: "xml"}, &buf); err == nil {
+		t.Error("expected an invalid configuration to be rejected")
+	}
+}
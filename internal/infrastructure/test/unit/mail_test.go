@@ -0,0 +1,108 @@
+package unit_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/infrastructure/mail"
+)
+
+// fakeSMTPServer accepts one SMTP session and sends the envelope and data it
+// received on the returned channel
+func fakeSMTPServer(t *testing.T) (string, <-chan string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var session strings.Builder
+		r := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"):
+				reply("250 localhost")
+			case strings.HasPrefix(command, "MAIL"), strings.HasPrefix(command, "RCPT"):
+				session.WriteString(strings.TrimSpace(line) + "\n")
+				reply("250 OK")
+			case command == "DATA":
+				reply("354 Go ahead")
+				for {
+					data, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if data == ".\r\n" {
+						break
+					}
+					session.WriteString(data)
+				}
+				reply("250 OK")
+			case command == "QUIT":
+				reply("221 Bye")
+				received <- session.String()
+				return
+			default:
+				reply("502 Not implemented")
+			}
+		}
+	}()
+	return listener.Addr().String(), received
+}
+
+func TestSMTPSend(t *testing.T) {
+	addr, received := fakeSMTPServer(t)
+	mailer := mail.NewSMTP(&config.MailConfig{SMTPAddr: addr, From: "noreply@example.com", Timeout: 5 * time.Second})
+
+	if err := mailer.Send(context.Background(), "alice@example.com", "Welcome", "Hello Alice\n"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	select {
+	case session := <-received:
+		for _, want := range []string{
+			"MAIL FROM:<noreply@example.com>",
+			"RCPT TO:<alice@example.com>",
+			"To: alice@example.com\r\n",
+			"Subject: Welcome\r\n",
+			"\r\n\r\nHello Alice\r\n",
+		} {
+			if !strings.Contains(session, want) {
+				t.Errorf("expected session to contain %q, got %q", want, session)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the relay to receive the email")
+	}
+}
+
+func TestSMTPRejectsMultilineHeaders(t *testing.T) {
+	mailer := mail.NewSMTP(&config.MailConfig{SMTPAddr: "127.0.0.1:25", From: "noreply@example.com", Timeout: time.Second})
+
+	err := mailer.Send(context.Background(), "alice@example.com\r\nBcc: eve@example.com", "Welcome", "Hello")
+	if !errors.Is(err, mail.ErrInvalidHeader) {
+		t.Errorf("expected ErrInvalidHeader, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package unit_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/infrastructure/tracing"
+)
+
+func TestLogHandlerAddsTraceAndSpanIDs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(tracing.NewLogHandler(slog.NewJSONHandler(&buf, nil))).With(slog.String("component", "test"))
+	provider := tracing.NewProvider(&config.TracingConfig{ServiceName: "test", SampleRatio: 1})
+	ctx, span := provider.Tracer("test").Start(context.Background(), "operation")
+	defer span.End()
+
+	logger.InfoContext(ctx, "inside span")
+	logger.InfoContext(context.Background(), "outside span")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(lines))
+	}
+	var inside, outside map[string]any
+	if err := json.Unmarshal(lines[0], &inside); err != nil {
+		t.Fatalf("failed to decode record: %v", err)
+	}
+	if err := json.Unmarshal(lines[1], &outside); err != nil {
+		t.Fatalf("failed to decode record: %v", err)
+	}
+
+	if inside["trace_id"] != span.SpanContext().TraceID().String() {
+		t.Errorf("expected trace_id %s, got %v", span.SpanContext().TraceID(), inside["trace_id"])
+	}
+	if inside["span_id"] != span.SpanContext().SpanID().String() {
+		t.Errorf("expected span_id %s, got %v", span.SpanContext().SpanID(), inside["span_id"])
+	}
+	if inside["component"] != "test" {
+		t.Errorf("expected attributes of the logger to be kept, got %v", inside)
+	}
+	if _, ok := outside["trace_id"]; ok {
+		t.Errorf("expected no trace_id outside a span, got %v", outside)
+	}
+}
+
+func TestNewTracingExporters(t *testing.T) {
+	_, _, err := tracing.New(context.Background(), &config.TracingConfig{Exporter: "zipkin", ServiceName: "test", SampleRatio: 1})
+	if err == nil {
+		t.Error("expected an unknown exporter to fail")
+	}
+
+	provider, shutdown, err := tracing.New(context.Background(), &config.TracingConfig{Exporter: tracing.ExporterNone})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, span := provider.Tracer("test").Start(context.Background(), "operation"); span.IsRecording() {
+		t.Error("expected the none exporter to record nothing")
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
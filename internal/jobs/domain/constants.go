@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+const (
+	// Pagination constraints
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+
+	// Job constraints
+	MaxJobTypeLength = 100
+	MaxErrorLength   = 4096
+
+	// Enqueue defaults
+	DefaultMaxAttempts = 5
+
+	// Worker defaults
+	DefaultPollInterval      = time.Second
+	DefaultConcurrency       = 4
+	DefaultVisibilityTimeout = 5 * time.Minute
+
+	// Retry backoff bounds
+	MinRetryBackoff = 5 * time.Second
+	MaxRetryBackoff = time.Hour
+
+	// How long succeeded jobs are kept before the cleanup job removes them
+	DefaultRetention = 7 * 24 * time.Hour
+)
+
+// Job statuses
+const (
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusDead      = "dead"
+)
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Job is a unit of background work with its queue state
+type Job struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	Priority    int             `json:"priority"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"`
+	LockedBy    string          `json:"locked_by,omitempty"`
+	LockedUntil *time.Time      `json:"locked_until,omitempty"`
+	LastError   string          `json:"last_error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+}
+
+// EnqueueOptions holds the scheduling settings of a new job
+type EnqueueOptions struct {
+	Priority    int
+	RunAt       time.Time
+	MaxAttempts int
+}
+
+// EnqueueOption configures optional EnqueueOptions settings
+type EnqueueOption func(*EnqueueOptions)
+
+// WithPriority sets the job priority; higher priorities are claimed first
+func WithPriority(priority int) EnqueueOption {
+	return func(o *EnqueueOptions) {
+		o.Priority = priority
+	}
+}
+
+// WithDelay delays the first attempt by d
+func WithDelay(d time.Duration) EnqueueOption {
+	return func(o *EnqueueOptions) {
+		o.RunAt = time.Now().Add(d)
+	}
+}
+
+// WithRunAt sets the earliest time of the first attempt
+func WithRunAt(runAt time.Time) EnqueueOption {
+	return func(o *EnqueueOptions) {
+		o.RunAt = runAt
+	}
+}
+
+// WithMaxAttempts sets how many attempts are made before the job is dead-lettered
+func WithMaxAttempts(attempts int) EnqueueOption {
+	return func(o *EnqueueOptions) {
+		o.MaxAttempts = attempts
+	}
+}
+
+// Handler processes jobs of one type. Returning an error schedules a retry with
+// backoff until the job runs out of attempts; wrap it with Permanent to
+// dead-letter the job straight away.
+type Handler struct {
+	Type   string
+	Handle func(ctx context.Context, job *Job) error
+
+	// VisibilityTimeout bounds one attempt; when it passes the job is handed to
+	// another worker. Defaults to DefaultVisibilityTimeout.
+	VisibilityTimeout time.Duration
+}
+
+// permanentError marks a failure that retrying cannot fix
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so the failed job is dead-lettered without further attempts
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was wrapped with Permanent
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
+// RetryBackoff returns how long to wait before the next attempt after the given
+// number of failed attempts, doubling from MinRetryBackoff up to MaxRetryBackoff
+func RetryBackoff(attempts int) time.Duration {
+	backoff := MinRetryBackoff
+	for i := 1; i < attempts && backoff < MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > MaxRetryBackoff {
+		backoff = MaxRetryBackoff
+	}
+	return backoff
+}
+
+// JobQuery describes a cursor paginated job listing, newest first
+type JobQuery struct {
+	Type   string
+	Status string
+	Cursor string
+	Limit  int
+}
+
+// JobCursor is the keyset position of a job in a listing
+type JobCursor struct {
+	CreatedAt string `json:"t"`
+	ID        string `json:"id"`
+}
+
+// NewJobCursor returns the keyset position of job
+func NewJobCursor(job *Job) JobCursor {
+	return JobCursor{
+		CreatedAt: job.CreatedAt.UTC().Format(time.RFC3339Nano),
+		ID:        job.ID,
+	}
+}
+
+// IsValidJobStatus reports whether status is a known job status
+func IsValidJobStatus(status string) bool {
+	switch status {
+	case StatusPending, StatusRunning, StatusSucceeded, StatusDead:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// Jobs domain-specific error codes
+const (
+	ErrCodeJobNotFound      = "JOB_NOT_FOUND"
+	ErrCodeJobNotRetryable  = "JOB_NOT_RETRYABLE"
+	ErrCodeInvalidJobStatus = "INVALID_JOB_STATUS"
+)
+
+// Jobs domain errors
+var (
+	ErrJobNotFound = pkg.NewDomainError(
+		ErrCodeJobNotFound,
+		"job not found",
+	)
+
+	ErrJobNotRetryable = pkg.NewDomainError(
+		ErrCodeJobNotRetryable,
+		"only dead jobs can be retried",
+	)
+
+	ErrInvalidJobStatus = pkg.NewDomainError(
+		ErrCodeInvalidJobStatus,
+		"status must be pending, running, succeeded or dead",
+	)
+)
+
+// Errors returned when enqueueing jobs or registering handlers
+var (
+	ErrInvalidJobType     = errors.New("job type must be between 1 and 100 characters")
+	ErrInvalidPayload     = errors.New("job payload must be valid JSON")
+	ErrInvalidHandler     = errors.New("job handler needs a type and a handle function")
+	ErrDuplicateHandler   = errors.New("job handler is already registered")
+	ErrInvalidMaxAttempts = errors.New("job max attempts must be at least 1")
+)
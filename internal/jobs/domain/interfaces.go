@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// JobRepository defines database operations for the job queue
+type JobRepository interface {
+	// CreateJob adds a pending job to the queue
+	CreateJob(ctx context.Context, job *Job) error
+
+	// GetJob retrieves a job by ID
+	GetJob(ctx context.Context, id string) (*Job, error)
+
+	// ListJobs retrieves up to limit jobs newest first, optionally filtered by type
+	// and status and starting after the given keyset position
+	ListJobs(ctx context.Context, jobType, status string, after *JobCursor, limit int) ([]*Job, error)
+
+	// ClaimJobs locks up to limit pending jobs due at now, highest priority first,
+	// for workerID. Only the types in timeouts are claimed, each locked until now
+	// plus its visibility timeout. Jobs locked by concurrent claims are skipped.
+	ClaimJobs(ctx context.Context, workerID string, timeouts map[string]time.Duration, now time.Time, limit int) ([]*Job, error)
+
+	// CompleteJob marks a job claimed by workerID as succeeded, reporting whether it still held the job
+	CompleteJob(ctx context.Context, id, workerID string, completedAt time.Time) (bool, error)
+
+	// RescheduleJob returns a job claimed by workerID to the queue to run again at
+	// runAt, reporting whether it still held the job
+	RescheduleJob(ctx context.Context, id, workerID string, runAt time.Time, lastError string) (bool, error)
+
+	// DeadLetterJob moves a job claimed by workerID to the dead state, reporting
+	// whether it still held the job
+	DeadLetterJob(ctx context.Context, id, workerID string, lastError string, failedAt time.Time) (bool, error)
+
+	// ReleaseExpiredJobs returns running jobs past their visibility timeout to the
+	// queue, or dead-letters those without attempts left, and reports how many of each
+	ReleaseExpiredJobs(ctx context.Context, now time.Time) (released int, dead int, err error)
+
+	// RetryJob makes a dead job pending again with a fresh attempt count, reporting whether it was dead
+	RetryJob(ctx context.Context, id string, runAt time.Time) (bool, error)
+
+	// DeleteSucceededJobs deletes jobs that succeeded before the cutoff and returns how many were removed
+	DeleteSucceededJobs(ctx context.Context, before time.Time) (int, error)
+}
+
+// Enqueuer adds jobs to the queue
+type Enqueuer interface {
+	// Enqueue adds a job of jobType with a JSON payload to the queue
+	Enqueue(ctx context.Context, jobType string, payload json.RawMessage, opts ...EnqueueOption) (*Job, error)
+}
+
+// JobUsecase defines business logic for enqueueing and inspecting jobs
+type JobUsecase interface {
+	Enqueuer
+
+	// ListJobs retrieves jobs, newest first
+	ListJobs(ctx context.Context, query JobQuery) (pkg.CursorPage[*Job], error)
+
+	// GetJob retrieves a job by ID
+	GetJob(ctx context.Context, id string) (*Job, error)
+
+	// RetryJob makes a dead job pending again with a fresh attempt count
+	RetryJob(ctx context.Context, id string) (*Job, error)
+}
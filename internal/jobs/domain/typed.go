@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Type binds a job type name to its payload type, so producers and handlers
+// agree on the payload without handling JSON themselves:
+//
+//	var SendWelcomeEmail = domain.NewType[WelcomeEmail]("user.send_welcome_email")
+//
+//	SendWelcomeEmail.Enqueue(ctx, queue, WelcomeEmail{UserID: id})
+//	worker.Register(SendWelcomeEmail.Handler(sendWelcomeEmail))
+type Type[T any] struct {
+	name string
+}
+
+// NewType returns the job type called name with payloads of type T
+func NewType[T any](name string) Type[T] {
+	return Type[T]{name: name}
+}
+
+// Name returns the job type name
+func (t Type[T]) Name() string {
+	return t.name
+}
+
+// Enqueue marshals payload and adds a job of this type to the queue
+func (t Type[T]) Enqueue(ctx context.Context, queue Enqueuer, payload T, opts ...EnqueueOption) (*Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s payload: %w", t.name, err)
+	}
+	return queue.Enqueue(ctx, t.name, data, opts...)
+}
+
+// Handler returns a handler decoding the payload before calling fn. A payload
+// that does not decode fails the job permanently.
+func (t Type[T]) Handler(fn func(ctx context.Context, payload T) error) Handler {
+	return Handler{
+		Type: t.name,
+		Handle: func(ctx context.Context, job *Job) error {
+			var payload T
+			if err := json.Unmarshal(job.Payload, &payload); err != nil {
+				return Permanent(fmt.Errorf("decode %s payload: %w", t.name, err))
+			}
+			return fn(ctx, payload)
+		},
+	}
+}
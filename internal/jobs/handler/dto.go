@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// JobResponse is the response body for a queued job
+type JobResponse struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload" swaggertype:"object"`
+	Priority    int             `json:"priority"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"`
+	LockedBy    string          `json:"locked_by,omitempty"`
+	LockedUntil *time.Time      `json:"locked_until,omitempty"`
+	LastError   string          `json:"last_error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+}
+
+// JobPageResponse is the response body for a cursor paginated job listing
+type JobPageResponse struct {
+	Jobs       []*JobResponse `json:"jobs"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	HasMore    bool           `json:"has_more"`
+}
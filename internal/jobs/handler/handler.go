@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/jobs/domain"
+	"github.com/zercle/template-go-echo/internal/middleware"
+	userdomain "github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// Handler handles job queue HTTP requests
+type Handler struct {
+	usecase domain.JobUsecase
+}
+
+// New creates a new job queue handler
+func New(usecase domain.JobUsecase) *Handler {
+	return &Handler{
+		usecase: usecase,
+	}
+}
+
+// RegisterRoutes registers administrator-only job queue routes
+func (h *Handler) RegisterRoutes(e *echo.Echo, jwtCfg *config.JWTConfig) {
+	group := e.Group("/api/v1/admin/jobs", middleware.JWTAuth(jwtCfg), middleware.RequireRole(userdomain.RoleAdmin))
+
+	group.GET("", h.ListJobs)
+	group.GET("/:id", h.GetJob)
+	group.POST("/:id/retry", h.RetryJob)
+}
+
+// ListJobs retrieves queued jobs
+// @Summary List background jobs
+// @Description Retrieve background jobs newest first, optionally filtered by type and status
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param type query string false "Filter by job type"
+// @Param status query string false "Filter by status: pending, running, succeeded or dead"
+// @Param limit query int false "Page limit (default: 20, max: 100)"
+// @Param cursor query string false "Opaque cursor from a previous page"
+// @Success 200 {object} pkg.JSendResponse{data=JobPageResponse}
+// @Failure 400 {object} pkg.JSendResponse
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 500 {object} pkg.JSendResponse
+// @Router /api/v1/admin/jobs [get]
+func (h *Handler) ListJobs(c echo.Context) error {
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	query := domain.JobQuery{
+		Type:   c.QueryParam("type"),
+		Status: c.QueryParam("status"),
+		Cursor: c.QueryParam("cursor"),
+		Limit:  limit,
+	}
+
+	page, err := h.usecase.ListJobs(c.Request().Context(), query)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	resp := &JobPageResponse{
+		Jobs:       make([]*JobResponse, len(page.Items)),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+	for i, job := range page.Items {
+		resp.Jobs[i] = toJobResponse(job)
+	}
+
+	return pkg.Success(c, http.StatusOK, resp)
+}
+
+// GetJob retrieves a queued job
+// @Summary Get background job
+// @Description Retrieve a background job with its payload, attempts and last error
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job ID"
+// @Success 200 {object} pkg.JSendResponse{data=JobResponse}
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 404 {object} pkg.JSendResponse
+// @Failure 500 {object} pkg.JSendResponse
+// @Router /api/v1/admin/jobs/{id} [get]
+func (h *Handler) GetJob(c echo.Context) error {
+	job, err := h.usecase.GetJob(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return pkg.Success(c, http.StatusOK, toJobResponse(job))
+}
+
+// RetryJob queues a dead job again
+// @Summary Retry background job
+// @Description Queue a dead job to run again immediately with a fresh attempt count
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job ID"
+// @Success 202 {object} pkg.JSendResponse{data=JobResponse}
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 404 {object} pkg.JSendResponse
+// @Failure 409 {object} pkg.JSendResponse
+// @Failure 500 {object} pkg.JSendResponse
+// @Router /api/v1/admin/jobs/{id}/retry [post]
+func (h *Handler) RetryJob(c echo.Context) error {
+	job, err := h.usecase.RetryJob(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return pkg.Success(c, http.StatusAccepted, toJobResponse(job))
+}
+
+// errorResponse maps job queue errors to responses; internal errors are left
+// to the Echo error handler, which never exposes their details
+func errorResponse(c echo.Context, err error) error {
+	domainErr, ok := err.(*pkg.DomainError)
+	if !ok || domainErr.Code == pkg.ErrCodeInternalError {
+		return err
+	}
+
+	status := http.StatusBadRequest
+	switch domainErr.Code {
+	case domain.ErrCodeJobNotFound:
+		status = http.StatusNotFound
+	case domain.ErrCodeJobNotRetryable:
+		status = http.StatusConflict
+	}
+	return pkg.Error(c, status, domainErr.Message, domainErr.Code)
+}
+
+// toJobResponse converts a domain job to its response representation
+func toJobResponse(job *domain.Job) *JobResponse {
+	return &JobResponse{
+		ID:          job.ID,
+		Type:        job.Type,
+		Payload:     job.Payload,
+		Priority:    job.Priority,
+		Status:      job.Status,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       job.RunAt,
+		LockedBy:    job.LockedBy,
+		LockedUntil: job.LockedUntil,
+		LastError:   job.LastError,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+		CompletedAt: job.CompletedAt,
+	}
+}
@@ -0,0 +1,340 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+	"github.com/zercle/template-go-echo/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-echo/internal/jobs/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// jobColumns lists job columns in sqlc.Jobs scan order
+const jobColumns = "id, job_type, payload, priority, status, attempts, max_attempts, run_at, locked_by, locked_until, last_error, created_at, updated_at, completed_at"
+
+// JobRepository implements domain.JobRepository using sqlc generated code
+type JobRepository struct {
+	q  sqlc.Querier
+	db sqlc.DBTX
+	tx *database.TxManager
+}
+
+// New creates a new job repository with sqlc querier. The db connection backs
+// filtered listings and claims, which need FOR UPDATE SKIP LOCKED and
+// therefore MariaDB 10.6, MySQL 8.0 or PostgreSQL; on SQLite claims rely on
+// the database write lock instead. Jobs enqueued with a context carrying a
+// transaction from database.TxManager are only queued if it commits.
+func New(q sqlc.Querier, db sqlc.DBTX) *JobRepository {
+	return &JobRepository{q: q, db: db, tx: database.NewTxManager(db)}
+}
+
+// queries returns the querier, bound to the transaction carried by ctx if any
+func (r *JobRepository) queries(ctx context.Context) sqlc.Querier {
+	return database.Queries(ctx, r.q)
+}
+
+// conn returns the connection for dynamic queries, the transaction carried by ctx if any
+func (r *JobRepository) conn(ctx context.Context) sqlc.DBTX {
+	return database.Conn(ctx, r.db)
+}
+
+// CreateJob adds a pending job to the queue
+func (r *JobRepository) CreateJob(ctx context.Context, job *domain.Job) error {
+	err := r.queries(ctx).CreateJob(ctx, sqlc.CreateJobParams{
+		ID:          job.ID,
+		JobType:     job.Type,
+		Payload:     job.Payload,
+		Priority:    int32(job.Priority),
+		MaxAttempts: uint32(job.MaxAttempts),
+		RunAt:       job.RunAt,
+		CreatedAt:   job.CreatedAt,
+	})
+	if err != nil {
+		slog.Error("failed to create job", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// GetJob retrieves a job by ID
+func (r *JobRepository) GetJob(ctx context.Context, id string) (*domain.Job, error) {
+	row, err := r.queries(ctx).GetJob(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		slog.Error("failed to get job", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return sqlcJobToDomain(&row), nil
+}
+
+// ListJobs retrieves up to limit jobs newest first, optionally filtered by type
+// and status and starting after the given keyset position
+func (r *JobRepository) ListJobs(ctx context.Context, jobType, status string, after *domain.JobCursor, limit int) ([]*domain.Job, error) {
+	conditions := []string{"1 = 1"}
+	var args []interface{}
+
+	if jobType != "" {
+		conditions = append(conditions, "job_type = ?")
+		args = append(args, jobType)
+	}
+	if status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, status)
+	}
+	if after != nil {
+		createdAt, err := time.Parse(time.RFC3339Nano, after.CreatedAt)
+		if err != nil {
+			return nil, pkg.ErrInvalidCursor
+		}
+		conditions = append(conditions, "(created_at < ? OR (created_at = ? AND id < ?))")
+		args = append(args, createdAt, createdAt, after.ID)
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf("-- name: ListJobsPage :many\nSELECT %s FROM jobs WHERE %s ORDER BY created_at DESC, id DESC LIMIT ?",
+		jobColumns, strings.Join(conditions, " AND "))
+
+	jobs, err := queryJobs(ctx, r.conn(ctx), query, args...)
+	if err != nil {
+		slog.Error("failed to list jobs", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// ClaimJobs locks up to limit pending jobs due at now, highest priority first,
+// for workerID. Only the types in timeouts are claimed, each locked until now
+// plus its visibility timeout. Jobs locked by concurrent claims are skipped.
+func (r *JobRepository) ClaimJobs(ctx context.Context, workerID string, timeouts map[string]time.Duration, now time.Time, limit int) ([]*domain.Job, error) {
+	if len(timeouts) == 0 || limit < 1 {
+		return nil, nil
+	}
+
+	types := make([]string, 0, len(timeouts))
+	for jobType := range timeouts {
+		types = append(types, jobType)
+	}
+	sort.Strings(types)
+
+	args := []interface{}{now}
+	for _, jobType := range types {
+		args = append(args, jobType)
+	}
+	args = append(args, limit)
+
+	// SKIP LOCKED lets concurrent workers claim disjoint jobs instead of
+	// queueing behind each other's row locks
+	query := fmt.Sprintf(
+		"-- name: ClaimJobs :many\nSELECT %s FROM jobs WHERE status = 'pending' AND run_at <= ? AND job_type IN (%s) ORDER BY priority DESC, run_at, id LIMIT ?%s",
+		jobColumns, strings.TrimSuffix(strings.Repeat("?, ", len(types)), ", "), database.DialectOf(r.db).ForUpdateSkipLocked())
+
+	var jobs []*domain.Job
+	err := r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		jobs, err = queryJobs(ctx, r.conn(ctx), query, args...)
+		if err != nil {
+			slog.Error("failed to select jobs to claim", slog.String("error", err.Error()))
+			return err
+		}
+
+		q := r.queries(ctx)
+		for _, job := range jobs {
+			lockedUntil := now.Add(timeouts[job.Type])
+			err := q.ClaimJob(ctx, sqlc.ClaimJobParams{
+				LockedBy:    sql.NullString{String: workerID, Valid: true},
+				LockedUntil: sql.NullTime{Time: lockedUntil, Valid: true},
+				ID:          job.ID,
+			})
+			if err != nil {
+				slog.Error("failed to claim job", slog.String("error", err.Error()))
+				return err
+			}
+			job.Status = domain.StatusRunning
+			job.Attempts++
+			job.LockedBy = workerID
+			job.LockedUntil = &lockedUntil
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// CompleteJob marks a job claimed by workerID as succeeded, reporting whether it still held the job
+func (r *JobRepository) CompleteJob(ctx context.Context, id, workerID string, completedAt time.Time) (bool, error) {
+	updated, err := r.queries(ctx).CompleteJob(ctx, sqlc.CompleteJobParams{
+		CompletedAt: sql.NullTime{Time: completedAt, Valid: true},
+		ID:          id,
+		LockedBy:    sql.NullString{String: workerID, Valid: true},
+	})
+	if err != nil {
+		slog.Error("failed to complete job", slog.String("error", err.Error()))
+		return false, err
+	}
+
+	return updated > 0, nil
+}
+
+// RescheduleJob returns a job claimed by workerID to the queue to run again at
+// runAt, reporting whether it still held the job
+func (r *JobRepository) RescheduleJob(ctx context.Context, id, workerID string, runAt time.Time, lastError string) (bool, error) {
+	updated, err := r.queries(ctx).RescheduleJob(ctx, sqlc.RescheduleJobParams{
+		RunAt:     runAt,
+		LastError: truncatedNullString(lastError, domain.MaxErrorLength),
+		ID:        id,
+		LockedBy:  sql.NullString{String: workerID, Valid: true},
+	})
+	if err != nil {
+		slog.Error("failed to reschedule job", slog.String("error", err.Error()))
+		return false, err
+	}
+
+	return updated > 0, nil
+}
+
+// DeadLetterJob moves a job claimed by workerID to the dead state, reporting
+// whether it still held the job
+func (r *JobRepository) DeadLetterJob(ctx context.Context, id, workerID string, lastError string, failedAt time.Time) (bool, error) {
+	updated, err := r.queries(ctx).DeadLetterJob(ctx, sqlc.DeadLetterJobParams{
+		CompletedAt: sql.NullTime{Time: failedAt, Valid: true},
+		LastError:   truncatedNullString(lastError, domain.MaxErrorLength),
+		ID:          id,
+		LockedBy:    sql.NullString{String: workerID, Valid: true},
+	})
+	if err != nil {
+		slog.Error("failed to dead-letter job", slog.String("error", err.Error()))
+		return false, err
+	}
+
+	return updated > 0, nil
+}
+
+// ReleaseExpiredJobs returns running jobs past their visibility timeout to the
+// queue, or dead-letters those without attempts left, and reports how many of each
+func (r *JobRepository) ReleaseExpiredJobs(ctx context.Context, now time.Time) (int, int, error) {
+	expiredBefore := sql.NullTime{Time: now, Valid: true}
+
+	dead, err := r.queries(ctx).DeadLetterExpiredJobs(ctx, sqlc.DeadLetterExpiredJobsParams{
+		CompletedAt: expiredBefore,
+		LockedUntil: expiredBefore,
+	})
+	if err != nil {
+		slog.Error("failed to dead-letter expired jobs", slog.String("error", err.Error()))
+		return 0, 0, err
+	}
+
+	released, err := r.queries(ctx).ReleaseExpiredJobs(ctx, expiredBefore)
+	if err != nil {
+		slog.Error("failed to release expired jobs", slog.String("error", err.Error()))
+		return 0, int(dead), err
+	}
+
+	return int(released), int(dead), nil
+}
+
+// RetryJob makes a dead job pending again with a fresh attempt count, reporting whether it was dead
+func (r *JobRepository) RetryJob(ctx context.Context, id string, runAt time.Time) (bool, error) {
+	updated, err := r.queries(ctx).RetryJob(ctx, sqlc.RetryJobParams{RunAt: runAt, ID: id})
+	if err != nil {
+		slog.Error("failed to retry job", slog.String("error", err.Error()))
+		return false, err
+	}
+
+	return updated > 0, nil
+}
+
+// DeleteSucceededJobs deletes jobs that succeeded before the cutoff and returns how many were removed
+func (r *JobRepository) DeleteSucceededJobs(ctx context.Context, before time.Time) (int, error) {
+	deleted, err := r.queries(ctx).DeleteSucceededJobs(ctx, sql.NullTime{Time: before, Valid: true})
+	if err != nil {
+		slog.Error("failed to delete succeeded jobs", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
+
+// queryJobs runs a query selecting jobColumns and converts the rows
+func queryJobs(ctx context.Context, db sqlc.DBTX, query string, args ...interface{}) ([]*domain.Job, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []*domain.Job
+	for rows.Next() {
+		var i sqlc.Jobs
+		if err := rows.Scan(
+			&i.ID,
+			&i.JobType,
+			&i.Payload,
+			&i.Priority,
+			&i.Status,
+			&i.Attempts,
+			&i.MaxAttempts,
+			&i.RunAt,
+			&i.LockedBy,
+			&i.LockedUntil,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, sqlcJobToDomain(&i))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// truncatedNullString converts s to a nullable column value of at most limit bytes
+func truncatedNullString(s string, limit int) sql.NullString {
+	if len(s) > limit {
+		s = s[:limit]
+	}
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// sqlcJobToDomain converts a sqlc job to a domain job
+func sqlcJobToDomain(r *sqlc.Jobs) *domain.Job {
+	job := &domain.Job{
+		ID:          r.ID,
+		Type:        r.JobType,
+		Payload:     r.Payload,
+		Priority:    int(r.Priority),
+		Status:      r.Status,
+		Attempts:    int(r.Attempts),
+		MaxAttempts: int(r.MaxAttempts),
+		RunAt:       r.RunAt,
+		LockedBy:    r.LockedBy.String,
+		LastError:   r.LastError.String,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+	if r.LockedUntil.Valid {
+		job.LockedUntil = &r.LockedUntil.Time
+	}
+	if r.CompletedAt.Valid {
+		job.CompletedAt = &r.CompletedAt.Time
+	}
+	return job
+}
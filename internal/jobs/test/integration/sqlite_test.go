@@ -0,0 +1,68 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/infrastructure/test/sqlitetest"
+	"github.com/zercle/template-go-echo/internal/jobs/domain"
+	"github.com/zercle/template-go-echo/internal/jobs/repository"
+	"github.com/zercle/template-go-echo/internal/jobs/usecase"
+)
+
+func TestSQLiteClaimAndCompleteJobs(t *testing.T) {
+	db := sqlitetest.Open(t)
+	repo := repository.New(db.Querier(), db.DBTX())
+	queue := usecase.New(repo)
+	ctx := context.Background()
+
+	low, err := queue.Enqueue(ctx, "email.send", []byte(`{"to":"a@example.com"}`))
+	if err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	high, err := queue.Enqueue(ctx, "email.send", []byte(`{"to":"b@example.com"}`), domain.WithPriority(10))
+	if err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	if _, err := queue.Enqueue(ctx, "report.build", nil); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	if _, err := queue.Enqueue(ctx, "email.send", nil, domain.WithDelay(time.Hour)); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	now := time.Now()
+	claimed, err := repo.ClaimJobs(ctx, "worker-1", map[string]time.Duration{"email.send": time.Minute}, now, 10)
+	if err != nil {
+		t.Fatalf("ClaimJobs failed: %v", err)
+	}
+	if len(claimed) != 2 || claimed[0].ID != high.ID || claimed[1].ID != low.ID {
+		t.Fatalf("expected the due email jobs by priority, got %+v", claimed)
+	}
+
+	// Claimed jobs are no longer pending
+	again, err := repo.ClaimJobs(ctx, "worker-2", map[string]time.Duration{"email.send": time.Minute}, now, 10)
+	if err != nil {
+		t.Fatalf("ClaimJobs failed: %v", err)
+	}
+	if len(again) != 0 {
+		t.Fatalf("expected no jobs left to claim, got %d", len(again))
+	}
+
+	done, err := repo.CompleteJob(ctx, high.ID, "worker-1", time.Now())
+	if err != nil || !done {
+		t.Fatalf("expected the job completed, got %v %v", done, err)
+	}
+
+	job, err := queue.GetJob(ctx, high.ID)
+	if err != nil {
+		t.Fatalf("GetJob failed: %v", err)
+	}
+	if job.Status != domain.StatusSucceeded || job.Attempts != 1 || job.CompletedAt == nil {
+		t.Errorf("unexpected completed job %+v", job)
+	}
+	if string(job.Payload) != `{"to":"b@example.com"}` {
+		t.Errorf("unexpected payload %s", job.Payload)
+	}
+}
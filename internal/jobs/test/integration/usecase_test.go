@@ -0,0 +1,147 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/jobs/domain"
+	"github.com/zercle/template-go-echo/internal/jobs/test/mocks"
+	"github.com/zercle/template-go-echo/internal/jobs/usecase"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+func TestEnqueueDefaults(t *testing.T) {
+	queue := usecase.New(mocks.NewMockRepository())
+
+	before := time.Now()
+	job, err := queue.Enqueue(context.Background(), "email.send", nil)
+	if err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	if job.Status != domain.StatusPending || job.MaxAttempts != domain.DefaultMaxAttempts || job.Priority != 0 {
+		t.Errorf("unexpected defaults %+v", job)
+	}
+	if string(job.Payload) != "null" || job.RunAt.Before(before) {
+		t.Errorf("expected null payload due now, got %s at %v", job.Payload, job.RunAt)
+	}
+}
+
+func TestEnqueueValidation(t *testing.T) {
+	queue := usecase.New(mocks.NewMockRepository())
+	ctx := context.Background()
+
+	if _, err := queue.Enqueue(ctx, "", []byte(`{}`)); err != domain.ErrInvalidJobType {
+		t.Errorf("expected invalid job type, got %v", err)
+	}
+	if _, err := queue.Enqueue(ctx, "email.send", []byte(`{`)); err != domain.ErrInvalidPayload {
+		t.Errorf("expected invalid payload, got %v", err)
+	}
+	if _, err := queue.Enqueue(ctx, "email.send", []byte(`{}`), domain.WithMaxAttempts(0)); err != domain.ErrInvalidMaxAttempts {
+		t.Errorf("expected invalid max attempts, got %v", err)
+	}
+}
+
+func TestListJobs(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	queue := usecase.New(repo)
+	ctx := context.Background()
+
+	for range 3 {
+		enqueue(t, queue, "image")
+	}
+	if _, err := queue.Enqueue(ctx, "email.send", []byte(`{}`)); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	page, err := queue.ListJobs(ctx, domain.JobQuery{Type: generateThumbnail.Name(), Limit: 2})
+	if err != nil {
+		t.Fatalf("ListJobs failed: %v", err)
+	}
+	if len(page.Items) != 2 || !page.HasMore || page.NextCursor == "" {
+		t.Fatalf("expected first page of 2 with more, got %d items", len(page.Items))
+	}
+
+	next, err := queue.ListJobs(ctx, domain.JobQuery{Type: generateThumbnail.Name(), Limit: 2, Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("ListJobs failed: %v", err)
+	}
+	if len(next.Items) != 1 || next.HasMore {
+		t.Errorf("expected last page of 1, got %d items", len(next.Items))
+	}
+
+	dead, err := queue.ListJobs(ctx, domain.JobQuery{Status: domain.StatusDead})
+	if err != nil || len(dead.Items) != 0 {
+		t.Errorf("expected no dead jobs, got %d, %v", len(dead.Items), err)
+	}
+
+	if _, err := queue.ListJobs(ctx, domain.JobQuery{Status: "failed"}); err != domain.ErrInvalidJobStatus {
+		t.Errorf("expected invalid status error, got %v", err)
+	}
+	if _, err := queue.ListJobs(ctx, domain.JobQuery{Cursor: "not-a-cursor"}); err != pkg.ErrInvalidCursor {
+		t.Errorf("expected invalid cursor error, got %v", err)
+	}
+}
+
+func TestGetJobNotFound(t *testing.T) {
+	queue := usecase.New(mocks.NewMockRepository())
+	if _, err := queue.GetJob(context.Background(), "missing"); err != domain.ErrJobNotFound {
+		t.Errorf("expected job not found, got %v", err)
+	}
+}
+
+func TestRetryJob(t *testing.T) {
+	repo, queue, worker := setupWorker(t, generateThumbnail.Handler(func(ctx context.Context, payload thumbnailPayload) error {
+		return domain.Permanent(errors.New("corrupt image"))
+	}))
+	ctx := context.Background()
+	job := enqueue(t, queue, "image-1")
+
+	if _, err := queue.RetryJob(ctx, job.ID); err != domain.ErrJobNotRetryable {
+		t.Errorf("expected pending job not to be retryable, got %v", err)
+	}
+	if _, err := queue.RetryJob(ctx, "missing"); err != domain.ErrJobNotFound {
+		t.Errorf("expected job not found, got %v", err)
+	}
+
+	processOnce(t, worker, 1)
+
+	retried, err := queue.RetryJob(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("RetryJob failed: %v", err)
+	}
+	if retried.Status != domain.StatusPending || retried.Attempts != 0 || retried.CompletedAt != nil {
+		t.Errorf("expected dead job to be pending with fresh attempts, got %+v", retried)
+	}
+
+	// The retried job runs again
+	processOnce(t, worker, 1)
+	if stored := getJob(t, repo, job.ID); stored.Attempts != 1 {
+		t.Errorf("expected retried job to be attempted again, got %d attempts", stored.Attempts)
+	}
+}
+
+func TestCleanupJobDeletesOldSucceededJobs(t *testing.T) {
+	repo, queue, worker := setupWorker(t, generateThumbnail.Handler(func(ctx context.Context, payload thumbnailPayload) error {
+		return nil
+	}))
+	ctx := context.Background()
+	done := enqueue(t, queue, "image-1")
+	processOnce(t, worker, 1)
+	pending := enqueue(t, queue, "image-2", domain.WithDelay(time.Hour))
+
+	// Nothing is old enough to remove yet
+	if err := usecase.CleanupJob(repo, time.Hour).Run(ctx); err != nil {
+		t.Fatalf("cleanup failed: %v", err)
+	}
+	getJob(t, repo, done.ID)
+
+	if err := usecase.CleanupJob(repo, -time.Minute).Run(ctx); err != nil {
+		t.Fatalf("cleanup failed: %v", err)
+	}
+	if job, _ := repo.GetJob(ctx, done.ID); job != nil {
+		t.Error("expected succeeded job to be deleted")
+	}
+	getJob(t, repo, pending.ID)
+}
@@ -0,0 +1,304 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/jobs/domain"
+	"github.com/zercle/template-go-echo/internal/jobs/test/mocks"
+	"github.com/zercle/template-go-echo/internal/jobs/usecase"
+)
+
+type thumbnailPayload struct {
+	ImageID string `json:"image_id"`
+}
+
+var generateThumbnail = domain.NewType[thumbnailPayload]("image.thumbnail")
+
+func setupWorker(t *testing.T, handlers ...domain.Handler) (*mocks.MockJobRepository, *usecase.JobUsecase, *usecase.Worker) {
+	t.Helper()
+	repo := mocks.NewMockRepository()
+	worker := usecase.NewWorker(repo, usecase.WithWorkerID("worker-1"), usecase.WithConcurrency(1))
+	if err := worker.Register(handlers...); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	return repo, usecase.New(repo), worker
+}
+
+func enqueue(t *testing.T, queue domain.Enqueuer, imageID string, opts ...domain.EnqueueOption) *domain.Job {
+	t.Helper()
+	job, err := generateThumbnail.Enqueue(context.Background(), queue, thumbnailPayload{ImageID: imageID}, opts...)
+	if err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	return job
+}
+
+func getJob(t *testing.T, repo *mocks.MockJobRepository, id string) *domain.Job {
+	t.Helper()
+	job, err := repo.GetJob(context.Background(), id)
+	if err != nil || job == nil {
+		t.Fatalf("GetJob failed: %v", err)
+	}
+	return job
+}
+
+func processOnce(t *testing.T, worker *usecase.Worker, expected int) {
+	t.Helper()
+	processed, err := worker.ProcessOnce(context.Background())
+	if err != nil {
+		t.Fatalf("ProcessOnce failed: %v", err)
+	}
+	if processed != expected {
+		t.Fatalf("expected %d jobs processed, got %d", expected, processed)
+	}
+}
+
+func TestWorkerRunsHighestPriorityFirst(t *testing.T) {
+	var processed []string
+	_, queue, worker := setupWorker(t, generateThumbnail.Handler(func(ctx context.Context, payload thumbnailPayload) error {
+		processed = append(processed, payload.ImageID)
+		return nil
+	}))
+
+	enqueue(t, queue, "low")
+	enqueue(t, queue, "high", domain.WithPriority(10))
+	enqueue(t, queue, "normal", domain.WithPriority(5))
+
+	for range 3 {
+		processOnce(t, worker, 1)
+	}
+	processOnce(t, worker, 0)
+
+	if strings.Join(processed, ",") != "high,normal,low" {
+		t.Errorf("expected jobs by priority, got %v", processed)
+	}
+}
+
+func TestWorkerMarksJobSucceeded(t *testing.T) {
+	repo, queue, worker := setupWorker(t, generateThumbnail.Handler(func(ctx context.Context, payload thumbnailPayload) error {
+		return nil
+	}))
+	job := enqueue(t, queue, "image-1")
+
+	processOnce(t, worker, 1)
+
+	stored := getJob(t, repo, job.ID)
+	if stored.Status != domain.StatusSucceeded || stored.Attempts != 1 || stored.CompletedAt == nil {
+		t.Errorf("expected succeeded job after one attempt, got %+v", stored)
+	}
+	if stored.LockedBy != "" || stored.LockedUntil != nil {
+		t.Error("expected lock to be released")
+	}
+}
+
+func TestWorkerWaitsForDelayedJobs(t *testing.T) {
+	repo, queue, worker := setupWorker(t, generateThumbnail.Handler(func(ctx context.Context, payload thumbnailPayload) error {
+		return nil
+	}))
+	job := enqueue(t, queue, "image-1", domain.WithDelay(time.Hour))
+
+	processOnce(t, worker, 0)
+
+	repo.SetRunAt(job.ID, time.Now())
+	processOnce(t, worker, 1)
+}
+
+func TestWorkerRetriesWithBackoffThenDeadLetters(t *testing.T) {
+	repo, queue, worker := setupWorker(t, generateThumbnail.Handler(func(ctx context.Context, payload thumbnailPayload) error {
+		return errors.New("storage unavailable")
+	}))
+	job := enqueue(t, queue, "image-1", domain.WithMaxAttempts(2))
+
+	before := time.Now()
+	processOnce(t, worker, 1)
+
+	stored := getJob(t, repo, job.ID)
+	if stored.Status != domain.StatusPending || stored.Attempts != 1 {
+		t.Fatalf("expected pending job after first failure, got %+v", stored)
+	}
+	if stored.LastError != "storage unavailable" {
+		t.Errorf("expected last error to be recorded, got %q", stored.LastError)
+	}
+	if stored.RunAt.Before(before.Add(domain.RetryBackoff(1))) {
+		t.Errorf("expected retry to be delayed by backoff, got %v", stored.RunAt)
+	}
+
+	// The retry is not due yet
+	processOnce(t, worker, 0)
+
+	repo.SetRunAt(job.ID, time.Now())
+	processOnce(t, worker, 1)
+
+	stored = getJob(t, repo, job.ID)
+	if stored.Status != domain.StatusDead || stored.Attempts != 2 || stored.CompletedAt == nil {
+		t.Errorf("expected dead job after max attempts, got %+v", stored)
+	}
+}
+
+func TestWorkerDeadLettersPermanentFailures(t *testing.T) {
+	repo, queue, worker := setupWorker(t, generateThumbnail.Handler(func(ctx context.Context, payload thumbnailPayload) error {
+		return domain.Permanent(errors.New("image deleted"))
+	}))
+	job := enqueue(t, queue, "image-1")
+
+	processOnce(t, worker, 1)
+
+	stored := getJob(t, repo, job.ID)
+	if stored.Status != domain.StatusDead || stored.Attempts != 1 {
+		t.Errorf("expected permanent failure to dead-letter on first attempt, got %+v", stored)
+	}
+}
+
+func TestWorkerRecoversPanics(t *testing.T) {
+	repo, queue, worker := setupWorker(t, generateThumbnail.Handler(func(ctx context.Context, payload thumbnailPayload) error {
+		panic("nil image")
+	}))
+	job := enqueue(t, queue, "image-1")
+
+	processOnce(t, worker, 1)
+
+	stored := getJob(t, repo, job.ID)
+	if stored.Status != domain.StatusPending || !strings.Contains(stored.LastError, "panicked") {
+		t.Errorf("expected panic to be recorded as a failed attempt, got %+v", stored)
+	}
+}
+
+func TestWorkerVisibilityTimeoutCancelsAttempt(t *testing.T) {
+	handler := generateThumbnail.Handler(func(ctx context.Context, payload thumbnailPayload) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	handler.VisibilityTimeout = 20 * time.Millisecond
+	repo, queue, worker := setupWorker(t, handler)
+	job := enqueue(t, queue, "image-1")
+
+	processOnce(t, worker, 1)
+
+	stored := getJob(t, repo, job.ID)
+	if stored.Status != domain.StatusPending || stored.LastError != context.DeadlineExceeded.Error() {
+		t.Errorf("expected timed out attempt to be retried, got %+v", stored)
+	}
+}
+
+func TestWorkerReclaimsJobsPastVisibilityTimeout(t *testing.T) {
+	var runs int
+	repo, queue, worker := setupWorker(t, generateThumbnail.Handler(func(ctx context.Context, payload thumbnailPayload) error {
+		runs++
+		return nil
+	}))
+	job := enqueue(t, queue, "image-1")
+
+	// Another worker claims the job and dies mid-attempt
+	timeouts := map[string]time.Duration{generateThumbnail.Name(): time.Minute}
+	claimed, err := repo.ClaimJobs(context.Background(), "crashed", timeouts, time.Now(), 1)
+	if err != nil || len(claimed) != 1 {
+		t.Fatalf("ClaimJobs failed: %v", err)
+	}
+	processOnce(t, worker, 0)
+
+	repo.ExpireLock(job.ID)
+	processOnce(t, worker, 1)
+
+	stored := getJob(t, repo, job.ID)
+	if runs != 1 || stored.Status != domain.StatusSucceeded || stored.Attempts != 2 {
+		t.Errorf("expected job to be reclaimed and completed, got %+v", stored)
+	}
+
+	// The crashed worker no longer holds the job
+	held, err := repo.CompleteJob(context.Background(), job.ID, "crashed", time.Now())
+	if err != nil || held {
+		t.Errorf("expected stale worker not to complete the job, got %v, %v", held, err)
+	}
+}
+
+func TestWorkerDeadLettersExpiredJobsWithoutAttempts(t *testing.T) {
+	repo, queue, worker := setupWorker(t, generateThumbnail.Handler(func(ctx context.Context, payload thumbnailPayload) error {
+		return nil
+	}))
+	job := enqueue(t, queue, "image-1", domain.WithMaxAttempts(1))
+
+	timeouts := map[string]time.Duration{generateThumbnail.Name(): time.Minute}
+	if _, err := repo.ClaimJobs(context.Background(), "crashed", timeouts, time.Now(), 1); err != nil {
+		t.Fatalf("ClaimJobs failed: %v", err)
+	}
+	repo.ExpireLock(job.ID)
+	processOnce(t, worker, 0)
+
+	stored := getJob(t, repo, job.ID)
+	if stored.Status != domain.StatusDead || stored.LastError != "visibility timeout expired" {
+		t.Errorf("expected expired job without attempts to be dead-lettered, got %+v", stored)
+	}
+}
+
+func TestWorkerOnlyClaimsRegisteredTypes(t *testing.T) {
+	repo, queue, worker := setupWorker(t, generateThumbnail.Handler(func(ctx context.Context, payload thumbnailPayload) error {
+		return nil
+	}))
+	other, err := queue.Enqueue(context.Background(), "email.send", []byte(`{}`))
+	if err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	processOnce(t, worker, 0)
+
+	if stored := getJob(t, repo, other.ID); stored.Status != domain.StatusPending {
+		t.Errorf("expected job without handler to stay pending, got %q", stored.Status)
+	}
+}
+
+func TestWorkerRegisterValidation(t *testing.T) {
+	worker := usecase.NewWorker(mocks.NewMockRepository())
+	handler := generateThumbnail.Handler(func(ctx context.Context, payload thumbnailPayload) error { return nil })
+
+	if err := worker.Register(domain.Handler{Type: "image.thumbnail"}); !errors.Is(err, domain.ErrInvalidHandler) {
+		t.Errorf("expected invalid handler error, got %v", err)
+	}
+	if err := worker.Register(handler); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := worker.Register(handler); !errors.Is(err, domain.ErrDuplicateHandler) {
+		t.Errorf("expected duplicate handler error, got %v", err)
+	}
+}
+
+func TestWorkerRunProcessesUntilCancelled(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	repo := mocks.NewMockRepository()
+	worker := usecase.NewWorker(repo, usecase.WithPollInterval(10*time.Millisecond), usecase.WithConcurrency(2))
+	if err := worker.Register(generateThumbnail.Handler(func(ctx context.Context, payload thumbnailPayload) error {
+		wg.Done()
+		return nil
+	})); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	queue := usecase.New(repo)
+	first := enqueue(t, queue, "image-1")
+	second := enqueue(t, queue, "image-2")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		worker.Run(ctx)
+		close(done)
+	}()
+
+	wg.Wait()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected Run to return after cancellation")
+	}
+
+	for _, id := range []string{first.ID, second.ID} {
+		if stored := getJob(t, repo, id); stored.Status != domain.StatusSucceeded {
+			t.Errorf("expected job %s to succeed, got %q", id, stored.Status)
+		}
+	}
+}
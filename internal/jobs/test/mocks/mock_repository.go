@@ -0,0 +1,264 @@
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/jobs/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// MockJobRepository is a simple in-memory job queue for testing
+type MockJobRepository struct {
+	mu   sync.Mutex
+	jobs map[string]*domain.Job
+}
+
+// NewMockRepository creates a new mock repository
+func NewMockRepository() *MockJobRepository {
+	return &MockJobRepository{
+		jobs: make(map[string]*domain.Job),
+	}
+}
+
+// CreateJob adds a pending job to the queue
+func (m *MockJobRepository) CreateJob(ctx context.Context, job *domain.Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copied := *job
+	copied.Status = domain.StatusPending
+	m.jobs[job.ID] = &copied
+	return nil
+}
+
+// GetJob retrieves a job by ID
+func (m *MockJobRepository) GetJob(ctx context.Context, id string) (*domain.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *job
+	return &copied, nil
+}
+
+// ListJobs retrieves up to limit jobs newest first, optionally filtered by type
+// and status and starting after the given keyset position
+func (m *MockJobRepository) ListJobs(ctx context.Context, jobType, status string, after *domain.JobCursor, limit int) ([]*domain.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var afterTime time.Time
+	if after != nil {
+		parsed, err := time.Parse(time.RFC3339Nano, after.CreatedAt)
+		if err != nil {
+			return nil, pkg.ErrInvalidCursor
+		}
+		afterTime = parsed
+	}
+
+	var jobs []*domain.Job
+	for _, job := range m.jobs {
+		if jobType != "" && job.Type != jobType {
+			continue
+		}
+		if status != "" && job.Status != status {
+			continue
+		}
+		if after != nil {
+			createdAt := job.CreatedAt.UTC()
+			if createdAt.After(afterTime) || (createdAt.Equal(afterTime) && job.ID >= after.ID) {
+				continue
+			}
+		}
+		copied := *job
+		jobs = append(jobs, &copied)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if !jobs[i].CreatedAt.Equal(jobs[j].CreatedAt) {
+			return jobs[i].CreatedAt.After(jobs[j].CreatedAt)
+		}
+		return jobs[i].ID > jobs[j].ID
+	})
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs, nil
+}
+
+// ClaimJobs locks up to limit pending jobs of the types in timeouts due at now,
+// highest priority first
+func (m *MockJobRepository) ClaimJobs(ctx context.Context, workerID string, timeouts map[string]time.Duration, now time.Time, limit int) ([]*domain.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var due []*domain.Job
+	for _, job := range m.jobs {
+		if _, ok := timeouts[job.Type]; !ok {
+			continue
+		}
+		if job.Status == domain.StatusPending && !job.RunAt.After(now) {
+			due = append(due, job)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].Priority != due[j].Priority {
+			return due[i].Priority > due[j].Priority
+		}
+		if !due[i].RunAt.Equal(due[j].RunAt) {
+			return due[i].RunAt.Before(due[j].RunAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]*domain.Job, 0, len(due))
+	for _, job := range due {
+		lockedUntil := now.Add(timeouts[job.Type])
+		job.Status = domain.StatusRunning
+		job.Attempts++
+		job.LockedBy = workerID
+		job.LockedUntil = &lockedUntil
+		copied := *job
+		claimed = append(claimed, &copied)
+	}
+	return claimed, nil
+}
+
+// CompleteJob marks a job claimed by workerID as succeeded
+func (m *MockJobRepository) CompleteJob(ctx context.Context, id, workerID string, completedAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job := m.held(id, workerID)
+	if job == nil {
+		return false, nil
+	}
+	job.Status = domain.StatusSucceeded
+	job.LastError = ""
+	job.CompletedAt = &completedAt
+	unlock(job)
+	return true, nil
+}
+
+// RescheduleJob returns a job claimed by workerID to the queue to run again at runAt
+func (m *MockJobRepository) RescheduleJob(ctx context.Context, id, workerID string, runAt time.Time, lastError string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job := m.held(id, workerID)
+	if job == nil {
+		return false, nil
+	}
+	job.Status = domain.StatusPending
+	job.RunAt = runAt
+	job.LastError = lastError
+	unlock(job)
+	return true, nil
+}
+
+// DeadLetterJob moves a job claimed by workerID to the dead state
+func (m *MockJobRepository) DeadLetterJob(ctx context.Context, id, workerID string, lastError string, failedAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job := m.held(id, workerID)
+	if job == nil {
+		return false, nil
+	}
+	job.Status = domain.StatusDead
+	job.LastError = lastError
+	job.CompletedAt = &failedAt
+	unlock(job)
+	return true, nil
+}
+
+// ReleaseExpiredJobs returns running jobs past their visibility timeout to the
+// queue, or dead-letters those without attempts left
+func (m *MockJobRepository) ReleaseExpiredJobs(ctx context.Context, now time.Time) (int, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var released, dead int
+	for _, job := range m.jobs {
+		if job.Status != domain.StatusRunning || job.LockedUntil == nil || job.LockedUntil.After(now) {
+			continue
+		}
+		job.LastError = "visibility timeout expired"
+		if job.Attempts >= job.MaxAttempts {
+			job.Status = domain.StatusDead
+			job.CompletedAt = &now
+			dead++
+		} else {
+			job.Status = domain.StatusPending
+			released++
+		}
+		unlock(job)
+	}
+	return released, dead, nil
+}
+
+// RetryJob makes a dead job pending again with a fresh attempt count
+func (m *MockJobRepository) RetryJob(ctx context.Context, id string, runAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok || job.Status != domain.StatusDead {
+		return false, nil
+	}
+	job.Status = domain.StatusPending
+	job.Attempts = 0
+	job.RunAt = runAt
+	job.LastError = ""
+	job.CompletedAt = nil
+	return true, nil
+}
+
+// DeleteSucceededJobs deletes jobs that succeeded before the cutoff
+func (m *MockJobRepository) DeleteSucceededJobs(ctx context.Context, before time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deleted int
+	for id, job := range m.jobs {
+		if job.Status == domain.StatusSucceeded && job.CompletedAt != nil && job.CompletedAt.Before(before) {
+			delete(m.jobs, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// SetRunAt changes when a pending job becomes due, for simulating the passage of time
+func (m *MockJobRepository) SetRunAt(id string, runAt time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if job, ok := m.jobs[id]; ok {
+		job.RunAt = runAt
+	}
+}
+
+// ExpireLock moves the visibility timeout of a running job into the past,
+// simulating a worker that died mid-attempt
+func (m *MockJobRepository) ExpireLock(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if job, ok := m.jobs[id]; ok && job.LockedUntil != nil {
+		expired := time.Now().Add(-time.Second)
+		job.LockedUntil = &expired
+	}
+}
+
+// held returns the job if it is running under workerID; callers must hold the mutex
+func (m *MockJobRepository) held(id, workerID string) *domain.Job {
+	job, ok := m.jobs[id]
+	if !ok || job.Status != domain.StatusRunning || job.LockedBy != workerID {
+		return nil
+	}
+	return job
+}
+
+func unlock(job *domain.Job) {
+	job.LockedBy = ""
+	job.LockedUntil = nil
+}
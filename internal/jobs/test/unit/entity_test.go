@@ -0,0 +1,107 @@
+package unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/jobs/domain"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	cases := map[int]time.Duration{
+		1:  domain.MinRetryBackoff,
+		2:  2 * domain.MinRetryBackoff,
+		3:  4 * domain.MinRetryBackoff,
+		50: domain.MaxRetryBackoff,
+	}
+	for attempts, expected := range cases {
+		if got := domain.RetryBackoff(attempts); got != expected {
+			t.Errorf("RetryBackoff(%d) = %v, expected %v", attempts, got, expected)
+		}
+	}
+}
+
+func TestPermanent(t *testing.T) {
+	if domain.Permanent(nil) != nil {
+		t.Error("expected Permanent(nil) to be nil")
+	}
+
+	cause := errors.New("bad input")
+	err := fmt.Errorf("handler: %w", domain.Permanent(cause))
+	if !domain.IsPermanent(err) {
+		t.Error("expected wrapped permanent error to be detected")
+	}
+	if !errors.Is(err, cause) {
+		t.Error("expected permanent error to unwrap to its cause")
+	}
+	if domain.IsPermanent(cause) {
+		t.Error("expected plain error not to be permanent")
+	}
+}
+
+func TestEnqueueOptions(t *testing.T) {
+	var options domain.EnqueueOptions
+	runAt := time.Now().Add(time.Hour)
+	for _, opt := range []domain.EnqueueOption{
+		domain.WithPriority(10),
+		domain.WithRunAt(runAt),
+		domain.WithMaxAttempts(3),
+	} {
+		opt(&options)
+	}
+	if options.Priority != 10 || !options.RunAt.Equal(runAt) || options.MaxAttempts != 3 {
+		t.Errorf("unexpected options %+v", options)
+	}
+
+	before := time.Now()
+	domain.WithDelay(time.Minute)(&options)
+	if options.RunAt.Before(before.Add(time.Minute)) {
+		t.Errorf("expected delay to move run time a minute ahead, got %v", options.RunAt)
+	}
+}
+
+type emailPayload struct {
+	To string `json:"to"`
+}
+
+func TestTypeHandlerDecodesPayload(t *testing.T) {
+	sendEmail := domain.NewType[emailPayload]("email.send")
+
+	var received emailPayload
+	handler := sendEmail.Handler(func(ctx context.Context, payload emailPayload) error {
+		received = payload
+		return nil
+	})
+	if handler.Type != "email.send" || sendEmail.Name() != "email.send" {
+		t.Errorf("unexpected handler type %q", handler.Type)
+	}
+
+	job := &domain.Job{Type: "email.send", Payload: json.RawMessage(`{"to":"john@example.com"}`)}
+	if err := handler.Handle(context.Background(), job); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+	if received.To != "john@example.com" {
+		t.Errorf("expected decoded payload, got %+v", received)
+	}
+
+	// A payload of the wrong shape can never succeed
+	job.Payload = json.RawMessage(`{"to":42}`)
+	if err := handler.Handle(context.Background(), job); !domain.IsPermanent(err) {
+		t.Errorf("expected permanent error for undecodable payload, got %v", err)
+	}
+}
+
+func TestIsValidJobStatus(t *testing.T) {
+	for _, status := range []string{domain.StatusPending, domain.StatusRunning, domain.StatusSucceeded, domain.StatusDead} {
+		if !domain.IsValidJobStatus(status) {
+			t.Errorf("expected %q to be valid", status)
+		}
+	}
+	if domain.IsValidJobStatus("failed") {
+		t.Error("expected unknown status to be invalid")
+	}
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zercle/template-go-echo/internal/jobs/domain"
+	schedulerdomain "github.com/zercle/template-go-echo/internal/scheduler/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// CleanupJobName is the name of the scheduled job removing succeeded jobs
+const CleanupJobName = "jobs.cleanup"
+
+// JobUsecase implements domain.JobUsecase
+type JobUsecase struct {
+	repo domain.JobRepository
+}
+
+// New creates a new job usecase
+func New(repo domain.JobRepository) *JobUsecase {
+	return &JobUsecase{repo: repo}
+}
+
+// Enqueue adds a job of jobType with a JSON payload to the queue. Without
+// options the job runs as soon as a worker is free, with priority zero and
+// DefaultMaxAttempts attempts.
+func (u *JobUsecase) Enqueue(ctx context.Context, jobType string, payload json.RawMessage, opts ...domain.EnqueueOption) (*domain.Job, error) {
+	if jobType == "" || len(jobType) > domain.MaxJobTypeLength {
+		return nil, domain.ErrInvalidJobType
+	}
+	if payload == nil {
+		payload = json.RawMessage("null")
+	}
+	if !json.Valid(payload) {
+		return nil, domain.ErrInvalidPayload
+	}
+
+	now := time.Now()
+	options := domain.EnqueueOptions{RunAt: now, MaxAttempts: domain.DefaultMaxAttempts}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.MaxAttempts < 1 {
+		return nil, domain.ErrInvalidMaxAttempts
+	}
+
+	job := &domain.Job{
+		ID:          uuid.New().String(),
+		Type:        jobType,
+		Payload:     payload,
+		Priority:    options.Priority,
+		Status:      domain.StatusPending,
+		MaxAttempts: options.MaxAttempts,
+		RunAt:       options.RunAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := u.repo.CreateJob(ctx, job); err != nil {
+		slog.Error("failed to enqueue job", slog.String("error", err.Error()))
+		return nil, pkg.ErrInternalError
+	}
+
+	return job, nil
+}
+
+// ListJobs retrieves jobs, newest first
+func (u *JobUsecase) ListJobs(ctx context.Context, query domain.JobQuery) (pkg.CursorPage[*domain.Job], error) {
+	if query.Status != "" && !domain.IsValidJobStatus(query.Status) {
+		return pkg.CursorPage[*domain.Job]{}, domain.ErrInvalidJobStatus
+	}
+
+	var after *domain.JobCursor
+	if query.Cursor != "" {
+		cursor, err := pkg.DecodeCursor[domain.JobCursor](query.Cursor)
+		if err != nil || cursor.ID == "" {
+			return pkg.CursorPage[*domain.Job]{}, pkg.ErrInvalidCursor
+		}
+		after = &cursor
+	}
+
+	// Fetch one extra row to detect whether another page exists
+	limit := pkg.ClampLimit(query.Limit, domain.DefaultPageSize, domain.MaxPageSize)
+	jobs, err := u.repo.ListJobs(ctx, query.Type, query.Status, after, limit+1)
+	if err != nil {
+		if domainErr, ok := err.(*pkg.DomainError); ok {
+			return pkg.CursorPage[*domain.Job]{}, domainErr
+		}
+		slog.Error("failed to list jobs", slog.String("error", err.Error()))
+		return pkg.CursorPage[*domain.Job]{}, pkg.ErrInternalError
+	}
+
+	return pkg.NewCursorPage(jobs, limit, func(job *domain.Job) any {
+		return domain.NewJobCursor(job)
+	}), nil
+}
+
+// GetJob retrieves a job by ID
+func (u *JobUsecase) GetJob(ctx context.Context, id string) (*domain.Job, error) {
+	job, err := u.repo.GetJob(ctx, id)
+	if err != nil {
+		slog.Error("failed to get job", slog.String("error", err.Error()))
+		return nil, pkg.ErrInternalError
+	}
+	if job == nil {
+		return nil, domain.ErrJobNotFound
+	}
+	return job, nil
+}
+
+// RetryJob makes a dead job pending again with a fresh attempt count
+func (u *JobUsecase) RetryJob(ctx context.Context, id string) (*domain.Job, error) {
+	retried, err := u.repo.RetryJob(ctx, id, time.Now())
+	if err != nil {
+		slog.Error("failed to retry job", slog.String("error", err.Error()))
+		return nil, pkg.ErrInternalError
+	}
+	if !retried {
+		// Tell a missing job apart from one that is not dead
+		if _, err := u.GetJob(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrJobNotRetryable
+	}
+
+	slog.Info("dead job queued for retry", slog.String("job_id", id))
+	return u.GetJob(ctx, id)
+}
+
+// CleanupJob returns a daily scheduled job deleting jobs that succeeded more than retention ago
+func CleanupJob(repo domain.JobRepository, retention time.Duration) schedulerdomain.Job {
+	return schedulerdomain.Job{
+		Name:     CleanupJobName,
+		Schedule: "@daily",
+		Jitter:   10 * time.Minute,
+		Timeout:  10 * time.Minute,
+		Run: func(ctx context.Context) error {
+			deleted, err := repo.DeleteSucceededJobs(ctx, time.Now().Add(-retention))
+			if err != nil {
+				return err
+			}
+			if deleted > 0 {
+				slog.Info("deleted succeeded jobs", slog.Int("count", deleted))
+			}
+			return nil
+		},
+	}
+}
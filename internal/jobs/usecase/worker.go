@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/jobs/domain"
+)
+
+// Worker claims queued jobs and runs them with their registered handlers.
+// Claims skip rows locked by other workers, so any number of workers may
+// share a database. A job whose worker dies is picked up again once its
+// visibility timeout passes.
+type Worker struct {
+	repo         domain.JobRepository
+	workerID     string
+	pollInterval time.Duration
+	concurrency  int
+
+	mu       sync.RWMutex
+	handlers map[string]domain.Handler
+}
+
+// WorkerOption configures optional Worker settings
+type WorkerOption func(*Worker)
+
+// WithPollInterval sets how often the queue is checked for due jobs
+func WithPollInterval(interval time.Duration) WorkerOption {
+	return func(w *Worker) {
+		w.pollInterval = interval
+	}
+}
+
+// WithConcurrency sets how many jobs run at the same time
+func WithConcurrency(concurrency int) WorkerOption {
+	return func(w *Worker) {
+		w.concurrency = concurrency
+	}
+}
+
+// WithWorkerID sets the name jobs are locked with; defaults to host name and process ID
+func WithWorkerID(id string) WorkerOption {
+	return func(w *Worker) {
+		w.workerID = id
+	}
+}
+
+// NewWorker creates a worker without handlers for jobs queued in repo
+func NewWorker(repo domain.JobRepository, opts ...WorkerOption) *Worker {
+	w := &Worker{
+		repo:         repo,
+		pollInterval: domain.DefaultPollInterval,
+		concurrency:  domain.DefaultConcurrency,
+		handlers:     make(map[string]domain.Handler),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	if w.workerID == "" {
+		host, _ := os.Hostname()
+		w.workerID = fmt.Sprintf("%s:%d", host, os.Getpid())
+	}
+	if w.concurrency < 1 {
+		w.concurrency = 1
+	}
+	return w
+}
+
+// Register adds handlers to the worker; only registered job types are claimed
+func (w *Worker) Register(handlers ...domain.Handler) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, handler := range handlers {
+		if handler.Type == "" || len(handler.Type) > domain.MaxJobTypeLength || handler.Handle == nil {
+			return fmt.Errorf("%w: %q", domain.ErrInvalidHandler, handler.Type)
+		}
+		if _, ok := w.handlers[handler.Type]; ok {
+			return fmt.Errorf("%w: %q", domain.ErrDuplicateHandler, handler.Type)
+		}
+		if handler.VisibilityTimeout <= 0 {
+			handler.VisibilityTimeout = domain.DefaultVisibilityTimeout
+		}
+		w.handlers[handler.Type] = handler
+	}
+	return nil
+}
+
+// Run claims and runs due jobs until ctx is cancelled, then waits for running
+// jobs to return. Handlers see the cancellation; jobs they abandon are queued
+// again straight away.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, w.concurrency)
+	slog.Info("job worker started", slog.String("worker_id", w.workerID), slog.Int("concurrency", w.concurrency))
+
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+		}
+
+		w.releaseExpired(ctx)
+
+		// Fill free slots while the queue keeps returning full claims
+		for ctx.Err() == nil {
+			free := w.concurrency - len(sem)
+			if free == 0 {
+				break
+			}
+			jobs, err := w.claim(ctx, free)
+			if err != nil {
+				break
+			}
+			for _, job := range jobs {
+				sem <- struct{}{}
+				wg.Add(1)
+				go func(job *domain.Job) {
+					defer func() {
+						<-sem
+						wg.Done()
+					}()
+					w.process(ctx, job)
+				}(job)
+			}
+			if len(jobs) < free {
+				break
+			}
+		}
+	}
+}
+
+// ProcessOnce claims up to the worker concurrency of due jobs, runs them and
+// returns how many were run
+func (w *Worker) ProcessOnce(ctx context.Context) (int, error) {
+	w.releaseExpired(ctx)
+
+	jobs, err := w.claim(ctx, w.concurrency)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		wg.Add(1)
+		go func(job *domain.Job) {
+			defer wg.Done()
+			w.process(ctx, job)
+		}(job)
+	}
+	wg.Wait()
+
+	return len(jobs), nil
+}
+
+// claim locks up to limit due jobs of the registered types
+func (w *Worker) claim(ctx context.Context, limit int) ([]*domain.Job, error) {
+	w.mu.RLock()
+	timeouts := make(map[string]time.Duration, len(w.handlers))
+	for jobType, handler := range w.handlers {
+		timeouts[jobType] = handler.VisibilityTimeout
+	}
+	w.mu.RUnlock()
+
+	jobs, err := w.repo.ClaimJobs(ctx, w.workerID, timeouts, time.Now(), limit)
+	if err != nil {
+		slog.Error("failed to claim jobs", slog.String("error", err.Error()))
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// releaseExpired hands jobs of workers that exceeded their visibility timeout to other workers
+func (w *Worker) releaseExpired(ctx context.Context) {
+	released, dead, err := w.repo.ReleaseExpiredJobs(ctx, time.Now())
+	if err != nil {
+		slog.Error("failed to release expired jobs", slog.String("error", err.Error()))
+		return
+	}
+	if released > 0 || dead > 0 {
+		slog.Warn("released jobs past their visibility timeout",
+			slog.Int("released", released),
+			slog.Int("dead", dead))
+	}
+}
+
+// process runs a claimed job and records the outcome
+func (w *Worker) process(ctx context.Context, job *domain.Job) {
+	w.mu.RLock()
+	handler := w.handlers[job.Type]
+	w.mu.RUnlock()
+
+	start := time.Now()
+	err := w.handle(ctx, handler, job)
+	// The outcome is recorded even when the worker is shutting down
+	recordCtx := context.WithoutCancel(ctx)
+	now := time.Now()
+
+	if err == nil {
+		held, err := w.repo.CompleteJob(recordCtx, job.ID, w.workerID, now)
+		if err != nil {
+			slog.Error("failed to complete job", slog.String("error", err.Error()))
+			return
+		}
+		if !held {
+			slog.Warn("job finished after its visibility timeout",
+				slog.String("job_id", job.ID),
+				slog.String("job_type", job.Type))
+		}
+		return
+	}
+
+	attrs := []any{
+		slog.String("job_id", job.ID),
+		slog.String("job_type", job.Type),
+		slog.Int("attempts", job.Attempts),
+		slog.Duration("duration", time.Since(start)),
+		slog.String("error", err.Error()),
+	}
+
+	var held bool
+	switch {
+	case domain.IsPermanent(err) || job.Attempts >= job.MaxAttempts:
+		slog.Error("job failed and was dead-lettered", attrs...)
+		held, err = w.repo.DeadLetterJob(recordCtx, job.ID, w.workerID, err.Error(), now)
+	case ctx.Err() != nil:
+		// Abandoned on shutdown; let the next worker pick it up without backoff
+		slog.Warn("job interrupted by shutdown", attrs...)
+		held, err = w.repo.RescheduleJob(recordCtx, job.ID, w.workerID, now, err.Error())
+	default:
+		slog.Warn("job attempt failed", attrs...)
+		held, err = w.repo.RescheduleJob(recordCtx, job.ID, w.workerID, now.Add(domain.RetryBackoff(job.Attempts)), err.Error())
+	}
+	if err != nil {
+		slog.Error("failed to record job failure", slog.String("error", err.Error()))
+		return
+	}
+	if !held {
+		slog.Warn("job failed after its visibility timeout",
+			slog.String("job_id", job.ID),
+			slog.String("job_type", job.Type))
+	}
+}
+
+// handle runs the handler within the visibility timeout, turning a panic into an error
+func (w *Worker) handle(ctx context.Context, handler domain.Handler, job *domain.Job) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, handler.VisibilityTimeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return handler.Handle(ctx, job)
+}
@@ -16,6 +16,7 @@ import (
 type Claims struct {
 	UserID string `json:"user_id"`
 	Email  string `json:"email"`
+	Role   string `json:"role"`
 	jwt.RegisteredClaims
 }
 
@@ -47,7 +48,7 @@ func JWTAuth(cfg *config.JWTConfig) echo.MiddlewareFunc {
 			})
 
 			if err != nil || !parsedToken.Valid {
-				slog.Warn("invalid token",
+				requestLogger(c).WarnContext(c.Request().Context(), "invalid token",
 					slog.String("error", err.Error()),
 				)
 				return pkg.Error(c, http.StatusUnauthorized, "invalid token", pkg.ErrCodeUnauthorized)
@@ -56,7 +57,9 @@ func JWTAuth(cfg *config.JWTConfig) echo.MiddlewareFunc {
 			// Store claims in context
 			c.Set("user_id", claims.UserID)
 			c.Set("email", claims.Email)
+			c.Set("role", claims.Role)
 			c.Set("claims", claims)
+			setRequestUser(c, claims.UserID)
 
 			return next(c)
 		}
@@ -95,7 +98,9 @@ func OptionalJWTAuth(cfg *config.JWTConfig) echo.MiddlewareFunc {
 				// Token is valid, store claims
 				c.Set("user_id", claims.UserID)
 				c.Set("email", claims.Email)
+				c.Set("role", claims.Role)
 				c.Set("claims", claims)
+				setRequestUser(c, claims.UserID)
 			}
 
 			return next(c)
@@ -103,6 +108,38 @@ func OptionalJWTAuth(cfg *config.JWTConfig) echo.MiddlewareFunc {
 	}
 }
 
+// setRequestUser records the authenticated user in the request context and
+// adds it to the request logger
+func setRequestUser(c echo.Context, userID string) {
+	req := c.Request()
+	info := pkg.RequestInfoFromContext(req.Context())
+	info.UserID = userID
+	ctx := pkg.WithRequestInfo(req.Context(), info)
+	ctx = pkg.WithLogger(ctx, pkg.Logger(ctx).With(slog.String("user_id", userID)))
+	c.SetRequest(req.WithContext(ctx))
+}
+
+// RequireRole restricts access to authenticated users holding one of the given roles.
+// It must run after JWTAuth.
+func RequireRole(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			role := GetRole(c)
+			for _, allowed := range roles {
+				if role == allowed {
+					return next(c)
+				}
+			}
+
+			requestLogger(c).WarnContext(c.Request().Context(), "forbidden: insufficient role",
+				slog.String("user_id", GetUserID(c)),
+				slog.String("role", role),
+			)
+			return pkg.Error(c, http.StatusForbidden, "insufficient permissions", pkg.ErrCodeForbidden)
+		}
+	}
+}
+
 // GetUserID extracts user ID from context
 func GetUserID(c echo.Context) string {
 	userID, ok := c.Get("user_id").(string)
@@ -120,3 +157,12 @@ func GetClaims(c echo.Context) *Claims {
 	}
 	return claims
 }
+
+// GetRole extracts the user role from context
+func GetRole(c echo.Context) string {
+	role, ok := c.Get("role").(string)
+	if !ok {
+		return ""
+	}
+	return role
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zercle/template-go-echo/internal/infrastructure/concurrency"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// Route classes whose requests ConcurrencyLimit caps separately
+const (
+	RouteClassRead  = "read"
+	RouteClassWrite = "write"
+)
+
+// ConcurrencyLimit caps the requests in flight per route class, reads (GET,
+// HEAD and OPTIONS) and writes, each with the limiter of its class in
+// limiters; classes without one are not limited. Requests over the limit
+// queue briefly and are shed with 503 and Retry-After once the queue is full
+// or they waited too long. Requests to routes starting with one of the
+// exempt prefixes, such as health checks and admin endpoints, are never
+// limited. It must run before Timeout, so that queued requests do not use up
+// their timeout and timed out requests lower the limit.
+func ConcurrencyLimit(limiters map[string]*concurrency.Limiter, retryAfter time.Duration, exempt ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			for _, prefix := range exempt {
+				if strings.HasPrefix(c.Path(), prefix) {
+					return next(c)
+				}
+			}
+
+			class := RouteClassWrite
+			switch c.Request().Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				class = RouteClassRead
+			}
+			limiter, ok := limiters[class]
+			if !ok {
+				return next(c)
+			}
+
+			token, err := limiter.Acquire(c.Request().Context())
+			if err != nil {
+				if !errors.Is(err, concurrency.ErrOverloaded) {
+					// The client went away while queued
+					return err
+				}
+				requestLogger(c).WarnContext(c.Request().Context(), "request shed by concurrency limit",
+					slog.String("class", class),
+					slog.String("route", c.Path()))
+				c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(max(ceilSeconds(retryAfter), 1)))
+				return pkg.Error(c, http.StatusServiceUnavailable, "server is overloaded", concurrency.ErrCodeOverloaded)
+			}
+
+			// A panicking handler releases its token as dropped, so that panics
+			// neither leak capacity nor go unnoticed by the limit
+			defer func() {
+				if r := recover(); r != nil {
+					token.Release(true)
+					panic(r)
+				}
+			}()
+			err = next(c)
+			token.Release(overloaded(c, err))
+			return err
+		}
+	}
+}
+
+// overloaded reports whether a request failed from overload: it timed out or
+// was answered with 503 or 504
+func overloaded(c echo.Context, err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	status := c.Response().Status
+	var httpErr *echo.HTTPError
+	if !c.Response().Committed && errors.As(err, &httpErr) {
+		status = httpErr.Code
+	}
+	return status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
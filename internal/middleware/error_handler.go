@@ -8,7 +8,10 @@ import (
 	"github.com/zercle/template-go-echo/pkg"
 )
 
-// ErrorHandler handles errors from Echo handlers
+// ErrorHandler handles errors from Echo handlers. Only the messages of
+// domain errors and Echo HTTP errors reach clients; any other error is
+// answered with a generic internal error and its details are only logged, so
+// that handlers can return errors of lower layers as they are.
 func ErrorHandler(err error, c echo.Context) {
 	code := http.StatusInternalServerError
 	message := "Internal server error"
@@ -16,7 +19,11 @@ func ErrorHandler(err error, c echo.Context) {
 
 	if he, ok := err.(*echo.HTTPError); ok {
 		code = he.Code
-		message = he.Message.(string)
+		if msg, ok := he.Message.(string); ok {
+			message = msg
+		} else {
+			message = http.StatusText(he.Code)
+		}
 	} else if de, ok := err.(*pkg.DomainError); ok {
 		errorCode = de.Code
 		message = de.Message
@@ -39,7 +46,7 @@ func ErrorHandler(err error, c echo.Context) {
 	}
 
 	// Log the error
-	slog.Error("HTTP request error",
+	requestLogger(c).ErrorContext(c.Request().Context(), "HTTP request error",
 		slog.String("method", c.Request().Method),
 		slog.String("path", c.Request().URL.Path),
 		slog.Int("code", code),
@@ -0,0 +1,226 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zercle/template-go-echo/internal/config"
+	idempotencydomain "github.com/zercle/template-go-echo/internal/idempotency/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// Idempotency makes retries of a request carrying an Idempotency-Key header
+// safe: the first request runs and its response is stored, and retries with
+// the same key get that response replayed with an Idempotent-Replayed header.
+//
+// Keys are scoped to the route and the authenticated user, so on protected
+// routes the middleware must run after JWTAuth. A key held by a request that
+// is still running gets 409, and a key reused with a different method, path
+// or body gets 422. Handler errors, 5xx responses and responses larger than
+// MaxResponseBytes are not stored, so those requests may be retried.
+//
+// Keys and request fingerprints are HMACs keyed by cfg.Secret, so that the
+// bodies of stored requests, which may hold passwords, cannot be recovered by
+// hashing guesses. Response bodies are encrypted with a key derived from the
+// same secret, so that stored tokens cannot be read from the store.
+func Idempotency(store idempotencydomain.Store, cfg *config.IdempotencyConfig) echo.MiddlewareFunc {
+	secret := []byte(cfg.Secret)
+	aead := responseCipher(secret)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			key := req.Header.Get(idempotencydomain.HeaderIdempotencyKey)
+			if key == "" {
+				return next(c)
+			}
+			if len(key) > idempotencydomain.MaxKeyLength {
+				return idempotencyError(c, idempotencydomain.ErrInvalidKey)
+			}
+
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return pkg.Fail(c, http.StatusBadRequest, nil, "invalid request body")
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			userID, _ := c.Get("user_id").(string)
+			storageKey := hashParts(secret, userID, req.Method, c.Path(), key)
+			requestHash := hashParts(secret, req.Method, req.URL.RequestURI(), string(body))
+
+			now := time.Now()
+			record, err := store.Reserve(req.Context(), storageKey, requestHash, now, now.Add(cfg.LockTimeout), now.Add(cfg.TTL))
+			if err != nil {
+				if domainErr, ok := err.(*pkg.DomainError); ok {
+					return idempotencyError(c, domainErr)
+				}
+				requestLogger(c).ErrorContext(c.Request().Context(), "failed to reserve idempotency key", slog.String("error", err.Error()))
+				return pkg.Error(c, http.StatusInternalServerError, "internal server error", pkg.ErrCodeInternalError)
+			}
+			if record != nil {
+				return replay(c, aead, record, storageKey, requestHash)
+			}
+
+			res := c.Response()
+			recorder := &idempotencyRecorder{ResponseWriter: res.Writer}
+			res.Writer = recorder
+			err = next(c)
+			res.Writer = recorder.ResponseWriter
+
+			// Record the outcome even if the client went away
+			ctx := context.WithoutCancel(req.Context())
+			if err != nil || !res.Committed || res.Status >= http.StatusInternalServerError || recorder.overflow {
+				if releaseErr := store.Release(ctx, storageKey); releaseErr != nil {
+					requestLogger(c).ErrorContext(c.Request().Context(), "failed to release idempotency key", slog.String("error", releaseErr.Error()))
+				}
+				return err
+			}
+
+			response := &idempotencydomain.Response{
+				StatusCode: res.Status,
+				Header:     http.Header{},
+				Body:       sealBody(aead, storageKey, recorder.body.Bytes()),
+			}
+			for _, name := range idempotencydomain.ReplayedHeaders {
+				if values := res.Header().Values(name); len(values) > 0 {
+					response.Header[name] = values
+				}
+			}
+			if err := store.Complete(ctx, storageKey, response); err != nil {
+				requestLogger(c).ErrorContext(c.Request().Context(), "failed to store idempotent response", slog.String("error", err.Error()))
+				if releaseErr := store.Release(ctx, storageKey); releaseErr != nil {
+					requestLogger(c).ErrorContext(c.Request().Context(), "failed to release idempotency key", slog.String("error", releaseErr.Error()))
+				}
+			}
+			return nil
+		}
+	}
+}
+
+// replay answers a request whose key is held by record
+func replay(c echo.Context, aead cipher.AEAD, record *idempotencydomain.Record, storageKey, requestHash string) error {
+	if record.RequestHash != requestHash {
+		return idempotencyError(c, idempotencydomain.ErrKeyReused)
+	}
+	if !record.IsCompleted() {
+		return idempotencyError(c, idempotencydomain.ErrRequestInProgress)
+	}
+
+	body, err := openBody(aead, storageKey, record.Response.Body)
+	if err != nil {
+		requestLogger(c).ErrorContext(c.Request().Context(), "failed to decrypt idempotent response", slog.String("error", err.Error()))
+		return pkg.Error(c, http.StatusInternalServerError, "internal server error", pkg.ErrCodeInternalError)
+	}
+
+	res := c.Response()
+	for name, values := range record.Response.Header {
+		res.Header()[name] = values
+	}
+	res.Header().Set(idempotencydomain.HeaderIdempotentReplayed, "true")
+	res.WriteHeader(record.Response.StatusCode)
+	_, err = res.Write(body)
+	return err
+}
+
+// idempotencyError writes an idempotency domain error with its HTTP status
+func idempotencyError(c echo.Context, err *pkg.DomainError) error {
+	code := http.StatusBadRequest
+	switch err.Code {
+	case idempotencydomain.ErrCodeRequestInProgress:
+		code = http.StatusConflict
+	case idempotencydomain.ErrCodeKeyReused:
+		code = http.StatusUnprocessableEntity
+	}
+	return pkg.Error(c, code, err.Message, err.Code)
+}
+
+// hashParts returns the hex HMAC-SHA256 under secret of parts, separated so
+// that they cannot run together
+func hashParts(secret []byte, parts ...string) string {
+	h := hmac.New(sha256.New, secret)
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseCipher returns the AES-256-GCM cipher encrypting stored response
+// bodies, keyed by an HMAC of a fixed label so that the key differs from the
+// one fingerprinting requests
+func responseCipher(secret []byte) cipher.AEAD {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("idempotency response body"))
+
+	// A 32 byte key always yields a valid AES-256 block and GCM cipher
+	block, _ := aes.NewCipher(mac.Sum(nil))
+	aead, _ := cipher.NewGCM(block)
+	return aead
+}
+
+// sealBody encrypts a response body bound to its storage key and encodes it
+// as base64, as text columns cannot hold arbitrary bytes
+func sealBody(aead cipher.AEAD, storageKey string, body []byte) []byte {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(body)+aead.Overhead())
+	_, _ = rand.Read(nonce)
+	sealed := aead.Seal(nonce, nonce, body, []byte(storageKey))
+
+	encoded := make([]byte, base64.StdEncoding.EncodedLen(len(sealed)))
+	base64.StdEncoding.Encode(encoded, sealed)
+	return encoded
+}
+
+// openBody decodes and decrypts a body sealed by sealBody
+func openBody(aead cipher.AEAD, storageKey string, encoded []byte) ([]byte, error) {
+	sealed := make([]byte, base64.StdEncoding.DecodedLen(len(encoded)))
+	n, err := base64.StdEncoding.Decode(sealed, encoded)
+	if err != nil {
+		return nil, err
+	}
+	sealed = sealed[:n]
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("sealed response body too short")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, []byte(storageKey))
+}
+
+// idempotencyRecorder copies the response body into a buffer while writing it,
+// giving up once it exceeds MaxResponseBytes
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	body     bytes.Buffer
+	overflow bool
+}
+
+// Write writes b to the client and, while it fits, to the buffer
+func (r *idempotencyRecorder) Write(b []byte) (int, error) {
+	if !r.overflow {
+		if r.body.Len()+len(b) > idempotencydomain.MaxResponseBytes {
+			r.overflow = true
+			r.body.Reset()
+		} else {
+			r.body.Write(b)
+		}
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying writer for http.ResponseController
+func (r *idempotencyRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
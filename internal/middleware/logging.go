@@ -4,8 +4,10 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/zercle/template-go-echo/pkg"
 )
 
 // RequestLogger creates a logging middleware using slog
@@ -18,7 +20,8 @@ func RequestLogger() echo.MiddlewareFunc {
 		LogRemoteIP:  true,
 		LogUserAgent: true,
 		LogValuesFunc: func(c echo.Context, values middleware.RequestLoggerValues) error {
-			slog.Info("HTTP request",
+			ctx := c.Request().Context()
+			pkg.Logger(ctx).InfoContext(ctx, "HTTP request",
 				slog.String("uri", values.URI),
 				slog.String("method", values.Method),
 				slog.Int("status", values.Status),
@@ -29,25 +32,111 @@ func RequestLogger() echo.MiddlewareFunc {
 			return nil
 		},
 		Skipper: func(c echo.Context) bool {
-			// Skip logging for health check endpoints
-			return c.Path() == "/health" || c.Path() == "/ready" || c.Path() == "/live"
+			// Skip logging for health check and metrics endpoints
+			return c.Path() == "/health" || c.Path() == "/ready" || c.Path() == "/live" || c.Path() == "/metrics"
 		},
 	})
 }
 
-// RequestID middleware adds a request ID to each request context
+// HeaderXTenantID is the request header naming the tenant a request acts for
+const HeaderXTenantID = "X-Tenant-ID"
+
+// maxRequestIDLength bounds the inbound request and tenant IDs that are kept
+const maxRequestIDLength = 128
+
+// RequestID sets the X-Request-ID response header to the request ID. An
+// inbound X-Request-ID is kept when it is at most 128 letters, digits and
+// -_.: characters, so that a caller's ID follows the request; otherwise a
+// time-ordered UUIDv7 is generated.
 func RequestID() echo.MiddlewareFunc {
-	return middleware.RequestIDWithConfig(middleware.RequestIDConfig{
-		Generator: func() string {
-			return time.Now().Format("20060102150405-000")
-		},
-	})
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id := c.Request().Header.Get(echo.HeaderXRequestID)
+			if !validID(id) {
+				id = newRequestID()
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, id)
+			return next(c)
+		}
+	}
 }
 
-// TimeoutMiddleware sets request timeout
-func Timeout(timeout time.Duration) echo.MiddlewareFunc {
+// newRequestID returns a UUIDv7, falling back to a random UUID should the
+// clock read fail
+func newRequestID() string {
+	if id, err := uuid.NewV7(); err == nil {
+		return id.String()
+	}
+	return uuid.NewString()
+}
+
+// validID reports whether an ID taken from a request header is safe to log
+// and to echo back
+func validID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// RequestContext copies the request ID, route, tenant, client IP and user
+// agent into the request context so that lower layers can attribute their
+// work, and adds a logger carrying the request ID, route and tenant, returned
+// by pkg.Logger. It must run after RequestID.
+func RequestContext() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			info := pkg.RequestInfoFromContext(req.Context())
+			info.RequestID = c.Response().Header().Get(echo.HeaderXRequestID)
+			info.Route = c.Path()
+			info.IPAddress = c.RealIP()
+			info.UserAgent = req.UserAgent()
+			if tenantID := req.Header.Get(HeaderXTenantID); validID(tenantID) {
+				info.TenantID = tenantID
+			}
+
+			logger := pkg.Logger(req.Context()).With(
+				slog.String("request_id", info.RequestID),
+				slog.String("route", info.Route),
+			)
+			if info.TenantID != "" {
+				logger = logger.With(slog.String("tenant_id", info.TenantID))
+			}
+
+			ctx := pkg.WithRequestInfo(req.Context(), info)
+			c.SetRequest(req.WithContext(pkg.WithLogger(ctx, logger)))
+			return next(c)
+		}
+	}
+}
+
+// requestLogger returns the logger of the request, as added by RequestContext
+func requestLogger(c echo.Context) *slog.Logger {
+	return pkg.Logger(c.Request().Context())
+}
+
+// TimeoutMiddleware sets request timeout. The timeout buffers the whole
+// response, so streaming routes listed in skipRoutes are exempt.
+func Timeout(timeout time.Duration, skipRoutes ...string) echo.MiddlewareFunc {
 	return middleware.TimeoutWithConfig(middleware.TimeoutConfig{
 		Timeout: timeout,
+		Skipper: func(c echo.Context) bool {
+			for _, route := range skipRoutes {
+				if c.Path() == route {
+					return true
+				}
+			}
+			return false
+		},
 	})
 }
 
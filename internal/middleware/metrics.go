@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// unmatchedRoute labels requests no route matched, so that probing random
+// URIs cannot create a series per URI
+const unmatchedRoute = "unmatched"
+
+// httpDurationBuckets are the request latency histogram buckets in seconds
+var httpDurationBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30}
+
+// Metrics counts requests and records their latency in histograms, and
+// gauges the requests in flight, labeled by method and route template rather
+// than URI. The collectors are registered with reg. Errors are handled here,
+// through the Echo error handler, so that their status is recorded; it should
+// run before middleware that needs the status of the response.
+func Metrics(reg prometheus.Registerer) echo.MiddlewareFunc {
+	requests := pkg.RegisterCollector(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by method, route template and status.",
+	}, []string{"method", "route", "status"}))
+	duration := pkg.RegisterCollector(reg, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Latency of HTTP requests by method, route template and status.",
+		Buckets: httpDurationBuckets,
+	}, []string{"method", "route", "status"}))
+	inFlight := pkg.RegisterCollector(reg, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "HTTP requests being served by method and route template.",
+	}, []string{"method", "route"}))
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			method := c.Request().Method
+			route := c.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+
+			gauge := inFlight.WithLabelValues(method, route)
+			gauge.Inc()
+			defer gauge.Dec()
+
+			start := time.Now()
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			status := strconv.Itoa(c.Response().Status)
+			requests.WithLabelValues(method, route, status).Inc()
+			duration.WithLabelValues(method, route, status).Observe(time.Since(start).Seconds())
+			return nil
+		}
+	}
+}
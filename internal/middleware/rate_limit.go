@@ -1,55 +1,96 @@
 package middleware
 
 import (
+	"log/slog"
 	"net/http"
-	"sync"
+	"strconv"
+	"time"
 
-	ecmiddleware "github.com/labstack/echo/v4/middleware"
 	"github.com/labstack/echo/v4"
+	ecmiddleware "github.com/labstack/echo/v4/middleware"
+	ratelimitdomain "github.com/zercle/template-go-echo/internal/ratelimit/domain"
 	"github.com/zercle/template-go-echo/pkg"
-	"golang.org/x/time/rate"
 )
 
-// RateLimiter represents a simple rate limiter using token bucket algorithm
-type RateLimiter struct {
-	limiters map[string]*rate.Limiter
-	mu       sync.RWMutex
-}
-
-// NewRateLimiter creates a new rate limiter
-func NewRateLimiter() *RateLimiter {
-	return &RateLimiter{
-		limiters: make(map[string]*rate.Limiter),
-	}
-}
-
-// Limit implements IP-based rate limiting
-func (rl *RateLimiter) Limit(rps float64, burst int) echo.MiddlewareFunc {
+// RateLimit limits the requests sharing a key of policy, such as those of one
+// client IP address, counting them in store. Responses carry RateLimit-Limit,
+// RateLimit-Remaining and RateLimit-Reset headers, and rejected requests get
+// 429 with Retry-After. When several policies apply, the headers describe the
+// one with the fewest requests remaining.
+//
+// User policies count anonymous requests by IP address, so on protected
+// routes they must run after JWTAuth. API key policies count by a hash of the
+// X-API-Key header, or by IP address without one, and route policies count
+// every request to the route together. In dry run, requests over the limit
+// are logged and let through without headers. Requests are also let through
+// when the store fails.
+func RateLimit(store ratelimitdomain.Store, policy ratelimitdomain.Policy) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			ip := c.RealIP()
-
-			rl.mu.RLock()
-			limiter, exists := rl.limiters[ip]
-			rl.mu.RUnlock()
-
-			if !exists {
-				limiter = rate.NewLimiter(rate.Limit(rps), burst)
-
-				rl.mu.Lock()
-				rl.limiters[ip] = limiter
-				rl.mu.Unlock()
+			result, err := store.Allow(c.Request().Context(), policy, rateLimitKey(c, policy.Key), time.Now())
+			if err != nil {
+				requestLogger(c).ErrorContext(c.Request().Context(), "failed to check rate limit",
+					slog.String("policy", policy.Name),
+					slog.String("error", err.Error()))
+				return next(c)
 			}
 
-			if !limiter.Allow() {
-				return pkg.Error(c, http.StatusTooManyRequests, "rate limit exceeded", "RATE_LIMIT_EXCEEDED")
+			if policy.DryRun {
+				if !result.Allowed {
+					requestLogger(c).WarnContext(c.Request().Context(), "rate limit exceeded in dry run",
+						slog.String("policy", policy.Name),
+						slog.String("route", c.Path()))
+				}
+				return next(c)
 			}
 
+			setRateLimitHeaders(c, result)
+			if !result.Allowed {
+				c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(max(ceilSeconds(result.RetryAfter), 1)))
+				requestLogger(c).WarnContext(c.Request().Context(), "rate limit exceeded",
+					slog.String("policy", policy.Name),
+					slog.String("route", c.Path()))
+				return pkg.Error(c, http.StatusTooManyRequests, "rate limit exceeded", ratelimitdomain.ErrCodeRateLimited)
+			}
 			return next(c)
 		}
 	}
 }
 
+// rateLimitKey returns the key the request is counted under
+func rateLimitKey(c echo.Context, kind string) string {
+	switch kind {
+	case ratelimitdomain.KeyUser:
+		if userID, _ := c.Get("user_id").(string); userID != "" {
+			return "user:" + userID
+		}
+	case ratelimitdomain.KeyAPIKey:
+		if apiKey := c.Request().Header.Get(ratelimitdomain.HeaderAPIKey); apiKey != "" {
+			return "api_key:" + hashParts(nil, apiKey)
+		}
+	case ratelimitdomain.KeyRoute:
+		return "route:" + c.Request().Method + " " + c.Path()
+	}
+	return "ip:" + c.RealIP()
+}
+
+// setRateLimitHeaders describes result in the response headers, unless an
+// earlier policy left fewer requests remaining
+func setRateLimitHeaders(c echo.Context, result ratelimitdomain.Result) {
+	header := c.Response().Header()
+	if remaining, err := strconv.Atoi(header.Get(ratelimitdomain.HeaderRemaining)); err == nil && remaining < result.Remaining {
+		return
+	}
+	header.Set(ratelimitdomain.HeaderLimit, strconv.Itoa(result.Limit))
+	header.Set(ratelimitdomain.HeaderRemaining, strconv.Itoa(result.Remaining))
+	header.Set(ratelimitdomain.HeaderReset, strconv.Itoa(ceilSeconds(result.Reset)))
+}
+
+// ceilSeconds rounds d up to whole seconds
+func ceilSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
+
 // BodyLimitMiddleware limits request body size
 func BodyLimitMiddleware(limit string) echo.MiddlewareFunc {
 	return ecmiddleware.BodyLimit(limit)
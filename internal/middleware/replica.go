@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+)
+
+// ReadYourWrites moves the database reads of a request to the primary once the
+// request has written, so that it reads its own changes rather than a
+// replica lagging behind. Without read replicas it has no effect.
+func ReadYourWrites() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(database.WithReadYourWrites(req.Context())))
+			return next(c)
+		}
+	}
+}
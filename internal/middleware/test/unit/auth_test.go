@@ -88,3 +88,33 @@ func TestGetUserID(t *testing.T) {
 		t.Errorf("expected 'test-user', got %s", userID)
 	}
 }
+
+func TestRequireRole(t *testing.T) {
+	e := echo.New()
+	mw := middleware.RequireRole("admin")
+	handler := mw(func(c echo.Context) error {
+		return c.String(http.StatusOK, "OK")
+	})
+
+	// Non-admin role is forbidden
+	req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("role", "user")
+
+	_ = handler(c)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected status %d, got %d", http.StatusForbidden, rec.Code)
+	}
+
+	// Admin role passes through
+	req = httptest.NewRequest(http.MethodGet, "/admin", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	c.Set("role", "admin")
+
+	_ = handler(c)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+}
@@ -0,0 +1,118 @@
+package unit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/zercle/template-go-echo/internal/infrastructure/concurrency"
+	"github.com/zercle/template-go-echo/internal/middleware"
+)
+
+// fixedAlgorithm never changes the limit
+type fixedAlgorithm struct{}
+
+func (fixedAlgorithm) Update(limit float64, _ concurrency.Sample) float64 { return limit }
+
+// newSaturatedServer limits reads to one request in flight without a queue,
+// and routes GET /items blocking until release is closed
+func newSaturatedServer(release chan struct{}) (*echo.Echo, *concurrency.Limiter) {
+	limiter := concurrency.NewLimiter(middleware.RouteClassRead, fixedAlgorithm{},
+		concurrency.WithInitialLimit(1),
+		concurrency.WithQueueSize(0),
+		concurrency.WithMetrics(concurrency.NewMetrics(prometheus.NewRegistry())),
+	)
+	e := echo.New()
+	e.Use(middleware.ConcurrencyLimit(map[string]*concurrency.Limiter{middleware.RouteClassRead: limiter}, 2*time.Second, "/health"))
+	block := func(c echo.Context) error {
+		<-release
+		return c.String(http.StatusOK, "OK")
+	}
+	e.GET("/items", block)
+	e.GET("/health", func(c echo.Context) error {
+		return c.String(http.StatusOK, "OK")
+	})
+	e.POST("/items", func(c echo.Context) error {
+		return c.String(http.StatusCreated, "created")
+	})
+	return e, limiter
+}
+
+func serve(e *echo.Echo, method, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func TestConcurrencyLimitShedsOverLimit(t *testing.T) {
+	release := make(chan struct{})
+	e, limiter := newSaturatedServer(release)
+
+	done := make(chan int)
+	go func() { done <- serve(e, http.MethodGet, "/items").Code }()
+	for limiter.InFlight() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	rec := serve(e, http.MethodGet, "/items")
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected a read over the limit to get 503, got %d", rec.Code)
+	}
+	if got := rec.Header().Get(echo.HeaderRetryAfter); got != "2" {
+		t.Errorf("expected Retry-After 2, got %q", got)
+	}
+
+	// Writes are limited separately, and have no limiter here
+	if rec := serve(e, http.MethodPost, "/items"); rec.Code != http.StatusCreated {
+		t.Errorf("expected a write to pass, got %d", rec.Code)
+	}
+
+	close(release)
+	if code := <-done; code != http.StatusOK {
+		t.Errorf("expected the admitted read to complete, got %d", code)
+	}
+	if limiter.InFlight() != 0 {
+		t.Errorf("expected the admitted read to be released, got %d in flight", limiter.InFlight())
+	}
+}
+
+func TestConcurrencyLimitExemptsRoutes(t *testing.T) {
+	release := make(chan struct{})
+	e, limiter := newSaturatedServer(release)
+
+	done := make(chan int)
+	go func() { done <- serve(e, http.MethodGet, "/items").Code }()
+	for limiter.InFlight() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	if rec := serve(e, http.MethodGet, "/health"); rec.Code != http.StatusOK {
+		t.Errorf("expected the health check to pass, got %d", rec.Code)
+	}
+	close(release)
+	<-done
+}
+
+func TestConcurrencyLimitReleasesPanickingRequests(t *testing.T) {
+	e, limiter := newSaturatedServer(make(chan struct{}))
+	e.GET("/panic", func(c echo.Context) error {
+		panic("handler failed")
+	})
+
+	for range 2 {
+		func() {
+			defer func() {
+				if r := recover(); r != "handler failed" {
+					t.Errorf("expected the panic to propagate, got %v", r)
+				}
+			}()
+			serve(e, http.MethodGet, "/panic")
+		}()
+		if limiter.InFlight() != 0 {
+			t.Fatalf("expected the panicking request to be released, got %d in flight", limiter.InFlight())
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package unit_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zercle/template-go-echo/internal/middleware"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+func TestErrorHandlerResponses(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		message string
+	}{
+		{"internal error", errors.New("pq: connection to 10.0.0.5 failed for alice@example.com"), http.StatusInternalServerError, "Internal server error"},
+		{"wrapped domain error", errors.Join(pkg.ErrNotFound, errors.New("row for alice@example.com")), http.StatusInternalServerError, "Internal server error"},
+		{"domain error", pkg.NewDomainError(pkg.ErrCodeConflict, "Email already registered"), http.StatusConflict, "Email already registered"},
+		{"http error", echo.NewHTTPError(http.StatusMethodNotAllowed, "Method Not Allowed"), http.StatusMethodNotAllowed, "Method Not Allowed"},
+		{"http error without text", echo.NewHTTPError(http.StatusBadGateway, errors.New("upstream 10.0.0.5")), http.StatusBadGateway, "Bad Gateway"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+			middleware.ErrorHandler(tt.err, c)
+
+			if rec.Code != tt.status {
+				t.Errorf("expected %d, got %d", tt.status, rec.Code)
+			}
+			var body pkg.JSendResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if body.Message != tt.message {
+				t.Errorf("expected message %q, got %q", tt.message, body.Message)
+			}
+			for _, leaked := range []string{"alice", "10.0.0.5", "pq:"} {
+				if strings.Contains(rec.Body.String(), leaked) {
+					t.Errorf("expected %q not to reach the client, got %s", leaked, rec.Body.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package unit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zercle/template-go-echo/internal/config"
+	idempotencydomain "github.com/zercle/template-go-echo/internal/idempotency/domain"
+	idempotencyrepository "github.com/zercle/template-go-echo/internal/idempotency/repository"
+	"github.com/zercle/template-go-echo/internal/middleware"
+)
+
+var idempotencyCfg = &config.IdempotencyConfig{
+	Store:       idempotencydomain.StoreMemory,
+	TTL:         time.Hour,
+	LockTimeout: time.Minute,
+	Secret:      "test-secret",
+}
+
+// newIdempotentServer routes POST /register through the idempotency middleware
+func newIdempotentServer(handler echo.HandlerFunc) *echo.Echo {
+	e := echo.New()
+	e.POST("/register", handler, middleware.Idempotency(idempotencyrepository.NewMemoryStore(), idempotencyCfg))
+	return e
+}
+
+func doIdempotent(e *echo.Echo, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set(idempotencydomain.HeaderIdempotencyKey, key)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotencyReplaysStoredResponse(t *testing.T) {
+	var calls atomic.Int32
+	e := newIdempotentServer(func(c echo.Context) error {
+		n := calls.Add(1)
+		c.Response().Header().Set("Location", "/users/1")
+		return c.JSON(http.StatusCreated, map[string]int32{"call": n})
+	})
+
+	first := doIdempotent(e, "key-1", `{"email":"a@example.com"}`)
+	second := doIdempotent(e, "key-1", `{"email":"a@example.com"}`)
+
+	if calls.Load() != 1 {
+		t.Fatalf("expected handler to run once, ran %d times", calls.Load())
+	}
+	if second.Code != http.StatusCreated {
+		t.Errorf("expected replayed status 201, got %d", second.Code)
+	}
+	if second.Body.String() != first.Body.String() {
+		t.Errorf("expected replayed body %q, got %q", first.Body.String(), second.Body.String())
+	}
+	if second.Header().Get("Location") != "/users/1" {
+		t.Errorf("expected replayed Location header, got %q", second.Header().Get("Location"))
+	}
+	if second.Header().Get(idempotencydomain.HeaderIdempotentReplayed) != "true" {
+		t.Error("expected replayed response to be marked")
+	}
+	if first.Header().Get(idempotencydomain.HeaderIdempotentReplayed) != "" {
+		t.Error("expected original response not to be marked")
+	}
+}
+
+func TestIdempotencyRejectsDifferentPayload(t *testing.T) {
+	e := newIdempotentServer(func(c echo.Context) error {
+		return c.NoContent(http.StatusCreated)
+	})
+
+	doIdempotent(e, "key-1", `{"email":"a@example.com"}`)
+	rec := doIdempotent(e, "key-1", `{"email":"b@example.com"}`)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422, got %d", rec.Code)
+	}
+}
+
+func TestIdempotencyRejectsConcurrentDuplicate(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	e := newIdempotentServer(func(c echo.Context) error {
+		close(started)
+		<-release
+		return c.NoContent(http.StatusCreated)
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- doIdempotent(e, "key-1", `{}`)
+	}()
+	<-started
+
+	rec := doIdempotent(e, "key-1", `{}`)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("expected 409 while the first request runs, got %d", rec.Code)
+	}
+
+	close(release)
+	if first := <-done; first.Code != http.StatusCreated {
+		t.Errorf("expected first request to succeed, got %d", first.Code)
+	}
+}
+
+func TestIdempotencyDoesNotStoreServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	e := newIdempotentServer(func(c echo.Context) error {
+		if calls.Add(1) == 1 {
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		return c.NoContent(http.StatusCreated)
+	})
+
+	if rec := doIdempotent(e, "key-1", `{}`); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+	if rec := doIdempotent(e, "key-1", `{}`); rec.Code != http.StatusCreated {
+		t.Errorf("expected retry to run the handler again, got %d", rec.Code)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected handler to run twice, ran %d times", calls.Load())
+	}
+}
+
+func TestIdempotencyWithoutKey(t *testing.T) {
+	var calls atomic.Int32
+	e := newIdempotentServer(func(c echo.Context) error {
+		calls.Add(1)
+		return c.NoContent(http.StatusCreated)
+	})
+
+	doIdempotent(e, "", `{}`)
+	doIdempotent(e, "", `{}`)
+
+	if calls.Load() != 2 {
+		t.Errorf("expected handler to run for every request without a key, ran %d times", calls.Load())
+	}
+}
+
+func TestIdempotencyRejectsLongKey(t *testing.T) {
+	e := newIdempotentServer(func(c echo.Context) error {
+		return c.NoContent(http.StatusCreated)
+	})
+
+	rec := doIdempotent(e, strings.Repeat("k", idempotencydomain.MaxKeyLength+1), `{}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestIdempotencyKeysAreScopedToUser(t *testing.T) {
+	var calls atomic.Int32
+	e := echo.New()
+	setUser := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("user_id", c.Request().Header.Get("X-Test-User"))
+			return next(c)
+		}
+	}
+	e.POST("/register", func(c echo.Context) error {
+		calls.Add(1)
+		return c.NoContent(http.StatusCreated)
+	}, setUser, middleware.Idempotency(idempotencyrepository.NewMemoryStore(), idempotencyCfg))
+
+	for _, user := range []string{"user-1", "user-2"} {
+		req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(`{}`))
+		req.Header.Set(idempotencydomain.HeaderIdempotencyKey, "key-1")
+		req.Header.Set("X-Test-User", user)
+		e.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if calls.Load() != 2 {
+		t.Errorf("expected the same key of different users not to collide, ran %d times", calls.Load())
+	}
+}
+
+func TestIdempotencyFingerprintsAreKeyedBySecret(t *testing.T) {
+	var calls atomic.Int32
+	store := idempotencyrepository.NewMemoryStore()
+	servers := make(map[string]*echo.Echo)
+	for _, secret := range []string{"secret-1", "secret-2"} {
+		cfg := *idempotencyCfg
+		cfg.Secret = secret
+		servers[secret] = echo.New()
+		servers[secret].POST("/register", func(c echo.Context) error {
+			calls.Add(1)
+			return c.NoContent(http.StatusCreated)
+		}, middleware.Idempotency(store, &cfg))
+	}
+
+	body := `{"password":"SecurePass123"}`
+	doIdempotent(servers["secret-1"], "key-1", body)
+	if rec := doIdempotent(servers["secret-1"], "key-1", body); rec.Header().Get(idempotencydomain.HeaderIdempotentReplayed) != "true" {
+		t.Error("expected a replay under the same secret")
+	}
+	doIdempotent(servers["secret-2"], "key-1", body)
+
+	if calls.Load() != 2 {
+		t.Errorf("expected keys stored under another secret not to match, ran %d times", calls.Load())
+	}
+}
+
+// capturingStore records the responses written to the wrapped store
+type capturingStore struct {
+	idempotencydomain.Store
+	stored []*idempotencydomain.Response
+}
+
+func (s *capturingStore) Complete(ctx context.Context, key string, response *idempotencydomain.Response) error {
+	s.stored = append(s.stored, response)
+	return s.Store.Complete(ctx, key, response)
+}
+
+func TestIdempotencyEncryptsStoredBodies(t *testing.T) {
+	store := &capturingStore{Store: idempotencyrepository.NewMemoryStore()}
+	e := echo.New()
+	e.POST("/register", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"access_token": "secret-token"})
+	}, middleware.Idempotency(store, idempotencyCfg))
+
+	first := doIdempotent(e, "key-1", `{"email":"a@example.com"}`)
+	second := doIdempotent(e, "key-1", `{"email":"a@example.com"}`)
+
+	if len(store.stored) != 1 {
+		t.Fatalf("expected one stored response, got %d", len(store.stored))
+	}
+	if strings.Contains(string(store.stored[0].Body), "secret-token") {
+		t.Error("expected the stored body to be encrypted")
+	}
+	if second.Body.String() != first.Body.String() {
+		t.Errorf("expected replayed body %q, got %q", first.Body.String(), second.Body.String())
+	}
+}
@@ -0,0 +1,120 @@
+package unit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/middleware"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+func TestRequestIDHonorsValidInboundID(t *testing.T) {
+	e := echo.New()
+	e.Use(middleware.RequestID())
+	e.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name    string
+		inbound string
+		keep    bool
+	}{
+		{"none", "", false},
+		{"valid", "req-42_a.b:c", true},
+		{"unsafe characters", "id\nforged=1", false},
+		{"too long", strings.Repeat("a", 129), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.inbound != "" {
+				req.Header.Set(echo.HeaderXRequestID, tt.inbound)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			got := rec.Header().Get(echo.HeaderXRequestID)
+			if tt.keep {
+				if got != tt.inbound {
+					t.Errorf("expected the inbound ID %q, got %q", tt.inbound, got)
+				}
+				return
+			}
+			id, err := uuid.Parse(got)
+			if err != nil || id.Version() != 7 {
+				t.Errorf("expected a generated UUIDv7, got %q", got)
+			}
+		})
+	}
+}
+
+func TestRequestContextLoggerCarriesRequestAttributes(t *testing.T) {
+	cfg := &config.JWTConfig{Secret: "test-secret", TTL: 3600}
+	var buf bytes.Buffer
+	base := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(pkg.WithLogger(req.Context(), base)))
+			return next(c)
+		}
+	})
+	e.Use(middleware.RequestID())
+	e.Use(middleware.RequestContext())
+	e.GET("/users/:id", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		pkg.Logger(ctx).InfoContext(ctx, "handled")
+		return c.NoContent(http.StatusNoContent)
+	}, middleware.JWTAuth(cfg))
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &middleware.Claims{
+		UserID: "user-123",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}).SignedString([]byte(cfg.Secret))
+	if err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/users/123", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	req.Header.Set(echo.HeaderXRequestID, "req-1")
+	req.Header.Set(middleware.HeaderXTenantID, "tenant-a")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("failed to decode record %q: %v", buf.String(), err)
+	}
+	want := map[string]string{
+		"msg":        "handled",
+		"request_id": "req-1",
+		"route":      "/users/:id",
+		"tenant_id":  "tenant-a",
+		"user_id":    "user-123",
+	}
+	for key, value := range want {
+		if record[key] != value {
+			t.Errorf("expected %s %q, got %v", key, value, record[key])
+		}
+	}
+}
+
+func TestLoggerDefaultsOutsideRequests(t *testing.T) {
+	if pkg.Logger(t.Context()) != slog.Default() {
+		t.Error("expected the default logger for a context without one")
+	}
+}
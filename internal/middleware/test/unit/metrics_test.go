@@ -0,0 +1,106 @@
+package unit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/zercle/template-go-echo/internal/middleware"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+func TestMetricsLabelsByRouteTemplate(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	e := echo.New()
+	e.HTTPErrorHandler = middleware.ErrorHandler
+	e.Use(middleware.Metrics(registry))
+	e.GET("/users/:id", func(c echo.Context) error {
+		if c.Param("id") == "missing" {
+			return pkg.ErrNotFound
+		}
+		return c.String(http.StatusOK, "OK")
+	})
+
+	for _, path := range []string{"/users/1", "/users/2", "/users/missing", "/unknown/path"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	requests, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	want := map[string]float64{
+		"GET /users/:id 200": 2,
+		"GET /users/:id 404": 1,
+		"GET unmatched 404":  1,
+	}
+	got := make(map[string]float64)
+	for _, family := range requests {
+		if family.GetName() != "http_requests_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			got[labels["method"]+" "+labels["route"]+" "+labels["status"]] = metric.GetCounter().GetValue()
+		}
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected series %v, got %v", want, got)
+	}
+	for series, count := range want {
+		if got[series] != count {
+			t.Errorf("expected %s to count %v, got %v", series, count, got[series])
+		}
+	}
+
+	if n := testutil.CollectAndCount(registry, "http_request_duration_seconds"); n != len(want) {
+		t.Errorf("expected %d latency histograms, got %d", len(want), n)
+	}
+	if n, err := testutil.GatherAndCount(registry, "http_requests_in_flight"); err != nil || n != 2 {
+		t.Errorf("expected 2 in-flight gauges, got %d, %v", n, err)
+	}
+}
+
+func TestMetricsGaugesRequestsInFlight(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	e := echo.New()
+	e.Use(middleware.Metrics(registry))
+
+	var during float64
+	e.GET("/slow", func(c echo.Context) error {
+		during = inFlight(t, registry)
+		return c.NoContent(http.StatusNoContent)
+	})
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+	if during != 1 {
+		t.Errorf("expected 1 request in flight while served, got %v", during)
+	}
+	if after := inFlight(t, registry); after != 0 {
+		t.Errorf("expected no request in flight once served, got %v", after)
+	}
+}
+
+// inFlight returns the sum of the in-flight gauges
+func inFlight(t *testing.T, gatherer prometheus.Gatherer) float64 {
+	t.Helper()
+	families, err := gatherer.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	var value float64
+	for _, family := range families {
+		if family.GetName() == "http_requests_in_flight" {
+			for _, metric := range family.GetMetric() {
+				value += metric.GetGauge().GetValue()
+			}
+		}
+	}
+	return value
+}
@@ -1,51 +1,204 @@
 package unit_test
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/zercle/template-go-echo/internal/middleware"
+	ratelimitdomain "github.com/zercle/template-go-echo/internal/ratelimit/domain"
+	ratelimitrepository "github.com/zercle/template-go-echo/internal/ratelimit/repository"
 )
 
-func TestNewRateLimiter(t *testing.T) {
-	rl := middleware.NewRateLimiter()
-	if rl == nil {
-		t.Fatal("expected non-nil rate limiter")
-	}
-	// RateLimiter is created with internal state - just verify it's not nil
+// failingStore is a rate limit store that is unreachable
+type failingStore struct{}
+
+func (failingStore) Allow(context.Context, ratelimitdomain.Policy, string, time.Time) (ratelimitdomain.Result, error) {
+	return ratelimitdomain.Result{}, errors.New("connection refused")
 }
 
-func TestRateLimiterLimit(t *testing.T) {
-	rl := middleware.NewRateLimiter()
-	mw := rl.Limit(100, 1) // 100 requests per second, burst of 1
+func mustPolicy(t *testing.T, spec string) ratelimitdomain.Policy {
+	t.Helper()
+	policies, err := ratelimitdomain.ParsePolicies(spec)
+	if err != nil {
+		t.Fatalf("failed to parse %q: %v", spec, err)
+	}
+	for _, policy := range policies {
+		return policy
+	}
+	t.Fatalf("no policy in %q", spec)
+	return ratelimitdomain.Policy{}
+}
 
+// newRateLimitedServer routes GET /items/:id through the rate limit
+// middleware, authenticating requests carrying X-User-ID as that user
+func newRateLimitedServer(store ratelimitdomain.Store, policy ratelimitdomain.Policy) *echo.Echo {
 	e := echo.New()
-	handler := mw(func(c echo.Context) error {
+	auth := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if userID := c.Request().Header.Get("X-User-ID"); userID != "" {
+				c.Set("user_id", userID)
+			}
+			return next(c)
+		}
+	}
+	e.GET("/items/:id", func(c echo.Context) error {
 		return c.String(http.StatusOK, "OK")
-	})
+	}, auth, middleware.RateLimit(store, policy))
+	return e
+}
 
-	// First request should succeed
-	req := httptest.NewRequest(http.MethodGet, "/", nil)
-	req.RemoteAddr = "127.0.0.1:8080"
+func doRateLimited(e *echo.Echo, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	e.ServeHTTP(rec, req)
+	return rec
+}
 
-	err := handler(c)
-	if err != nil {
-		t.Errorf("first request should succeed: %v", err)
+func TestRateLimitSetsHeadersAndRejects(t *testing.T) {
+	e := newRateLimitedServer(ratelimitrepository.NewMemoryStore(), mustPolicy(t, "test=ip:2/1m"))
+
+	first := doRateLimited(e, "/items/1", nil)
+	if first.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", first.Code)
+	}
+	if got := first.Header().Get(ratelimitdomain.HeaderLimit); got != "2" {
+		t.Errorf("expected RateLimit-Limit 2, got %q", got)
+	}
+	if got := first.Header().Get(ratelimitdomain.HeaderRemaining); got != "1" {
+		t.Errorf("expected RateLimit-Remaining 1, got %q", got)
+	}
+	if got := first.Header().Get(ratelimitdomain.HeaderReset); got != "30" {
+		t.Errorf("expected RateLimit-Reset 30, got %q", got)
+	}
+
+	doRateLimited(e, "/items/1", nil)
+	rejected := doRateLimited(e, "/items/1", nil)
+	if rejected.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", rejected.Code)
+	}
+	if got := rejected.Header().Get(ratelimitdomain.HeaderRemaining); got != "0" {
+		t.Errorf("expected RateLimit-Remaining 0, got %q", got)
+	}
+	if got := rejected.Header().Get(echo.HeaderRetryAfter); got != "30" {
+		t.Errorf("expected Retry-After 30, got %q", got)
+	}
+}
+
+func TestRateLimitDryRunLetsRequestsThrough(t *testing.T) {
+	e := newRateLimitedServer(ratelimitrepository.NewMemoryStore(), mustPolicy(t, "test=ip:1/1m:dry_run"))
+
+	for i := 0; i < 3; i++ {
+		rec := doRateLimited(e, "/items/1", nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200 in dry run, got %d", i, rec.Code)
+		}
+		if got := rec.Header().Get(ratelimitdomain.HeaderLimit); got != "" {
+			t.Errorf("request %d: expected no headers in dry run, got RateLimit-Limit %q", i, got)
+		}
+	}
+}
+
+func TestRateLimitFailsOpen(t *testing.T) {
+	e := newRateLimitedServer(failingStore{}, mustPolicy(t, "test=ip:1/1m"))
+
+	for i := 0; i < 2; i++ {
+		if rec := doRateLimited(e, "/items/1", nil); rec.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200 when the store fails, got %d", i, rec.Code)
+		}
+	}
+}
+
+func TestRateLimitKeys(t *testing.T) {
+	type request struct {
+		path    string
+		headers map[string]string
 	}
+	alice := map[string]string{"X-User-ID": "alice"}
+	bob := map[string]string{"X-User-ID": "bob"}
 
-	// Second request immediately after should fail due to rate limiting
-	// The limiter is created with 1 burst token for this IP
-	req = httptest.NewRequest(http.MethodGet, "/", nil)
-	req.RemoteAddr = "127.0.0.1:8080"
-	rec = httptest.NewRecorder()
-	c = e.NewContext(req, rec)
+	tests := []struct {
+		name          string
+		spec          string
+		first, second request
+		// separate is set when the requests are counted under different keys
+		separate bool
+	}{
+		{
+			name:   "ip",
+			spec:   "test=ip:1/1m",
+			first:  request{path: "/items/1", headers: alice},
+			second: request{path: "/items/2", headers: bob},
+		},
+		{
+			name:     "user",
+			spec:     "test=user:1/1m",
+			first:    request{path: "/items/1", headers: alice},
+			second:   request{path: "/items/1", headers: bob},
+			separate: true,
+		},
+		{
+			name:   "anonymous user falls back to ip",
+			spec:   "test=user:1/1m",
+			first:  request{path: "/items/1"},
+			second: request{path: "/items/1"},
+		},
+		{
+			name:     "api key",
+			spec:     "test=api_key:1/1m",
+			first:    request{path: "/items/1", headers: map[string]string{ratelimitdomain.HeaderAPIKey: "key-1"}},
+			second:   request{path: "/items/1", headers: map[string]string{ratelimitdomain.HeaderAPIKey: "key-2"}},
+			separate: true,
+		},
+		{
+			name:   "route",
+			spec:   "test=route:1/1m",
+			first:  request{path: "/items/1", headers: alice},
+			second: request{path: "/items/2", headers: bob},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newRateLimitedServer(ratelimitrepository.NewMemoryStore(), mustPolicy(t, tt.spec))
+
+			if rec := doRateLimited(e, tt.first.path, tt.first.headers); rec.Code != http.StatusOK {
+				t.Fatalf("expected first request to pass, got %d", rec.Code)
+			}
+			want := http.StatusTooManyRequests
+			if tt.separate {
+				want = http.StatusOK
+			}
+			if rec := doRateLimited(e, tt.second.path, tt.second.headers); rec.Code != want {
+				t.Errorf("expected second request to get %d, got %d", want, rec.Code)
+			}
+		})
+	}
+}
 
-	// This test may be flaky if the rate limiter generates a token quickly
-	// For reliable testing, we'd need to mock time or use a different approach
-	// For now, we'll skip the assertion as it's implementation-dependent
-	_ = handler(c)
+func TestRateLimitHeadersDescribeTightestPolicy(t *testing.T) {
+	store := ratelimitrepository.NewMemoryStore()
+	e := echo.New()
+	e.GET("/login", func(c echo.Context) error {
+		return c.String(http.StatusOK, "OK")
+	},
+		middleware.RateLimit(store, mustPolicy(t, "global=ip:100/1s")),
+		middleware.RateLimit(store, mustPolicy(t, "auth=ip:10/1m:sliding_window")),
+	)
+
+	rec := doRateLimited(e, "/login", nil)
+	if got := rec.Header().Get(ratelimitdomain.HeaderLimit); got != "10" {
+		t.Errorf("expected the auth policy's RateLimit-Limit 10, got %q", got)
+	}
+	if got := rec.Header().Get(ratelimitdomain.HeaderRemaining); got != "9" {
+		t.Errorf("expected RateLimit-Remaining 9, got %q", got)
+	}
 }
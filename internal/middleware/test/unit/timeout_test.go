@@ -0,0 +1,35 @@
+package unit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zercle/template-go-echo/internal/middleware"
+)
+
+func TestTimeoutSkipsStreamingRoutes(t *testing.T) {
+	e := echo.New()
+	e.Use(middleware.Timeout(20*time.Millisecond, "/stream"))
+
+	slow := func(c echo.Context) error {
+		time.Sleep(60 * time.Millisecond)
+		return c.String(http.StatusOK, "OK")
+	}
+	e.GET("/stream", slow)
+	e.GET("/slow", slow)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected skipped route to complete with 200, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected timed out route to return 503, got %d", rec.Code)
+	}
+}
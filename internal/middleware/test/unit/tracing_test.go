@@ -0,0 +1,102 @@
+package unit_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zercle/template-go-echo/internal/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingContinuesInboundTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	e := echo.New()
+	e.HTTPErrorHandler = middleware.ErrorHandler
+	e.Use(middleware.Tracing(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), propagation.TraceContext{}))
+
+	var handlerSpan trace.SpanContext
+	e.GET("/users/:id", func(c echo.Context) error {
+		handlerSpan = trace.SpanContextFromContext(c.Request().Context())
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "GET /users/:id" {
+		t.Errorf("expected the span to be named by route template, got %s", span.Name())
+	}
+	if span.SpanKind() != trace.SpanKindServer {
+		t.Errorf("expected a server span, got %s", span.SpanKind())
+	}
+	if got := span.SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected the inbound trace to continue, got trace %s", got)
+	}
+	if got := span.Parent().SpanID().String(); got != "00f067aa0ba902b7" || !span.Parent().IsRemote() {
+		t.Errorf("expected the remote parent span, got %s", got)
+	}
+	if handlerSpan.SpanID() != span.SpanContext().SpanID() {
+		t.Error("expected the span in the handler's request context")
+	}
+	if !hasAttribute(span, attribute.Int("http.response.status_code", http.StatusNoContent)) {
+		t.Errorf("expected the status code attribute, got %v", span.Attributes())
+	}
+}
+
+func TestTracingMarksServerErrors(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	e := echo.New()
+	e.HTTPErrorHandler = middleware.ErrorHandler
+	e.Use(middleware.Tracing(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), propagation.TraceContext{}))
+	e.GET("/fail", func(c echo.Context) error {
+		return errors.New("boom")
+	})
+	e.GET("/missing", func(c echo.Context) error {
+		return echo.ErrNotFound
+	})
+
+	for _, path := range []string{"/fail", "/missing"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	if spans[0].Parent().IsValid() {
+		t.Error("expected a new trace without an inbound traceparent")
+	}
+	if spans[0].Status().Code != codes.Error {
+		t.Error("expected a 500 to fail the span")
+	}
+	if !hasAttribute(spans[0], attribute.Int("http.response.status_code", http.StatusInternalServerError)) {
+		t.Errorf("expected status 500, got %v", spans[0].Attributes())
+	}
+	if spans[1].Status().Code == codes.Error {
+		t.Error("expected a 404 not to fail the span")
+	}
+}
+
+// hasAttribute reports whether span carries want
+func hasAttribute(span sdktrace.ReadOnlySpan, want attribute.KeyValue) bool {
+	for _, attr := range span.Attributes() {
+		if attr == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the request spans
+const tracerName = "github.com/zercle/template-go-echo/internal/middleware"
+
+// Tracing starts a server span per request, continuing the trace of a W3C
+// traceparent header when the request carries one, and passes it on in the
+// request context. Spans are named after the route template rather than the
+// URI. Errors are handled here, through the Echo error handler, so that their
+// status is recorded; 5xx responses mark the span as failed.
+func Tracing(provider trace.TracerProvider, propagator propagation.TextMapPropagator) echo.MiddlewareFunc {
+	tracer := provider.Tracer(tracerName)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			route := c.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+
+			ctx := propagator.Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+			ctx, span := tracer.Start(ctx, req.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", req.Method),
+					attribute.String("http.route", route),
+					attribute.String("url.path", req.URL.Path),
+					attribute.String("client.address", c.RealIP()),
+					attribute.String("user_agent.original", req.UserAgent()),
+				),
+			)
+			defer span.End()
+			c.SetRequest(req.WithContext(ctx))
+
+			if err := next(c); err != nil {
+				span.RecordError(err)
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			span.SetAttributes(attribute.Int("http.response.status_code", status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+			return nil
+		}
+	}
+}
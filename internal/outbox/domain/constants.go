@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+const (
+	// Relay defaults
+	DefaultPollInterval   = time.Second
+	DefaultBatchSize      = 100
+	DefaultPublishTimeout = 10 * time.Second
+	DefaultRetention      = 7 * 24 * time.Hour
+
+	// How often published messages past retention are removed
+	CleanupInterval = time.Hour
+
+	// Name of the lock held by the relay publishing a batch
+	RelayLockName = "outbox.relay"
+
+	// Delivery retry backoff bounds
+	MinRetryBackoff = time.Second
+	MaxRetryBackoff = 5 * time.Minute
+
+	// Column limits; longer values are truncated
+	MaxLastErrorLength = 1000
+)
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// Message is a domain event stored in the outbox until it is published
+type Message struct {
+	// Sequence is assigned by the database and defines delivery order
+	Sequence      uint64          `json:"sequence"`
+	EventID       string          `json:"event_id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	EventType     string          `json:"event_type"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Attempts      int             `json:"attempts"`
+	LastError     string          `json:"last_error,omitempty"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	PublishedAt   *time.Time      `json:"published_at,omitempty"`
+}
+
+// NewMessage serializes event into an outbox message with a new event ID
+func NewMessage(event pkg.DomainEvent) (*Message, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	occurredAt := event.OccurredAt()
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	return &Message{
+		EventID:       uuid.New().String(),
+		AggregateType: event.AggregateType(),
+		AggregateID:   event.AggregateID(),
+		EventType:     event.EventType(),
+		Payload:       payload,
+		OccurredAt:    occurredAt.UTC(),
+		NextAttemptAt: occurredAt.UTC(),
+	}, nil
+}
+
+// RetryBackoff returns how long to wait before the next delivery attempt after
+// the given number of failed attempts, doubling from MinRetryBackoff up to MaxRetryBackoff
+func RetryBackoff(attempts int) time.Duration {
+	backoff := MinRetryBackoff
+	for i := 1; i < attempts && backoff < MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > MaxRetryBackoff {
+		backoff = MaxRetryBackoff
+	}
+	return backoff
+}
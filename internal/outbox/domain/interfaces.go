@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// OutboxRepository defines database operations for the relay. Messages are
+// appended by the repositories that change state, in the same transaction.
+type OutboxRepository interface {
+	// ListPending retrieves up to limit unpublished messages due at now, in sequence
+	// order, leaving out aggregates whose earlier messages are waiting for a retry
+	ListPending(ctx context.Context, now time.Time, limit int) ([]*Message, error)
+
+	// MarkPublished records the successful delivery of a message
+	MarkPublished(ctx context.Context, sequence uint64, publishedAt time.Time) error
+
+	// MarkFailed records a failed delivery attempt and when to try again
+	MarkFailed(ctx context.Context, sequence uint64, lastError string, nextAttemptAt time.Time) error
+
+	// DeletePublished removes messages published before the cutoff and returns how many were removed
+	DeletePublished(ctx context.Context, publishedBefore time.Time) (int, error)
+}
+
+// Locker takes named locks shared by every instance, such as the scheduler's
+// database session locks, so that one relay publishes at a time
+type Locker interface {
+	// TryLock takes the named lock without waiting. When acquired, release must
+	// be called once the batch has been published.
+	TryLock(ctx context.Context, name string) (release func(), acquired bool, err error)
+}
+
+// Publisher delivers outbox messages to other services, for example a message
+// broker. Delivery is at least once, so consumers should deduplicate by EventID.
+type Publisher interface {
+	// Publish delivers a message; an error schedules a retry
+	Publish(ctx context.Context, message *Message) error
+}
+
+// PublisherFunc adapts a function to the Publisher interface
+type PublisherFunc func(ctx context.Context, message *Message) error
+
+// Publish calls f(ctx, message)
+func (f PublisherFunc) Publish(ctx context.Context, message *Message) error {
+	return f(ctx, message)
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-echo/internal/outbox/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// OutboxRepository implements domain.OutboxRepository using sqlc generated code
+type OutboxRepository struct {
+	q sqlc.Querier
+}
+
+// New creates a new outbox repository with sqlc querier
+func New(q sqlc.Querier) *OutboxRepository {
+	return &OutboxRepository{q: q}
+}
+
+// Append writes events to the outbox through q. Callers pass a querier bound to
+// the transaction of the state change, so events are stored if and only if the
+// change is committed.
+func Append(ctx context.Context, q sqlc.Querier, events ...pkg.DomainEvent) error {
+	for _, event := range events {
+		message, err := domain.NewMessage(event)
+		if err != nil {
+			slog.Error("failed to encode outbox message", slog.String("event_type", event.EventType()), slog.String("error", err.Error()))
+			return err
+		}
+
+		params := sqlc.CreateOutboxMessageParams{
+			EventID:       message.EventID,
+			AggregateType: message.AggregateType,
+			AggregateID:   message.AggregateID,
+			EventType:     message.EventType,
+			Payload:       message.Payload,
+			OccurredAt:    message.OccurredAt,
+		}
+		if err := q.CreateOutboxMessage(ctx, params); err != nil {
+			slog.Error("failed to create outbox message", slog.String("error", err.Error()))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListPending retrieves up to limit unpublished messages due at now, in sequence
+// order, leaving out aggregates whose earlier messages are waiting for a retry
+func (r *OutboxRepository) ListPending(ctx context.Context, now time.Time, limit int) ([]*domain.Message, error) {
+	params := sqlc.ListPendingOutboxMessagesParams{
+		Now:   now,
+		Limit: int32(limit),
+	}
+
+	rows, err := r.q.ListPendingOutboxMessages(ctx, params)
+	if err != nil {
+		slog.Error("failed to list pending outbox messages", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	messages := make([]*domain.Message, len(rows))
+	for i := range rows {
+		messages[i] = sqlcOutboxToDomain(&rows[i])
+	}
+
+	return messages, nil
+}
+
+// MarkPublished records the successful delivery of a message
+func (r *OutboxRepository) MarkPublished(ctx context.Context, sequence uint64, publishedAt time.Time) error {
+	params := sqlc.MarkOutboxMessagePublishedParams{
+		PublishedAt: sql.NullTime{Time: publishedAt, Valid: true},
+		ID:          sequence,
+	}
+
+	err := r.q.MarkOutboxMessagePublished(ctx, params)
+	if err != nil {
+		slog.Error("failed to mark outbox message published", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// MarkFailed records a failed delivery attempt and when to try again
+func (r *OutboxRepository) MarkFailed(ctx context.Context, sequence uint64, lastError string, nextAttemptAt time.Time) error {
+	if len(lastError) > domain.MaxLastErrorLength {
+		lastError = lastError[:domain.MaxLastErrorLength]
+	}
+
+	params := sqlc.MarkOutboxMessageFailedParams{
+		LastError:     sql.NullString{String: lastError, Valid: lastError != ""},
+		NextAttemptAt: nextAttemptAt,
+		ID:            sequence,
+	}
+
+	err := r.q.MarkOutboxMessageFailed(ctx, params)
+	if err != nil {
+		slog.Error("failed to mark outbox message failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// DeletePublished removes messages published before the cutoff and returns how many were removed
+func (r *OutboxRepository) DeletePublished(ctx context.Context, publishedBefore time.Time) (int, error) {
+	deleted, err := r.q.DeletePublishedOutboxMessages(ctx, sql.NullTime{Time: publishedBefore, Valid: true})
+	if err != nil {
+		slog.Error("failed to delete published outbox messages", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
+
+// Helper functions to convert sqlc types to domain types
+
+func sqlcOutboxToDomain(row *sqlc.Outbox) *domain.Message {
+	message := &domain.Message{
+		Sequence:      row.ID,
+		EventID:       row.EventID,
+		AggregateType: row.AggregateType,
+		AggregateID:   row.AggregateID,
+		EventType:     row.EventType,
+		Payload:       row.Payload,
+		OccurredAt:    row.OccurredAt,
+		Attempts:      int(row.Attempts),
+		NextAttemptAt: row.NextAttemptAt,
+	}
+
+	if row.LastError.Valid {
+		message.LastError = row.LastError.String
+	}
+
+	if row.PublishedAt.Valid {
+		message.PublishedAt = &row.PublishedAt.Time
+	}
+
+	return message
+}
@@ -0,0 +1,204 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/outbox/domain"
+	"github.com/zercle/template-go-echo/internal/outbox/test/mocks"
+	"github.com/zercle/template-go-echo/internal/outbox/usecase"
+)
+
+// recordingPublisher captures published messages and fails for selected event types
+type recordingPublisher struct {
+	published []*domain.Message
+	failing   map[string]bool
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, message *domain.Message) error {
+	if p.failing[message.EventType] {
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, message)
+	return nil
+}
+
+func (p *recordingPublisher) eventTypes(aggregateID string) []string {
+	var types []string
+	for _, message := range p.published {
+		if message.AggregateID == aggregateID {
+			types = append(types, message.EventType)
+		}
+	}
+	return types
+}
+
+func TestRelayPublishesInOrder(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	publisher := &recordingPublisher{}
+	relay := usecase.NewRelay(repo, publisher)
+
+	repo.Add("user-1", "user.registered")
+	repo.Add("user-2", "user.registered")
+	repo.Add("user-1", "user.email_changed")
+	repo.Add("user-1", "user.deleted")
+
+	published, err := relay.RelayOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if published != 4 {
+		t.Errorf("expected 4 published messages, got %d", published)
+	}
+
+	expected := []string{"user.registered", "user.email_changed", "user.deleted"}
+	got := publisher.eventTypes("user-1")
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	}
+
+	// Published messages are not delivered again
+	if published, _ := relay.RelayOnce(context.Background()); published != 0 {
+		t.Errorf("expected nothing left to publish, got %d", published)
+	}
+}
+
+func TestRelayHoldsBackAggregateAfterFailure(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	publisher := &recordingPublisher{failing: map[string]bool{"user.email_changed": true}}
+	relay := usecase.NewRelay(repo, publisher)
+
+	repo.Add("user-1", "user.registered")
+	failing := repo.Add("user-1", "user.email_changed")
+	repo.Add("user-1", "user.deleted")
+	repo.Add("user-2", "user.registered")
+
+	if _, err := relay.RelayOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The failed message is scheduled for a retry and blocks the rest of its aggregate
+	message := repo.Get(failing.Sequence)
+	if message.Attempts != 1 || message.LastError == "" || !message.NextAttemptAt.After(time.Now()) {
+		t.Errorf("expected failed attempt to be recorded, got %+v", message)
+	}
+	if got := publisher.eventTypes("user-1"); len(got) != 1 {
+		t.Errorf("expected later user-1 messages to be held back, got %v", got)
+	}
+	if got := publisher.eventTypes("user-2"); len(got) != 1 {
+		t.Errorf("expected other aggregates to be published, got %v", got)
+	}
+
+	// Nothing of the aggregate is published while the retry is pending
+	if published, _ := relay.RelayOnce(context.Background()); published != 0 {
+		t.Errorf("expected aggregate to stay blocked during backoff, got %d published", published)
+	}
+
+	// Once the publisher recovers and the retry is due, delivery resumes in order
+	publisher.failing = nil
+	repo.SetNextAttempt(failing.Sequence, time.Now())
+	if published, _ := relay.RelayOnce(context.Background()); published != 2 {
+		t.Errorf("expected 2 published messages after recovery, got %d", published)
+	}
+
+	expected := []string{"user.registered", "user.email_changed", "user.deleted"}
+	got := publisher.eventTypes("user-1")
+	for i := range expected {
+		if i >= len(got) || got[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestRelayCleanup(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	relay := usecase.NewRelay(repo, &recordingPublisher{}, usecase.WithRetention(time.Nanosecond))
+
+	repo.Add("user-1", "user.registered")
+	_, _ = relay.RelayOnce(context.Background())
+	repo.Add("user-1", "user.deleted")
+
+	time.Sleep(time.Millisecond)
+	deleted, err := relay.Cleanup(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deleted != 1 || repo.Len() != 1 {
+		t.Errorf("expected only the published message to be removed, deleted %d, left %d", deleted, repo.Len())
+	}
+}
+
+func TestRelayRun(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	publisher := &recordingPublisher{}
+	relay := usecase.NewRelay(repo, publisher, usecase.WithPollInterval(5*time.Millisecond), usecase.WithBatchSize(2))
+
+	for i := 0; i < 5; i++ {
+		repo.Add("user-1", "user.registered")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if pending, _ := repo.ListPending(context.Background(), time.Now(), 10); len(pending) == 0 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	if pending, _ := repo.ListPending(context.Background(), time.Now(), 10); len(pending) != 0 {
+		t.Errorf("expected all messages to be published, %d pending", len(pending))
+	}
+}
+
+// memoryLocker is a Locker shared by relays of one test
+type memoryLocker struct {
+	held map[string]bool
+}
+
+func (l *memoryLocker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	if l.held[name] {
+		return nil, false, nil
+	}
+	l.held[name] = true
+	return func() { delete(l.held, name) }, true, nil
+}
+
+func TestRelaySkipsWhileAnotherRelayHoldsTheLock(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	publisher := &recordingPublisher{}
+	locker := &memoryLocker{held: make(map[string]bool)}
+	relay := usecase.NewRelay(repo, publisher, usecase.WithLocker(locker))
+
+	repo.Add("user-1", "user.registered")
+	repo.Add("user-1", "user.deleted")
+
+	// Another instance is publishing
+	release, _, _ := locker.TryLock(context.Background(), domain.RelayLockName)
+	if published, err := relay.RelayOnce(context.Background()); err != nil || published != 0 {
+		t.Fatalf("expected nothing published while the lock is held, got %d, %v", published, err)
+	}
+	release()
+
+	if published, err := relay.RelayOnce(context.Background()); err != nil || published != 2 {
+		t.Fatalf("expected the batch published once the lock is free, got %d, %v", published, err)
+	}
+	if locker.held[domain.RelayLockName] {
+		t.Error("expected the lock released after the batch")
+	}
+}
@@ -0,0 +1,59 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/infrastructure/test/sqlitetest"
+	"github.com/zercle/template-go-echo/internal/outbox/repository"
+)
+
+// testEvent is a minimal domain event
+type testEvent struct {
+	eventType   string
+	aggregateID string
+	occurredAt  time.Time
+}
+
+func (e testEvent) EventType() string     { return e.eventType }
+func (e testEvent) AggregateType() string { return "user" }
+func (e testEvent) AggregateID() string   { return e.aggregateID }
+func (e testEvent) OccurredAt() time.Time { return e.occurredAt }
+
+func TestSQLiteListPendingHoldsBackRetriedAggregates(t *testing.T) {
+	db := sqlitetest.Open(t)
+	q := db.Querier()
+	repo := repository.New(q)
+	ctx := context.Background()
+	now := time.Now()
+
+	err := repository.Append(ctx, q,
+		testEvent{eventType: "user.registered", aggregateID: "user-1", occurredAt: now},
+		testEvent{eventType: "user.registered", aggregateID: "user-2", occurredAt: now},
+		testEvent{eventType: "user.deleted", aggregateID: "user-1", occurredAt: now},
+	)
+	if err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	pending, err := repo.ListPending(ctx, now.Add(time.Second), 10)
+	if err != nil {
+		t.Fatalf("ListPending failed: %v", err)
+	}
+	if len(pending) != 3 {
+		t.Fatalf("expected 3 pending messages, got %d", len(pending))
+	}
+
+	// The first message of user-1 waits for a retry, holding back the later one
+	if err := repo.MarkFailed(ctx, pending[0].Sequence, "broker unavailable", now.Add(time.Hour)); err != nil {
+		t.Fatalf("MarkFailed failed: %v", err)
+	}
+	pending, err = repo.ListPending(ctx, now.Add(time.Second), 10)
+	if err != nil {
+		t.Fatalf("ListPending failed: %v", err)
+	}
+	if len(pending) != 1 || pending[0].AggregateID != "user-2" {
+		t.Fatalf("expected only the user-2 message, got %+v", pending)
+	}
+}
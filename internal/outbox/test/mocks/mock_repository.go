@@ -0,0 +1,136 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zercle/template-go-echo/internal/outbox/domain"
+)
+
+// MockOutboxRepository is a simple in-memory outbox for testing
+type MockOutboxRepository struct {
+	mu       sync.Mutex
+	messages []*domain.Message
+	sequence uint64
+}
+
+// NewMockRepository creates a new mock repository
+func NewMockRepository() *MockOutboxRepository {
+	return &MockOutboxRepository{}
+}
+
+// Add stores a pending message for the given aggregate, as a state change would
+func (m *MockOutboxRepository) Add(aggregateID, eventType string) *domain.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sequence++
+	message := &domain.Message{
+		Sequence:      m.sequence,
+		EventID:       uuid.New().String(),
+		AggregateType: "user",
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Payload:       []byte(`{}`),
+		OccurredAt:    time.Now(),
+		NextAttemptAt: time.Now(),
+	}
+	m.messages = append(m.messages, message)
+	return message
+}
+
+// Get returns a copy of the message with the given sequence number
+func (m *MockOutboxRepository) Get(sequence uint64) *domain.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, message := range m.messages {
+		if message.Sequence == sequence {
+			copied := *message
+			return &copied
+		}
+	}
+	return nil
+}
+
+// Len returns the number of stored messages
+func (m *MockOutboxRepository) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.messages)
+}
+
+func (m *MockOutboxRepository) ListPending(ctx context.Context, now time.Time, limit int) ([]*domain.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	waiting := make(map[string]bool)
+	var pending []*domain.Message
+	for _, message := range m.messages {
+		if message.PublishedAt != nil {
+			continue
+		}
+		key := message.AggregateType + "/" + message.AggregateID
+		if message.NextAttemptAt.After(now) {
+			waiting[key] = true
+			continue
+		}
+		if waiting[key] || len(pending) == limit {
+			continue
+		}
+		copied := *message
+		pending = append(pending, &copied)
+	}
+	return pending, nil
+}
+
+func (m *MockOutboxRepository) MarkPublished(ctx context.Context, sequence uint64, publishedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, message := range m.messages {
+		if message.Sequence == sequence {
+			message.PublishedAt = &publishedAt
+		}
+	}
+	return nil
+}
+
+func (m *MockOutboxRepository) MarkFailed(ctx context.Context, sequence uint64, lastError string, nextAttemptAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, message := range m.messages {
+		if message.Sequence == sequence {
+			message.Attempts++
+			message.LastError = lastError
+			message.NextAttemptAt = nextAttemptAt
+		}
+	}
+	return nil
+}
+
+func (m *MockOutboxRepository) DeletePublished(ctx context.Context, publishedBefore time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	kept := m.messages[:0]
+	deleted := 0
+	for _, message := range m.messages {
+		if message.PublishedAt != nil && message.PublishedAt.Before(publishedBefore) {
+			deleted++
+			continue
+		}
+		kept = append(kept, message)
+	}
+	m.messages = kept
+	return deleted, nil
+}
+
+// SetNextAttempt moves the retry time of a message, simulating the passage of time
+func (m *MockOutboxRepository) SetNextAttempt(sequence uint64, at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, message := range m.messages {
+		if message.Sequence == sequence {
+			message.NextAttemptAt = at
+		}
+	}
+}
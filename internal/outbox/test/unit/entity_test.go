@@ -0,0 +1,60 @@
+package unit_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/outbox/domain"
+)
+
+type testEvent struct {
+	UserID string    `json:"user_id"`
+	At     time.Time `json:"at"`
+}
+
+func (e testEvent) EventType() string     { return "test.happened" }
+func (e testEvent) AggregateType() string { return "test" }
+func (e testEvent) AggregateID() string   { return e.UserID }
+func (e testEvent) OccurredAt() time.Time { return e.At }
+
+func TestNewMessage(t *testing.T) {
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	message, err := domain.NewMessage(testEvent{UserID: "user-1", At: at})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if message.EventID == "" {
+		t.Error("expected event ID to be generated")
+	}
+	if message.EventType != "test.happened" || message.AggregateType != "test" || message.AggregateID != "user-1" {
+		t.Errorf("unexpected message metadata: %+v", message)
+	}
+	if !message.OccurredAt.Equal(at) || !message.NextAttemptAt.Equal(at) {
+		t.Error("expected message to be due when the event occurred")
+	}
+
+	var payload map[string]string
+	if err := json.Unmarshal(message.Payload, &payload); err != nil || payload["user_id"] != "user-1" {
+		t.Errorf("expected JSON payload, got %s", message.Payload)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{1, domain.MinRetryBackoff},
+		{2, 2 * domain.MinRetryBackoff},
+		{4, 8 * domain.MinRetryBackoff},
+		{100, domain.MaxRetryBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := domain.RetryBackoff(tt.attempts); got != tt.expected {
+			t.Errorf("RetryBackoff(%d) = %v, expected %v", tt.attempts, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zercle/template-go-echo/internal/outbox/domain"
+)
+
+// LogPublisher implements domain.Publisher by logging messages. It is the
+// default until a message broker publisher is configured.
+type LogPublisher struct{}
+
+// Publish logs the message
+func (LogPublisher) Publish(ctx context.Context, message *domain.Message) error {
+	slog.Info("outbox event published",
+		slog.String("event_id", message.EventID),
+		slog.String("event_type", message.EventType),
+		slog.String("aggregate_type", message.AggregateType),
+		slog.String("aggregate_id", message.AggregateID))
+	return nil
+}
+
+// MultiPublisher implements domain.Publisher by publishing to each publisher in
+// order. A failure stops the rest and the whole message is retried, so every
+// publisher must tolerate duplicates.
+type MultiPublisher []domain.Publisher
+
+// Publish publishes the message to every publisher
+func (m MultiPublisher) Publish(ctx context.Context, message *domain.Message) error {
+	for _, publisher := range m {
+		if err := publisher.Publish(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
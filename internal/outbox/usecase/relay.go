@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/outbox/domain"
+)
+
+// Relay delivers outbox messages through a Publisher at least once. Messages of
+// the same aggregate are published one at a time in sequence order: when one
+// fails, later messages of that aggregate wait until it has been delivered.
+// Relays of several instances must share a Locker, so that only one of them
+// publishes at a time; without one, run a single relay per database, since
+// concurrent relays may duplicate and reorder deliveries.
+type Relay struct {
+	repo           domain.OutboxRepository
+	publisher      domain.Publisher
+	locker         domain.Locker
+	pollInterval   time.Duration
+	batchSize      int
+	publishTimeout time.Duration
+	retention      time.Duration
+}
+
+// RelayOption configures optional Relay settings
+type RelayOption func(*Relay)
+
+// WithPollInterval sets how often the outbox is checked for new messages
+func WithPollInterval(interval time.Duration) RelayOption {
+	return func(r *Relay) {
+		r.pollInterval = interval
+	}
+}
+
+// WithBatchSize sets the maximum number of messages read per poll
+func WithBatchSize(size int) RelayOption {
+	return func(r *Relay) {
+		r.batchSize = size
+	}
+}
+
+// WithPublishTimeout sets how long a single delivery may take before it is retried
+func WithPublishTimeout(timeout time.Duration) RelayOption {
+	return func(r *Relay) {
+		r.publishTimeout = timeout
+	}
+}
+
+// WithRetention sets how long published messages are kept; zero keeps them forever
+func WithRetention(retention time.Duration) RelayOption {
+	return func(r *Relay) {
+		r.retention = retention
+	}
+}
+
+// WithLocker sets the lock each batch is published under, so that relays of
+// several instances take turns
+func WithLocker(locker domain.Locker) RelayOption {
+	return func(r *Relay) {
+		r.locker = locker
+	}
+}
+
+// NewRelay creates a relay publishing outbox messages through publisher
+func NewRelay(repo domain.OutboxRepository, publisher domain.Publisher, opts ...RelayOption) *Relay {
+	r := &Relay{
+		repo:           repo,
+		publisher:      publisher,
+		pollInterval:   domain.DefaultPollInterval,
+		batchSize:      domain.DefaultBatchSize,
+		publishTimeout: domain.DefaultPublishTimeout,
+		retention:      domain.DefaultRetention,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Run polls the outbox until ctx is cancelled, publishing pending messages and
+// periodically removing published messages older than the retention period
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	var lastCleanup time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Keep going while full batches indicate a backlog
+		for ctx.Err() == nil {
+			published, err := r.RelayOnce(ctx)
+			if err != nil || published < r.batchSize {
+				break
+			}
+		}
+
+		if r.retention > 0 && time.Since(lastCleanup) >= domain.CleanupInterval {
+			if _, err := r.Cleanup(ctx); err == nil {
+				lastCleanup = time.Now()
+			}
+		}
+	}
+}
+
+// RelayOnce publishes one batch of pending messages and returns how many were
+// delivered. While another relay holds the lock it publishes nothing.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	if r.locker != nil {
+		release, acquired, err := r.locker.TryLock(ctx, domain.RelayLockName)
+		if err != nil || !acquired {
+			return 0, err
+		}
+		defer release()
+	}
+
+	messages, err := r.repo.ListPending(ctx, time.Now(), r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	blocked := make(map[string]bool)
+	for _, message := range messages {
+		key := message.AggregateType + "/" + message.AggregateID
+		if blocked[key] {
+			continue
+		}
+
+		if err := r.publish(ctx, message); err != nil {
+			// Hold back the rest of the aggregate so it is delivered in order
+			blocked[key] = true
+
+			attempts := message.Attempts + 1
+			nextAttemptAt := time.Now().Add(domain.RetryBackoff(attempts))
+			slog.Warn("failed to publish outbox message",
+				slog.String("event_id", message.EventID),
+				slog.String("event_type", message.EventType),
+				slog.Int("attempts", attempts),
+				slog.String("error", err.Error()))
+
+			if err := r.repo.MarkFailed(ctx, message.Sequence, err.Error(), nextAttemptAt); err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		// A failure here leads to a redelivery, which consumers deduplicate by event ID
+		if err := r.repo.MarkPublished(ctx, message.Sequence, time.Now()); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+// Cleanup removes published messages older than the retention period
+func (r *Relay) Cleanup(ctx context.Context) (int, error) {
+	deleted, err := r.repo.DeletePublished(ctx, time.Now().Add(-r.retention))
+	if err != nil {
+		return 0, err
+	}
+
+	if deleted > 0 {
+		slog.Info("published outbox messages removed", slog.Int("count", deleted))
+	}
+	return deleted, nil
+}
+
+// publish delivers a message within the publish timeout
+func (r *Relay) publish(ctx context.Context, message *domain.Message) error {
+	ctx, cancel := context.WithTimeout(ctx, r.publishTimeout)
+	defer cancel()
+	return r.publisher.Publish(ctx, message)
+}
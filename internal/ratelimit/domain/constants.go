@@ -0,0 +1,47 @@
+package domain
+
+const (
+	// Response headers, as in the IETF RateLimit header fields draft
+	HeaderLimit     = "RateLimit-Limit"
+	HeaderRemaining = "RateLimit-Remaining"
+	HeaderReset     = "RateLimit-Reset"
+
+	// Request header carrying the API key of API key policies
+	HeaderAPIKey = "X-API-Key"
+
+	// Error code of rejected requests
+	ErrCodeRateLimited = "RATE_LIMIT_EXCEEDED"
+)
+
+// Algorithms counting requests
+const (
+	// AlgorithmGCRA spaces requests evenly, allowing bursts of up to Burst
+	AlgorithmGCRA = "gcra"
+
+	// AlgorithmSlidingWindow allows Limit requests in any window of Period,
+	// weighting the previous fixed window by its overlap with the sliding one
+	AlgorithmSlidingWindow = "sliding_window"
+)
+
+// Keys requests are counted under
+const (
+	KeyIP     = "ip"
+	KeyUser   = "user"
+	KeyAPIKey = "api_key"
+	KeyRoute  = "route"
+)
+
+// Store types selected by configuration
+const (
+	StoreMemory = "memory"
+	StoreRedis  = "redis"
+)
+
+// Policies applied by the API when configured
+const (
+	// PolicyGlobal applies to every request
+	PolicyGlobal = "global"
+
+	// PolicyAuth applies to registration, login, token refresh and restore
+	PolicyAuth = "auth"
+)
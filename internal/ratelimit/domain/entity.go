@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Policy is a named limit on the requests sharing a key, such as those of one
+// client IP address
+type Policy struct {
+	Name      string
+	Key       string
+	Limit     int
+	Period    time.Duration
+	Algorithm string
+
+	// Burst is how many requests GCRA allows at once; Limit when zero
+	Burst int
+
+	// DryRun logs requests exceeding the limit instead of rejecting them
+	DryRun bool
+}
+
+// Result is the outcome of counting a request under a policy
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+
+	// Reset is how long until the limit is available again in full, or
+	// until the current window ends for sliding windows
+	Reset time.Duration
+
+	// RetryAfter is how long until a request would be allowed, when denied
+	RetryAfter time.Duration
+}
+
+// BurstSize returns how many requests GCRA allows at once
+func (p Policy) BurstSize() int {
+	if p.Burst > 0 {
+		return p.Burst
+	}
+	return p.Limit
+}
+
+// Interval returns the spacing GCRA enforces between requests once the burst is spent
+func (p Policy) Interval() time.Duration {
+	return p.Period / time.Duration(p.Limit)
+}
+
+// GCRA decides a request arriving at now given the theoretical arrival time
+// stored for its key, or the zero time when none is stored. It returns the
+// result and the theoretical arrival time to store, unchanged when the
+// request is denied.
+func (p Policy) GCRA(now, tat time.Time) (Result, time.Time) {
+	if tat.Before(now) {
+		tat = now
+	}
+	next := tat.Add(p.Interval())
+	allowAt := next.Add(-p.Interval() * time.Duration(p.BurstSize()))
+	if now.Before(allowAt) {
+		return p.GCRAResult(now, tat, false), tat
+	}
+	return p.GCRAResult(now, next, true), next
+}
+
+// GCRAResult describes the theoretical arrival time of a key after a request
+// at now was allowed or denied, for stores deciding requests themselves
+func (p Policy) GCRAResult(now, tat time.Time, allowed bool) Result {
+	interval := p.Interval()
+	burst := p.BurstSize()
+	ahead := max(tat.Sub(now), 0)
+
+	result := Result{
+		Allowed:   allowed,
+		Limit:     burst,
+		Remaining: max(burst-int((ahead+interval-1)/interval), 0),
+		Reset:     ahead,
+	}
+	if !allowed {
+		result.RetryAfter = max(ahead-interval*time.Duration(burst-1), 0)
+	}
+	return result
+}
+
+// PreviousWeight returns the share of the previous fixed window still inside
+// the sliding window, elapsed into the current fixed window
+func (p Policy) PreviousWeight(elapsed time.Duration) float64 {
+	return 1 - float64(elapsed)/float64(p.Period)
+}
+
+// SlidingWindowAllows reports whether a request arriving elapsed into the
+// current fixed window keeps the weighted count of the previous and current
+// windows within Limit. An allowed request is then counted in the current window.
+func (p Policy) SlidingWindowAllows(previous, current int64, elapsed time.Duration) bool {
+	return float64(previous)*p.PreviousWeight(elapsed)+float64(current)+1 <= float64(p.Limit)
+}
+
+// SlidingWindowResult describes the counts of a key after a request was
+// allowed, and counted in current, or denied
+func (p Policy) SlidingWindowResult(previous, current int64, elapsed time.Duration, allowed bool) Result {
+	limit := float64(p.Limit)
+	period := float64(p.Period)
+	count := float64(previous)*p.PreviousWeight(elapsed) + float64(current)
+
+	result := Result{
+		Allowed:   allowed,
+		Limit:     p.Limit,
+		Remaining: max(int(limit-count), 0),
+		Reset:     p.Period - elapsed,
+	}
+	if allowed {
+		return result
+	}
+
+	// Either the previous window must slide out far enough, or the current
+	// window is full and becomes the previous one
+	var wait float64
+	if float64(current)+1 <= limit {
+		wait = period*(1-(limit-float64(current)-1)/float64(previous)) - float64(elapsed)
+	} else {
+		wait = period - float64(elapsed) + period*(1-(limit-1)/float64(current))
+	}
+	result.RetryAfter = time.Duration(math.Ceil(max(wait, 0)))
+	return result
+}
+
+// ParsePolicies parses comma separated policies of the form
+// name=key:limit/period[:option...], where key is ip, user, api_key or route,
+// and options are gcra or sliding_window, burst=N and dry_run. For example,
+// "auth=ip:10/1m:sliding_window" allows 10 logins per minute per IP address.
+// GCRA is used unless set otherwise.
+func ParsePolicies(spec string) (map[string]Policy, error) {
+	policies := make(map[string]Policy)
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		policy, err := parsePolicy(item)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := policies[policy.Name]; exists {
+			return nil, fmt.Errorf("rate limit policy %q is defined twice", policy.Name)
+		}
+		policies[policy.Name] = policy
+	}
+	return policies, nil
+}
+
+// parsePolicy parses a single name=key:limit/period[:option...] policy
+func parsePolicy(item string) (Policy, error) {
+	name, rest, ok := strings.Cut(item, "=")
+	fields := strings.Split(rest, ":")
+	if !ok || name == "" || len(fields) < 2 {
+		return Policy{}, fmt.Errorf("rate limit policy %q must look like name=key:limit/period", item)
+	}
+
+	policy := Policy{Name: name, Key: fields[0], Algorithm: AlgorithmGCRA}
+	switch policy.Key {
+	case KeyIP, KeyUser, KeyAPIKey, KeyRoute:
+	default:
+		return Policy{}, fmt.Errorf("rate limit policy %q: key must be ip, user, api_key or route", name)
+	}
+
+	limit, period, ok := strings.Cut(fields[1], "/")
+	var err error
+	if policy.Limit, err = strconv.Atoi(limit); !ok || err != nil || policy.Limit <= 0 {
+		return Policy{}, fmt.Errorf("rate limit policy %q: limit must be a positive number", name)
+	}
+	if policy.Period, err = time.ParseDuration(period); err != nil || policy.Period <= 0 {
+		return Policy{}, fmt.Errorf("rate limit policy %q: period must be a positive duration", name)
+	}
+	if policy.Interval() < time.Microsecond {
+		return Policy{}, fmt.Errorf("rate limit policy %q: limit is too high for the period", name)
+	}
+
+	for _, option := range fields[2:] {
+		switch {
+		case option == AlgorithmGCRA || option == AlgorithmSlidingWindow:
+			policy.Algorithm = option
+		case option == "dry_run":
+			policy.DryRun = true
+		case strings.HasPrefix(option, "burst="):
+			if policy.Burst, err = strconv.Atoi(strings.TrimPrefix(option, "burst=")); err != nil || policy.Burst <= 0 {
+				return Policy{}, fmt.Errorf("rate limit policy %q: burst must be a positive number", name)
+			}
+		default:
+			return Policy{}, fmt.Errorf("rate limit policy %q: unknown option %q", name, option)
+		}
+	}
+	if policy.Burst > 0 && policy.Algorithm != AlgorithmGCRA {
+		return Policy{}, fmt.Errorf("rate limit policy %q: burst only applies to gcra", name)
+	}
+	return policy, nil
+}
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Store counts requests per policy and key. Implementations must count
+// atomically across all API instances sharing the store.
+type Store interface {
+	// Allow counts a request arriving at now under key and returns whether
+	// policy allows it. Denied requests are not counted.
+	Allow(ctx context.Context, policy Policy, key string, now time.Time) (Result, error)
+}
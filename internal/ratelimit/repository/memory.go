@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/ratelimit/domain"
+)
+
+// memorySweepInterval is how often Allow drops idle keys from a MemoryStore
+const memorySweepInterval = time.Minute
+
+// memoryEntry is the state of a key: the theoretical arrival time for GCRA,
+// or the counts of the current and previous fixed windows for sliding windows
+type memoryEntry struct {
+	tat      time.Time
+	window   int64
+	current  int64
+	previous int64
+
+	// idleAt is when the state no longer limits anything and can be dropped
+	idleAt time.Time
+}
+
+// MemoryStore implements domain.Store in process memory. Limits are not
+// shared between API instances, so each instance allows the full limit.
+// Keys idle long enough to have no effect on their limit are dropped.
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]*memoryEntry
+	lastSweep time.Time
+}
+
+// NewMemoryStore creates an empty in-memory rate limit store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]*memoryEntry)}
+}
+
+// Allow counts a request under key unless policy denies it
+func (s *MemoryStore) Allow(ctx context.Context, policy domain.Policy, key string, now time.Time) (domain.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= memorySweepInterval {
+		s.sweep(now)
+		s.lastSweep = now
+	}
+
+	key = policy.Name + ":" + key
+	entry, ok := s.entries[key]
+	if !ok {
+		entry = &memoryEntry{}
+		s.entries[key] = entry
+	}
+
+	if policy.Algorithm == domain.AlgorithmSlidingWindow {
+		return s.slidingWindow(policy, entry, now), nil
+	}
+
+	result, tat := policy.GCRA(now, entry.tat)
+	entry.tat, entry.idleAt = tat, tat
+	return result, nil
+}
+
+// slidingWindow counts a request in the fixed windows of entry
+func (s *MemoryStore) slidingWindow(policy domain.Policy, entry *memoryEntry, now time.Time) domain.Result {
+	window := now.UnixNano() / int64(policy.Period)
+	switch window {
+	case entry.window:
+	case entry.window + 1:
+		entry.previous, entry.current = entry.current, 0
+	default:
+		entry.previous, entry.current = 0, 0
+	}
+	entry.window = window
+	entry.idleAt = time.Unix(0, (window+2)*int64(policy.Period))
+
+	elapsed := time.Duration(now.UnixNano() - window*int64(policy.Period))
+	allowed := policy.SlidingWindowAllows(entry.previous, entry.current, elapsed)
+	if allowed {
+		entry.current++
+	}
+	return policy.SlidingWindowResult(entry.previous, entry.current, elapsed, allowed)
+}
+
+// sweep drops idle keys; the caller holds the lock
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, entry := range s.entries {
+		if !now.Before(entry.idleAt) {
+			delete(s.entries, key)
+		}
+	}
+}
+
+// Len returns the number of keys held
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zercle/template-go-echo/internal/ratelimit/domain"
+)
+
+// DefaultKeyPrefix namespaces the keys rate limits are counted under in Redis
+const DefaultKeyPrefix = "template-go-echo:ratelimit:"
+
+// gcraScript advances the theoretical arrival time of KEYS[1] when the
+// request arriving at ARGV[1] is allowed. Times are in microseconds.
+var gcraScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+local burst = tonumber(ARGV[3])
+local tat = tonumber(redis.call('GET', KEYS[1]) or now)
+if tat < now then
+	tat = now
+end
+local next_tat = tat + interval
+if now < next_tat - interval * burst then
+	return {0, tat}
+end
+redis.call('SET', KEYS[1], string.format('%d', next_tat), 'PX', math.ceil((next_tat - now) / 1000))
+return {1, next_tat}
+`)
+
+// slidingWindowScript counts a request in the current window KEYS[1] when the
+// weighted count with the previous window KEYS[2] stays within ARGV[1]. ARGV[2]
+// is the weight of the previous window and ARGV[3] the window length in milliseconds.
+var slidingWindowScript = redis.NewScript(`
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+local previous = tonumber(redis.call('GET', KEYS[2]) or '0')
+if previous * tonumber(ARGV[2]) + current + 1 > tonumber(ARGV[1]) then
+	return {0, previous, current}
+end
+current = redis.call('INCR', KEYS[1])
+if current == 1 then
+	redis.call('PEXPIRE', KEYS[1], tonumber(ARGV[3]) * 2)
+end
+return {1, previous, current}
+`)
+
+// RedisStore implements domain.Store on Redis or Valkey, so that every API
+// instance counts against the same limit. Each request is decided by a
+// script, atomically. The keys of a policy and client share a hash tag, so
+// the store also works on Redis Cluster. Instance clocks should be kept in
+// sync, as requests are timed by the instance receiving them.
+type RedisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisStore creates a rate limit store counting on client
+func NewRedisStore(client redis.UniversalClient) *RedisStore {
+	return &RedisStore{client: client, prefix: DefaultKeyPrefix}
+}
+
+// Allow counts a request under key unless policy denies it
+func (s *RedisStore) Allow(ctx context.Context, policy domain.Policy, key string, now time.Time) (domain.Result, error) {
+	key = s.prefix + "{" + policy.Name + ":" + key + "}"
+	if policy.Algorithm == domain.AlgorithmSlidingWindow {
+		return s.slidingWindow(ctx, policy, key, now)
+	}
+
+	values, err := gcraScript.Run(ctx, s.client, []string{key},
+		now.UnixMicro(), policy.Interval().Microseconds(), policy.BurstSize()).Int64Slice()
+	if err != nil {
+		return domain.Result{}, err
+	}
+	if len(values) != 2 {
+		return domain.Result{}, fmt.Errorf("unexpected gcra script reply %v", values)
+	}
+	return policy.GCRAResult(now, time.UnixMicro(values[1]), values[0] == 1), nil
+}
+
+// slidingWindow counts a request in the fixed windows of key
+func (s *RedisStore) slidingWindow(ctx context.Context, policy domain.Policy, key string, now time.Time) (domain.Result, error) {
+	window := now.UnixNano() / int64(policy.Period)
+	elapsed := time.Duration(now.UnixNano() - window*int64(policy.Period))
+	keys := []string{
+		key + ":" + strconv.FormatInt(window, 10),
+		key + ":" + strconv.FormatInt(window-1, 10),
+	}
+
+	values, err := slidingWindowScript.Run(ctx, s.client, keys,
+		policy.Limit,
+		strconv.FormatFloat(policy.PreviousWeight(elapsed), 'f', -1, 64),
+		max(policy.Period.Milliseconds(), 1)).Int64Slice()
+	if err != nil {
+		return domain.Result{}, err
+	}
+	if len(values) != 3 {
+		return domain.Result{}, fmt.Errorf("unexpected sliding window script reply %v", values)
+	}
+	return policy.SlidingWindowResult(values[1], values[2], elapsed, values[0] == 1), nil
+}
@@ -0,0 +1,71 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/zercle/template-go-echo/internal/ratelimit/domain"
+	"github.com/zercle/template-go-echo/internal/ratelimit/repository"
+)
+
+// newRedisStore returns a store counting on an in-process Redis server
+func newRedisStore(t *testing.T) (*repository.RedisStore, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return repository.NewRedisStore(client), server
+}
+
+// TestRedisStoreMatchesMemoryStore checks both stores decide the same
+// sequence of requests alike
+func TestRedisStoreMatchesMemoryStore(t *testing.T) {
+	policies := []domain.Policy{
+		{Name: "global", Key: domain.KeyIP, Limit: 5, Period: time.Second, Algorithm: domain.AlgorithmGCRA, Burst: 3},
+		{Name: "auth", Key: domain.KeyIP, Limit: 3, Period: time.Minute, Algorithm: domain.AlgorithmSlidingWindow},
+	}
+	start := time.Unix(1_700_000_040, 0)
+	offsets := []time.Duration{0, 0, 0, 0, 100 * time.Millisecond, 200 * time.Millisecond, 45 * time.Second, 75 * time.Second, 75 * time.Second, 90 * time.Second}
+
+	for _, policy := range policies {
+		t.Run(policy.Name, func(t *testing.T) {
+			redisStore, server := newRedisStore(t)
+			memoryStore := repository.NewMemoryStore()
+			ctx := context.Background()
+
+			for i, offset := range offsets {
+				now := start.Add(offset)
+				server.SetTime(now)
+				want, _ := memoryStore.Allow(ctx, policy, "ip:192.0.2.1", now)
+				got, err := redisStore.Allow(ctx, policy, "ip:192.0.2.1", now)
+				if err != nil {
+					t.Fatalf("request %d: unexpected error: %v", i, err)
+				}
+				if got != want {
+					t.Errorf("request %d at %v: expected %+v, got %+v", i, offset, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRedisStoreExpiresKeys(t *testing.T) {
+	store, server := newRedisStore(t)
+	policy := domain.Policy{Name: "global", Key: domain.KeyIP, Limit: 10, Period: time.Second, Algorithm: domain.AlgorithmGCRA}
+	ctx := context.Background()
+
+	if _, err := store.Allow(ctx, policy, "ip:192.0.2.1", time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	key := repository.DefaultKeyPrefix + "{global:ip:192.0.2.1}"
+	if !server.Exists(key) {
+		t.Fatalf("expected %s to be stored, got %v", key, server.Keys())
+	}
+
+	server.FastForward(time.Second)
+	if server.Exists(key) {
+		t.Errorf("expected %s to expire once idle", key)
+	}
+}
@@ -0,0 +1,62 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/ratelimit/domain"
+	"github.com/zercle/template-go-echo/internal/ratelimit/repository"
+)
+
+func TestMemoryStoreSlidingWindow(t *testing.T) {
+	store := repository.NewMemoryStore()
+	policy := domain.Policy{Name: "auth", Key: domain.KeyIP, Limit: 2, Period: time.Minute, Algorithm: domain.AlgorithmSlidingWindow}
+	ctx := context.Background()
+	start := time.Unix(1_700_000_040, 0) // the start of a fixed window
+
+	for i := 0; i < 2; i++ {
+		if result, _ := store.Allow(ctx, policy, "ip:192.0.2.1", start); !result.Allowed {
+			t.Fatalf("request %d: expected allowed, got %+v", i, result)
+		}
+	}
+	if result, _ := store.Allow(ctx, policy, "ip:192.0.2.1", start.Add(59*time.Second)); result.Allowed {
+		t.Fatalf("expected the limit to be reached, got %+v", result)
+	}
+	if result, _ := store.Allow(ctx, policy, "ip:192.0.2.2", start); !result.Allowed {
+		t.Errorf("expected another key to be counted separately, got %+v", result)
+	}
+
+	// Half into the next window, half of the previous two requests still count
+	if result, _ := store.Allow(ctx, policy, "ip:192.0.2.1", start.Add(90*time.Second)); !result.Allowed || result.Remaining != 0 {
+		t.Errorf("expected one request to be allowed, got %+v", result)
+	}
+	if result, _ := store.Allow(ctx, policy, "ip:192.0.2.1", start.Add(90*time.Second)); result.Allowed {
+		t.Errorf("expected the limit to be reached again, got %+v", result)
+	}
+}
+
+func TestMemoryStoreEvictsIdleKeys(t *testing.T) {
+	store := repository.NewMemoryStore()
+	gcra := domain.Policy{Name: "global", Key: domain.KeyIP, Limit: 10, Period: time.Second, Algorithm: domain.AlgorithmGCRA}
+	sliding := domain.Policy{Name: "auth", Key: domain.KeyIP, Limit: 10, Period: time.Minute, Algorithm: domain.AlgorithmSlidingWindow}
+	ctx := context.Background()
+	now := time.Unix(1_700_000_040, 0)
+
+	_, _ = store.Allow(ctx, gcra, "ip:192.0.2.1", now)
+	_, _ = store.Allow(ctx, sliding, "ip:192.0.2.1", now)
+	if store.Len() != 2 {
+		t.Fatalf("expected 2 keys, got %d", store.Len())
+	}
+
+	// After a minute the GCRA key is idle, but the sliding window still counts
+	_, _ = store.Allow(ctx, gcra, "ip:192.0.2.2", now.Add(time.Minute))
+	if store.Len() != 2 {
+		t.Fatalf("expected the idle GCRA key to be dropped, got %d keys", store.Len())
+	}
+
+	_, _ = store.Allow(ctx, gcra, "ip:192.0.2.3", now.Add(3*time.Minute))
+	if store.Len() != 1 {
+		t.Errorf("expected only the new key to remain, got %d keys", store.Len())
+	}
+}
@@ -0,0 +1,102 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/ratelimit/domain"
+)
+
+func TestParsePolicies(t *testing.T) {
+	policies, err := domain.ParsePolicies("global=ip:100/1s:burst=200, auth=user:10/1m:sliding_window:dry_run")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]domain.Policy{
+		"global": {Name: "global", Key: domain.KeyIP, Limit: 100, Period: time.Second, Algorithm: domain.AlgorithmGCRA, Burst: 200},
+		"auth":   {Name: "auth", Key: domain.KeyUser, Limit: 10, Period: time.Minute, Algorithm: domain.AlgorithmSlidingWindow, DryRun: true},
+	}
+	if len(policies) != len(want) {
+		t.Fatalf("expected %d policies, got %v", len(want), policies)
+	}
+	for name, policy := range want {
+		if policies[name] != policy {
+			t.Errorf("expected %s to be %+v, got %+v", name, policy, policies[name])
+		}
+	}
+}
+
+func TestParsePoliciesRejectsInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"global",
+		"global=ip",
+		"global=tenant:1/1s",
+		"global=ip:0/1s",
+		"global=ip:ten/1s",
+		"global=ip:1/0s",
+		"global=ip:1/forever",
+		"global=ip:10000000/1s",
+		"global=ip:1/1s:burst=0",
+		"global=ip:1/1s:sliding_window:burst=2",
+		"global=ip:1/1s:fast",
+		"global=ip:1/1s,global=ip:2/1s",
+	} {
+		if _, err := domain.ParsePolicies(spec); err == nil {
+			t.Errorf("expected %q to be rejected", spec)
+		}
+	}
+}
+
+func TestGCRA(t *testing.T) {
+	policy := domain.Policy{Limit: 2, Period: time.Minute, Algorithm: domain.AlgorithmGCRA, Burst: 3}
+	now := time.Unix(1_700_000_000, 0)
+
+	var tat time.Time
+	for i := 0; i < 3; i++ {
+		var result domain.Result
+		result, tat = policy.GCRA(now, tat)
+		if !result.Allowed || result.Remaining != 2-i {
+			t.Fatalf("request %d: expected allowed with %d remaining, got %+v", i, 2-i, result)
+		}
+	}
+
+	result, next := policy.GCRA(now, tat)
+	if result.Allowed || !next.Equal(tat) {
+		t.Fatalf("expected the burst to be spent, got %+v", result)
+	}
+	if result.RetryAfter != 30*time.Second || result.Reset != 90*time.Second {
+		t.Errorf("expected retry after 30s and reset after 90s, got %+v", result)
+	}
+
+	result, _ = policy.GCRA(now.Add(30*time.Second), tat)
+	if !result.Allowed || result.Remaining != 0 {
+		t.Errorf("expected a request after one interval to be allowed, got %+v", result)
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	policy := domain.Policy{Limit: 10, Period: time.Minute, Algorithm: domain.AlgorithmSlidingWindow}
+
+	// A quarter into the window, three quarters of the previous 8 requests still count
+	if !policy.SlidingWindowAllows(8, 3, 15*time.Second) {
+		t.Error("expected 6 + 3 requests to leave room for one more")
+	}
+	if policy.SlidingWindowAllows(8, 4, 15*time.Second) {
+		t.Error("expected 6 + 4 requests to fill the limit")
+	}
+
+	result := policy.SlidingWindowResult(8, 4, 15*time.Second, false)
+	if result.Remaining != 0 || result.Reset != 45*time.Second {
+		t.Errorf("expected nothing remaining and reset after 45s, got %+v", result)
+	}
+	// The previous window must weigh at most 5/8 for a fifth request to fit
+	if result.RetryAfter != 7500*time.Millisecond {
+		t.Errorf("expected retry after 7.5s, got %v", result.RetryAfter)
+	}
+
+	result = policy.SlidingWindowResult(0, 10, 15*time.Second, false)
+	if result.RetryAfter != 45*time.Second+6*time.Second {
+		t.Errorf("expected a full window to retry after it slides over, got %v", result.RetryAfter)
+	}
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+const (
+	// Pagination constraints
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+
+	// MaxJobNameLength keeps lock names within the 64 character limit of GET_LOCK
+	MaxJobNameLength = 50
+
+	// LockPrefix namespaces the database locks taken by the scheduler
+	LockPrefix = "scheduler:"
+
+	// MaxErrorLength bounds the error stored with a failed run; longer errors are truncated
+	MaxErrorLength = 4096
+
+	// DefaultHistoryRetention is how long run history is kept
+	DefaultHistoryRetention = 30 * 24 * time.Hour
+)
+
+// Run statuses
+const (
+	RunStatusRunning   = "running"
+	RunStatusSucceeded = "succeeded"
+	RunStatusFailed    = "failed"
+)
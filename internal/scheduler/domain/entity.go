@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Job is a task run on a cron schedule. Modules expose their jobs and the
+// process running the scheduler registers them.
+type Job struct {
+	// Name identifies the job in logs, run history and its database lock
+	Name string
+	// Schedule is a cron expression or descriptor accepted by ParseSchedule
+	Schedule string
+	// Jitter is the maximum random delay added before each run, spreading load across jobs and instances
+	Jitter time.Duration
+	// Timeout cancels the context of a run that takes longer; zero never cancels
+	Timeout time.Duration
+	// Run performs the job; an error marks the run as failed
+	Run func(ctx context.Context) error
+}
+
+// Run is one execution of a job
+type Run struct {
+	ID         string        `json:"id"`
+	JobName    string        `json:"job_name"`
+	Instance   string        `json:"instance"`
+	Status     string        `json:"status"`
+	Error      string        `json:"error,omitempty"`
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt *time.Time    `json:"finished_at,omitempty"`
+	Duration   time.Duration `json:"duration,omitempty"`
+}
+
+// RunQuery describes a cursor paginated run history listing, newest first
+type RunQuery struct {
+	JobName string
+	Status  string
+	Cursor  string
+	Limit   int
+}
+
+// RunCursor is the keyset position of a run in a listing
+type RunCursor struct {
+	StartedAt string `json:"t"`
+	ID        string `json:"id"`
+}
+
+// NewRunCursor returns the keyset position of run
+func NewRunCursor(run *Run) RunCursor {
+	return RunCursor{
+		StartedAt: run.StartedAt.UTC().Format(time.RFC3339Nano),
+		ID:        run.ID,
+	}
+}
+
+// IsValidRunStatus reports whether status is a known run status
+func IsValidRunStatus(status string) bool {
+	switch status {
+	case RunStatusRunning, RunStatusSucceeded, RunStatusFailed:
+		return true
+	}
+	return false
+}
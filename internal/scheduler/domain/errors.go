@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// Scheduler domain-specific error codes
+const (
+	ErrCodeInvalidRunStatus = "INVALID_RUN_STATUS"
+)
+
+// Scheduler domain errors
+var (
+	ErrInvalidRunStatus = pkg.NewDomainError(
+		ErrCodeInvalidRunStatus,
+		"status must be running, succeeded or failed",
+	)
+)
+
+// Errors returned when registering or running jobs
+var (
+	ErrInvalidJob   = errors.New("scheduled job needs a name of at most 50 characters and a run function")
+	ErrDuplicateJob = errors.New("scheduled job is already registered")
+	ErrJobNotFound  = errors.New("scheduled job is not registered")
+	ErrJobRunning   = errors.New("scheduled job is already running in this process")
+	ErrJobLocked    = errors.New("scheduled job is running on another instance")
+)
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// Locker provides locks shared by every instance running the scheduler
+type Locker interface {
+	// TryLock takes the named lock without waiting. When acquired, release must
+	// be called once the job has finished.
+	TryLock(ctx context.Context, name string) (release func(), acquired bool, err error)
+}
+
+// RunRepository defines database operations for run history
+type RunRepository interface {
+	// CreateRun records the start of a run
+	CreateRun(ctx context.Context, run *Run) error
+
+	// FinishRun records the outcome of a run
+	FinishRun(ctx context.Context, run *Run) error
+
+	// ListRuns retrieves up to limit runs newest first, optionally filtered by job
+	// and status and starting after the given keyset position
+	ListRuns(ctx context.Context, jobName, status string, after *RunCursor, limit int) ([]*Run, error)
+
+	// DeleteRunsBefore deletes runs started before the cutoff and returns how many were removed
+	DeleteRunsBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// SchedulerUsecase defines business logic for inspecting scheduled jobs
+type SchedulerUsecase interface {
+	// ListRuns retrieves the run history, newest first
+	ListRuns(ctx context.Context, query RunQuery) (pkg.CursorPage[*Run], error)
+}
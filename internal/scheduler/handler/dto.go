@@ -0,0 +1,22 @@
+package handler
+
+import "time"
+
+// RunResponse is the response body for a scheduled job run
+type RunResponse struct {
+	ID         string     `json:"id"`
+	JobName    string     `json:"job_name"`
+	Instance   string     `json:"instance"`
+	Status     string     `json:"status"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	DurationMs int64      `json:"duration_ms"`
+}
+
+// RunPageResponse is the response body for a cursor paginated run history listing
+type RunPageResponse struct {
+	Runs       []*RunResponse `json:"runs"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	HasMore    bool           `json:"has_more"`
+}
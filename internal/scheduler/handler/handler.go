@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/middleware"
+	"github.com/zercle/template-go-echo/internal/scheduler/domain"
+	userdomain "github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// Handler handles scheduler HTTP requests
+type Handler struct {
+	usecase domain.SchedulerUsecase
+}
+
+// New creates a new scheduler handler
+func New(usecase domain.SchedulerUsecase) *Handler {
+	return &Handler{
+		usecase: usecase,
+	}
+}
+
+// RegisterRoutes registers administrator-only scheduler routes
+func (h *Handler) RegisterRoutes(e *echo.Echo, jwtCfg *config.JWTConfig) {
+	e.GET("/api/v1/admin/scheduler/runs", h.ListRuns,
+		middleware.JWTAuth(jwtCfg), middleware.RequireRole(userdomain.RoleAdmin))
+}
+
+// ListRuns retrieves the run history of scheduled jobs
+// @Summary List scheduled job runs
+// @Description Retrieve runs of scheduled maintenance jobs newest first, from every instance
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param job query string false "Filter by job name, such as user.session_cleanup"
+// @Param status query string false "Filter by status: running, succeeded or failed"
+// @Param limit query int false "Page limit (default: 20, max: 100)"
+// @Param cursor query string false "Opaque cursor from a previous page"
+// @Success 200 {object} pkg.JSendResponse{data=RunPageResponse}
+// @Failure 400 {object} pkg.JSendResponse
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 500 {object} pkg.JSendResponse
+// @Router /api/v1/admin/scheduler/runs [get]
+func (h *Handler) ListRuns(c echo.Context) error {
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	query := domain.RunQuery{
+		JobName: c.QueryParam("job"),
+		Status:  c.QueryParam("status"),
+		Cursor:  c.QueryParam("cursor"),
+		Limit:   limit,
+	}
+
+	page, err := h.usecase.ListRuns(c.Request().Context(), query)
+	if err != nil {
+		if domainErr, ok := err.(*pkg.DomainError); ok && domainErr.Code != pkg.ErrCodeInternalError {
+			return pkg.Error(c, http.StatusBadRequest, domainErr.Message, domainErr.Code)
+		}
+		return err
+	}
+
+	resp := &RunPageResponse{
+		Runs:       make([]*RunResponse, len(page.Items)),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+	for i, run := range page.Items {
+		resp.Runs[i] = &RunResponse{
+			ID:         run.ID,
+			JobName:    run.JobName,
+			Instance:   run.Instance,
+			Status:     run.Status,
+			Error:      run.Error,
+			StartedAt:  run.StartedAt,
+			FinishedAt: run.FinishedAt,
+			DurationMs: run.Duration.Milliseconds(),
+		}
+	}
+
+	return pkg.Success(c, http.StatusOK, resp)
+}
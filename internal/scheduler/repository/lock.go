@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"log/slog"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+	"github.com/zercle/template-go-echo/internal/scheduler/domain"
+)
+
+// releaseTimeout bounds releasing a lock after the job context has ended
+const releaseTimeout = 5 * time.Second
+
+// Lock statements per dialect. The lock statement selects 1 when the lock was
+// taken without waiting.
+const (
+	mysqlLockQuery      = "SELECT GET_LOCK(?, 0)"
+	mysqlUnlockQuery    = "DO RELEASE_LOCK(?)"
+	postgresLockQuery   = "SELECT CASE WHEN pg_try_advisory_lock(hashtextextended($1, 0)) THEN 1 ELSE 0 END"
+	postgresUnlockQuery = "SELECT pg_advisory_unlock(hashtextextended($1, 0))"
+)
+
+// SessionLocker implements domain.Locker with locks held by a database
+// session: MariaDB/MySQL named locks or PostgreSQL advisory locks. A session
+// lock belongs to the connection that took it, so each held lock pins one
+// pooled connection until it is released. The server also releases the lock if
+// that connection drops.
+type SessionLocker struct {
+	db          *sql.DB
+	lockQuery   string
+	unlockQuery string
+}
+
+// NewLocker creates a locker taking session locks on db. SQLite has no locks
+// shared between processes, so there it returns nil and the scheduler runs
+// without one; run the scheduler in a single process on SQLite.
+func NewLocker(db *sql.DB, dialect database.Dialect) domain.Locker {
+	switch dialect {
+	case database.DialectPostgres:
+		return &SessionLocker{db: db, lockQuery: postgresLockQuery, unlockQuery: postgresUnlockQuery}
+	case database.DialectSQLite:
+		return nil
+	}
+	return &SessionLocker{db: db, lockQuery: mysqlLockQuery, unlockQuery: mysqlUnlockQuery}
+}
+
+// TryLock takes the named lock without waiting
+func (l *SessionLocker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		slog.Error("failed to get lock connection", slog.String("error", err.Error()))
+		return nil, false, err
+	}
+
+	lockName := domain.LockPrefix + name
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, l.lockQuery, lockName).Scan(&acquired); err != nil {
+		_ = conn.Close()
+		slog.Error("failed to take scheduler lock", slog.String("lock", lockName), slog.String("error", err.Error()))
+		return nil, false, err
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		_ = conn.Close()
+		return nil, false, nil
+	}
+
+	release := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+		defer cancel()
+		if _, err := conn.ExecContext(ctx, l.unlockQuery, lockName); err != nil {
+			slog.Error("failed to release scheduler lock", slog.String("lock", lockName), slog.String("error", err.Error()))
+			// Discard the connection rather than return it to the pool still holding the lock
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+		_ = conn.Close()
+	}
+	return release, true, nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-echo/internal/scheduler/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// schedulerRunColumns lists run columns in sqlc.SchedulerRuns scan order
+const schedulerRunColumns = "id, job_name, instance, status, error, started_at, finished_at, duration_ms"
+
+// RunRepository implements domain.RunRepository using sqlc generated code
+type RunRepository struct {
+	q  sqlc.Querier
+	db sqlc.DBTX
+}
+
+// New creates a new run history repository with sqlc querier. The db
+// connection backs filtered listings.
+func New(q sqlc.Querier, db sqlc.DBTX) *RunRepository {
+	return &RunRepository{q: q, db: db}
+}
+
+// CreateRun records the start of a run
+func (r *RunRepository) CreateRun(ctx context.Context, run *domain.Run) error {
+	err := r.q.CreateSchedulerRun(ctx, sqlc.CreateSchedulerRunParams{
+		ID:        run.ID,
+		JobName:   run.JobName,
+		Instance:  run.Instance,
+		Status:    run.Status,
+		StartedAt: run.StartedAt,
+	})
+	if err != nil {
+		slog.Error("failed to create scheduler run", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// FinishRun records the outcome of a run
+func (r *RunRepository) FinishRun(ctx context.Context, run *domain.Run) error {
+	params := sqlc.FinishSchedulerRunParams{
+		Status:     run.Status,
+		DurationMs: sql.NullInt64{Int64: run.Duration.Milliseconds(), Valid: true},
+		ID:         run.ID,
+	}
+	if run.Error != "" {
+		message := run.Error
+		if len(message) > domain.MaxErrorLength {
+			message = message[:domain.MaxErrorLength]
+		}
+		params.Error = sql.NullString{String: message, Valid: true}
+	}
+	if run.FinishedAt != nil {
+		params.FinishedAt = sql.NullTime{Time: *run.FinishedAt, Valid: true}
+	}
+
+	if err := r.q.FinishSchedulerRun(ctx, params); err != nil {
+		slog.Error("failed to finish scheduler run", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// ListRuns retrieves up to limit runs newest first, optionally filtered by job
+// and status and starting after the given keyset position
+func (r *RunRepository) ListRuns(ctx context.Context, jobName, status string, after *domain.RunCursor, limit int) ([]*domain.Run, error) {
+	conditions := []string{"1 = 1"}
+	var args []interface{}
+
+	if jobName != "" {
+		conditions = append(conditions, "job_name = ?")
+		args = append(args, jobName)
+	}
+	if status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, status)
+	}
+	if after != nil {
+		startedAt, err := time.Parse(time.RFC3339Nano, after.StartedAt)
+		if err != nil {
+			return nil, pkg.ErrInvalidCursor
+		}
+		conditions = append(conditions, "(started_at < ? OR (started_at = ? AND id < ?))")
+		args = append(args, startedAt, startedAt, after.ID)
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf("-- name: ListSchedulerRunsPage :many\nSELECT %s FROM scheduler_runs WHERE %s ORDER BY started_at DESC, id DESC LIMIT ?",
+		schedulerRunColumns, strings.Join(conditions, " AND "))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		slog.Error("failed to list scheduler runs", slog.String("error", err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []*domain.Run
+	for rows.Next() {
+		var i sqlc.SchedulerRuns
+		if err := rows.Scan(
+			&i.ID,
+			&i.JobName,
+			&i.Instance,
+			&i.Status,
+			&i.Error,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.DurationMs,
+		); err != nil {
+			slog.Error("failed to scan scheduler run", slog.String("error", err.Error()))
+			return nil, err
+		}
+		runs = append(runs, sqlcRunToDomain(&i))
+	}
+	if err := rows.Err(); err != nil {
+		slog.Error("failed to list scheduler runs", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+// DeleteRunsBefore deletes runs started before the cutoff and returns how many were removed
+func (r *RunRepository) DeleteRunsBefore(ctx context.Context, before time.Time) (int, error) {
+	deleted, err := r.q.DeleteSchedulerRunsBefore(ctx, before)
+	if err != nil {
+		slog.Error("failed to delete scheduler runs", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
+
+// sqlcRunToDomain converts a sqlc run to a domain run
+func sqlcRunToDomain(r *sqlc.SchedulerRuns) *domain.Run {
+	run := &domain.Run{
+		ID:        r.ID,
+		JobName:   r.JobName,
+		Instance:  r.Instance,
+		Status:    r.Status,
+		Error:     r.Error.String,
+		StartedAt: r.StartedAt,
+		Duration:  time.Duration(r.DurationMs.Int64) * time.Millisecond,
+	}
+	if r.FinishedAt.Valid {
+		run.FinishedAt = &r.FinishedAt.Time
+	}
+	return run
+}
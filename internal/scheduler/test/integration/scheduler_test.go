@@ -0,0 +1,179 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/scheduler/domain"
+	"github.com/zercle/template-go-echo/internal/scheduler/test/mocks"
+	"github.com/zercle/template-go-echo/internal/scheduler/usecase"
+)
+
+func TestRunJobRecordsHistory(t *testing.T) {
+	history := mocks.NewMockRepository()
+	scheduler := usecase.NewScheduler(usecase.WithHistory(history), usecase.WithInstance("worker-1"))
+
+	err := scheduler.Register(
+		domain.Job{Name: "ok", Schedule: "@hourly", Run: func(ctx context.Context) error { return nil }},
+		domain.Job{Name: "broken", Schedule: "@hourly", Run: func(ctx context.Context) error { return errors.New("disk full") }},
+		domain.Job{Name: "panics", Schedule: "@hourly", Run: func(ctx context.Context) error { panic("boom") }},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		job    string
+		status string
+		err    string
+	}{
+		{"ok", domain.RunStatusSucceeded, ""},
+		{"broken", domain.RunStatusFailed, "disk full"},
+		{"panics", domain.RunStatusFailed, "job panicked: boom"},
+	}
+	for _, tc := range cases {
+		run, err := scheduler.RunJob(context.Background(), tc.job)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.job, err)
+		}
+		if run.Status != tc.status || run.Error != tc.err || run.FinishedAt == nil || run.Instance != "worker-1" {
+			t.Errorf("%s: unexpected run %+v", tc.job, run)
+		}
+	}
+
+	runs := history.Runs()
+	if len(runs) != 3 {
+		t.Fatalf("expected 3 recorded runs, got %d", len(runs))
+	}
+	for i, tc := range cases {
+		if runs[i].JobName != tc.job || runs[i].Status != tc.status {
+			t.Errorf("expected %s run to be recorded as %s, got %+v", tc.job, tc.status, runs[i])
+		}
+	}
+
+	if _, err := scheduler.RunJob(context.Background(), "missing"); !errors.Is(err, domain.ErrJobNotFound) {
+		t.Errorf("expected ErrJobNotFound, got %v", err)
+	}
+}
+
+func TestRegisterValidation(t *testing.T) {
+	scheduler := usecase.NewScheduler()
+	noop := func(ctx context.Context) error { return nil }
+
+	if err := scheduler.Register(domain.Job{Name: "job", Schedule: "@daily", Run: noop}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		job  domain.Job
+		want error
+	}{
+		{domain.Job{Schedule: "@daily", Run: noop}, domain.ErrInvalidJob},
+		{domain.Job{Name: "no-run", Schedule: "@daily"}, domain.ErrInvalidJob},
+		{domain.Job{Name: "job", Schedule: "@daily", Run: noop}, domain.ErrDuplicateJob},
+	}
+	for _, tc := range cases {
+		if err := scheduler.Register(tc.job); !errors.Is(err, tc.want) {
+			t.Errorf("%q: expected %v, got %v", tc.job.Name, tc.want, err)
+		}
+	}
+
+	if err := scheduler.Register(domain.Job{Name: "bad-schedule", Schedule: "every day", Run: noop}); err == nil {
+		t.Error("expected invalid schedule to be rejected")
+	}
+}
+
+func TestJobsDoNotOverlap(t *testing.T) {
+	locker := mocks.NewMockLocker()
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	job := domain.Job{
+		Name:     "slow",
+		Schedule: "@hourly",
+		Run: func(ctx context.Context) error {
+			close(started)
+			<-finish
+			return nil
+		},
+	}
+
+	// Two schedulers sharing a lock stand in for two instances
+	first := usecase.NewScheduler(usecase.WithLocker(locker))
+	second := usecase.NewScheduler(usecase.WithLocker(locker))
+	_ = first.Register(job)
+	_ = second.Register(job)
+
+	done := make(chan error)
+	go func() {
+		_, err := first.RunJob(context.Background(), "slow")
+		done <- err
+	}()
+	<-started
+
+	if _, err := first.RunJob(context.Background(), "slow"); !errors.Is(err, domain.ErrJobRunning) {
+		t.Errorf("expected ErrJobRunning in the same instance, got %v", err)
+	}
+	if _, err := second.RunJob(context.Background(), "slow"); !errors.Is(err, domain.ErrJobLocked) {
+		t.Errorf("expected ErrJobLocked on another instance, got %v", err)
+	}
+
+	close(finish)
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The lock is released once the run finishes
+	if _, err := second.RunJob(context.Background(), "slow"); err != nil {
+		t.Errorf("expected job to run after the lock was released, got %v", err)
+	}
+}
+
+func TestRunJobTimeout(t *testing.T) {
+	scheduler := usecase.NewScheduler()
+	_ = scheduler.Register(domain.Job{
+		Name:     "stuck",
+		Schedule: "@hourly",
+		Timeout:  10 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	run, err := scheduler.RunJob(context.Background(), "stuck")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.Status != domain.RunStatusFailed || run.Error != context.DeadlineExceeded.Error() {
+		t.Errorf("expected run to fail with a timeout, got %+v", run)
+	}
+}
+
+func TestRunFiresOnSchedule(t *testing.T) {
+	history := mocks.NewMockRepository()
+	scheduler := usecase.NewScheduler(usecase.WithHistory(history))
+
+	var runs atomic.Int32
+	_ = scheduler.Register(domain.Job{
+		Name:     "ticker",
+		Schedule: "@every 1s",
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+	scheduler.Run(ctx)
+
+	if got := runs.Load(); got != 1 {
+		t.Errorf("expected 1 scheduled run, got %d", got)
+	}
+	if got := len(history.Runs()); got != 1 {
+		t.Errorf("expected 1 recorded run, got %d", got)
+	}
+}
@@ -0,0 +1,80 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zercle/template-go-echo/internal/scheduler/domain"
+	"github.com/zercle/template-go-echo/internal/scheduler/test/mocks"
+	"github.com/zercle/template-go-echo/internal/scheduler/usecase"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+func addRun(repo *mocks.MockRunRepository, jobName, status string, startedAt time.Time) {
+	_ = repo.CreateRun(context.Background(), &domain.Run{
+		ID:        uuid.New().String(),
+		JobName:   jobName,
+		Status:    status,
+		StartedAt: startedAt,
+	})
+}
+
+func TestListRuns(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo)
+
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		addRun(repo, "user.session_cleanup", domain.RunStatusSucceeded, now.Add(time.Duration(i)*time.Minute))
+	}
+	addRun(repo, "user.account_purge", domain.RunStatusFailed, now.Add(5*time.Minute))
+
+	first, err := uc.ListRuns(context.Background(), domain.RunQuery{JobName: "user.session_cleanup", Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(first.Items) != 2 || !first.HasMore {
+		t.Fatalf("expected a full first page, got %+v", first)
+	}
+
+	second, err := uc.ListRuns(context.Background(), domain.RunQuery{JobName: "user.session_cleanup", Limit: 2, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(second.Items) != 1 || second.HasMore {
+		t.Errorf("expected 1 remaining run, got %+v", second)
+	}
+
+	failed, _ := uc.ListRuns(context.Background(), domain.RunQuery{Status: domain.RunStatusFailed})
+	if len(failed.Items) != 1 || failed.Items[0].JobName != "user.account_purge" {
+		t.Errorf("expected only the failed purge run, got %+v", failed.Items)
+	}
+
+	if _, err := uc.ListRuns(context.Background(), domain.RunQuery{Status: "skipped"}); err != domain.ErrInvalidRunStatus {
+		t.Errorf("expected ErrInvalidRunStatus, got %v", err)
+	}
+	if _, err := uc.ListRuns(context.Background(), domain.RunQuery{Cursor: "not-a-cursor"}); err != pkg.ErrInvalidCursor {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestHistoryCleanupJob(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	addRun(repo, "old", domain.RunStatusSucceeded, time.Now().Add(-48*time.Hour))
+	addRun(repo, "recent", domain.RunStatusSucceeded, time.Now())
+
+	job := usecase.HistoryCleanupJob(repo, 24*time.Hour)
+	if _, err := domain.ParseSchedule(job.Schedule); err != nil {
+		t.Fatalf("unexpected schedule error: %v", err)
+	}
+	if err := job.Run(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	runs := repo.Runs()
+	if len(runs) != 1 || runs[0].JobName != "recent" {
+		t.Errorf("expected only the recent run to be kept, got %+v", runs)
+	}
+}
@@ -0,0 +1,125 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/scheduler/domain"
+)
+
+// MockRunRepository is a simple in-memory run history for testing
+type MockRunRepository struct {
+	mu   sync.Mutex
+	runs []*domain.Run
+}
+
+// NewMockRepository creates a new mock repository
+func NewMockRepository() *MockRunRepository {
+	return &MockRunRepository{}
+}
+
+// CreateRun records the start of a run
+func (m *MockRunRepository) CreateRun(ctx context.Context, run *domain.Run) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copied := *run
+	m.runs = append(m.runs, &copied)
+	return nil
+}
+
+// FinishRun records the outcome of a run
+func (m *MockRunRepository) FinishRun(ctx context.Context, run *domain.Run) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, existing := range m.runs {
+		if existing.ID == run.ID {
+			copied := *run
+			m.runs[i] = &copied
+		}
+	}
+	return nil
+}
+
+// ListRuns retrieves runs newest first
+func (m *MockRunRepository) ListRuns(ctx context.Context, jobName, status string, after *domain.RunCursor, limit int) ([]*domain.Run, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var runs []*domain.Run
+	for i := len(m.runs) - 1; i >= 0; i-- {
+		run := m.runs[i]
+		if (jobName != "" && run.JobName != jobName) || (status != "" && run.Status != status) {
+			continue
+		}
+		copied := *run
+		runs = append(runs, &copied)
+	}
+
+	// Runs are stored in start order, so the cursor ID marks the position
+	if after != nil {
+		for i, run := range runs {
+			if run.ID == after.ID {
+				runs = runs[i+1:]
+				break
+			}
+		}
+	}
+	if len(runs) > limit {
+		runs = runs[:limit]
+	}
+	return runs, nil
+}
+
+// DeleteRunsBefore deletes runs started before the cutoff
+func (m *MockRunRepository) DeleteRunsBefore(ctx context.Context, before time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	kept := m.runs[:0]
+	for _, run := range m.runs {
+		if !run.StartedAt.Before(before) {
+			kept = append(kept, run)
+		}
+	}
+	deleted := len(m.runs) - len(kept)
+	m.runs = kept
+	return deleted, nil
+}
+
+// Runs returns copies of all runs in start order
+func (m *MockRunRepository) Runs() []*domain.Run {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	runs := make([]*domain.Run, len(m.runs))
+	for i, run := range m.runs {
+		copied := *run
+		runs[i] = &copied
+	}
+	return runs
+}
+
+// MockLocker is an in-memory domain.Locker shared by schedulers standing in for separate instances
+type MockLocker struct {
+	mu   sync.Mutex
+	held map[string]bool
+}
+
+// NewMockLocker creates a new mock locker
+func NewMockLocker() *MockLocker {
+	return &MockLocker{held: make(map[string]bool)}
+}
+
+// TryLock takes the named lock without waiting
+func (l *MockLocker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.held[name] {
+		return nil, false, nil
+	}
+	l.held[name] = true
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.held, name)
+	}, true, nil
+}
@@ -0,0 +1,75 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/scheduler/domain"
+)
+
+func TestParseScheduleNext(t *testing.T) {
+	// Wednesday
+	from := time.Date(2024, 1, 10, 10, 7, 30, 0, time.UTC)
+
+	cases := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 1, 10, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 1, 10, 10, 15, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2024, 1, 11, 3, 0, 0, 0, time.UTC)},
+		{"30 9-17/4 * * *", time.Date(2024, 1, 10, 13, 30, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Restricted day-of-month and day-of-week match either field
+		{"0 0 20 * 5", time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, 1, 10, 11, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 90s", from.Add(90 * time.Second)},
+	}
+	for _, tc := range cases {
+		schedule, err := domain.ParseSchedule(tc.spec)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.spec, err)
+			continue
+		}
+		if got := schedule.Next(from); !got.Equal(tc.want) {
+			t.Errorf("%q: expected %v, got %v", tc.spec, tc.want, got)
+		}
+	}
+}
+
+func TestParseScheduleNeverFires(t *testing.T) {
+	schedule, err := domain.ParseSchedule("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next := schedule.Next(time.Now()); !next.IsZero() {
+		t.Errorf("expected no activation for February 30, got %v", next)
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	specs := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@every",
+		"@every 10ms",
+		"@fortnightly",
+	}
+	for _, spec := range specs {
+		if _, err := domain.ParseSchedule(spec); err == nil {
+			t.Errorf("expected %q to be rejected", spec)
+		}
+	}
+}
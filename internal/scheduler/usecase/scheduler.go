@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zercle/template-go-echo/internal/scheduler/domain"
+)
+
+// Scheduler runs registered jobs on their cron schedules. A job never overlaps
+// itself: a run is skipped while the previous one is still going in this
+// process, and with a Locker only one instance runs it at a time.
+type Scheduler struct {
+	locker   domain.Locker
+	history  domain.RunRepository
+	instance string
+
+	mu   sync.Mutex
+	jobs map[string]*scheduledJob
+	wg   sync.WaitGroup
+}
+
+// scheduledJob is a registered job with its parsed schedule
+type scheduledJob struct {
+	job      domain.Job
+	schedule domain.Schedule
+	running  atomic.Bool
+}
+
+// Option configures optional Scheduler settings
+type Option func(*Scheduler)
+
+// WithLocker sets the lock shared by every instance, so each job runs on one instance at a time
+func WithLocker(locker domain.Locker) Option {
+	return func(s *Scheduler) {
+		s.locker = locker
+	}
+}
+
+// WithHistory sets where runs are recorded
+func WithHistory(history domain.RunRepository) Option {
+	return func(s *Scheduler) {
+		s.history = history
+	}
+}
+
+// WithInstance sets the name recorded with runs; defaults to host name and process ID
+func WithInstance(instance string) Option {
+	return func(s *Scheduler) {
+		s.instance = instance
+	}
+}
+
+// NewScheduler creates a scheduler without jobs
+func NewScheduler(opts ...Option) *Scheduler {
+	s := &Scheduler{jobs: make(map[string]*scheduledJob)}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.instance == "" {
+		host, _ := os.Hostname()
+		s.instance = fmt.Sprintf("%s:%d", host, os.Getpid())
+	}
+	return s
+}
+
+// Register adds jobs to the scheduler; jobs registered after Run starts are not scheduled
+func (s *Scheduler) Register(jobs ...domain.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, job := range jobs {
+		if job.Name == "" || len(job.Name) > domain.MaxJobNameLength || job.Run == nil {
+			return fmt.Errorf("%w: %q", domain.ErrInvalidJob, job.Name)
+		}
+		if _, ok := s.jobs[job.Name]; ok {
+			return fmt.Errorf("%w: %q", domain.ErrDuplicateJob, job.Name)
+		}
+		schedule, err := domain.ParseSchedule(job.Schedule)
+		if err != nil {
+			return fmt.Errorf("job %q: %w", job.Name, err)
+		}
+		s.jobs[job.Name] = &scheduledJob{job: job, schedule: schedule}
+	}
+	return nil
+}
+
+// Run schedules every registered job until ctx is cancelled, then waits for
+// runs in progress to finish
+func (s *Scheduler) Run(ctx context.Context) {
+	s.mu.Lock()
+	for _, sj := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, sj)
+	}
+	slog.Info("scheduler started", slog.Int("jobs", len(s.jobs)), slog.String("instance", s.instance))
+	s.mu.Unlock()
+
+	<-ctx.Done()
+	s.wg.Wait()
+}
+
+// RunJob runs a job immediately, without jitter, and returns its run. It fails
+// with ErrJobRunning or ErrJobLocked when the job is already running.
+func (s *Scheduler) RunJob(ctx context.Context, name string) (*domain.Run, error) {
+	s.mu.Lock()
+	sj, ok := s.jobs[name]
+	s.mu.Unlock()
+	if !ok {
+		return nil, domain.ErrJobNotFound
+	}
+
+	if !sj.running.CompareAndSwap(false, true) {
+		return nil, domain.ErrJobRunning
+	}
+	defer sj.running.Store(false)
+
+	return s.execute(ctx, sj)
+}
+
+// loop fires a job at each activation of its schedule
+func (s *Scheduler) loop(ctx context.Context, sj *scheduledJob) {
+	defer s.wg.Done()
+
+	for {
+		next := sj.schedule.Next(time.Now())
+		if next.IsZero() {
+			slog.Warn("scheduled job has no future activation", slog.String("job", sj.job.Name))
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// Skip this activation rather than queue behind a run still in progress
+		if !sj.running.CompareAndSwap(false, true) {
+			slog.Warn("skipping scheduled job still running", slog.String("job", sj.job.Name))
+			continue
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer sj.running.Store(false)
+
+			if sj.job.Jitter > 0 {
+				delay := rand.N(sj.job.Jitter)
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(delay):
+				}
+			}
+
+			_, _ = s.execute(ctx, sj)
+		}()
+	}
+}
+
+// execute takes the job lock, runs the job and records the run
+func (s *Scheduler) execute(ctx context.Context, sj *scheduledJob) (*domain.Run, error) {
+	name := sj.job.Name
+
+	if s.locker != nil {
+		release, acquired, err := s.locker.TryLock(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if !acquired {
+			slog.Debug("scheduled job locked by another instance", slog.String("job", name))
+			return nil, domain.ErrJobLocked
+		}
+		defer release()
+	}
+
+	run := &domain.Run{
+		ID:        uuid.New().String(),
+		JobName:   name,
+		Instance:  s.instance,
+		Status:    domain.RunStatusRunning,
+		StartedAt: time.Now(),
+	}
+	if s.history != nil {
+		// History is best effort and never prevents the job from running
+		_ = s.history.CreateRun(ctx, run)
+	}
+
+	runCtx := ctx
+	if sj.job.Timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, sj.job.Timeout)
+		defer cancel()
+	}
+
+	err := safeRun(runCtx, sj.job.Run)
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.Duration = finishedAt.Sub(run.StartedAt)
+	run.Status = domain.RunStatusSucceeded
+	if err != nil {
+		run.Status = domain.RunStatusFailed
+		run.Error = err.Error()
+		slog.Error("scheduled job failed", slog.String("job", name), slog.Duration("duration", run.Duration), slog.String("error", run.Error))
+	} else {
+		slog.Info("scheduled job finished", slog.String("job", name), slog.Duration("duration", run.Duration))
+	}
+
+	if s.history != nil {
+		// Record the outcome even when the run was cancelled by shutdown
+		finishCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		_ = s.history.FinishRun(finishCtx, run)
+	}
+
+	return run, nil
+}
+
+// safeRun calls fn, converting a panic into an error so one job cannot stop the scheduler
+func safeRun(ctx context.Context, fn func(context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/scheduler/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// HistoryCleanupJobName is the name of the job removing old run history
+const HistoryCleanupJobName = "scheduler.history_cleanup"
+
+// SchedulerUsecase implements domain.SchedulerUsecase
+type SchedulerUsecase struct {
+	repo domain.RunRepository
+}
+
+// New creates a new scheduler usecase
+func New(repo domain.RunRepository) *SchedulerUsecase {
+	return &SchedulerUsecase{repo: repo}
+}
+
+// ListRuns retrieves the run history, newest first
+func (u *SchedulerUsecase) ListRuns(ctx context.Context, query domain.RunQuery) (pkg.CursorPage[*domain.Run], error) {
+	if query.Status != "" && !domain.IsValidRunStatus(query.Status) {
+		return pkg.CursorPage[*domain.Run]{}, domain.ErrInvalidRunStatus
+	}
+
+	var after *domain.RunCursor
+	if query.Cursor != "" {
+		cursor, err := pkg.DecodeCursor[domain.RunCursor](query.Cursor)
+		if err != nil || cursor.ID == "" {
+			return pkg.CursorPage[*domain.Run]{}, pkg.ErrInvalidCursor
+		}
+		after = &cursor
+	}
+
+	// Fetch one extra row to detect whether another page exists
+	limit := pkg.ClampLimit(query.Limit, domain.DefaultPageSize, domain.MaxPageSize)
+	runs, err := u.repo.ListRuns(ctx, query.JobName, query.Status, after, limit+1)
+	if err != nil {
+		if domainErr, ok := err.(*pkg.DomainError); ok {
+			return pkg.CursorPage[*domain.Run]{}, domainErr
+		}
+		slog.Error("failed to list scheduler runs", slog.String("error", err.Error()))
+		return pkg.CursorPage[*domain.Run]{}, pkg.ErrInternalError
+	}
+
+	return pkg.NewCursorPage(runs, limit, func(run *domain.Run) any {
+		return domain.NewRunCursor(run)
+	}), nil
+}
+
+// HistoryCleanupJob returns a daily job deleting runs older than retention
+func HistoryCleanupJob(repo domain.RunRepository, retention time.Duration) domain.Job {
+	return domain.Job{
+		Name:     HistoryCleanupJobName,
+		Schedule: "@daily",
+		Jitter:   10 * time.Minute,
+		Timeout:  10 * time.Minute,
+		Run: func(ctx context.Context) error {
+			deleted, err := repo.DeleteRunsBefore(ctx, time.Now().Add(-retention))
+			if err != nil {
+				return err
+			}
+			if deleted > 0 {
+				slog.Info("deleted scheduler run history", slog.Int("count", deleted))
+			}
+			return nil
+		},
+	}
+}
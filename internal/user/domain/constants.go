@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 const (
 	// Password constraints
 	MinPasswordLength = 8
@@ -12,22 +14,55 @@ const (
 	// Email constraints
 	MaxEmailLength = 255
 
+	// Pagination constraints
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+
+	// Bulk import constraints
+	DefaultImportBatchSize  = 100
+	MaxImportBatchSize      = 1000
+	GeneratedPasswordLength = 24
+	MaxImportLineBytes      = 64 * 1024
+
+	// Export constraints
+	MaxXLSXExportRows = 1048575 // worksheet row limit minus the header
+
+	// Optimistic concurrency
+	InitialVersion = 1 // version of a newly created user, matching the column default
+
+	// Suspension constraints
+	MaxSuspensionReasonLength = 500
+
 	// Session constraints
 	SessionDurationHours = 24 // 24-hour session duration
 	TokenExpiryHours     = 1  // 1-hour token expiry
+
+	// Account deletion constraints
+	DefaultDeletionGracePeriod = 30 * 24 * time.Hour // 30-day restore window
+)
+
+// User roles
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
 )
 
+// IsValidRole checks if a role is one of the known user roles
+func IsValidRole(role string) bool {
+	return role == RoleUser || role == RoleAdmin
+}
+
 // ValidationMessages provides domain-specific validation messages
 var ValidationMessages = map[string]string{
-	"email_required":      "Email is required",
-	"email_invalid":       "Email format is invalid",
-	"email_exists":        "Email is already registered",
-	"password_required":   "Password is required",
-	"password_too_short":  "Password must be at least 8 characters",
-	"password_too_long":   "Password must be at most 128 characters",
-	"password_weak":       "Password must contain uppercase, lowercase, number, and special character",
-	"name_required":       "Name is required",
-	"name_too_short":      "Name must be at least 1 character",
-	"name_too_long":       "Name must be at most 255 characters",
+	"email_required":       "Email is required",
+	"email_invalid":        "Email format is invalid",
+	"email_exists":         "Email is already registered",
+	"password_required":    "Password is required",
+	"password_too_short":   "Password must be at least 8 characters",
+	"password_too_long":    "Password must be at most 128 characters",
+	"password_weak":        "Password must contain uppercase, lowercase, number, and special character",
+	"name_required":        "Name is required",
+	"name_too_short":       "Name must be at least 1 character",
+	"name_too_long":        "Name must be at most 255 characters",
 	"old_password_invalid": "Old password is incorrect",
 }
@@ -4,14 +4,23 @@ import "time"
 
 // User represents a user entity in the domain
 type User struct {
-	ID           string    `db:"id" json:"id"`
-	Email        string    `db:"email" json:"email"`
-	Name         string    `db:"name" json:"name"`
-	PasswordHash string    `db:"password_hash" json:"-"` // Never expose password hash
-	IsActive     bool      `db:"is_active" json:"is_active"`
-	CreatedAt    time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt    time.Time `db:"updated_at" json:"updated_at"`
+	ID           string     `db:"id" json:"id"`
+	Email        string     `db:"email" json:"email"`
+	Name         string     `db:"name" json:"name"`
+	PasswordHash string     `db:"password_hash" json:"-"` // Never expose password hash
+	IsActive     bool       `db:"is_active" json:"is_active"`
+	Role         string     `db:"role" json:"role"`
+	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time  `db:"updated_at" json:"updated_at"`
 	DeletedAt    *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+
+	SuspendedAt           *time.Time `db:"suspended_at" json:"suspended_at,omitempty"`
+	SuspendedUntil        *time.Time `db:"suspended_until" json:"suspended_until,omitempty"`
+	SuspensionReason      string     `db:"suspension_reason" json:"suspension_reason,omitempty"`
+	PasswordResetRequired bool       `db:"password_reset_required" json:"password_reset_required"`
+
+	// Version is incremented by every update and guards profile updates against lost writes
+	Version int64 `db:"version" json:"version"`
 }
 
 // IsDeleted checks if user is soft deleted
@@ -19,6 +28,27 @@ func (u *User) IsDeleted() bool {
 	return u.DeletedAt != nil
 }
 
+// IsSuspended checks if the user is currently suspended, honoring suspension expiry
+func (u *User) IsSuspended() bool {
+	if u.IsActive {
+		return false
+	}
+	return u.SuspendedUntil == nil || time.Now().Before(*u.SuspendedUntil)
+}
+
+// PurgeAt returns when a soft deleted user becomes eligible for purging
+func (u *User) PurgeAt(gracePeriod time.Duration) time.Time {
+	if u.DeletedAt == nil {
+		return time.Time{}
+	}
+	return u.DeletedAt.Add(gracePeriod)
+}
+
+// IsRestorable checks if a soft deleted user is still within the grace period
+func (u *User) IsRestorable(gracePeriod time.Duration) bool {
+	return u.IsDeleted() && time.Now().Before(u.PurgeAt(gracePeriod))
+}
+
 // UserSession represents a user session with refresh token
 type UserSession struct {
 	ID               string    `db:"id" json:"id"`
@@ -4,15 +4,31 @@ import "github.com/zercle/template-go-echo/pkg"
 
 // User domain-specific error codes
 const (
-	ErrCodeUserNotFound       = "USER_NOT_FOUND"
-	ErrCodeUserExists         = "USER_ALREADY_EXISTS"
-	ErrCodeInvalidCredentials = "INVALID_CREDENTIALS"
-	ErrCodeInvalidEmail       = "INVALID_EMAIL"
-	ErrCodeInvalidPassword    = "INVALID_PASSWORD"
-	ErrCodeInvalidName        = "INVALID_NAME"
-	ErrCodeSessionNotFound    = "SESSION_NOT_FOUND"
-	ErrCodeSessionExpired     = "SESSION_EXPIRED"
-	ErrCodeUnauthorized       = "UNAUTHORIZED"
+	ErrCodeUserNotFound             = "USER_NOT_FOUND"
+	ErrCodeUserExists               = "USER_ALREADY_EXISTS"
+	ErrCodeInvalidCredentials       = "INVALID_CREDENTIALS"
+	ErrCodeInvalidEmail             = "INVALID_EMAIL"
+	ErrCodeInvalidPassword          = "INVALID_PASSWORD"
+	ErrCodeInvalidName              = "INVALID_NAME"
+	ErrCodeSessionNotFound          = "SESSION_NOT_FOUND"
+	ErrCodeSessionExpired           = "SESSION_EXPIRED"
+	ErrCodeUnauthorized             = "UNAUTHORIZED"
+	ErrCodePendingDeletion          = "ACCOUNT_PENDING_DELETION"
+	ErrCodeAccountSuspended         = "ACCOUNT_SUSPENDED"
+	ErrCodePasswordResetRequired    = "PASSWORD_RESET_REQUIRED"
+	ErrCodePasswordResetNotRequired = "PASSWORD_RESET_NOT_REQUIRED"
+	ErrCodeInvalidRole              = "INVALID_ROLE"
+	ErrCodeInvalidSuspension        = "INVALID_SUSPENSION"
+	ErrCodeSelfModification         = "SELF_MODIFICATION_FORBIDDEN"
+	ErrCodeInvalidImport            = "INVALID_IMPORT"
+	ErrCodeInvalidImportRow         = "INVALID_IMPORT_ROW"
+	ErrCodeDuplicateImportRow       = "DUPLICATE_IMPORT_ROW"
+	ErrCodeInvalidPasswordHash      = "INVALID_PASSWORD_HASH"
+	ErrCodePasswordHashRequired     = "PASSWORD_HASH_REQUIRED"
+	ErrCodeInviteFailed             = "INVITE_FAILED"
+	ErrCodeInvalidExport            = "INVALID_EXPORT"
+	ErrCodeExportTooLarge           = "EXPORT_TOO_LARGE"
+	ErrCodeVersionConflict          = "VERSION_CONFLICT"
 )
 
 // User domain errors
@@ -61,4 +77,84 @@ var (
 		ErrCodeUnauthorized,
 		"unauthorized access",
 	)
+
+	ErrPendingDeletion = pkg.NewDomainError(
+		ErrCodePendingDeletion,
+		"account is pending deletion; log in or restore it to reactivate",
+	)
+
+	ErrAccountSuspended = pkg.NewDomainError(
+		ErrCodeAccountSuspended,
+		"account is suspended",
+	)
+
+	ErrPasswordResetRequired = pkg.NewDomainError(
+		ErrCodePasswordResetRequired,
+		"password must be changed before logging in",
+	)
+
+	ErrPasswordResetNotRequired = pkg.NewDomainError(
+		ErrCodePasswordResetNotRequired,
+		"account has no pending password reset; log in to change the password",
+	)
+
+	ErrInvalidRole = pkg.NewDomainError(
+		ErrCodeInvalidRole,
+		"role is not recognized",
+	)
+
+	ErrInvalidSuspension = pkg.NewDomainError(
+		ErrCodeInvalidSuspension,
+		"suspension reason is required and expiry must be in the future",
+	)
+
+	ErrSelfModification = pkg.NewDomainError(
+		ErrCodeSelfModification,
+		"administrators cannot suspend or demote themselves",
+	)
+
+	ErrInvalidImport = pkg.NewDomainError(
+		ErrCodeInvalidImport,
+		"import must be CSV or JSONL with email and name columns",
+	)
+
+	ErrInvalidImportRow = pkg.NewDomainError(
+		ErrCodeInvalidImportRow,
+		"row could not be parsed",
+	)
+
+	ErrDuplicateImportRow = pkg.NewDomainError(
+		ErrCodeDuplicateImportRow,
+		"email appears earlier in the import",
+	)
+
+	ErrInvalidPasswordHash = pkg.NewDomainError(
+		ErrCodeInvalidPasswordHash,
+		"password hash must be a bcrypt hash",
+	)
+
+	ErrPasswordHashRequired = pkg.NewDomainError(
+		ErrCodePasswordHashRequired,
+		"password hash is required when no invite sender is configured",
+	)
+
+	ErrInviteFailed = pkg.NewDomainError(
+		ErrCodeInviteFailed,
+		"user was created but the password invite could not be sent",
+	)
+
+	ErrInvalidExport = pkg.NewDomainError(
+		ErrCodeInvalidExport,
+		"export format must be csv, jsonl or xlsx and columns must be exportable",
+	)
+
+	ErrExportTooLarge = pkg.NewDomainError(
+		ErrCodeExportTooLarge,
+		"export exceeds the maximum number of worksheet rows; use csv or jsonl",
+	)
+
+	ErrVersionConflict = pkg.NewDomainError(
+		ErrCodeVersionConflict,
+		"user was modified by another request; fetch the latest version and retry",
+	)
 )
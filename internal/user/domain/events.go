@@ -0,0 +1,112 @@
+package domain
+
+import "time"
+
+// AggregateUser is the aggregate type of all user events; session events are
+// ordered together with the events of their user
+const AggregateUser = "user"
+
+// Event types published through the outbox
+const (
+	EventUserRegistered   = "user.registered"
+	EventUserUpdated      = "user.updated"
+	EventUserEmailChanged = "user.email_changed"
+	EventUserDeleted      = "user.deleted"
+	EventSessionRevoked   = "user.session_revoked"
+)
+
+// Reasons a session was revoked
+const (
+	RevokeReasonLogout        = "logout"
+	RevokeReasonLogoutAll     = "logout_all"
+	RevokeReasonSuspended     = "suspended"
+	RevokeReasonPasswordReset = "password_reset"
+	RevokeReasonAdmin         = "admin"
+	RevokeReasonDeleted       = "account_deleted"
+)
+
+// UserRegistered is published when an account is created, by sign up, an administrator or an import
+type UserRegistered struct {
+	UserID       string    `json:"user_id"`
+	Email        string    `json:"email"`
+	Name         string    `json:"name"`
+	Role         string    `json:"role"`
+	RegisteredAt time.Time `json:"registered_at"`
+}
+
+// NewUserRegistered returns the registration event of user
+func NewUserRegistered(user *User) UserRegistered {
+	return UserRegistered{
+		UserID:       user.ID,
+		Email:        user.Email,
+		Name:         user.Name,
+		Role:         user.Role,
+		RegisteredAt: user.CreatedAt,
+	}
+}
+
+func (e UserRegistered) EventType() string     { return EventUserRegistered }
+func (e UserRegistered) AggregateType() string { return AggregateUser }
+func (e UserRegistered) AggregateID() string   { return e.UserID }
+func (e UserRegistered) OccurredAt() time.Time { return e.RegisteredAt }
+
+// UserUpdated is published when a user changes their profile
+type UserUpdated struct {
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (e UserUpdated) EventType() string     { return EventUserUpdated }
+func (e UserUpdated) AggregateType() string { return AggregateUser }
+func (e UserUpdated) AggregateID() string   { return e.UserID }
+func (e UserUpdated) OccurredAt() time.Time { return e.UpdatedAt }
+
+// UserEmailChanged is published when a user changes their email address
+type UserEmailChanged struct {
+	UserID    string    `json:"user_id"`
+	OldEmail  string    `json:"old_email"`
+	NewEmail  string    `json:"new_email"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+func (e UserEmailChanged) EventType() string     { return EventUserEmailChanged }
+func (e UserEmailChanged) AggregateType() string { return AggregateUser }
+func (e UserEmailChanged) AggregateID() string   { return e.UserID }
+func (e UserEmailChanged) OccurredAt() time.Time { return e.ChangedAt }
+
+// UserDeleted is published when an account is soft deleted and enters its grace period
+type UserDeleted struct {
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+func (e UserDeleted) EventType() string     { return EventUserDeleted }
+func (e UserDeleted) AggregateType() string { return AggregateUser }
+func (e UserDeleted) AggregateID() string   { return e.UserID }
+func (e UserDeleted) OccurredAt() time.Time { return e.DeletedAt }
+
+// SessionRevoked is published when a session is ended before it expires
+type SessionRevoked struct {
+	SessionID string    `json:"session_id"`
+	UserID    string    `json:"user_id"`
+	Reason    string    `json:"reason"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+// NewSessionRevoked returns the revocation event of session
+func NewSessionRevoked(session *UserSession, reason string) SessionRevoked {
+	return SessionRevoked{
+		SessionID: session.ID,
+		UserID:    session.UserID,
+		Reason:    reason,
+		RevokedAt: time.Now(),
+	}
+}
+
+func (e SessionRevoked) EventType() string     { return EventSessionRevoked }
+func (e SessionRevoked) AggregateType() string { return AggregateUser }
+func (e SessionRevoked) AggregateID() string   { return e.UserID }
+func (e SessionRevoked) OccurredAt() time.Time { return e.RevokedAt }
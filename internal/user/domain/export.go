@@ -0,0 +1,83 @@
+package domain
+
+import "strings"
+
+// ExportFormat identifies the encoding of a user export
+type ExportFormat string
+
+// Supported export formats
+const (
+	ExportFormatCSV   ExportFormat = "csv"
+	ExportFormatJSONL ExportFormat = "jsonl"
+	ExportFormatXLSX  ExportFormat = "xlsx"
+)
+
+// ParseExportFormat resolves a format name to an export format
+func ParseExportFormat(raw string) (ExportFormat, bool) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", "csv":
+		return ExportFormatCSV, true
+	case "jsonl", "ndjson":
+		return ExportFormatJSONL, true
+	case "xlsx":
+		return ExportFormatXLSX, true
+	default:
+		return "", false
+	}
+}
+
+// Exportable user columns
+const (
+	ExportColumnID                    = "id"
+	ExportColumnEmail                 = "email"
+	ExportColumnName                  = "name"
+	ExportColumnRole                  = "role"
+	ExportColumnIsActive              = "is_active"
+	ExportColumnPasswordResetRequired = "password_reset_required"
+	ExportColumnSuspendedAt           = "suspended_at"
+	ExportColumnSuspendedUntil        = "suspended_until"
+	ExportColumnSuspensionReason      = "suspension_reason"
+	ExportColumnPasswordHash          = "password_hash"
+	ExportColumnCreatedAt             = "created_at"
+	ExportColumnUpdatedAt             = "updated_at"
+)
+
+// ExportColumns is the whitelist of exportable columns in their default order
+var ExportColumns = []string{
+	ExportColumnID,
+	ExportColumnEmail,
+	ExportColumnName,
+	ExportColumnRole,
+	ExportColumnIsActive,
+	ExportColumnPasswordResetRequired,
+	ExportColumnSuspendedAt,
+	ExportColumnSuspendedUntil,
+	ExportColumnSuspensionReason,
+	ExportColumnPasswordHash,
+	ExportColumnCreatedAt,
+	ExportColumnUpdatedAt,
+}
+
+// SensitiveExportColumns are only exported when requested by name
+var SensitiveExportColumns = map[string]bool{
+	ExportColumnPasswordHash:     true,
+	ExportColumnSuspensionReason: true,
+}
+
+// DefaultExportColumns returns the exportable columns that are not sensitive
+func DefaultExportColumns() []string {
+	columns := make([]string, 0, len(ExportColumns))
+	for _, column := range ExportColumns {
+		if !SensitiveExportColumns[column] {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// UserExportQuery selects the users, ordering and columns of an export
+type UserExportQuery struct {
+	Filter  UserFilter
+	Sort    string
+	Columns []string
+}
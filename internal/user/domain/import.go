@@ -0,0 +1,72 @@
+package domain
+
+import "strings"
+
+// ImportFormat identifies the encoding of a bulk user import
+type ImportFormat string
+
+// Supported import formats
+const (
+	ImportFormatCSV   ImportFormat = "csv"
+	ImportFormatJSONL ImportFormat = "jsonl"
+)
+
+// ParseImportFormat resolves a format name or file extension to an import format
+func ParseImportFormat(raw string) (ImportFormat, bool) {
+	switch strings.TrimPrefix(strings.ToLower(strings.TrimSpace(raw)), ".") {
+	case "csv", "text/csv":
+		return ImportFormatCSV, true
+	case "jsonl", "ndjson", "application/x-ndjson", "application/jsonl":
+		return ImportFormatJSONL, true
+	default:
+		return "", false
+	}
+}
+
+// Import row outcomes
+const (
+	ImportStatusCreated = "created"
+	ImportStatusValid   = "valid"
+	ImportStatusFailed  = "failed"
+)
+
+// ImportRow is a single decoded record of a bulk import. PasswordHash is an
+// optional preset bcrypt hash; when empty a password is generated and the
+// user is invited to reset it.
+type ImportRow struct {
+	Line         int    `json:"-"`
+	Email        string `json:"email"`
+	Name         string `json:"name"`
+	Role         string `json:"role"`
+	PasswordHash string `json:"password_hash"`
+}
+
+// ImportOptions controls how a bulk import is applied
+type ImportOptions struct {
+	// DryRun validates every row without writing anything
+	DryRun bool
+
+	// BatchSize is the number of rows inserted per transaction
+	BatchSize int
+}
+
+// ImportRowResult reports the outcome of a single import row
+type ImportRowResult struct {
+	Line      int    `json:"line"`
+	Email     string `json:"email,omitempty"`
+	Status    string `json:"status"`
+	UserID    string `json:"user_id,omitempty"`
+	Invited   bool   `json:"invited,omitempty"`
+	ErrorCode string `json:"error_code,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ImportReport summarises a bulk import with a per-row breakdown
+type ImportReport struct {
+	DryRun  bool              `json:"dry_run"`
+	Total   int               `json:"total"`
+	Created int               `json:"created"`
+	Valid   int               `json:"valid"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
@@ -1,14 +1,24 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/zercle/template-go-echo/pkg"
+)
 
 //go:generate go run github.com/uber-go/mock/cmd/mockgen -destination=../mock/mock_repository.go -package=mock github.com/zercle/template-go-echo/internal/user/domain UserRepository
 //go:generate go run github.com/uber-go/mock/cmd/mockgen -destination=../mock/mock_usecase.go -package=mock github.com/zercle/template-go-echo/internal/user/domain UserUsecase
 
-// UserRepository defines database operations for users
+// UserRepository defines database operations for users. Methods accepting
+// domain events append them to the outbox in the same transaction as the change.
 type UserRepository interface {
 	// CreateUser creates a new user in the database
-	CreateUser(ctx context.Context, user *User) error
+	CreateUser(ctx context.Context, user *User, events ...pkg.DomainEvent) error
+
+	// CreateUsers creates users in a single transaction; either all rows are inserted or none
+	CreateUsers(ctx context.Context, users []*User, events ...pkg.DomainEvent) error
 
 	// GetUserByID retrieves a user by ID
 	GetUserByID(ctx context.Context, id string) (*User, error)
@@ -16,11 +26,12 @@ type UserRepository interface {
 	// GetUserByEmail retrieves a user by email
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
 
-	// UpdateUser updates an existing user
-	UpdateUser(ctx context.Context, user *User) error
+	// UpdateUser updates an existing user if its stored version still equals user.Version,
+	// returning ErrVersionConflict otherwise and incrementing user.Version on success
+	UpdateUser(ctx context.Context, user *User, events ...pkg.DomainEvent) error
 
 	// DeleteUser soft deletes a user
-	DeleteUser(ctx context.Context, id string) error
+	DeleteUser(ctx context.Context, id string, events ...pkg.DomainEvent) error
 
 	// ListUsers retrieves a paginated list of users
 	ListUsers(ctx context.Context, limit, offset int) ([]*User, error)
@@ -28,6 +39,17 @@ type UserRepository interface {
 	// GetUserCount returns the total count of non-deleted users
 	GetUserCount(ctx context.Context) (int, error)
 
+	// ListUsersPage retrieves up to limit non-deleted users matching filter, ordered by sort
+	// and starting after the given keyset position
+	ListUsersPage(ctx context.Context, filter UserFilter, sort pkg.Sort, after *UserCursor, limit int) ([]*User, error)
+
+	// CountUsers returns the count of non-deleted users matching filter
+	CountUsers(ctx context.Context, filter UserFilter) (int, error)
+
+	// StreamUsers calls fn for every non-deleted user matching filter in sort order,
+	// reading from a database cursor; iteration stops at the first error
+	StreamUsers(ctx context.Context, filter UserFilter, sort pkg.Sort, fn func(*User) error) error
+
 	// CreateSession creates a new user session
 	CreateSession(ctx context.Context, session *UserSession) error
 
@@ -38,13 +60,46 @@ type UserRepository interface {
 	GetSessionsByUserID(ctx context.Context, userID string) ([]*UserSession, error)
 
 	// DeleteSession deletes a session
-	DeleteSession(ctx context.Context, id string) error
+	DeleteSession(ctx context.Context, id string, events ...pkg.DomainEvent) error
 
-	// DeleteExpiredSessions deletes all expired sessions
-	DeleteExpiredSessions(ctx context.Context) error
+	// DeleteExpiredSessions deletes all expired sessions and returns how many were removed
+	DeleteExpiredSessions(ctx context.Context) (int, error)
 
 	// GetSessionByTokenHash retrieves a session by token hash
 	GetSessionByTokenHash(ctx context.Context, tokenHash string) (*UserSession, error)
+
+	// GetDeletedUserByEmail retrieves the most recently soft deleted user by email
+	GetDeletedUserByEmail(ctx context.Context, email string) (*User, error)
+
+	// RestoreUser clears the soft delete marker of a user
+	RestoreUser(ctx context.Context, id string) error
+
+	// ListDeletedUsers retrieves a paginated list of soft deleted users, oldest deletion first
+	ListDeletedUsers(ctx context.Context, limit, offset int) ([]*User, error)
+
+	// GetDeletedUserCount returns the total count of soft deleted users
+	GetDeletedUserCount(ctx context.Context) (int, error)
+
+	// PurgeDeletedUsers hard deletes users soft deleted before the cutoff, cascading to sessions
+	PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int, error)
+
+	// PurgeDeletedUser hard deletes a single user soft deleted before the cutoff, cascading to sessions
+	PurgeDeletedUser(ctx context.Context, id string, deletedBefore time.Time) error
+
+	// UpdatePassword replaces the password hash and sets the forced reset flag
+	UpdatePassword(ctx context.Context, id, passwordHash string, resetRequired bool) error
+
+	// UpdateUserRole changes the role of a user
+	UpdateUserRole(ctx context.Context, id, role string) error
+
+	// SuspendUser deactivates a user with a reason and optional expiry
+	SuspendUser(ctx context.Context, id, reason string, until *time.Time) error
+
+	// ReactivateUser lifts a suspension
+	ReactivateUser(ctx context.Context, id string) error
+
+	// DeleteSessionsByUserID deletes all sessions of a user
+	DeleteSessionsByUserID(ctx context.Context, userID string, events ...pkg.DomainEvent) (int, error)
 }
 
 // UserUsecase defines business logic for users
@@ -61,18 +116,26 @@ type UserUsecase interface {
 	// GetUserByEmail retrieves a user by email
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
 
-	// UpdateUserProfile updates user information
-	UpdateUserProfile(ctx context.Context, id, name, email string) (*User, error)
+	// UpdateUserProfile updates user information. A non-zero expectedVersion must equal the
+	// current version of the user, otherwise ErrVersionConflict is returned
+	UpdateUserProfile(ctx context.Context, id, name, email string, expectedVersion int64) (*User, error)
 
 	// ChangePassword changes user password
 	ChangePassword(ctx context.Context, id, oldPassword, newPassword string) error
 
+	// CompletePasswordReset changes the password of an account whose password reset was
+	// forced, which cannot log in or refresh tokens until it does
+	CompletePasswordReset(ctx context.Context, email, oldPassword, newPassword string) error
+
 	// DeleteUser deletes a user account
 	DeleteUser(ctx context.Context, id string) error
 
 	// ListUsers retrieves a paginated list of users
 	ListUsers(ctx context.Context, limit, offset int) ([]*User, int, error)
 
+	// ListUsersPage retrieves a filtered, sorted, cursor paginated list of users
+	ListUsersPage(ctx context.Context, query UserListQuery) (pkg.CursorPage[*User], error)
+
 	// RefreshToken generates a new access token from refresh token
 	RefreshToken(ctx context.Context, refreshToken string) (string, error)
 
@@ -81,4 +144,56 @@ type UserUsecase interface {
 
 	// LogoutAllSessions invalidates all sessions for a user
 	LogoutAllSessions(ctx context.Context, userID string) error
+
+	// RestoreUser reactivates a soft deleted account within the grace period
+	RestoreUser(ctx context.Context, email, password string) (*User, error)
+
+	// ListPendingDeletion retrieves a paginated list of accounts awaiting purge
+	ListPendingDeletion(ctx context.Context, limit, offset int) ([]*User, int, error)
+
+	// PurgeDeletedUsers hard deletes accounts whose grace period has elapsed
+	PurgeDeletedUsers(ctx context.Context) (int, error)
+
+	// CleanupExpiredSessions deletes sessions past their expiry and returns how many were removed
+	CleanupExpiredSessions(ctx context.Context) (int, error)
+
+	// DeletionGracePeriod returns the configured restore window for deleted accounts
+	DeletionGracePeriod() time.Duration
+
+	// AdminCreateUser creates a user with the given role on behalf of an administrator
+	AdminCreateUser(ctx context.Context, email, name, password, role string) (*User, error)
+
+	// SuspendUser suspends an account with a reason and optional expiry, revoking its sessions
+	SuspendUser(ctx context.Context, actorID, id, reason string, until *time.Time) (*User, error)
+
+	// ReactivateUser lifts the suspension of an account
+	ReactivateUser(ctx context.Context, id string) (*User, error)
+
+	// ForcePasswordReset requires a password change and revokes all sessions of an account
+	ForcePasswordReset(ctx context.Context, id string) error
+
+	// RevokeSessions revokes all sessions of an account and returns how many were removed
+	RevokeSessions(ctx context.Context, id string) (int, error)
+
+	// SetUserRole changes the role of an account
+	SetUserRole(ctx context.Context, actorID, id, role string) (*User, error)
+
+	// ImportUsers creates users from a CSV or JSONL stream and reports the outcome of every row
+	ImportUsers(ctx context.Context, r io.Reader, format ImportFormat, opts ImportOptions) (*ImportReport, error)
+
+	// ExportUsers streams the users and columns selected by query to w and returns the row count
+	ExportUsers(ctx context.Context, w io.Writer, format ExportFormat, query UserExportQuery) (int, error)
+}
+
+// InviteSender delivers the temporary password of an imported user, who must
+// change it on first login
+type InviteSender interface {
+	// SendPasswordInvite invites a user to log in with a temporary password and reset it
+	SendPasswordInvite(ctx context.Context, user *User, temporaryPassword string) error
+}
+
+// Mailer sends plain text emails
+type Mailer interface {
+	// Send delivers an email to a single recipient
+	Send(ctx context.Context, to, subject, body string) error
 }
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// Sortable user fields
+const (
+	SortFieldCreatedAt = "created_at"
+	SortFieldEmail     = "email"
+	SortFieldName      = "name"
+)
+
+// UserSortFields is the whitelist of fields users may be sorted by
+var UserSortFields = []string{SortFieldCreatedAt, SortFieldEmail, SortFieldName}
+
+// DefaultUserSort orders users newest first
+var DefaultUserSort = pkg.Sort{Field: SortFieldCreatedAt, Direction: pkg.SortDesc}
+
+// UserFilter narrows a user listing; zero values are ignored
+type UserFilter struct {
+	EmailPrefix   string
+	NameContains  string
+	IsActive      *bool
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// UserListQuery describes a keyset paginated user listing
+type UserListQuery struct {
+	Filter       UserFilter
+	Sort         string
+	Cursor       string
+	Limit        int
+	IncludeTotal bool
+}
+
+// UserCursor is the keyset position of a user within a sorted listing
+type UserCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+// NewUserCursor returns the keyset position of a user for the given sort
+func NewUserCursor(user *User, sort pkg.Sort) UserCursor {
+	cursor := UserCursor{Sort: sort.String(), ID: user.ID}
+	switch sort.Field {
+	case SortFieldEmail:
+		cursor.Value = user.Email
+	case SortFieldName:
+		cursor.Value = user.Name
+	default:
+		cursor.Value = user.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return cursor
+}
@@ -0,0 +1,485 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/middleware"
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// StreamingRoutes are routes that stream their request or response and must
+// not be cut short or buffered by the request timeout. An import commits its
+// batches as it reads them, so timing it out would lose the report of rows
+// already created.
+var StreamingRoutes = []string{"/api/v1/admin/users/import", "/api/v1/admin/users/export"}
+
+// exportContentTypes maps export formats to response content types
+var exportContentTypes = map[domain.ExportFormat]string{
+	domain.ExportFormatCSV:   "text/csv; charset=utf-8",
+	domain.ExportFormatJSONL: "application/x-ndjson",
+	domain.ExportFormatXLSX:  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// registerAdminRoutes registers administrator-only user routes
+func (h *Handler) registerAdminRoutes(e *echo.Echo, jwtCfg *config.JWTConfig) {
+	group := e.Group("/api/v1/admin/users", middleware.JWTAuth(jwtCfg), middleware.RequireRole(domain.RoleAdmin))
+
+	group.POST("", h.AdminCreateUser, h.idempotency)
+	group.POST("/import", h.ImportUsers)
+	group.GET("/export", h.ExportUsers)
+	group.GET("/pending-deletion", h.ListPendingDeletion)
+	group.POST("/:id/suspend", h.SuspendUser)
+	group.POST("/:id/reactivate", h.ReactivateUser)
+	group.POST("/:id/password-reset", h.ForcePasswordReset)
+	group.DELETE("/:id/sessions", h.RevokeSessions)
+	group.PUT("/:id/role", h.SetUserRole)
+}
+
+// adminErrorStatus maps user domain error codes to HTTP status codes for admin endpoints
+func adminErrorStatus(code string) int {
+	switch code {
+	case domain.ErrCodeUserNotFound:
+		return http.StatusNotFound
+	case domain.ErrCodeUserExists, domain.ErrCodePendingDeletion:
+		return http.StatusConflict
+	case domain.ErrCodeSelfModification:
+		return http.StatusForbidden
+	case domain.ErrCodeInvalidImport, domain.ErrCodeExportTooLarge:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// toAdminUserResponse converts a domain user to its administrative representation
+func toAdminUserResponse(user *domain.User) *AdminUserResponse {
+	return &AdminUserResponse{
+		ID:                    user.ID,
+		Email:                 user.Email,
+		Name:                  user.Name,
+		Role:                  user.Role,
+		IsActive:              user.IsActive,
+		SuspendedAt:           user.SuspendedAt,
+		SuspendedUntil:        user.SuspendedUntil,
+		SuspensionReason:      user.SuspensionReason,
+		PasswordResetRequired: user.PasswordResetRequired,
+		CreatedAt:             user.CreatedAt,
+		UpdatedAt:             user.UpdatedAt,
+	}
+}
+
+// AdminCreateUser creates a user on behalf of an administrator
+// @Summary Create user
+// @Description Create a user account with an explicit role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Unique key making retries replay the first response"
+// @Param request body AdminCreateUserRequest true "Create user request"
+// @Success 201 {object} pkg.JSendResponse{data=AdminUserResponse}
+// @Failure 400 {object} pkg.JSendResponse
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 409 {object} pkg.JSendResponse
+// @Failure 422 {object} pkg.JSendResponse
+// @Failure 500 {object} pkg.JSendResponse
+// @Router /api/v1/admin/users [post]
+func (h *Handler) AdminCreateUser(c echo.Context) error {
+	req := &AdminCreateUserRequest{}
+	if err := c.Bind(req); err != nil {
+		return pkg.Fail(c, http.StatusBadRequest, nil, "invalid request body")
+	}
+
+	user, err := h.usecase.AdminCreateUser(c.Request().Context(), req.Email, req.Name, req.Password, req.Role)
+	if err != nil {
+		if domainErr, ok := err.(*pkg.DomainError); ok {
+			return pkg.Error(c, adminErrorStatus(domainErr.Code), domainErr.Message, domainErr.Code)
+		}
+		return err
+	}
+
+	return pkg.Success(c, http.StatusCreated, toAdminUserResponse(user))
+}
+
+// SuspendUser suspends a user account
+// @Summary Suspend user
+// @Description Suspend a user account with a reason and optional expiry, revoking all sessions
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param request body SuspendUserRequest true "Suspend request"
+// @Success 200 {object} pkg.JSendResponse{data=AdminUserResponse}
+// @Failure 400 {object} pkg.JSendResponse
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 404 {object} pkg.JSendResponse
+// @Router /api/v1/admin/users/{id}/suspend [post]
+func (h *Handler) SuspendUser(c echo.Context) error {
+	userID := c.Param("id")
+	if userID == "" {
+		return pkg.Fail(c, http.StatusBadRequest, nil, "user id is required")
+	}
+
+	req := &SuspendUserRequest{}
+	if err := c.Bind(req); err != nil {
+		return pkg.Fail(c, http.StatusBadRequest, nil, "invalid request body")
+	}
+
+	user, err := h.usecase.SuspendUser(c.Request().Context(), middleware.GetUserID(c), userID, req.Reason, req.Until)
+	if err != nil {
+		if domainErr, ok := err.(*pkg.DomainError); ok {
+			return pkg.Error(c, adminErrorStatus(domainErr.Code), domainErr.Message, domainErr.Code)
+		}
+		return err
+	}
+
+	return pkg.Success(c, http.StatusOK, toAdminUserResponse(user))
+}
+
+// ReactivateUser lifts a user suspension
+// @Summary Reactivate user
+// @Description Lift the suspension of a user account
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} pkg.JSendResponse{data=AdminUserResponse}
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 404 {object} pkg.JSendResponse
+// @Router /api/v1/admin/users/{id}/reactivate [post]
+func (h *Handler) ReactivateUser(c echo.Context) error {
+	userID := c.Param("id")
+	if userID == "" {
+		return pkg.Fail(c, http.StatusBadRequest, nil, "user id is required")
+	}
+
+	user, err := h.usecase.ReactivateUser(c.Request().Context(), userID)
+	if err != nil {
+		if domainErr, ok := err.(*pkg.DomainError); ok {
+			return pkg.Error(c, adminErrorStatus(domainErr.Code), domainErr.Message, domainErr.Code)
+		}
+		return err
+	}
+
+	return pkg.Success(c, http.StatusOK, toAdminUserResponse(user))
+}
+
+// ForcePasswordReset requires a user to change their password
+// @Summary Force password reset
+// @Description Require a password change on next login and revoke all sessions
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} pkg.JSendResponse
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 404 {object} pkg.JSendResponse
+// @Router /api/v1/admin/users/{id}/password-reset [post]
+func (h *Handler) ForcePasswordReset(c echo.Context) error {
+	userID := c.Param("id")
+	if userID == "" {
+		return pkg.Fail(c, http.StatusBadRequest, nil, "user id is required")
+	}
+
+	if err := h.usecase.ForcePasswordReset(c.Request().Context(), userID); err != nil {
+		if domainErr, ok := err.(*pkg.DomainError); ok {
+			return pkg.Error(c, adminErrorStatus(domainErr.Code), domainErr.Message, domainErr.Code)
+		}
+		return err
+	}
+
+	return pkg.SuccessWithMessage(c, http.StatusOK, nil, "password reset required")
+}
+
+// RevokeSessions revokes all sessions of a user
+// @Summary Revoke user sessions
+// @Description Revoke all sessions of a user account
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} pkg.JSendResponse{data=RevokeSessionsResponse}
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 404 {object} pkg.JSendResponse
+// @Router /api/v1/admin/users/{id}/sessions [delete]
+func (h *Handler) RevokeSessions(c echo.Context) error {
+	userID := c.Param("id")
+	if userID == "" {
+		return pkg.Fail(c, http.StatusBadRequest, nil, "user id is required")
+	}
+
+	revoked, err := h.usecase.RevokeSessions(c.Request().Context(), userID)
+	if err != nil {
+		if domainErr, ok := err.(*pkg.DomainError); ok {
+			return pkg.Error(c, adminErrorStatus(domainErr.Code), domainErr.Message, domainErr.Code)
+		}
+		return err
+	}
+
+	return pkg.Success(c, http.StatusOK, &RevokeSessionsResponse{Revoked: revoked})
+}
+
+// SetUserRole changes the role of a user
+// @Summary Set user role
+// @Description Change the authorization role of a user account
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param request body SetRoleRequest true "Role request"
+// @Success 200 {object} pkg.JSendResponse{data=AdminUserResponse}
+// @Failure 400 {object} pkg.JSendResponse
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 404 {object} pkg.JSendResponse
+// @Router /api/v1/admin/users/{id}/role [put]
+func (h *Handler) SetUserRole(c echo.Context) error {
+	userID := c.Param("id")
+	if userID == "" {
+		return pkg.Fail(c, http.StatusBadRequest, nil, "user id is required")
+	}
+
+	req := &SetRoleRequest{}
+	if err := c.Bind(req); err != nil {
+		return pkg.Fail(c, http.StatusBadRequest, nil, "invalid request body")
+	}
+
+	user, err := h.usecase.SetUserRole(c.Request().Context(), middleware.GetUserID(c), userID, req.Role)
+	if err != nil {
+		if domainErr, ok := err.(*pkg.DomainError); ok {
+			return pkg.Error(c, adminErrorStatus(domainErr.Code), domainErr.Message, domainErr.Code)
+		}
+		return err
+	}
+
+	return pkg.Success(c, http.StatusOK, toAdminUserResponse(user))
+}
+
+// ListPendingDeletion retrieves accounts awaiting purge
+// @Summary List accounts pending deletion
+// @Description Retrieve a paginated list of soft deleted accounts and when they will be purged
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Page limit (default: 10, max: 100)"
+// @Param offset query int false "Page offset (default: 0)"
+// @Success 200 {object} pkg.JSendResponse{data=PendingDeletionListResponse}
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 500 {object} pkg.JSendResponse
+// @Router /api/v1/admin/users/pending-deletion [get]
+func (h *Handler) ListPendingDeletion(c echo.Context) error {
+	limit := 10
+	offset := 0
+
+	if l := c.QueryParam("limit"); l != "" {
+		if parsedLimit, err := strconv.Atoi(l); err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+			if limit > 100 {
+				limit = 100
+			}
+		}
+	}
+
+	if o := c.QueryParam("offset"); o != "" {
+		if parsedOffset, err := strconv.Atoi(o); err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	users, total, err := h.usecase.ListPendingDeletion(c.Request().Context(), limit, offset)
+	if err != nil {
+		return err
+	}
+
+	gracePeriod := h.usecase.DeletionGracePeriod()
+	responses := make([]*PendingDeletionResponse, len(users))
+	for i, user := range users {
+		responses[i] = &PendingDeletionResponse{
+			ID:        user.ID,
+			Email:     user.Email,
+			Name:      user.Name,
+			DeletedAt: *user.DeletedAt,
+			PurgeAt:   user.PurgeAt(gracePeriod),
+		}
+	}
+
+	totalPages := (total + limit - 1) / limit
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	return pkg.Success(c, http.StatusOK, &PendingDeletionListResponse{
+		Users:      responses,
+		Total:      total,
+		Limit:      limit,
+		Offset:     offset,
+		TotalPages: totalPages,
+	})
+}
+
+// ImportUsers creates users in bulk from a CSV or JSONL upload
+// @Summary Import users
+// @Description Create users from a CSV (with header) or JSONL stream sent as the request body or as the multipart "file" field. Columns are email, name, optional role and optional password_hash (bcrypt); rows without a hash get a generated password and a reset invite, and fail when no invite sender is configured. The response reports the outcome of every row.
+// @Tags admin
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param format query string false "Input format (csv or jsonl); detected from the file name or Content-Type when omitted"
+// @Param dry_run query bool false "Validate rows without creating users"
+// @Param batch_size query int false "Rows inserted per transaction (default 100, max 1000)"
+// @Param file formData file false "Import file"
+// @Success 200 {object} pkg.JSendResponse{data=ImportUsersResponse}
+// @Failure 400 {object} pkg.JSendResponse
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 422 {object} pkg.JSendResponse
+// @Failure 500 {object} pkg.JSendResponse
+// @Router /api/v1/admin/users/import [post]
+func (h *Handler) ImportUsers(c echo.Context) error {
+	opts := domain.ImportOptions{}
+	if raw := c.QueryParam("dry_run"); raw != "" {
+		dryRun, err := strconv.ParseBool(raw)
+		if err != nil {
+			return pkg.Fail(c, http.StatusBadRequest, nil, "dry_run must be a boolean")
+		}
+		opts.DryRun = dryRun
+	}
+	if raw := c.QueryParam("batch_size"); raw != "" {
+		batchSize, err := strconv.Atoi(raw)
+		if err != nil || batchSize < 1 {
+			return pkg.Fail(c, http.StatusBadRequest, nil, "batch_size must be a positive integer")
+		}
+		opts.BatchSize = batchSize
+	}
+
+	var body io.Reader = c.Request().Body
+	formatHint := c.Request().Header.Get(echo.HeaderContentType)
+	if file, err := c.FormFile("file"); err == nil {
+		src, err := file.Open()
+		if err != nil {
+			return pkg.Fail(c, http.StatusBadRequest, nil, "invalid import file")
+		}
+		defer src.Close()
+		body = src
+		formatHint = filepath.Ext(file.Filename)
+	}
+	if raw := c.QueryParam("format"); raw != "" {
+		formatHint = raw
+	}
+
+	format, ok := domain.ParseImportFormat(strings.SplitN(formatHint, ";", 2)[0])
+	if !ok {
+		return pkg.Fail(c, http.StatusBadRequest, nil, "format must be csv or jsonl")
+	}
+
+	report, err := h.usecase.ImportUsers(c.Request().Context(), body, format, opts)
+	if err != nil {
+		if domainErr, ok := err.(*pkg.DomainError); ok {
+			return pkg.Error(c, adminErrorStatus(domainErr.Code), domainErr.Message, domainErr.Code)
+		}
+		return err
+	}
+
+	return pkg.Success(c, http.StatusOK, toImportUsersResponse(report))
+}
+
+// toImportUsersResponse converts an import report to its response representation
+func toImportUsersResponse(report *domain.ImportReport) *ImportUsersResponse {
+	rows := make([]ImportRowResponse, len(report.Rows))
+	for i, row := range report.Rows {
+		rows[i] = ImportRowResponse(row)
+	}
+
+	return &ImportUsersResponse{
+		DryRun:  report.DryRun,
+		Total:   report.Total,
+		Created: report.Created,
+		Valid:   report.Valid,
+		Failed:  report.Failed,
+		Rows:    rows,
+	}
+}
+
+// ExportUsers streams users matching the list filters as CSV, JSONL or XLSX
+// @Summary Export users
+// @Description Stream all users matching the list filters as a file download. Sensitive columns (password_hash, suspension_reason) are only included when named in columns. A response cut short by a server error is aborted rather than completed.
+// @Tags admin
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param format query string false "Export format (csv, jsonl or xlsx)" default(csv)
+// @Param columns query string false "Comma separated columns to export"
+// @Param sort query string false "Sort field, prefixed with - for descending (created_at, email, name)" default(-created_at)
+// @Param email_prefix query string false "Filter by email prefix"
+// @Param name query string false "Filter by name substring"
+// @Param is_active query bool false "Filter by active status"
+// @Param created_after query string false "Only users created at or after this RFC3339 timestamp"
+// @Param created_before query string false "Only users created before this RFC3339 timestamp"
+// @Success 200 {file} file
+// @Failure 400 {object} pkg.JSendResponse
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 422 {object} pkg.JSendResponse
+// @Failure 500 {object} pkg.JSendResponse
+// @Router /api/v1/admin/users/export [get]
+func (h *Handler) ExportUsers(c echo.Context) error {
+	format, ok := domain.ParseExportFormat(c.QueryParam("format"))
+	if !ok {
+		return pkg.Fail(c, http.StatusBadRequest, nil, "format must be csv, jsonl or xlsx")
+	}
+
+	filter, err := parseUserFilter(c)
+	if err != nil {
+		return pkg.Fail(c, http.StatusBadRequest, nil, err.Error())
+	}
+
+	query := domain.UserExportQuery{Filter: filter, Sort: c.QueryParam("sort")}
+	if v := c.QueryParam("columns"); v != "" {
+		query.Columns = strings.Split(v, ",")
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, exportContentTypes[format])
+	res.Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf(`attachment; filename="users-%s.%s"`, time.Now().UTC().Format("20060102T150405Z"), format))
+
+	if _, err := h.usecase.ExportUsers(c.Request().Context(), res, format, query); err != nil {
+		if res.Committed {
+			// Rows are already on the wire; abort the connection so the client
+			// sees a truncated transfer instead of a complete file
+			panic(http.ErrAbortHandler)
+		}
+
+		res.Header().Del(echo.HeaderContentDisposition)
+		if domainErr, ok := err.(*pkg.DomainError); ok {
+			return pkg.Error(c, adminErrorStatus(domainErr.Code), domainErr.Message, domainErr.Code)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -27,11 +27,24 @@ type ChangePasswordRequest struct {
 	NewPassword string `json:"new_password" validate:"required,min=8,max=128"`
 }
 
+// CompletePasswordResetRequest is the request body for completing a forced password reset
+type CompletePasswordResetRequest struct {
+	Email       string `json:"email" validate:"required,email"`
+	OldPassword string `json:"old_password" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=8,max=128"`
+}
+
 // RefreshTokenRequest is the request body for token refresh
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
+// RestoreRequest is the request body for restoring a deleted account
+type RestoreRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
 // UserResponse is the response body for user endpoints
 type UserResponse struct {
 	ID        string    `json:"id"`
@@ -48,6 +61,8 @@ type LoginResponse struct {
 	AccessToken  string        `json:"access_token"`
 	RefreshToken string        `json:"refresh_token"`
 	ExpiresIn    int           `json:"expires_in"`
+
+	PasswordResetRequired bool `json:"password_reset_required"`
 }
 
 // TokenResponse is the response body for token refresh
@@ -56,11 +71,88 @@ type TokenResponse struct {
 	ExpiresIn   int    `json:"expires_in"`
 }
 
-// UserListResponse is the response body for user list endpoint
-type UserListResponse struct {
+// UserPageResponse is the response body for the cursor paginated user list endpoint
+type UserPageResponse struct {
 	Users      []*UserResponse `json:"users"`
-	Total      int             `json:"total"`
-	Limit      int             `json:"limit"`
-	Offset     int             `json:"offset"`
-	TotalPages int             `json:"total_pages"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+	HasMore    bool            `json:"has_more"`
+	Total      *int            `json:"total,omitempty"`
+}
+
+// PendingDeletionResponse is the response body for an account awaiting purge
+type PendingDeletionResponse struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	DeletedAt time.Time `json:"deleted_at"`
+	PurgeAt   time.Time `json:"purge_at"`
+}
+
+// PendingDeletionListResponse is the response body for the pending deletion list endpoint
+type PendingDeletionListResponse struct {
+	Users      []*PendingDeletionResponse `json:"users"`
+	Total      int                        `json:"total"`
+	Limit      int                        `json:"limit"`
+	Offset     int                        `json:"offset"`
+	TotalPages int                        `json:"total_pages"`
+}
+
+// AdminCreateUserRequest is the request body for admin user creation
+type AdminCreateUserRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Name     string `json:"name" validate:"required,min=1,max=255"`
+	Password string `json:"password" validate:"required,min=8,max=128"`
+	Role     string `json:"role" validate:"omitempty,oneof=user admin"`
+}
+
+// SuspendUserRequest is the request body for suspending a user
+type SuspendUserRequest struct {
+	Reason string     `json:"reason" validate:"required,max=500"`
+	Until  *time.Time `json:"until,omitempty"`
+}
+
+// SetRoleRequest is the request body for changing a user role
+type SetRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=user admin"`
+}
+
+// AdminUserResponse is the response body for admin user endpoints
+type AdminUserResponse struct {
+	ID                    string     `json:"id"`
+	Email                 string     `json:"email"`
+	Name                  string     `json:"name"`
+	Role                  string     `json:"role"`
+	IsActive              bool       `json:"is_active"`
+	SuspendedAt           *time.Time `json:"suspended_at,omitempty"`
+	SuspendedUntil        *time.Time `json:"suspended_until,omitempty"`
+	SuspensionReason      string     `json:"suspension_reason,omitempty"`
+	PasswordResetRequired bool       `json:"password_reset_required"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+}
+
+// RevokeSessionsResponse is the response body for session revocation
+type RevokeSessionsResponse struct {
+	Revoked int `json:"revoked"`
+}
+
+// ImportRowResponse reports the outcome of a single import row
+type ImportRowResponse struct {
+	Line      int    `json:"line"`
+	Email     string `json:"email,omitempty"`
+	Status    string `json:"status"`
+	UserID    string `json:"user_id,omitempty"`
+	Invited   bool   `json:"invited,omitempty"`
+	ErrorCode string `json:"error_code,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ImportUsersResponse is the response body for a bulk user import
+type ImportUsersResponse struct {
+	DryRun  bool                `json:"dry_run"`
+	Total   int                 `json:"total"`
+	Created int                 `json:"created"`
+	Valid   int                 `json:"valid"`
+	Failed  int                 `json:"failed"`
+	Rows    []ImportRowResponse `json:"rows"`
 }
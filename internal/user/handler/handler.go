@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/zercle/template-go-echo/internal/config"
@@ -14,14 +16,44 @@ import (
 
 // Handler handles user HTTP requests
 type Handler struct {
-	usecase domain.UserUsecase
+	usecase     domain.UserUsecase
+	idempotency echo.MiddlewareFunc
+	rateLimit   echo.MiddlewareFunc
+}
+
+// Option configures a Handler
+type Option func(*Handler)
+
+// WithIdempotency sets the middleware honoring Idempotency-Key on endpoints
+// that create users or sessions
+func WithIdempotency(mw echo.MiddlewareFunc) Option {
+	return func(h *Handler) {
+		h.idempotency = mw
+	}
+}
+
+// WithRateLimit sets the middleware limiting the rate of registrations,
+// logins, token refreshes and restores
+func WithRateLimit(mw echo.MiddlewareFunc) Option {
+	return func(h *Handler) {
+		h.rateLimit = mw
+	}
 }
 
 // New creates a new user handler
-func New(usecase domain.UserUsecase) *Handler {
-	return &Handler{
-		usecase: usecase,
+func New(usecase domain.UserUsecase, opts ...Option) *Handler {
+	noop := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return next
+	}
+	h := &Handler{
+		usecase:     usecase,
+		idempotency: noop,
+		rateLimit:   noop,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // RegisterRoutes registers user routes
@@ -29,9 +61,11 @@ func (h *Handler) RegisterRoutes(e *echo.Echo, jwtCfg *config.JWTConfig) {
 	group := e.Group("/api/v1/users")
 
 	// Public routes
-	group.POST("/register", h.Register)
-	group.POST("/login", h.Login)
-	group.POST("/token/refresh", h.RefreshToken)
+	group.POST("/register", h.Register, h.rateLimit, h.idempotency)
+	group.POST("/login", h.Login, h.rateLimit, h.idempotency)
+	group.POST("/token/refresh", h.RefreshToken, h.rateLimit)
+	group.POST("/restore", h.Restore, h.rateLimit, h.idempotency)
+	group.POST("/password-reset", h.CompletePasswordReset, h.rateLimit)
 
 	// Protected routes
 	group.GET("/:id", h.GetUser, middleware.JWTAuth(jwtCfg))
@@ -41,6 +75,8 @@ func (h *Handler) RegisterRoutes(e *echo.Echo, jwtCfg *config.JWTConfig) {
 	group.DELETE("/:id", h.DeleteUser, middleware.JWTAuth(jwtCfg))
 	group.POST("/logout", h.Logout, middleware.JWTAuth(jwtCfg))
 	group.POST("/logout-all", h.LogoutAll, middleware.JWTAuth(jwtCfg))
+
+	h.registerAdminRoutes(e, jwtCfg)
 }
 
 // Register creates a new user account
@@ -49,10 +85,13 @@ func (h *Handler) RegisterRoutes(e *echo.Echo, jwtCfg *config.JWTConfig) {
 // @Tags users
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Unique key making retries replay the first response"
 // @Param request body RegisterRequest true "Registration request"
 // @Success 201 {object} pkg.JSendResponse{data=UserResponse}
 // @Failure 400 {object} pkg.JSendResponse
 // @Failure 409 {object} pkg.JSendResponse
+// @Failure 422 {object} pkg.JSendResponse
+// @Failure 429 {object} pkg.JSendResponse
 // @Failure 500 {object} pkg.JSendResponse
 // @Router /api/v1/users/register [post]
 func (h *Handler) Register(c echo.Context) error {
@@ -66,7 +105,7 @@ func (h *Handler) Register(c echo.Context) error {
 		if domainErr, ok := err.(*pkg.DomainError); ok {
 			return pkg.Error(c, http.StatusConflict, domainErr.Message, domainErr.Code)
 		}
-		return pkg.Error(c, http.StatusInternalServerError, err.Error(), pkg.ErrCodeInternalError)
+		return err
 	}
 
 	return pkg.Success(c, http.StatusCreated, &UserResponse{
@@ -85,10 +124,15 @@ func (h *Handler) Register(c echo.Context) error {
 // @Tags users
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Unique key making retries replay the first response"
 // @Param request body LoginRequest true "Login request"
 // @Success 200 {object} pkg.JSendResponse{data=LoginResponse}
 // @Failure 400 {object} pkg.JSendResponse
 // @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 409 {object} pkg.JSendResponse
+// @Failure 422 {object} pkg.JSendResponse
+// @Failure 429 {object} pkg.JSendResponse
 // @Failure 500 {object} pkg.JSendResponse
 // @Router /api/v1/users/login [post]
 func (h *Handler) Login(c echo.Context) error {
@@ -106,9 +150,13 @@ func (h *Handler) Login(c echo.Context) error {
 	)
 	if err != nil {
 		if domainErr, ok := err.(*pkg.DomainError); ok {
-			return pkg.Error(c, http.StatusUnauthorized, domainErr.Message, domainErr.Code)
+			code := http.StatusUnauthorized
+			if domainErr.Code == domain.ErrCodeAccountSuspended || domainErr.Code == domain.ErrCodePasswordResetRequired {
+				code = http.StatusForbidden
+			}
+			return pkg.Error(c, code, domainErr.Message, domainErr.Code)
 		}
-		return pkg.Error(c, http.StatusInternalServerError, err.Error(), pkg.ErrCodeInternalError)
+		return err
 	}
 
 	return pkg.Success(c, http.StatusOK, &LoginResponse{
@@ -123,6 +171,8 @@ func (h *Handler) Login(c echo.Context) error {
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		ExpiresIn:    3600, // 1 hour
+
+		PasswordResetRequired: user.PasswordResetRequired,
 	})
 }
 
@@ -135,6 +185,7 @@ func (h *Handler) Login(c echo.Context) error {
 // @Security BearerAuth
 // @Param id path string true "User ID"
 // @Success 200 {object} pkg.JSendResponse{data=UserResponse}
+// @Header 200 {string} ETag "Current version of the user, for use with If-Match"
 // @Failure 400 {object} pkg.JSendResponse
 // @Failure 401 {object} pkg.JSendResponse
 // @Failure 404 {object} pkg.JSendResponse
@@ -154,9 +205,10 @@ func (h *Handler) GetUser(c echo.Context) error {
 			}
 			return pkg.Error(c, code, domainErr.Message, domainErr.Code)
 		}
-		return pkg.Error(c, http.StatusInternalServerError, err.Error(), pkg.ErrCodeInternalError)
+		return err
 	}
 
+	c.Response().Header().Set("ETag", pkg.VersionETag(user.Version))
 	return pkg.Success(c, http.StatusOK, &UserResponse{
 		ID:        user.ID,
 		Email:     user.Email,
@@ -167,45 +219,43 @@ func (h *Handler) GetUser(c echo.Context) error {
 	})
 }
 
-// ListUsers retrieves a paginated list of users
+// ListUsers retrieves a filtered, cursor paginated list of users
 // @Summary List users
-// @Description Retrieve a paginated list of users
+// @Description Retrieve a filtered, sorted, cursor paginated list of users
 // @Tags users
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param limit query int false "Page limit (default: 10, max: 100)"
-// @Param offset query int false "Page offset (default: 0)"
-// @Success 200 {object} pkg.JSendResponse{data=UserListResponse}
+// @Param cursor query string false "Opaque cursor from a previous page"
+// @Param sort query string false "Sort field, prefix with - for descending (created_at, email, name; default: -created_at)"
+// @Param email_prefix query string false "Filter by email prefix"
+// @Param name query string false "Filter by name substring"
+// @Param is_active query bool false "Filter by account status"
+// @Param created_after query string false "Filter by creation time (RFC3339, inclusive)"
+// @Param created_before query string false "Filter by creation time (RFC3339, exclusive)"
+// @Param include_total query bool false "Include the total count of matching users"
+// @Success 200 {object} pkg.JSendResponse{data=UserPageResponse}
+// @Failure 400 {object} pkg.JSendResponse
 // @Failure 401 {object} pkg.JSendResponse
 // @Failure 500 {object} pkg.JSendResponse
 // @Router /api/v1/users [get]
 func (h *Handler) ListUsers(c echo.Context) error {
-	limit := 10
-	offset := 0
-
-	if l := c.QueryParam("limit"); l != "" {
-		if parsedLimit, err := strconv.Atoi(l); err == nil && parsedLimit > 0 {
-			limit = parsedLimit
-			if limit > 100 {
-				limit = 100
-			}
-		}
-	}
-
-	if o := c.QueryParam("offset"); o != "" {
-		if parsedOffset, err := strconv.Atoi(o); err == nil && parsedOffset >= 0 {
-			offset = parsedOffset
-		}
+	query, err := parseUserListQuery(c)
+	if err != nil {
+		return pkg.Fail(c, http.StatusBadRequest, nil, err.Error())
 	}
 
-	users, total, err := h.usecase.ListUsers(c.Request().Context(), limit, offset)
+	page, err := h.usecase.ListUsersPage(c.Request().Context(), query)
 	if err != nil {
-		return pkg.Error(c, http.StatusInternalServerError, err.Error(), pkg.ErrCodeInternalError)
+		if domainErr, ok := err.(*pkg.DomainError); ok && domainErr.Code == pkg.ErrCodeBadRequest {
+			return pkg.Error(c, http.StatusBadRequest, domainErr.Message, domainErr.Code)
+		}
+		return err
 	}
 
-	userResponses := make([]*UserResponse, len(users))
-	for i, user := range users {
+	userResponses := make([]*UserResponse, len(page.Items))
+	for i, user := range page.Items {
 		userResponses[i] = &UserResponse{
 			ID:        user.ID,
 			Email:     user.Email,
@@ -216,33 +266,96 @@ func (h *Handler) ListUsers(c echo.Context) error {
 		}
 	}
 
-	totalPages := (total + limit - 1) / limit
-	if totalPages == 0 {
-		totalPages = 1
-	}
-
-	return pkg.Success(c, http.StatusOK, &UserListResponse{
+	return pkg.Success(c, http.StatusOK, &UserPageResponse{
 		Users:      userResponses,
-		Total:      total,
-		Limit:      limit,
-		Offset:     offset,
-		TotalPages: totalPages,
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+		Total:      page.Total,
 	})
 }
 
+// parseUserListQuery reads filter, sort and pagination parameters for user listings
+func parseUserListQuery(c echo.Context) (domain.UserListQuery, error) {
+	query := domain.UserListQuery{
+		Sort:   c.QueryParam("sort"),
+		Cursor: c.QueryParam("cursor"),
+	}
+
+	if l := c.QueryParam("limit"); l != "" {
+		if parsedLimit, err := strconv.Atoi(l); err == nil {
+			query.Limit = parsedLimit
+		}
+	}
+
+	filter, err := parseUserFilter(c)
+	if err != nil {
+		return query, err
+	}
+	query.Filter = filter
+
+	if v := c.QueryParam("include_total"); v != "" {
+		includeTotal, err := strconv.ParseBool(v)
+		if err != nil {
+			return query, fmt.Errorf("include_total must be a boolean")
+		}
+		query.IncludeTotal = includeTotal
+	}
+
+	return query, nil
+}
+
+// parseUserFilter reads the user filter query parameters shared by listing and export
+func parseUserFilter(c echo.Context) (domain.UserFilter, error) {
+	filter := domain.UserFilter{
+		EmailPrefix:  c.QueryParam("email_prefix"),
+		NameContains: c.QueryParam("name"),
+	}
+
+	if v := c.QueryParam("is_active"); v != "" {
+		isActive, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, fmt.Errorf("is_active must be a boolean")
+		}
+		filter.IsActive = &isActive
+	}
+
+	if v := c.QueryParam("created_after"); v != "" {
+		createdAfter, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, fmt.Errorf("created_after must be an RFC3339 timestamp")
+		}
+		filter.CreatedAfter = &createdAfter
+	}
+
+	if v := c.QueryParam("created_before"); v != "" {
+		createdBefore, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, fmt.Errorf("created_before must be an RFC3339 timestamp")
+		}
+		filter.CreatedBefore = &createdBefore
+	}
+
+	return filter, nil
+}
+
 // UpdateProfile updates user profile
 // @Summary Update user profile
-// @Description Update user name and email
+// @Description Update user name and email. Send the ETag from GET /users/{id} in If-Match
+// @Description to reject the update with 412 if the user changed in the meantime.
 // @Tags users
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "User ID"
+// @Param If-Match header string false "ETag of the version being updated"
 // @Param request body UpdateProfileRequest true "Update request"
 // @Success 200 {object} pkg.JSendResponse{data=UserResponse}
+// @Header 200 {string} ETag "New version of the user"
 // @Failure 400 {object} pkg.JSendResponse
 // @Failure 401 {object} pkg.JSendResponse
 // @Failure 404 {object} pkg.JSendResponse
+// @Failure 409 {object} pkg.JSendResponse
+// @Failure 412 {object} pkg.JSendResponse
 // @Router /api/v1/users/{id} [put]
 func (h *Handler) UpdateProfile(c echo.Context) error {
 	userID := c.Param("id")
@@ -250,12 +363,18 @@ func (h *Handler) UpdateProfile(c echo.Context) error {
 		return pkg.Fail(c, http.StatusBadRequest, nil, "user id is required")
 	}
 
+	expectedVersion, err := pkg.ParseIfMatch(c.Request().Header.Get("If-Match"))
+	if err != nil {
+		// Weak or foreign tags can never match a version, so the precondition fails
+		return pkg.Fail(c, http.StatusPreconditionFailed, nil, err.Error())
+	}
+
 	req := &UpdateProfileRequest{}
 	if err := c.Bind(req); err != nil {
 		return pkg.Fail(c, http.StatusBadRequest, nil, "invalid request body")
 	}
 
-	user, err := h.usecase.UpdateUserProfile(c.Request().Context(), userID, req.Name, req.Email)
+	user, err := h.usecase.UpdateUserProfile(c.Request().Context(), userID, req.Name, req.Email, expectedVersion)
 	if err != nil {
 		if domainErr, ok := err.(*pkg.DomainError); ok {
 			code := http.StatusBadRequest
@@ -264,12 +383,20 @@ func (h *Handler) UpdateProfile(c echo.Context) error {
 				code = http.StatusNotFound
 			case domain.ErrCodeUserExists:
 				code = http.StatusConflict
+			case domain.ErrCodeVersionConflict:
+				// A failed If-Match is a precondition failure; without one the
+				// update lost a race with a concurrent writer
+				code = http.StatusConflict
+				if expectedVersion != 0 {
+					code = http.StatusPreconditionFailed
+				}
 			}
 			return pkg.Error(c, code, domainErr.Message, domainErr.Code)
 		}
-		return pkg.Error(c, http.StatusInternalServerError, err.Error(), pkg.ErrCodeInternalError)
+		return err
 	}
 
+	c.Response().Header().Set("ETag", pkg.VersionETag(user.Version))
 	return pkg.Success(c, http.StatusOK, &UserResponse{
 		ID:        user.ID,
 		Email:     user.Email,
@@ -314,7 +441,48 @@ func (h *Handler) ChangePassword(c echo.Context) error {
 			}
 			return pkg.Error(c, code, domainErr.Message, domainErr.Code)
 		}
-		return pkg.Error(c, http.StatusInternalServerError, err.Error(), pkg.ErrCodeInternalError)
+		return err
+	}
+
+	return pkg.SuccessWithMessage(c, http.StatusOK, nil, "password changed successfully")
+}
+
+// CompletePasswordReset changes the password of an account whose password reset was forced
+// @Summary Complete a forced password reset
+// @Description Change the password of an account an administrator required to reset it. Such accounts cannot log in or refresh tokens until they do, so the current password authenticates the request.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body CompletePasswordResetRequest true "Password reset request"
+// @Success 200 {object} pkg.JSendResponse
+// @Failure 400 {object} pkg.JSendResponse
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 409 {object} pkg.JSendResponse
+// @Failure 429 {object} pkg.JSendResponse
+// @Failure 500 {object} pkg.JSendResponse
+// @Router /api/v1/users/password-reset [post]
+func (h *Handler) CompletePasswordReset(c echo.Context) error {
+	req := &CompletePasswordResetRequest{}
+	if err := c.Bind(req); err != nil {
+		return pkg.Fail(c, http.StatusBadRequest, nil, "invalid request body")
+	}
+
+	err := h.usecase.CompletePasswordReset(c.Request().Context(), req.Email, req.OldPassword, req.NewPassword)
+	if err != nil {
+		if domainErr, ok := err.(*pkg.DomainError); ok {
+			code := http.StatusBadRequest
+			switch domainErr.Code {
+			case domain.ErrCodeInvalidCredentials:
+				code = http.StatusUnauthorized
+			case domain.ErrCodeAccountSuspended:
+				code = http.StatusForbidden
+			case domain.ErrCodePasswordResetNotRequired:
+				code = http.StatusConflict
+			}
+			return pkg.Error(c, code, domainErr.Message, domainErr.Code)
+		}
+		return err
 	}
 
 	return pkg.SuccessWithMessage(c, http.StatusOK, nil, "password changed successfully")
@@ -344,7 +512,7 @@ func (h *Handler) DeleteUser(c echo.Context) error {
 		if domainErr, ok := err.(*pkg.DomainError); ok {
 			return pkg.Error(c, http.StatusNotFound, domainErr.Message, domainErr.Code)
 		}
-		return pkg.Error(c, http.StatusInternalServerError, err.Error(), pkg.ErrCodeInternalError)
+		return err
 	}
 
 	return c.NoContent(http.StatusNoContent)
@@ -363,13 +531,13 @@ func (h *Handler) DeleteUser(c echo.Context) error {
 func (h *Handler) Logout(c echo.Context) error {
 	sessionID := c.QueryParam("session_id")
 	if sessionID == "" {
-		slog.Warn("logout called without session_id")
+		pkg.Logger(c.Request().Context()).WarnContext(c.Request().Context(), "logout called without session_id")
 		return pkg.SuccessWithMessage(c, http.StatusOK, nil, "logged out successfully")
 	}
 
 	err := h.usecase.LogoutUser(c.Request().Context(), sessionID)
 	if err != nil {
-		slog.Warn("logout failed", slog.String("error", err.Error()))
+		pkg.Logger(c.Request().Context()).WarnContext(c.Request().Context(), "logout failed", slog.String("error", err.Error()))
 	}
 
 	return pkg.SuccessWithMessage(c, http.StatusOK, nil, "logged out successfully")
@@ -393,7 +561,7 @@ func (h *Handler) LogoutAll(c echo.Context) error {
 
 	err := h.usecase.LogoutAllSessions(c.Request().Context(), userID)
 	if err != nil {
-		return pkg.Error(c, http.StatusInternalServerError, err.Error(), pkg.ErrCodeInternalError)
+		return err
 	}
 
 	return pkg.SuccessWithMessage(c, http.StatusOK, nil, "all sessions logged out successfully")
@@ -409,6 +577,8 @@ func (h *Handler) LogoutAll(c echo.Context) error {
 // @Success 200 {object} pkg.JSendResponse{data=TokenResponse}
 // @Failure 400 {object} pkg.JSendResponse
 // @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 429 {object} pkg.JSendResponse
 // @Router /api/v1/users/token/refresh [post]
 func (h *Handler) RefreshToken(c echo.Context) error {
 	req := &RefreshTokenRequest{}
@@ -419,9 +589,13 @@ func (h *Handler) RefreshToken(c echo.Context) error {
 	accessToken, err := h.usecase.RefreshToken(c.Request().Context(), req.RefreshToken)
 	if err != nil {
 		if domainErr, ok := err.(*pkg.DomainError); ok {
-			return pkg.Error(c, http.StatusUnauthorized, domainErr.Message, domainErr.Code)
+			code := http.StatusUnauthorized
+			if domainErr.Code == domain.ErrCodePasswordResetRequired {
+				code = http.StatusForbidden
+			}
+			return pkg.Error(c, code, domainErr.Message, domainErr.Code)
 		}
-		return pkg.Error(c, http.StatusInternalServerError, err.Error(), pkg.ErrCodeInternalError)
+		return err
 	}
 
 	return pkg.Success(c, http.StatusOK, &TokenResponse{
@@ -429,3 +603,48 @@ func (h *Handler) RefreshToken(c echo.Context) error {
 		ExpiresIn:   3600,
 	})
 }
+
+// Restore reactivates a deleted account within the grace period
+// @Summary Restore a deleted account
+// @Description Reactivate a soft deleted account before its grace period elapses
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Unique key making retries replay the first response"
+// @Param request body RestoreRequest true "Restore request"
+// @Success 200 {object} pkg.JSendResponse{data=UserResponse}
+// @Failure 400 {object} pkg.JSendResponse
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 404 {object} pkg.JSendResponse
+// @Failure 409 {object} pkg.JSendResponse
+// @Failure 422 {object} pkg.JSendResponse
+// @Failure 429 {object} pkg.JSendResponse
+// @Failure 500 {object} pkg.JSendResponse
+// @Router /api/v1/users/restore [post]
+func (h *Handler) Restore(c echo.Context) error {
+	req := &RestoreRequest{}
+	if err := c.Bind(req); err != nil {
+		return pkg.Fail(c, http.StatusBadRequest, nil, "invalid request body")
+	}
+
+	user, err := h.usecase.RestoreUser(c.Request().Context(), req.Email, req.Password)
+	if err != nil {
+		if domainErr, ok := err.(*pkg.DomainError); ok {
+			code := http.StatusNotFound
+			if domainErr.Code == domain.ErrCodeInvalidCredentials {
+				code = http.StatusUnauthorized
+			}
+			return pkg.Error(c, code, domainErr.Message, domainErr.Code)
+		}
+		return err
+	}
+
+	return pkg.Success(c, http.StatusOK, &UserResponse{
+		ID:        user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+		IsActive:  user.IsActive,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	})
+}
@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/infrastructure/cache"
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// Cache TTL defaults
+const (
+	DefaultCacheTTL         = time.Minute
+	DefaultNegativeCacheTTL = 10 * time.Second
+)
+
+// Cache key prefixes
+const (
+	userKeyPrefix    = "user:id:"
+	sessionKeyPrefix = "user:session:"
+)
+
+// CachedRepository decorates a domain.UserRepository with a cache-aside
+// layer for the lookups made on every authenticated request, GetUserByID and
+// GetSessionByTokenHash. Lookups finding nothing are cached too, for a
+// shorter negative TTL.
+//
+// Changes to a user or its sessions delete the cached entries once the change
+// is committed. Deleting a session looks it up first, since entries are keyed
+// by token hash. Reads inside a transaction bypass the cache, so that they see
+// the transaction's own writes. A read racing a change may still cache the
+// previous row, which then lives until its TTL elapses.
+//
+// Cache failures are logged and the database is used instead.
+type CachedRepository struct {
+	domain.UserRepository
+	cache       cache.Cache
+	ttl         time.Duration
+	negativeTTL time.Duration
+}
+
+// CacheOption configures optional CachedRepository settings
+type CacheOption func(*CachedRepository)
+
+// WithCacheTTL sets how long found users and sessions are cached
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(r *CachedRepository) {
+		r.ttl = ttl
+	}
+}
+
+// WithNegativeCacheTTL sets how long lookups finding nothing are cached; zero
+// disables negative caching
+func WithNegativeCacheTTL(ttl time.Duration) CacheOption {
+	return func(r *CachedRepository) {
+		r.negativeTTL = ttl
+	}
+}
+
+// NewCached wraps repo with c
+func NewCached(repo domain.UserRepository, c cache.Cache, opts ...CacheOption) *CachedRepository {
+	r := &CachedRepository{
+		UserRepository: repo,
+		cache:          c,
+		ttl:            DefaultCacheTTL,
+		negativeTTL:    DefaultNegativeCacheTTL,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// GetUserByID retrieves a user by ID from the cache, or from the repository on a miss
+func (r *CachedRepository) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
+	return cached(ctx, r, userKeyPrefix+id, func() (*domain.User, error) {
+		return r.UserRepository.GetUserByID(ctx, id)
+	})
+}
+
+// GetSessionByTokenHash retrieves a session by token hash from the cache, or
+// from the repository on a miss
+func (r *CachedRepository) GetSessionByTokenHash(ctx context.Context, tokenHash string) (*domain.UserSession, error) {
+	return cached(ctx, r, sessionKeyPrefix+tokenHash, func() (*domain.UserSession, error) {
+		return r.UserRepository.GetSessionByTokenHash(ctx, tokenHash)
+	})
+}
+
+// CreateUser creates a user, dropping a cached miss for its ID
+func (r *CachedRepository) CreateUser(ctx context.Context, user *domain.User, events ...pkg.DomainEvent) error {
+	if err := r.UserRepository.CreateUser(ctx, user, events...); err != nil {
+		return err
+	}
+	r.invalidate(ctx, userKeyPrefix+user.ID)
+	return nil
+}
+
+// CreateUsers creates users, dropping cached misses for their IDs
+func (r *CachedRepository) CreateUsers(ctx context.Context, users []*domain.User, events ...pkg.DomainEvent) error {
+	if err := r.UserRepository.CreateUsers(ctx, users, events...); err != nil {
+		return err
+	}
+	keys := make([]string, len(users))
+	for i, user := range users {
+		keys[i] = userKeyPrefix + user.ID
+	}
+	r.invalidate(ctx, keys...)
+	return nil
+}
+
+// UpdateUser updates a user and drops its cached entry
+func (r *CachedRepository) UpdateUser(ctx context.Context, user *domain.User, events ...pkg.DomainEvent) error {
+	if err := r.UserRepository.UpdateUser(ctx, user, events...); err != nil {
+		return err
+	}
+	r.invalidate(ctx, userKeyPrefix+user.ID)
+	return nil
+}
+
+// DeleteUser soft deletes a user and drops its cached entry
+func (r *CachedRepository) DeleteUser(ctx context.Context, id string, events ...pkg.DomainEvent) error {
+	if err := r.UserRepository.DeleteUser(ctx, id, events...); err != nil {
+		return err
+	}
+	r.invalidate(ctx, userKeyPrefix+id)
+	return nil
+}
+
+// RestoreUser restores a user and drops its cached miss
+func (r *CachedRepository) RestoreUser(ctx context.Context, id string) error {
+	if err := r.UserRepository.RestoreUser(ctx, id); err != nil {
+		return err
+	}
+	r.invalidate(ctx, userKeyPrefix+id)
+	return nil
+}
+
+// UpdatePassword replaces the password hash of a user and drops its cached entry
+func (r *CachedRepository) UpdatePassword(ctx context.Context, id, passwordHash string, resetRequired bool) error {
+	if err := r.UserRepository.UpdatePassword(ctx, id, passwordHash, resetRequired); err != nil {
+		return err
+	}
+	r.invalidate(ctx, userKeyPrefix+id)
+	return nil
+}
+
+// UpdateUserRole changes the role of a user and drops its cached entry
+func (r *CachedRepository) UpdateUserRole(ctx context.Context, id, role string) error {
+	if err := r.UserRepository.UpdateUserRole(ctx, id, role); err != nil {
+		return err
+	}
+	r.invalidate(ctx, userKeyPrefix+id)
+	return nil
+}
+
+// SuspendUser suspends a user and drops its cached entry
+func (r *CachedRepository) SuspendUser(ctx context.Context, id, reason string, until *time.Time) error {
+	if err := r.UserRepository.SuspendUser(ctx, id, reason, until); err != nil {
+		return err
+	}
+	r.invalidate(ctx, userKeyPrefix+id)
+	return nil
+}
+
+// ReactivateUser lifts a suspension and drops the cached entry of the user
+func (r *CachedRepository) ReactivateUser(ctx context.Context, id string) error {
+	if err := r.UserRepository.ReactivateUser(ctx, id); err != nil {
+		return err
+	}
+	r.invalidate(ctx, userKeyPrefix+id)
+	return nil
+}
+
+// CreateSession creates a session, dropping a cached miss for its token hash
+func (r *CachedRepository) CreateSession(ctx context.Context, session *domain.UserSession) error {
+	if err := r.UserRepository.CreateSession(ctx, session); err != nil {
+		return err
+	}
+	r.invalidate(ctx, sessionKeyPrefix+session.RefreshTokenHash)
+	return nil
+}
+
+// DeleteSession deletes a session and drops its cached entry
+func (r *CachedRepository) DeleteSession(ctx context.Context, id string, events ...pkg.DomainEvent) error {
+	session, err := r.UserRepository.GetSessionByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.UserRepository.DeleteSession(ctx, id, events...); err != nil {
+		return err
+	}
+	if session != nil {
+		r.invalidate(ctx, sessionKeyPrefix+session.RefreshTokenHash)
+	}
+	return nil
+}
+
+// DeleteSessionsByUserID deletes all sessions of a user and drops their cached
+// entries. Expired sessions left cached are refused by their expiry.
+func (r *CachedRepository) DeleteSessionsByUserID(ctx context.Context, userID string, events ...pkg.DomainEvent) (int, error) {
+	sessions, err := r.UserRepository.GetSessionsByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := r.UserRepository.DeleteSessionsByUserID(ctx, userID, events...)
+	if err != nil {
+		return 0, err
+	}
+	keys := make([]string, len(sessions))
+	for i, session := range sessions {
+		keys[i] = sessionKeyPrefix + session.RefreshTokenHash
+	}
+	r.invalidate(ctx, keys...)
+	return deleted, nil
+}
+
+// invalidate deletes keys from the cache once the transaction carried by ctx
+// commits, or right away outside a transaction
+func (r *CachedRepository) invalidate(ctx context.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	database.AfterCommit(ctx, func() {
+		// The change is done; a cancelled request must still drop the entries
+		if err := r.cache.Delete(context.WithoutCancel(ctx), keys...); err != nil {
+			pkg.Logger(ctx).WarnContext(ctx, "failed to invalidate cache", slog.String("error", err.Error()))
+		}
+	})
+}
+
+// cached returns the value under key, calling load and caching its result on
+// a miss. A nil result is cached as an empty value for the negative TTL.
+func cached[T any](ctx context.Context, r *CachedRepository, key string, load func() (*T, error)) (*T, error) {
+	if _, inTx := database.TxFromContext(ctx); inTx {
+		return load()
+	}
+
+	data, err := r.cache.Get(ctx, key)
+	switch {
+	case err == nil && len(data) == 0:
+		return nil, nil
+	case err == nil:
+		value := new(T)
+		decodeErr := gob.NewDecoder(bytes.NewReader(data)).Decode(value)
+		if decodeErr == nil {
+			return value, nil
+		}
+		pkg.Logger(ctx).WarnContext(ctx, "failed to decode cached value", slog.String("key", key), slog.String("error", decodeErr.Error()))
+	case !errors.Is(err, cache.ErrMiss):
+		pkg.Logger(ctx).WarnContext(ctx, "failed to read cache", slog.String("error", err.Error()))
+	}
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := r.ttl
+	var buf bytes.Buffer
+	if value == nil {
+		ttl = r.negativeTTL
+	} else if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		pkg.Logger(ctx).WarnContext(ctx, "failed to encode cached value", slog.String("key", key), slog.String("error", err.Error()))
+		return value, nil
+	}
+	if ttl > 0 {
+		if err := r.cache.Set(ctx, key, buf.Bytes(), ttl); err != nil {
+			pkg.Logger(ctx).WarnContext(ctx, "failed to write cache", slog.String("error", err.Error()))
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+	"github.com/zercle/template-go-echo/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// userColumns lists user columns in sqlc.Users scan order
+const userColumns = "id, email, name, password_hash, is_active, created_at, updated_at, deleted_at, role, " +
+	"suspended_at, suspended_until, suspension_reason, password_reset_required, version"
+
+// userSortColumns maps whitelisted sort fields to their columns
+var userSortColumns = map[string]string{
+	domain.SortFieldCreatedAt: "created_at",
+	domain.SortFieldEmail:     "email",
+	domain.SortFieldName:      "name",
+}
+
+// likeEscaper escapes LIKE wildcards in user supplied patterns
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ListUsersPage retrieves up to limit non-deleted users matching filter, ordered by sort
+// and starting after the given keyset position
+func (r *UserRepository) ListUsersPage(ctx context.Context, filter domain.UserFilter, sort pkg.Sort, after *domain.UserCursor, limit int) ([]*domain.User, error) {
+	column, ok := userSortColumns[sort.Field]
+	if !ok {
+		return nil, pkg.ErrInvalidSort
+	}
+
+	op, direction := ">", "ASC"
+	if sort.Direction == pkg.SortDesc {
+		op, direction = "<", "DESC"
+	}
+
+	where, args := userFilterClause(database.DialectOf(r.db), filter)
+	if after != nil {
+		value, err := userCursorValue(sort.Field, after.Value)
+		if err != nil {
+			return nil, pkg.ErrInvalidCursor
+		}
+		where += fmt.Sprintf(" AND (%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", column, op)
+		args = append(args, value, value, after.ID)
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf("-- name: ListUsersPage :many\nSELECT %s FROM users WHERE %s ORDER BY %s %s, id %s LIMIT ?",
+		userColumns, where, column, direction, direction)
+
+	rows, err := r.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to list users page", slog.String("error", err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	users, err := scanUsers(rows)
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to scan users page", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// CountUsers returns the count of non-deleted users matching filter
+func (r *UserRepository) CountUsers(ctx context.Context, filter domain.UserFilter) (int, error) {
+	where, args := userFilterClause(database.DialectOf(r.db), filter)
+
+	var count int
+	err := r.conn(ctx).QueryRowContext(ctx, "-- name: CountUsers :one\nSELECT COUNT(*) FROM users WHERE "+where, args...).Scan(&count)
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to count users", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// userFilterClause builds the WHERE clause and arguments for a user filter
+func userFilterClause(dialect database.Dialect, filter domain.UserFilter) (string, []interface{}) {
+	conditions := []string{"deleted_at IS NULL"}
+	var args []interface{}
+
+	if filter.EmailPrefix != "" {
+		conditions = append(conditions, dialect.Like("email"))
+		args = append(args, likeEscaper.Replace(filter.EmailPrefix)+"%")
+	}
+	if filter.NameContains != "" {
+		conditions = append(conditions, dialect.Like("name"))
+		args = append(args, "%"+likeEscaper.Replace(filter.NameContains)+"%")
+	}
+	if filter.IsActive != nil {
+		conditions = append(conditions, "is_active = ?")
+		args = append(args, *filter.IsActive)
+	}
+	if filter.CreatedAfter != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, *filter.CreatedBefore)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// userCursorValue converts a cursor value back to the type of its sort column
+func userCursorValue(field, value string) (interface{}, error) {
+	if field == domain.SortFieldCreatedAt {
+		return time.Parse(time.RFC3339Nano, value)
+	}
+	return value, nil
+}
+
+// StreamUsers calls fn for every non-deleted user matching filter in sort order,
+// reading from a database cursor; iteration stops at the first error
+func (r *UserRepository) StreamUsers(ctx context.Context, filter domain.UserFilter, sort pkg.Sort, fn func(*domain.User) error) error {
+	column, ok := userSortColumns[sort.Field]
+	if !ok {
+		return pkg.ErrInvalidSort
+	}
+
+	direction := "ASC"
+	if sort.Direction == pkg.SortDesc {
+		direction = "DESC"
+	}
+
+	where, args := userFilterClause(database.DialectOf(r.db), filter)
+	query := fmt.Sprintf("-- name: StreamUsers :many\nSELECT %s FROM users WHERE %s ORDER BY %s %s, id %s",
+		userColumns, where, column, direction, direction)
+
+	rows, err := r.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to stream users", slog.String("error", err.Error()))
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			pkg.Logger(ctx).ErrorContext(ctx, "failed to scan streamed user", slog.String("error", err.Error()))
+			return err
+		}
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// scanUsers scans rows selected with userColumns into domain users
+func scanUsers(rows *sql.Rows) ([]*domain.User, error) {
+	var users []*domain.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// scanUser scans the current row selected with userColumns into a domain user
+func scanUser(rows *sql.Rows) (*domain.User, error) {
+	var i sqlc.Users
+	if err := rows.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.PasswordHash,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Role,
+		&i.SuspendedAt,
+		&i.SuspendedUntil,
+		&i.SuspensionReason,
+		&i.PasswordResetRequired,
+		&i.Version,
+	); err != nil {
+		return nil, err
+	}
+	return sqlcUserToDomain(&i), nil
+}
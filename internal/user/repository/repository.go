@@ -4,48 +4,112 @@ import (
 	"context"
 	"database/sql"
 	"log/slog"
+	"time"
 
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
 	"github.com/zercle/template-go-echo/internal/infrastructure/sqlc"
+	outboxrepository "github.com/zercle/template-go-echo/internal/outbox/repository"
 	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/pkg"
 )
 
 // UserRepository implements domain.UserRepository using sqlc generated code
 type UserRepository struct {
-	q sqlc.Querier
+	q  sqlc.Querier
+	db sqlc.DBTX
+	tx *database.TxManager
 }
 
-// New creates a new user repository with sqlc querier
-func New(q sqlc.Querier) *UserRepository {
-	return &UserRepository{q: q}
+// New creates a new user repository with sqlc querier. The db connection backs
+// queries that are built dynamically, such as filtered listings, and the
+// transactions writing outbox events. Calls made with a context carrying a
+// transaction from database.TxManager run in that transaction.
+func New(q sqlc.Querier, db sqlc.DBTX) *UserRepository {
+	return &UserRepository{q: q, db: db, tx: database.NewTxManager(db)}
 }
 
-// CreateUser creates a new user in the database
-func (r *UserRepository) CreateUser(ctx context.Context, user *domain.User) error {
-	params := sqlc.CreateUserParams{
-		ID:           user.ID,
-		Email:        user.Email,
-		Name:         user.Name,
-		PasswordHash: user.PasswordHash,
-		IsActive:     sql.NullBool{Bool: user.IsActive, Valid: true},
+// queries returns the querier, bound to the transaction carried by ctx if any
+func (r *UserRepository) queries(ctx context.Context) sqlc.Querier {
+	return database.Queries(ctx, r.q)
+}
+
+// conn returns the connection for dynamic queries, the transaction carried by ctx if any
+func (r *UserRepository) conn(ctx context.Context) sqlc.DBTX {
+	return database.Conn(ctx, r.db)
+}
+
+// withEvents runs fn and appends events to the outbox in the same transaction,
+// so events are published if and only if the change is committed. Without
+// events fn runs directly on the repository querier.
+func (r *UserRepository) withEvents(ctx context.Context, events []pkg.DomainEvent, fn func(q sqlc.Querier) error) error {
+	if len(events) == 0 {
+		return fn(r.queries(ctx))
+	}
+
+	return r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		q := r.queries(ctx)
+		if err := fn(q); err != nil {
+			return err
+		}
+		return outboxrepository.Append(ctx, q, events...)
+	})
+}
+
+// createUserParams converts a domain user to sqlc insert parameters
+func createUserParams(user *domain.User) sqlc.CreateUserParams {
+	return sqlc.CreateUserParams{
+		ID:                    user.ID,
+		Email:                 user.Email,
+		Name:                  user.Name,
+		PasswordHash:          user.PasswordHash,
+		IsActive:              sql.NullBool{Bool: user.IsActive, Valid: true},
+		Role:                  user.Role,
+		PasswordResetRequired: user.PasswordResetRequired,
 	}
+}
 
-	err := r.q.CreateUser(ctx, params)
+// CreateUser creates a new user in the database, appending events to the outbox
+func (r *UserRepository) CreateUser(ctx context.Context, user *domain.User, events ...pkg.DomainEvent) error {
+	err := r.withEvents(ctx, events, func(q sqlc.Querier) error {
+		return q.CreateUser(ctx, createUserParams(user))
+	})
 	if err != nil {
-		slog.Error("failed to create user", slog.String("error", err.Error()))
+		if database.IsDuplicateKey(err) {
+			return domain.ErrUserExists
+		}
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to create user", slog.String("error", err.Error()))
 		return err
 	}
 
 	return nil
 }
 
+// CreateUsers creates users and appends events to the outbox in a single
+// transaction; either all rows are inserted or none
+func (r *UserRepository) CreateUsers(ctx context.Context, users []*domain.User, events ...pkg.DomainEvent) error {
+	return r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		q := r.queries(ctx)
+		for _, user := range users {
+			if err := q.CreateUser(ctx, createUserParams(user)); err != nil {
+				if database.IsDuplicateKey(err) {
+					return domain.ErrUserExists
+				}
+				pkg.Logger(ctx).ErrorContext(ctx, "failed to create user batch", slog.String("error", err.Error()))
+				return err
+			}
+		}
+		return outboxrepository.Append(ctx, q, events...)
+	})
+}
+
 // GetUserByID retrieves a user by ID
 func (r *UserRepository) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
-	sqlcUser, err := r.q.GetUserByID(ctx, id)
+	sqlcUser, err := r.queries(ctx).GetUserByID(ctx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		slog.Error("failed to get user by id", slog.String("error", err.Error()))
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to get user by id", slog.String("error", err.Error()))
 		return nil, err
 	}
 
@@ -54,40 +118,57 @@ func (r *UserRepository) GetUserByID(ctx context.Context, id string) (*domain.Us
 
 // GetUserByEmail retrieves a user by email
 func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
-	sqlcUser, err := r.q.GetUserByEmail(ctx, email)
+	sqlcUser, err := r.queries(ctx).GetUserByEmail(ctx, email)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		slog.Error("failed to get user by email", slog.String("error", err.Error()))
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to get user by email", slog.String("error", err.Error()))
 		return nil, err
 	}
 
 	return sqlcUserToDomain(&sqlcUser), nil
 }
 
-// UpdateUser updates an existing user
-func (r *UserRepository) UpdateUser(ctx context.Context, user *domain.User) error {
+// UpdateUser updates an existing user, appending events to the outbox. The update only
+// applies while the stored version equals user.Version, which is incremented on success.
+func (r *UserRepository) UpdateUser(ctx context.Context, user *domain.User, events ...pkg.DomainEvent) error {
 	params := sqlc.UpdateUserParams{
-		Email: user.Email,
-		Name:  user.Name,
-		ID:    user.ID,
+		Email:   user.Email,
+		Name:    user.Name,
+		ID:      user.ID,
+		Version: user.Version,
 	}
 
-	err := r.q.UpdateUser(ctx, params)
+	err := r.withEvents(ctx, events, func(q sqlc.Querier) error {
+		updated, err := q.UpdateUser(ctx, params)
+		if err != nil {
+			return err
+		}
+		if updated == 0 {
+			return domain.ErrVersionConflict
+		}
+		return nil
+	})
+	if err == domain.ErrVersionConflict {
+		return err
+	}
 	if err != nil {
-		slog.Error("failed to update user", slog.String("error", err.Error()))
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to update user", slog.String("error", err.Error()))
 		return err
 	}
 
+	user.Version++
 	return nil
 }
 
-// DeleteUser soft deletes a user
-func (r *UserRepository) DeleteUser(ctx context.Context, id string) error {
-	err := r.q.DeleteUser(ctx, id)
+// DeleteUser soft deletes a user, appending events to the outbox
+func (r *UserRepository) DeleteUser(ctx context.Context, id string, events ...pkg.DomainEvent) error {
+	err := r.withEvents(ctx, events, func(q sqlc.Querier) error {
+		return q.DeleteUser(ctx, id)
+	})
 	if err != nil {
-		slog.Error("failed to delete user", slog.String("error", err.Error()))
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to delete user", slog.String("error", err.Error()))
 		return err
 	}
 
@@ -101,9 +182,9 @@ func (r *UserRepository) ListUsers(ctx context.Context, limit, offset int) ([]*d
 		Offset: int32(offset),
 	}
 
-	sqlcUsers, err := r.q.ListUsers(ctx, params)
+	sqlcUsers, err := r.queries(ctx).ListUsers(ctx, params)
 	if err != nil {
-		slog.Error("failed to list users", slog.String("error", err.Error()))
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to list users", slog.String("error", err.Error()))
 		return nil, err
 	}
 
@@ -117,9 +198,9 @@ func (r *UserRepository) ListUsers(ctx context.Context, limit, offset int) ([]*d
 
 // GetUserCount returns the total count of non-deleted users
 func (r *UserRepository) GetUserCount(ctx context.Context) (int, error) {
-	count, err := r.q.GetUserCount(ctx)
+	count, err := r.queries(ctx).GetUserCount(ctx)
 	if err != nil {
-		slog.Error("failed to get user count", slog.String("error", err.Error()))
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to get user count", slog.String("error", err.Error()))
 		return 0, err
 	}
 
@@ -137,9 +218,9 @@ func (r *UserRepository) CreateSession(ctx context.Context, session *domain.User
 		ExpiresAt:        session.ExpiresAt,
 	}
 
-	err := r.q.CreateSession(ctx, params)
+	err := r.queries(ctx).CreateSession(ctx, params)
 	if err != nil {
-		slog.Error("failed to create session", slog.String("error", err.Error()))
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to create session", slog.String("error", err.Error()))
 		return err
 	}
 
@@ -148,12 +229,12 @@ func (r *UserRepository) CreateSession(ctx context.Context, session *domain.User
 
 // GetSessionByID retrieves a session by ID
 func (r *UserRepository) GetSessionByID(ctx context.Context, id string) (*domain.UserSession, error) {
-	sqlcSession, err := r.q.GetSessionByID(ctx, id)
+	sqlcSession, err := r.queries(ctx).GetSessionByID(ctx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		slog.Error("failed to get session by id", slog.String("error", err.Error()))
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to get session by id", slog.String("error", err.Error()))
 		return nil, err
 	}
 
@@ -162,9 +243,9 @@ func (r *UserRepository) GetSessionByID(ctx context.Context, id string) (*domain
 
 // GetSessionsByUserID retrieves all active sessions for a user
 func (r *UserRepository) GetSessionsByUserID(ctx context.Context, userID string) ([]*domain.UserSession, error) {
-	sqlcSessions, err := r.q.GetSessionByUserID(ctx, userID)
+	sqlcSessions, err := r.queries(ctx).GetSessionByUserID(ctx, userID)
 	if err != nil {
-		slog.Error("failed to get sessions by user id", slog.String("error", err.Error()))
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to get sessions by user id", slog.String("error", err.Error()))
 		return nil, err
 	}
 
@@ -176,42 +257,205 @@ func (r *UserRepository) GetSessionsByUserID(ctx context.Context, userID string)
 	return sessions, nil
 }
 
-// DeleteSession deletes a session
-func (r *UserRepository) DeleteSession(ctx context.Context, id string) error {
-	err := r.q.DeleteSession(ctx, id)
+// DeleteSession deletes a session, appending events to the outbox
+func (r *UserRepository) DeleteSession(ctx context.Context, id string, events ...pkg.DomainEvent) error {
+	err := r.withEvents(ctx, events, func(q sqlc.Querier) error {
+		return q.DeleteSession(ctx, id)
+	})
 	if err != nil {
-		slog.Error("failed to delete session", slog.String("error", err.Error()))
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to delete session", slog.String("error", err.Error()))
 		return err
 	}
 
 	return nil
 }
 
-// DeleteExpiredSessions deletes all expired sessions
-func (r *UserRepository) DeleteExpiredSessions(ctx context.Context) error {
-	err := r.q.DeleteExpiredSessions(ctx)
+// DeleteExpiredSessions deletes all expired sessions and returns how many were removed
+func (r *UserRepository) DeleteExpiredSessions(ctx context.Context) (int, error) {
+	deleted, err := r.queries(ctx).DeleteExpiredSessions(ctx)
 	if err != nil {
-		slog.Error("failed to delete expired sessions", slog.String("error", err.Error()))
-		return err
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to delete expired sessions", slog.String("error", err.Error()))
+		return 0, err
 	}
 
-	return nil
+	return int(deleted), nil
 }
 
 // GetSessionByTokenHash retrieves a session by token hash
 func (r *UserRepository) GetSessionByTokenHash(ctx context.Context, tokenHash string) (*domain.UserSession, error) {
-	sqlcSession, err := r.q.GetSessionByTokenHash(ctx, tokenHash)
+	sqlcSession, err := r.queries(ctx).GetSessionByTokenHash(ctx, tokenHash)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		slog.Error("failed to get session by token hash", slog.String("error", err.Error()))
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to get session by token hash", slog.String("error", err.Error()))
 		return nil, err
 	}
 
 	return sqlcSessionToDomain(&sqlcSession), nil
 }
 
+// GetDeletedUserByEmail retrieves the most recently soft deleted user by email
+func (r *UserRepository) GetDeletedUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	sqlcUser, err := r.queries(ctx).GetDeletedUserByEmail(ctx, email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to get deleted user by email", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return sqlcUserToDomain(&sqlcUser), nil
+}
+
+// RestoreUser clears the soft delete marker of a user
+func (r *UserRepository) RestoreUser(ctx context.Context, id string) error {
+	err := r.queries(ctx).RestoreUser(ctx, id)
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to restore user", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// ListDeletedUsers retrieves a paginated list of soft deleted users, oldest deletion first
+func (r *UserRepository) ListDeletedUsers(ctx context.Context, limit, offset int) ([]*domain.User, error) {
+	params := sqlc.ListDeletedUsersParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+
+	sqlcUsers, err := r.queries(ctx).ListDeletedUsers(ctx, params)
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to list deleted users", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	users := make([]*domain.User, len(sqlcUsers))
+	for i, sqlcUser := range sqlcUsers {
+		users[i] = sqlcUserToDomain(&sqlcUser)
+	}
+
+	return users, nil
+}
+
+// GetDeletedUserCount returns the total count of soft deleted users
+func (r *UserRepository) GetDeletedUserCount(ctx context.Context) (int, error) {
+	count, err := r.queries(ctx).GetDeletedUserCount(ctx)
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to get deleted user count", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+// PurgeDeletedUsers hard deletes users soft deleted before the cutoff, cascading to sessions
+func (r *UserRepository) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int, error) {
+	purged, err := r.queries(ctx).PurgeDeletedUsers(ctx, sql.NullTime{Time: deletedBefore, Valid: true})
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to purge deleted users", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	return int(purged), nil
+}
+
+// PurgeDeletedUser hard deletes a single user soft deleted before the cutoff, cascading to sessions
+func (r *UserRepository) PurgeDeletedUser(ctx context.Context, id string, deletedBefore time.Time) error {
+	params := sqlc.PurgeDeletedUserParams{
+		ID:        id,
+		DeletedAt: sql.NullTime{Time: deletedBefore, Valid: true},
+	}
+	if _, err := r.queries(ctx).PurgeDeletedUser(ctx, params); err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to purge deleted user", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// UpdatePassword replaces the password hash and sets the forced reset flag
+func (r *UserRepository) UpdatePassword(ctx context.Context, id, passwordHash string, resetRequired bool) error {
+	params := sqlc.UpdatePasswordParams{
+		PasswordHash:          passwordHash,
+		PasswordResetRequired: resetRequired,
+		ID:                    id,
+	}
+
+	err := r.queries(ctx).UpdatePassword(ctx, params)
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to update password", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// UpdateUserRole changes the role of a user
+func (r *UserRepository) UpdateUserRole(ctx context.Context, id, role string) error {
+	params := sqlc.UpdateUserRoleParams{
+		Role: role,
+		ID:   id,
+	}
+
+	err := r.queries(ctx).UpdateUserRole(ctx, params)
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to update user role", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// SuspendUser deactivates a user with a reason and optional expiry
+func (r *UserRepository) SuspendUser(ctx context.Context, id, reason string, until *time.Time) error {
+	params := sqlc.SuspendUserParams{
+		SuspensionReason: sql.NullString{String: reason, Valid: reason != ""},
+		ID:               id,
+	}
+	if until != nil {
+		params.SuspendedUntil = sql.NullTime{Time: *until, Valid: true}
+	}
+
+	err := r.queries(ctx).SuspendUser(ctx, params)
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to suspend user", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// ReactivateUser lifts a suspension
+func (r *UserRepository) ReactivateUser(ctx context.Context, id string) error {
+	err := r.queries(ctx).ReactivateUser(ctx, id)
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to reactivate user", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSessionsByUserID deletes all sessions of a user, appending events to the outbox
+func (r *UserRepository) DeleteSessionsByUserID(ctx context.Context, userID string, events ...pkg.DomainEvent) (int, error) {
+	var deleted int64
+	err := r.withEvents(ctx, events, func(q sqlc.Querier) error {
+		var err error
+		deleted, err = q.DeleteSessionsByUserID(ctx, userID)
+		return err
+	})
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to delete sessions by user id", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
+
 // Helper functions to convert sqlc types to domain types
 
 func sqlcUserToDomain(sqlcUser *sqlc.Users) *domain.User {
@@ -221,6 +465,10 @@ func sqlcUserToDomain(sqlcUser *sqlc.Users) *domain.User {
 		Name:         sqlcUser.Name,
 		PasswordHash: sqlcUser.PasswordHash,
 		IsActive:     sqlcUser.IsActive.Bool,
+		Role:         sqlcUser.Role,
+
+		PasswordResetRequired: sqlcUser.PasswordResetRequired,
+		Version:               sqlcUser.Version,
 	}
 
 	if sqlcUser.CreatedAt.Valid {
@@ -235,6 +483,18 @@ func sqlcUserToDomain(sqlcUser *sqlc.Users) *domain.User {
 		user.DeletedAt = &sqlcUser.DeletedAt.Time
 	}
 
+	if sqlcUser.SuspendedAt.Valid {
+		user.SuspendedAt = &sqlcUser.SuspendedAt.Time
+	}
+
+	if sqlcUser.SuspendedUntil.Valid {
+		user.SuspendedUntil = &sqlcUser.SuspendedUntil.Time
+	}
+
+	if sqlcUser.SuspensionReason.Valid {
+		user.SuspensionReason = sqlcUser.SuspensionReason.String
+	}
+
 	return user
 }
 
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/pkg"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the repository spans
+const tracerName = "github.com/zercle/template-go-echo/internal/user/repository"
+
+// TracedRepository decorates a domain.UserRepository with a span per call,
+// named UserRepository.<Method>. Wrapping a CachedRepository traces cache hits
+// too, which then show as spans without query spans below them.
+type TracedRepository struct {
+	repo   domain.UserRepository
+	tracer trace.Tracer
+}
+
+// NewTraced wraps repo, taking spans from provider
+func NewTraced(repo domain.UserRepository, provider trace.TracerProvider) *TracedRepository {
+	return &TracedRepository{repo: repo, tracer: provider.Tracer(tracerName)}
+}
+
+// start opens the span of method
+func (r *TracedRepository) start(ctx context.Context, method string) (context.Context, trace.Span) {
+	return r.tracer.Start(ctx, "UserRepository."+method)
+}
+
+func (r *TracedRepository) CreateUser(ctx context.Context, user *domain.User, events ...pkg.DomainEvent) error {
+	ctx, span := r.start(ctx, "CreateUser")
+	err := r.repo.CreateUser(ctx, user, events...)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (r *TracedRepository) CreateUsers(ctx context.Context, users []*domain.User, events ...pkg.DomainEvent) error {
+	ctx, span := r.start(ctx, "CreateUsers")
+	err := r.repo.CreateUsers(ctx, users, events...)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (r *TracedRepository) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
+	ctx, span := r.start(ctx, "GetUserByID")
+	user, err := r.repo.GetUserByID(ctx, id)
+	pkg.EndSpan(span, err)
+	return user, err
+}
+
+func (r *TracedRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	ctx, span := r.start(ctx, "GetUserByEmail")
+	user, err := r.repo.GetUserByEmail(ctx, email)
+	pkg.EndSpan(span, err)
+	return user, err
+}
+
+func (r *TracedRepository) UpdateUser(ctx context.Context, user *domain.User, events ...pkg.DomainEvent) error {
+	ctx, span := r.start(ctx, "UpdateUser")
+	err := r.repo.UpdateUser(ctx, user, events...)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (r *TracedRepository) DeleteUser(ctx context.Context, id string, events ...pkg.DomainEvent) error {
+	ctx, span := r.start(ctx, "DeleteUser")
+	err := r.repo.DeleteUser(ctx, id, events...)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (r *TracedRepository) ListUsers(ctx context.Context, limit, offset int) ([]*domain.User, error) {
+	ctx, span := r.start(ctx, "ListUsers")
+	users, err := r.repo.ListUsers(ctx, limit, offset)
+	pkg.EndSpan(span, err)
+	return users, err
+}
+
+func (r *TracedRepository) GetUserCount(ctx context.Context) (int, error) {
+	ctx, span := r.start(ctx, "GetUserCount")
+	count, err := r.repo.GetUserCount(ctx)
+	pkg.EndSpan(span, err)
+	return count, err
+}
+
+func (r *TracedRepository) ListUsersPage(ctx context.Context, filter domain.UserFilter, sort pkg.Sort, after *domain.UserCursor, limit int) ([]*domain.User, error) {
+	ctx, span := r.start(ctx, "ListUsersPage")
+	users, err := r.repo.ListUsersPage(ctx, filter, sort, after, limit)
+	pkg.EndSpan(span, err)
+	return users, err
+}
+
+func (r *TracedRepository) CountUsers(ctx context.Context, filter domain.UserFilter) (int, error) {
+	ctx, span := r.start(ctx, "CountUsers")
+	count, err := r.repo.CountUsers(ctx, filter)
+	pkg.EndSpan(span, err)
+	return count, err
+}
+
+func (r *TracedRepository) StreamUsers(ctx context.Context, filter domain.UserFilter, sort pkg.Sort, fn func(*domain.User) error) error {
+	ctx, span := r.start(ctx, "StreamUsers")
+	err := r.repo.StreamUsers(ctx, filter, sort, fn)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (r *TracedRepository) CreateSession(ctx context.Context, session *domain.UserSession) error {
+	ctx, span := r.start(ctx, "CreateSession")
+	err := r.repo.CreateSession(ctx, session)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (r *TracedRepository) GetSessionByID(ctx context.Context, id string) (*domain.UserSession, error) {
+	ctx, span := r.start(ctx, "GetSessionByID")
+	session, err := r.repo.GetSessionByID(ctx, id)
+	pkg.EndSpan(span, err)
+	return session, err
+}
+
+func (r *TracedRepository) GetSessionsByUserID(ctx context.Context, userID string) ([]*domain.UserSession, error) {
+	ctx, span := r.start(ctx, "GetSessionsByUserID")
+	sessions, err := r.repo.GetSessionsByUserID(ctx, userID)
+	pkg.EndSpan(span, err)
+	return sessions, err
+}
+
+func (r *TracedRepository) DeleteSession(ctx context.Context, id string, events ...pkg.DomainEvent) error {
+	ctx, span := r.start(ctx, "DeleteSession")
+	err := r.repo.DeleteSession(ctx, id, events...)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (r *TracedRepository) DeleteExpiredSessions(ctx context.Context) (int, error) {
+	ctx, span := r.start(ctx, "DeleteExpiredSessions")
+	deleted, err := r.repo.DeleteExpiredSessions(ctx)
+	pkg.EndSpan(span, err)
+	return deleted, err
+}
+
+func (r *TracedRepository) GetSessionByTokenHash(ctx context.Context, tokenHash string) (*domain.UserSession, error) {
+	ctx, span := r.start(ctx, "GetSessionByTokenHash")
+	session, err := r.repo.GetSessionByTokenHash(ctx, tokenHash)
+	pkg.EndSpan(span, err)
+	return session, err
+}
+
+func (r *TracedRepository) GetDeletedUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	ctx, span := r.start(ctx, "GetDeletedUserByEmail")
+	user, err := r.repo.GetDeletedUserByEmail(ctx, email)
+	pkg.EndSpan(span, err)
+	return user, err
+}
+
+func (r *TracedRepository) RestoreUser(ctx context.Context, id string) error {
+	ctx, span := r.start(ctx, "RestoreUser")
+	err := r.repo.RestoreUser(ctx, id)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (r *TracedRepository) ListDeletedUsers(ctx context.Context, limit, offset int) ([]*domain.User, error) {
+	ctx, span := r.start(ctx, "ListDeletedUsers")
+	users, err := r.repo.ListDeletedUsers(ctx, limit, offset)
+	pkg.EndSpan(span, err)
+	return users, err
+}
+
+func (r *TracedRepository) GetDeletedUserCount(ctx context.Context) (int, error) {
+	ctx, span := r.start(ctx, "GetDeletedUserCount")
+	count, err := r.repo.GetDeletedUserCount(ctx)
+	pkg.EndSpan(span, err)
+	return count, err
+}
+
+func (r *TracedRepository) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int, error) {
+	ctx, span := r.start(ctx, "PurgeDeletedUsers")
+	purged, err := r.repo.PurgeDeletedUsers(ctx, deletedBefore)
+	pkg.EndSpan(span, err)
+	return purged, err
+}
+
+func (r *TracedRepository) PurgeDeletedUser(ctx context.Context, id string, deletedBefore time.Time) error {
+	ctx, span := r.start(ctx, "PurgeDeletedUser")
+	err := r.repo.PurgeDeletedUser(ctx, id, deletedBefore)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (r *TracedRepository) UpdatePassword(ctx context.Context, id, passwordHash string, resetRequired bool) error {
+	ctx, span := r.start(ctx, "UpdatePassword")
+	err := r.repo.UpdatePassword(ctx, id, passwordHash, resetRequired)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (r *TracedRepository) UpdateUserRole(ctx context.Context, id, role string) error {
+	ctx, span := r.start(ctx, "UpdateUserRole")
+	err := r.repo.UpdateUserRole(ctx, id, role)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (r *TracedRepository) SuspendUser(ctx context.Context, id, reason string, until *time.Time) error {
+	ctx, span := r.start(ctx, "SuspendUser")
+	err := r.repo.SuspendUser(ctx, id, reason, until)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (r *TracedRepository) ReactivateUser(ctx context.Context, id string) error {
+	ctx, span := r.start(ctx, "ReactivateUser")
+	err := r.repo.ReactivateUser(ctx, id)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (r *TracedRepository) DeleteSessionsByUserID(ctx context.Context, userID string, events ...pkg.DomainEvent) (int, error) {
+	ctx, span := r.start(ctx, "DeleteSessionsByUserID")
+	deleted, err := r.repo.DeleteSessionsByUserID(ctx, userID, events...)
+	pkg.EndSpan(span, err)
+	return deleted, err
+}
@@ -0,0 +1,273 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/infrastructure/test/sqlitetest"
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/internal/user/repository"
+	"github.com/zercle/template-go-echo/internal/user/test/mocks"
+	"github.com/zercle/template-go-echo/internal/user/usecase"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+const adminID = "admin-1"
+
+func TestAdminCreateUser(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	user, err := uc.AdminCreateUser(context.Background(), "admin@example.com", "Admin", "SecurePass123", domain.RoleAdmin)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if user.Role != domain.RoleAdmin {
+		t.Errorf("expected role %s, got %s", domain.RoleAdmin, user.Role)
+	}
+
+	// Role defaults to user
+	user, err = uc.AdminCreateUser(context.Background(), "user@example.com", "User", "SecurePass123", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if user.Role != domain.RoleUser {
+		t.Errorf("expected role %s, got %s", domain.RoleUser, user.Role)
+	}
+
+	_, err = uc.AdminCreateUser(context.Background(), "other@example.com", "Other", "SecurePass123", "superuser")
+	if err != domain.ErrInvalidRole {
+		t.Errorf("expected ErrInvalidRole, got %v", err)
+	}
+}
+
+func TestSuspendUser(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	user, _ := uc.RegisterUser(context.Background(), "test@example.com", "Test User", "SecurePass123")
+	_, _, _, _ = uc.LoginUser(context.Background(), "test@example.com", "SecurePass123", "", "")
+
+	if _, err := uc.SuspendUser(context.Background(), adminID, user.ID, "", nil); err != domain.ErrInvalidSuspension {
+		t.Errorf("expected ErrInvalidSuspension for empty reason, got %v", err)
+	}
+
+	past := time.Now().Add(-time.Hour)
+	if _, err := uc.SuspendUser(context.Background(), adminID, user.ID, "abuse", &past); err != domain.ErrInvalidSuspension {
+		t.Errorf("expected ErrInvalidSuspension for past expiry, got %v", err)
+	}
+
+	if _, err := uc.SuspendUser(context.Background(), user.ID, user.ID, "abuse", nil); err != domain.ErrSelfModification {
+		t.Errorf("expected ErrSelfModification, got %v", err)
+	}
+
+	suspended, err := uc.SuspendUser(context.Background(), adminID, user.ID, "abuse", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !suspended.IsSuspended() || suspended.SuspensionReason != "abuse" {
+		t.Error("expected user to be suspended with reason")
+	}
+
+	sessions, _ := repo.GetSessionsByUserID(context.Background(), user.ID)
+	if len(sessions) != 0 {
+		t.Errorf("expected sessions to be revoked, got %d", len(sessions))
+	}
+
+	_, _, _, err = uc.LoginUser(context.Background(), "test@example.com", "SecurePass123", "", "")
+	if err != domain.ErrAccountSuspended {
+		t.Errorf("expected ErrAccountSuspended, got %v", err)
+	}
+}
+
+func TestSuspensionExpiresOnLogin(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	user, _ := uc.RegisterUser(context.Background(), "test@example.com", "Test User", "SecurePass123")
+	until := time.Now().Add(time.Hour)
+	_, _ = uc.SuspendUser(context.Background(), adminID, user.ID, "cool-off", &until)
+
+	// Simulate the suspension expiring
+	expired := time.Now().Add(-time.Minute)
+	user.SuspendedUntil = &expired
+
+	loggedIn, _, _, err := uc.LoginUser(context.Background(), "test@example.com", "SecurePass123", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !loggedIn.IsActive || loggedIn.SuspendedAt != nil {
+		t.Error("expected expired suspension to be lifted")
+	}
+}
+
+func TestReactivateUser(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	user, _ := uc.RegisterUser(context.Background(), "test@example.com", "Test User", "SecurePass123")
+	_, _ = uc.SuspendUser(context.Background(), adminID, user.ID, "abuse", nil)
+
+	reactivated, err := uc.ReactivateUser(context.Background(), user.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if reactivated.IsSuspended() || reactivated.SuspensionReason != "" {
+		t.Error("expected suspension to be lifted")
+	}
+
+	if _, err := uc.ReactivateUser(context.Background(), "non-existent-id"); err != domain.ErrUserNotFound {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestForcePasswordReset(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	user, _ := uc.RegisterUser(context.Background(), "test@example.com", "Test User", "SecurePass123")
+	_, _, _, _ = uc.LoginUser(context.Background(), "test@example.com", "SecurePass123", "", "")
+
+	if err := uc.ForcePasswordReset(context.Background(), user.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sessions, _ := repo.GetSessionsByUserID(context.Background(), user.ID)
+	if len(sessions) != 0 {
+		t.Errorf("expected sessions to be revoked, got %d", len(sessions))
+	}
+
+	// The old password no longer yields a session
+	if _, _, _, err := uc.LoginUser(context.Background(), "test@example.com", "SecurePass123", "", ""); err != domain.ErrPasswordResetRequired {
+		t.Fatalf("expected login to require a password reset, got %v", err)
+	}
+	if err := uc.CompletePasswordReset(context.Background(), "test@example.com", "WrongPass123", "NewSecurePass456"); err != domain.ErrInvalidCredentials {
+		t.Errorf("expected the reset to need the current password, got %v", err)
+	}
+
+	// Changing the password clears the flag
+	if err := uc.CompletePasswordReset(context.Background(), "test@example.com", "SecurePass123", "NewSecurePass456"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loggedIn, _, _, err := uc.LoginUser(context.Background(), "test@example.com", "NewSecurePass456", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loggedIn.PasswordResetRequired {
+		t.Error("expected password reset flag to be cleared")
+	}
+	if err := uc.CompletePasswordReset(context.Background(), "test@example.com", "NewSecurePass456", "OtherSecurePass789"); err != domain.ErrPasswordResetNotRequired {
+		t.Errorf("expected no reset once completed, got %v", err)
+	}
+}
+
+func TestForcedPasswordResetBlocksTokenRefresh(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	user, _ := uc.RegisterUser(context.Background(), "test@example.com", "Test User", "SecurePass123")
+	_, _, refreshToken, _ := uc.LoginUser(context.Background(), "test@example.com", "SecurePass123", "", "")
+
+	// Flag the account without revoking its session
+	_ = repo.UpdatePassword(context.Background(), user.ID, user.PasswordHash, true)
+
+	if _, err := uc.RefreshToken(context.Background(), refreshToken); err != domain.ErrPasswordResetRequired {
+		t.Fatalf("expected refresh to require a password reset, got %v", err)
+	}
+	if err := uc.ChangePassword(context.Background(), user.ID, "SecurePass123", "NewSecurePass456"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.RefreshToken(context.Background(), refreshToken); err != nil {
+		t.Errorf("expected refresh to work once the password changed, got %v", err)
+	}
+}
+
+func TestRevokeSessions(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	user, _ := uc.RegisterUser(context.Background(), "test@example.com", "Test User", "SecurePass123")
+	for i := 0; i < 2; i++ {
+		_, _, _, _ = uc.LoginUser(context.Background(), "test@example.com", "SecurePass123", "", "")
+	}
+
+	revoked, err := uc.RevokeSessions(context.Background(), user.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if revoked != 2 {
+		t.Errorf("expected 2 revoked sessions, got %d", revoked)
+	}
+}
+
+func TestSetUserRole(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	user, _ := uc.RegisterUser(context.Background(), "test@example.com", "Test User", "SecurePass123")
+
+	updated, err := uc.SetUserRole(context.Background(), adminID, user.ID, domain.RoleAdmin)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if updated.Role != domain.RoleAdmin {
+		t.Errorf("expected role %s, got %s", domain.RoleAdmin, updated.Role)
+	}
+
+	if _, err := uc.SetUserRole(context.Background(), adminID, user.ID, "root"); err != domain.ErrInvalidRole {
+		t.Errorf("expected ErrInvalidRole, got %v", err)
+	}
+
+	if _, err := uc.SetUserRole(context.Background(), user.ID, user.ID, domain.RoleUser); err != domain.ErrSelfModification {
+		t.Errorf("expected ErrSelfModification, got %v", err)
+	}
+}
+
+// failingRevokeRepository fails every session revocation
+type failingRevokeRepository struct {
+	domain.UserRepository
+}
+
+func (r *failingRevokeRepository) DeleteSessionsByUserID(ctx context.Context, userID string, events ...pkg.DomainEvent) (int, error) {
+	return 0, errors.New("sessions unavailable")
+}
+
+func TestAdminActionsRollBackWhenRevokeFails(t *testing.T) {
+	db := sqlitetest.Open(t)
+	inner := repository.New(db.Querier(), db.DBTX())
+	uc := usecase.New(&failingRevokeRepository{UserRepository: inner}, 3600, usecase.WithTxManager(db.TxManager()))
+	ctx := context.Background()
+
+	user, err := uc.RegisterUser(ctx, "test@example.com", "Test User", "SecurePass123")
+	if err != nil {
+		t.Fatalf("RegisterUser failed: %v", err)
+	}
+
+	if _, err := uc.SuspendUser(ctx, adminID, user.ID, "Abuse", nil); err != pkg.ErrInternalError {
+		t.Fatalf("expected ErrInternalError, got %v", err)
+	}
+	if err := uc.ForcePasswordReset(ctx, user.ID); err != pkg.ErrInternalError {
+		t.Fatalf("expected ErrInternalError, got %v", err)
+	}
+
+	// Neither the suspension nor the reset flag outlives the failed revocation
+	stored, err := inner.GetUserByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetUserByID failed: %v", err)
+	}
+	if !stored.IsActive || stored.SuspendedAt != nil {
+		t.Error("expected the suspension to be rolled back")
+	}
+	if stored.PasswordResetRequired {
+		t.Error("expected the password reset flag to be rolled back")
+	}
+}
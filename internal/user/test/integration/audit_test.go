@@ -0,0 +1,68 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	auditdomain "github.com/zercle/template-go-echo/internal/audit/domain"
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/internal/user/test/mocks"
+	"github.com/zercle/template-go-echo/internal/user/usecase"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// recordingAuditor captures audit events synchronously
+type recordingAuditor struct {
+	events []auditdomain.AuditEvent
+}
+
+func (r *recordingAuditor) Record(ctx context.Context, event auditdomain.AuditEvent) {
+	r.events = append(r.events, event)
+}
+
+func (r *recordingAuditor) actions() []string {
+	actions := make([]string, len(r.events))
+	for i, event := range r.events {
+		actions[i] = event.Action
+	}
+	return actions
+}
+
+func TestUserActionsAreAudited(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	auditor := &recordingAuditor{}
+	uc := usecase.New(repo, 3600, usecase.WithAuditRecorder(auditor))
+
+	user, _ := uc.RegisterUser(context.Background(), "test@example.com", "Test User", "SecurePass123")
+	_, _, _, _ = uc.LoginUser(context.Background(), "test@example.com", "WrongPass123", "", "")
+	_, _, _, _ = uc.LoginUser(context.Background(), "test@example.com", "SecurePass123", "", "")
+
+	ctx := pkg.WithRequestInfo(context.Background(), pkg.RequestInfo{UserID: adminID})
+	if _, err := uc.SetUserRole(ctx, adminID, user.ID, domain.RoleAdmin); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		auditdomain.ActionUserRegistered,
+		auditdomain.ActionUserLoginFailed,
+		auditdomain.ActionUserLogin,
+		auditdomain.ActionUserRoleChanged,
+	}
+	actions := auditor.actions()
+	if len(actions) != len(expected) {
+		t.Fatalf("expected actions %v, got %v", expected, actions)
+	}
+	for i := range expected {
+		if actions[i] != expected[i] {
+			t.Errorf("expected action %d to be %s, got %s", i, expected[i], actions[i])
+		}
+	}
+
+	roleChange := auditor.events[3]
+	if roleChange.TargetID != user.ID || roleChange.TargetType != auditdomain.TargetUser {
+		t.Errorf("expected role change to target the user, got %+v", roleChange)
+	}
+	if roleChange.Metadata["from"] != domain.RoleUser || roleChange.Metadata["to"] != domain.RoleAdmin {
+		t.Errorf("expected role change metadata, got %v", roleChange.Metadata)
+	}
+}
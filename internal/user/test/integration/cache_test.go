@@ -0,0 +1,239 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/zercle/template-go-echo/internal/infrastructure/cache"
+	"github.com/zercle/template-go-echo/internal/infrastructure/test/sqlitetest"
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/internal/user/repository"
+	"github.com/zercle/template-go-echo/internal/user/usecase"
+)
+
+// countingRepository counts the cached lookups reaching the database
+type countingRepository struct {
+	domain.UserRepository
+	userLookups    atomic.Int64
+	sessionLookups atomic.Int64
+}
+
+func (r *countingRepository) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
+	r.userLookups.Add(1)
+	return r.UserRepository.GetUserByID(ctx, id)
+}
+
+func (r *countingRepository) GetSessionByTokenHash(ctx context.Context, tokenHash string) (*domain.UserSession, error) {
+	r.sessionLookups.Add(1)
+	return r.UserRepository.GetSessionByTokenHash(ctx, tokenHash)
+}
+
+func cachedUser(id, email string) *domain.User {
+	return &domain.User{ID: id, Email: email, Name: "Cached", PasswordHash: "hash", IsActive: true, Role: domain.RoleUser, Version: 1}
+}
+
+func TestCachedRepositoryUsers(t *testing.T) {
+	db := sqlitetest.Open(t)
+	inner := &countingRepository{UserRepository: repository.New(db.Querier(), db.DBTX())}
+	repo := repository.NewCached(inner, cache.NewLRU(100))
+	ctx := context.Background()
+
+	user := cachedUser("u-1", "cached@example.com")
+	if err := repo.CreateUser(ctx, user); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		found, err := repo.GetUserByID(ctx, "u-1")
+		if err != nil || found == nil || found.PasswordHash != "hash" {
+			t.Fatalf("GetUserByID returned %+v, %v", found, err)
+		}
+	}
+	if n := inner.userLookups.Load(); n != 1 {
+		t.Errorf("expected one database lookup, got %d", n)
+	}
+
+	// Unknown users are cached as misses
+	for i := 0; i < 2; i++ {
+		if found, err := repo.GetUserByID(ctx, "missing"); err != nil || found != nil {
+			t.Fatalf("expected no user, got %+v, %v", found, err)
+		}
+	}
+	if n := inner.userLookups.Load(); n != 2 {
+		t.Errorf("expected the miss cached, got %d lookups", n)
+	}
+
+	// Changes drop the cached user
+	user.Name = "Renamed"
+	if err := repo.UpdateUser(ctx, user); err != nil {
+		t.Fatalf("UpdateUser failed: %v", err)
+	}
+	if found, _ := repo.GetUserByID(ctx, "u-1"); found == nil || found.Name != "Renamed" || found.Version != user.Version {
+		t.Errorf("expected the updated user, got %+v", found)
+	}
+	if err := repo.SuspendUser(ctx, "u-1", "abuse", nil); err != nil {
+		t.Fatalf("SuspendUser failed: %v", err)
+	}
+	if found, _ := repo.GetUserByID(ctx, "u-1"); found == nil || !found.IsSuspended() {
+		t.Errorf("expected the suspended user, got %+v", found)
+	}
+	if err := repo.DeleteUser(ctx, "u-1"); err != nil {
+		t.Fatalf("DeleteUser failed: %v", err)
+	}
+	if found, _ := repo.GetUserByID(ctx, "u-1"); found != nil {
+		t.Errorf("expected the deleted user gone, got %+v", found)
+	}
+	if err := repo.RestoreUser(ctx, "u-1"); err != nil {
+		t.Fatalf("RestoreUser failed: %v", err)
+	}
+	if found, _ := repo.GetUserByID(ctx, "u-1"); found == nil {
+		t.Error("expected the restored user despite the cached miss")
+	}
+}
+
+func TestCachedRepositorySessions(t *testing.T) {
+	db := sqlitetest.Open(t)
+	inner := &countingRepository{UserRepository: repository.New(db.Querier(), db.DBTX())}
+	repo := repository.NewCached(inner, cache.NewLRU(100))
+	ctx := context.Background()
+
+	if err := repo.CreateUser(ctx, cachedUser("u-1", "sessions@example.com")); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	if found, _ := repo.GetSessionByTokenHash(ctx, "hash-1"); found != nil {
+		t.Fatalf("expected no session yet, got %+v", found)
+	}
+	for i, hash := range []string{"hash-1", "hash-2", "hash-3"} {
+		session := &domain.UserSession{ID: "s-" + hash, UserID: "u-1", RefreshTokenHash: hash, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := repo.CreateSession(ctx, session); err != nil {
+			t.Fatalf("CreateSession %d failed: %v", i, err)
+		}
+	}
+
+	// Creating the session dropped the cached miss
+	for i := 0; i < 3; i++ {
+		if found, err := repo.GetSessionByTokenHash(ctx, "hash-1"); err != nil || found == nil || found.RefreshTokenHash != "hash-1" {
+			t.Fatalf("GetSessionByTokenHash returned %+v, %v", found, err)
+		}
+	}
+	if n := inner.sessionLookups.Load(); n != 2 {
+		t.Errorf("expected two database lookups, got %d", n)
+	}
+
+	if err := repo.DeleteSession(ctx, "s-hash-1"); err != nil {
+		t.Fatalf("DeleteSession failed: %v", err)
+	}
+	if found, _ := repo.GetSessionByTokenHash(ctx, "hash-1"); found != nil {
+		t.Errorf("expected the deleted session gone, got %+v", found)
+	}
+
+	_, _ = repo.GetSessionByTokenHash(ctx, "hash-2")
+	_, _ = repo.GetSessionByTokenHash(ctx, "hash-3")
+	if deleted, err := repo.DeleteSessionsByUserID(ctx, "u-1"); err != nil || deleted != 2 {
+		t.Fatalf("DeleteSessionsByUserID returned %d, %v", deleted, err)
+	}
+	for _, hash := range []string{"hash-2", "hash-3"} {
+		if found, _ := repo.GetSessionByTokenHash(ctx, hash); found != nil {
+			t.Errorf("expected session %s gone, got %+v", hash, found)
+		}
+	}
+}
+
+func TestCachedRepositoryTransactions(t *testing.T) {
+	db := sqlitetest.Open(t)
+	inner := &countingRepository{UserRepository: repository.New(db.Querier(), db.DBTX())}
+	lru := cache.NewLRU(100)
+	repo := repository.NewCached(inner, lru)
+	ctx := context.Background()
+
+	user := cachedUser("u-1", "tx@example.com")
+	if err := repo.CreateUser(ctx, user); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	_, _ = repo.GetUserByID(ctx, "u-1")
+
+	err := db.TxManager().WithinTx(ctx, func(ctx context.Context) error {
+		user.Name = "In transaction"
+		if err := repo.UpdateUser(ctx, user); err != nil {
+			return err
+		}
+		// Reads in the transaction see its writes rather than the cache
+		if found, _ := repo.GetUserByID(ctx, "u-1"); found == nil || found.Name != "In transaction" {
+			t.Errorf("expected the uncommitted change, got %+v", found)
+		}
+		if lru.Len() != 1 {
+			t.Error("expected the entry kept until the commit")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("transaction failed: %v", err)
+	}
+	if found, _ := repo.GetUserByID(ctx, "u-1"); found == nil || found.Name != "In transaction" {
+		t.Errorf("expected the committed change, got %+v", found)
+	}
+
+	// A rolled back change keeps the cached entry
+	lookups := inner.userLookups.Load()
+	rollback := errors.New("rollback")
+	_ = db.TxManager().WithinTx(ctx, func(ctx context.Context) error {
+		user.Name = "Rolled back"
+		if err := repo.UpdateUser(ctx, user); err != nil {
+			return err
+		}
+		return rollback
+	})
+	if found, _ := repo.GetUserByID(ctx, "u-1"); found == nil || found.Name != "In transaction" {
+		t.Errorf("expected the committed name, got %+v", found)
+	}
+	if n := inner.userLookups.Load(); n != lookups {
+		t.Errorf("expected the read served from the cache, got %d lookups", n-lookups)
+	}
+}
+
+func TestCachedRepositoryRedisLogout(t *testing.T) {
+	db := sqlitetest.Open(t)
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	// Two instances share the cache, so a logout on one is seen by the other
+	newInstance := func() *usecase.UserUsecase {
+		repo := repository.NewCached(repository.New(db.Querier(), db.DBTX()), cache.NewRedis(client))
+		return usecase.New(repo, 3600, usecase.WithTxManager(db.TxManager()))
+	}
+	first, second := newInstance(), newInstance()
+	ctx := context.Background()
+
+	user, err := first.RegisterUser(ctx, "redis@example.com", "Redis User", "SecurePass123")
+	if err != nil {
+		t.Fatalf("RegisterUser failed: %v", err)
+	}
+	_, _, refreshToken, err := first.LoginUser(ctx, "redis@example.com", "SecurePass123", "127.0.0.1", "test-agent")
+	if err != nil {
+		t.Fatalf("LoginUser failed: %v", err)
+	}
+	if _, err := second.RefreshToken(ctx, refreshToken); err != nil {
+		t.Fatalf("RefreshToken failed: %v", err)
+	}
+	if len(server.Keys()) != 2 {
+		t.Errorf("expected the user and session cached, got %v", server.Keys())
+	}
+
+	if err := first.LogoutAllSessions(ctx, user.ID); err != nil {
+		t.Fatalf("LogoutAllSessions failed: %v", err)
+	}
+	if _, err := second.RefreshToken(ctx, refreshToken); !errors.Is(err, domain.ErrSessionNotFound) {
+		t.Errorf("expected the revoked session refused, got %v", err)
+	}
+
+	// Without Redis the instances fall back to the database
+	server.SetError("server unavailable")
+	if _, err := second.GetUser(ctx, user.ID); err != nil {
+		t.Errorf("expected the user read from the database, got %v", err)
+	}
+}
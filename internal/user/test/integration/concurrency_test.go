@@ -0,0 +1,68 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/internal/user/test/mocks"
+	"github.com/zercle/template-go-echo/internal/user/usecase"
+)
+
+func TestUpdateUserProfileVersionConflict(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	user, err := uc.RegisterUser(context.Background(), "test@example.com", "Test User", "SecurePass123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Version != domain.InitialVersion {
+		t.Fatalf("expected version %d, got %d", domain.InitialVersion, user.Version)
+	}
+
+	updated, err := uc.UpdateUserProfile(context.Background(), user.ID, "First Admin", "test@example.com", domain.InitialVersion)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Version != domain.InitialVersion+1 {
+		t.Errorf("expected version %d, got %d", domain.InitialVersion+1, updated.Version)
+	}
+
+	// A second editor still holding the original version must not overwrite the change
+	_, err = uc.UpdateUserProfile(context.Background(), user.ID, "Second Admin", "test@example.com", domain.InitialVersion)
+	if err != domain.ErrVersionConflict {
+		t.Fatalf("expected ErrVersionConflict, got %v", err)
+	}
+
+	current, _ := uc.GetUser(context.Background(), user.ID)
+	if current.Name != "First Admin" {
+		t.Errorf("expected name First Admin, got %s", current.Name)
+	}
+
+	// Updates without a precondition apply to the latest version
+	updated, err = uc.UpdateUserProfile(context.Background(), user.ID, "Second Admin", "test@example.com", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Version != domain.InitialVersion+2 {
+		t.Errorf("expected version %d, got %d", domain.InitialVersion+2, updated.Version)
+	}
+}
+
+func TestPasswordChangeInvalidatesProfileVersion(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	user, _ := uc.RegisterUser(context.Background(), "test@example.com", "Test User", "SecurePass123")
+	version := user.Version
+
+	if err := uc.ChangePassword(context.Background(), user.ID, "SecurePass123", "NewSecurePass456"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := uc.UpdateUserProfile(context.Background(), user.ID, "Renamed", "test@example.com", version)
+	if err != domain.ErrVersionConflict {
+		t.Errorf("expected ErrVersionConflict, got %v", err)
+	}
+}
@@ -0,0 +1,242 @@
+package integration_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/internal/user/test/mocks"
+	"github.com/zercle/template-go-echo/internal/user/usecase"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestRegisterUserPendingDeletion(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	user, _ := uc.RegisterUser(context.Background(), "test@example.com", "Test User", "SecurePass123")
+	if err := uc.DeleteUser(context.Background(), user.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := uc.RegisterUser(context.Background(), "test@example.com", "Test User", "SecurePass123")
+	if err != domain.ErrPendingDeletion {
+		t.Errorf("expected ErrPendingDeletion, got %v", err)
+	}
+}
+
+func TestLoginRestoresDeletedUser(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	user, _ := uc.RegisterUser(context.Background(), "test@example.com", "Test User", "SecurePass123")
+	_ = uc.DeleteUser(context.Background(), user.ID)
+
+	loggedIn, _, _, err := uc.LoginUser(context.Background(), "test@example.com", "SecurePass123", "127.0.0.1", "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if loggedIn.IsDeleted() {
+		t.Error("expected user to be restored on login")
+	}
+
+	if _, err := uc.GetUser(context.Background(), user.ID); err != nil {
+		t.Errorf("expected restored user to be retrievable, got %v", err)
+	}
+}
+
+func TestRestoreUser(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	user, _ := uc.RegisterUser(context.Background(), "test@example.com", "Test User", "SecurePass123")
+	_ = uc.DeleteUser(context.Background(), user.ID)
+
+	if _, err := uc.RestoreUser(context.Background(), "test@example.com", "WrongPass123"); err != domain.ErrInvalidCredentials {
+		t.Errorf("expected ErrInvalidCredentials, got %v", err)
+	}
+
+	restored, err := uc.RestoreUser(context.Background(), "test@example.com", "SecurePass123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if restored.IsDeleted() {
+		t.Error("expected user to be restored")
+	}
+}
+
+func TestRestoreUserAfterGracePeriod(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600, usecase.WithDeletionGracePeriod(time.Hour))
+
+	user, _ := uc.RegisterUser(context.Background(), "test@example.com", "Test User", "SecurePass123")
+	deletedAt := time.Now().Add(-2 * time.Hour)
+	user.DeletedAt = &deletedAt
+
+	if _, err := uc.RestoreUser(context.Background(), "test@example.com", "SecurePass123"); err != domain.ErrUserNotFound {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+
+	if _, _, _, err := uc.LoginUser(context.Background(), "test@example.com", "SecurePass123", "", ""); err != domain.ErrInvalidCredentials {
+		t.Errorf("expected ErrInvalidCredentials, got %v", err)
+	}
+}
+
+func TestPurgeDeletedUsers(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600, usecase.WithDeletionGracePeriod(time.Hour))
+
+	expired, _ := uc.RegisterUser(context.Background(), "expired@example.com", "Expired", "SecurePass123")
+	pending, _ := uc.RegisterUser(context.Background(), "pending@example.com", "Pending", "SecurePass123")
+	_ = uc.DeleteUser(context.Background(), pending.ID)
+	deletedAt := time.Now().Add(-2 * time.Hour)
+	expired.DeletedAt = &deletedAt
+
+	purged, err := uc.PurgeDeletedUsers(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if purged != 1 {
+		t.Errorf("expected 1 purged user, got %d", purged)
+	}
+
+	users, total, err := uc.ListPendingDeletion(context.Background(), 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if total != 1 || len(users) != 1 || users[0].ID != pending.ID {
+		t.Errorf("expected only pending user to remain, got %d users", total)
+	}
+
+	// A purged email can be registered again
+	if _, err := uc.RegisterUser(context.Background(), "expired@example.com", "Expired", "SecurePass123"); err != nil {
+		t.Errorf("expected purged email to be reusable, got %v", err)
+	}
+}
+
+func TestRegisterPurgesExpiredAccount(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600, usecase.WithDeletionGracePeriod(time.Hour))
+	deletedAt := time.Now().Add(-2 * time.Hour)
+
+	expired, _ := uc.RegisterUser(context.Background(), "expired@example.com", "Expired", "SecurePass123")
+	other, _ := uc.RegisterUser(context.Background(), "other@example.com", "Other", "SecurePass123")
+	for _, user := range []*domain.User{expired, other} {
+		_ = uc.DeleteUser(context.Background(), user.ID)
+		user.DeletedAt = &deletedAt
+	}
+
+	// No scheduled purge has run, yet the expired account cannot be restored
+	user, err := uc.RegisterUser(context.Background(), "expired@example.com", "Expired", "SecurePass123")
+	if err != nil {
+		t.Fatalf("expected an expired account not to block registration, got %v", err)
+	}
+	if user.ID == expired.ID {
+		t.Error("expected a new account")
+	}
+	if deleted, _ := repo.GetDeletedUserByEmail(context.Background(), "expired@example.com"); deleted != nil {
+		t.Error("expected the expired account to be purged")
+	}
+	if deleted, _ := repo.GetDeletedUserByEmail(context.Background(), "other@example.com"); deleted == nil {
+		t.Error("expected other expired accounts to be left for the scheduled purge")
+	}
+}
+
+func TestImportPurgesOnlyOnWrite(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600, usecase.WithDeletionGracePeriod(time.Hour))
+	deletedAt := time.Now().Add(-2 * time.Hour)
+
+	expired, _ := uc.RegisterUser(context.Background(), "expired@example.com", "Expired", "SecurePass123")
+	other, _ := uc.RegisterUser(context.Background(), "other@example.com", "Other", "SecurePass123")
+	for _, user := range []*domain.User{expired, other} {
+		_ = uc.DeleteUser(context.Background(), user.ID)
+		user.DeletedAt = &deletedAt
+	}
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("PresetPass123"), bcrypt.MinCost)
+	input := "email,name,password_hash\nexpired@example.com,Expired," + string(hash) + "\n"
+
+	// A dry run reports the row as valid without deleting anything
+	report, err := uc.ImportUsers(context.Background(), strings.NewReader(input), domain.ImportFormatCSV, domain.ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Valid != 1 {
+		t.Fatalf("expected the row to be valid, got %+v", report)
+	}
+	if deleted, _ := repo.GetDeletedUserByEmail(context.Background(), "expired@example.com"); deleted == nil {
+		t.Fatal("expected a dry run not to purge the expired account")
+	}
+
+	report, err = uc.ImportUsers(context.Background(), strings.NewReader(input), domain.ImportFormatCSV, domain.ImportOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Created != 1 {
+		t.Fatalf("expected the row to be created, got %+v", report)
+	}
+	if deleted, _ := repo.GetDeletedUserByEmail(context.Background(), "expired@example.com"); deleted != nil {
+		t.Error("expected the expired account to be purged")
+	}
+	if deleted, _ := repo.GetDeletedUserByEmail(context.Background(), "other@example.com"); deleted == nil {
+		t.Error("expected other expired accounts to be left for the scheduled purge")
+	}
+}
+
+func TestDeleteUserRevokesSessionsInOneTransaction(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	tx := &mocks.MockTxManager{}
+	uc := usecase.New(repo, 3600, usecase.WithTxManager(tx))
+	ctx := context.Background()
+
+	user, err := uc.RegisterUser(ctx, "test@example.com", "Test User", "SecurePass123")
+	if err != nil {
+		t.Fatalf("RegisterUser failed: %v", err)
+	}
+	if _, _, _, err := uc.LoginUser(ctx, "test@example.com", "SecurePass123", "127.0.0.1", "test"); err != nil {
+		t.Fatalf("LoginUser failed: %v", err)
+	}
+
+	tx.Calls = 0
+	if err := uc.DeleteUser(ctx, user.ID); err != nil {
+		t.Fatalf("DeleteUser failed: %v", err)
+	}
+	if tx.Calls != 1 || tx.RolledBack {
+		t.Errorf("expected deletion to commit one transaction, got %d calls", tx.Calls)
+	}
+
+	sessions, _ := repo.GetSessionsByUserID(ctx, user.ID)
+	if len(sessions) != 0 {
+		t.Errorf("expected sessions to be revoked, got %d", len(sessions))
+	}
+
+	events := repo.Events()
+	revoked, ok := events[len(events)-1].(domain.SessionRevoked)
+	if !ok || revoked.Reason != domain.RevokeReasonDeleted {
+		t.Errorf("expected session revoked for account deletion, got %#v", events[len(events)-1])
+	}
+}
+
+func TestRegisterUserChecksEmailInTransaction(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	tx := &mocks.MockTxManager{}
+	uc := usecase.New(repo, 3600, usecase.WithTxManager(tx))
+	ctx := context.Background()
+
+	if _, err := uc.RegisterUser(ctx, "test@example.com", "Test User", "SecurePass123"); err != nil {
+		t.Fatalf("RegisterUser failed: %v", err)
+	}
+	if _, err := uc.RegisterUser(ctx, "test@example.com", "Test User", "SecurePass123"); err != domain.ErrUserExists {
+		t.Errorf("expected ErrUserExists, got %v", err)
+	}
+	if tx.Calls != 2 || !tx.RolledBack {
+		t.Errorf("expected the duplicate registration to roll back, got %d calls", tx.Calls)
+	}
+}
@@ -0,0 +1,113 @@
+package integration_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/internal/user/test/mocks"
+	"github.com/zercle/template-go-echo/internal/user/usecase"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// eventTypes lists the types of events appended to the outbox
+func eventTypes(events []pkg.DomainEvent) []string {
+	types := make([]string, len(events))
+	for i, event := range events {
+		types[i] = event.EventType()
+	}
+	return types
+}
+
+func TestUserLifecycleEvents(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	user, _ := uc.RegisterUser(context.Background(), "test@example.com", "Test User", "SecurePass123")
+	_, _, _, _ = uc.LoginUser(context.Background(), "test@example.com", "SecurePass123", "", "")
+
+	// Unchanged profiles do not publish an event; email changes publish both events
+	_, _ = uc.UpdateUserProfile(context.Background(), user.ID, "Test User", "test@example.com", 0)
+	_, _ = uc.UpdateUserProfile(context.Background(), user.ID, "Renamed", "test@example.com", 0)
+	_, _ = uc.UpdateUserProfile(context.Background(), user.ID, "Renamed", "new@example.com", 0)
+
+	if err := uc.DeleteUser(context.Background(), user.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		domain.EventUserRegistered,
+		domain.EventUserUpdated,
+		domain.EventUserUpdated,
+		domain.EventUserEmailChanged,
+		domain.EventUserDeleted,
+		domain.EventSessionRevoked,
+	}
+	events := repo.Events()
+	got := eventTypes(events)
+	if len(got) != len(expected) {
+		t.Fatalf("expected events %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("expected event %d to be %s, got %s", i, expected[i], got[i])
+		}
+	}
+
+	for _, event := range events {
+		if event.AggregateType() != domain.AggregateUser || event.AggregateID() != user.ID {
+			t.Errorf("expected %s event to belong to the user aggregate", event.EventType())
+		}
+	}
+
+	changed := events[3].(domain.UserEmailChanged)
+	if changed.OldEmail != "test@example.com" || changed.NewEmail != "new@example.com" {
+		t.Errorf("unexpected email change event: %+v", changed)
+	}
+}
+
+func TestSessionRevokedEvents(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	user, _ := uc.RegisterUser(context.Background(), "test@example.com", "Test User", "SecurePass123")
+	for i := 0; i < 2; i++ {
+		_, _, _, _ = uc.LoginUser(context.Background(), "test@example.com", "SecurePass123", "", "")
+	}
+
+	if _, err := uc.SuspendUser(context.Background(), adminID, user.ID, "abuse", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var revoked []domain.SessionRevoked
+	for _, event := range repo.Events() {
+		if event, ok := event.(domain.SessionRevoked); ok {
+			revoked = append(revoked, event)
+		}
+	}
+
+	if len(revoked) != 2 {
+		t.Fatalf("expected 2 revoked sessions, got %d", len(revoked))
+	}
+	for _, event := range revoked {
+		if event.SessionID == "" || event.UserID != user.ID || event.Reason != domain.RevokeReasonSuspended {
+			t.Errorf("unexpected session revoked event: %+v", event)
+		}
+	}
+}
+
+func TestImportedUsersPublishRegistration(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600, usecase.WithInviteSender(&recordingInviteSender{passwords: make(map[string]string)}))
+
+	input := "email,name\none@example.com,One\ntwo@example.com,Two\n"
+	if _, err := uc.ImportUsers(context.Background(), strings.NewReader(input), domain.ImportFormatCSV, domain.ImportOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := eventTypes(repo.Events())
+	if len(got) != 2 || got[0] != domain.EventUserRegistered || got[1] != domain.EventUserRegistered {
+		t.Errorf("expected a registration event per imported user, got %v", got)
+	}
+}
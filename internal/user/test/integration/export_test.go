@@ -0,0 +1,188 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/internal/user/test/mocks"
+	"github.com/zercle/template-go-echo/internal/user/usecase"
+)
+
+// seedExportUsers inserts users directly to avoid hashing passwords
+func seedExportUsers(t *testing.T, repo *mocks.MockUserRepository, count int) {
+	t.Helper()
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < count; i++ {
+		user := &domain.User{
+			ID:           fmt.Sprintf("user-%04d", i),
+			Email:        fmt.Sprintf("user%04d@example.com", i),
+			Name:         fmt.Sprintf("User %d", i),
+			PasswordHash: "secret-hash",
+			IsActive:     i%2 == 0,
+			Role:         domain.RoleUser,
+			CreatedAt:    created.Add(time.Duration(i) * time.Minute),
+			UpdatedAt:    created,
+		}
+		if err := repo.CreateUser(context.Background(), user); err != nil {
+			t.Fatalf("failed to seed user: %v", err)
+		}
+	}
+}
+
+func TestExportUsersCSV(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+	seedExportUsers(t, repo, 3)
+
+	var buf bytes.Buffer
+	count, err := uc.ExportUsers(context.Background(), &buf, domain.ExportFormatCSV, domain.UserExportQuery{Sort: "email"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 rows, got %d", count)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid csv: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("expected header and 3 rows, got %d records", len(records))
+	}
+
+	// Sensitive columns are excluded by default
+	header := strings.Join(records[0], ",")
+	if header != strings.Join(domain.DefaultExportColumns(), ",") {
+		t.Errorf("unexpected header %q", header)
+	}
+	if strings.Contains(buf.String(), "secret-hash") || strings.Contains(header, "password_hash") {
+		t.Error("expected password hashes to be excluded by default")
+	}
+	if records[1][1] != "user0000@example.com" || records[1][8] != "2024-01-01T00:00:00Z" {
+		t.Errorf("unexpected first row %v", records[1])
+	}
+}
+
+func TestExportUsersColumnsAndFilter(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+	seedExportUsers(t, repo, 4)
+
+	active := true
+	var buf bytes.Buffer
+	_, err := uc.ExportUsers(context.Background(), &buf, domain.ExportFormatJSONL, domain.UserExportQuery{
+		Filter:  domain.UserFilter{IsActive: &active},
+		Sort:    "-email",
+		Columns: []string{"email", "password_hash", "suspended_at"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 active users, got %d lines", len(lines))
+	}
+
+	// Keys follow the requested column order and sensitive columns can be requested by name
+	expected := `{"email":"user0002@example.com","password_hash":"secret-hash","suspended_at":null}`
+	if lines[0] != expected {
+		t.Errorf("expected %s, got %s", expected, lines[0])
+	}
+	var row map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &row); err != nil || row["email"] != "user0000@example.com" {
+		t.Errorf("unexpected second line %s", lines[1])
+	}
+
+	_, err = uc.ExportUsers(context.Background(), &buf, domain.ExportFormatCSV, domain.UserExportQuery{Columns: []string{"email", "secret"}})
+	if err != domain.ErrInvalidExport {
+		t.Errorf("expected ErrInvalidExport for unknown column, got %v", err)
+	}
+}
+
+func TestExportUsersXLSX(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+	seedExportUsers(t, repo, 2)
+
+	var buf bytes.Buffer
+	_, err := uc.ExportUsers(context.Background(), &buf, domain.ExportFormatXLSX, domain.UserExportQuery{
+		Sort:    "email",
+		Columns: []string{"id", "email", "is_active", "suspended_until"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	file, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("invalid xlsx: %v", err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows("Users")
+	if err != nil {
+		t.Fatalf("failed to read sheet: %v", err)
+	}
+	if len(rows) != 3 || rows[0][1] != "email" || rows[2][1] != "user0001@example.com" || rows[1][2] != "TRUE" {
+		t.Errorf("unexpected sheet contents %v", rows)
+	}
+}
+
+func TestExportUsersEscapesFormulas(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+	_ = repo.CreateUser(context.Background(), &domain.User{ID: "1", Email: "a@example.com", Name: "=HYPERLINK(\"x\")"})
+
+	var buf bytes.Buffer
+	if _, err := uc.ExportUsers(context.Background(), &buf, domain.ExportFormatCSV, domain.UserExportQuery{Columns: []string{"name"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `"'=HYPERLINK(""x"")"`) {
+		t.Errorf("expected formula to be neutralised, got %s", buf.String())
+	}
+}
+
+// failingWriter simulates a client that disconnects after some bytes
+type failingWriter struct {
+	remaining int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.remaining {
+		return 0, errors.New("connection reset by peer")
+	}
+	w.remaining -= len(p)
+	return len(p), nil
+}
+
+func TestExportUsersClientDisconnect(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+	seedExportUsers(t, repo, 1200)
+
+	count, err := uc.ExportUsers(context.Background(), &failingWriter{remaining: 8192}, domain.ExportFormatJSONL, domain.UserExportQuery{})
+	if err == nil {
+		t.Fatal("expected error when the client disconnects")
+	}
+	if count >= 1200 {
+		t.Errorf("expected export to stop early, wrote %d rows", count)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := uc.ExportUsers(ctx, &bytes.Buffer{}, domain.ExportFormatCSV, domain.UserExportQuery{}); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+package integration_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/internal/user/test/mocks"
+	"github.com/zercle/template-go-echo/internal/user/usecase"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// recordingInviteSender captures temporary passwords sent to imported users
+type recordingInviteSender struct {
+	passwords map[string]string
+}
+
+func (s *recordingInviteSender) SendPasswordInvite(ctx context.Context, user *domain.User, temporaryPassword string) error {
+	s.passwords[user.Email] = temporaryPassword
+	return nil
+}
+
+func TestImportUsersCSV(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	invites := &recordingInviteSender{passwords: make(map[string]string)}
+	uc := usecase.New(repo, 3600, usecase.WithInviteSender(invites))
+
+	_, _ = uc.RegisterUser(context.Background(), "existing@example.com", "Existing", "SecurePass123")
+	hash, _ := bcrypt.GenerateFromPassword([]byte("PresetPass123"), bcrypt.MinCost)
+
+	input := "email,name,role,password_hash\n" +
+		"alice@example.com,Alice,admin," + string(hash) + "\n" +
+		"bob@example.com,Bob,,\n" +
+		"not-an-email,Carol,,\n" +
+		"ALICE@example.com,Alice Again,,\n" +
+		"existing@example.com,Existing,,\n" +
+		"dave@example.com,Dave,superuser,\n" +
+		"erin@example.com,Erin,,plaintext\n" +
+		"frank@example.com,Frank\n"
+
+	report, err := uc.ImportUsers(context.Background(), strings.NewReader(input), domain.ImportFormatCSV, domain.ImportOptions{BatchSize: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.Total != 8 || report.Created != 2 || report.Failed != 6 {
+		t.Fatalf("expected 8 rows with 2 created and 6 failed, got %+v", report)
+	}
+
+	expected := []struct {
+		line   int
+		status string
+		code   string
+	}{
+		{2, domain.ImportStatusCreated, ""},
+		{3, domain.ImportStatusCreated, ""},
+		{4, domain.ImportStatusFailed, domain.ErrCodeInvalidEmail},
+		{5, domain.ImportStatusFailed, domain.ErrCodeDuplicateImportRow},
+		{6, domain.ImportStatusFailed, domain.ErrCodeUserExists},
+		{7, domain.ImportStatusFailed, domain.ErrCodeInvalidRole},
+		{8, domain.ImportStatusFailed, domain.ErrCodeInvalidPasswordHash},
+		{9, domain.ImportStatusFailed, domain.ErrCodeInvalidImportRow},
+	}
+	for i, want := range expected {
+		row := report.Rows[i]
+		if row.Line != want.line || row.Status != want.status || row.ErrorCode != want.code {
+			t.Errorf("row %d: expected line %d %s %s, got line %d %s %s",
+				i, want.line, want.status, want.code, row.Line, row.Status, row.ErrorCode)
+		}
+	}
+
+	// Preset hashes are kept and need no invite
+	alice, _ := repo.GetUserByEmail(context.Background(), "alice@example.com")
+	if alice == nil || alice.Role != domain.RoleAdmin || alice.PasswordResetRequired {
+		t.Fatalf("expected alice to be an admin without forced reset, got %+v", alice)
+	}
+	if _, _, _, err := uc.LoginUser(context.Background(), "alice@example.com", "PresetPass123", "", ""); err != nil {
+		t.Errorf("expected preset password to log in, got %v", err)
+	}
+
+	// Generated passwords are sent as invites and must be reset
+	bob, _ := repo.GetUserByEmail(context.Background(), "bob@example.com")
+	if bob == nil || !bob.PasswordResetRequired || !report.Rows[1].Invited {
+		t.Fatalf("expected bob to be invited with a forced reset, got %+v", bob)
+	}
+	if _, ok := invites.passwords["alice@example.com"]; ok {
+		t.Error("expected no invite for preset password")
+	}
+	if err := uc.CompletePasswordReset(context.Background(), "bob@example.com", invites.passwords["bob@example.com"], "ChosenPass123"); err != nil {
+		t.Errorf("expected temporary password to authenticate the reset, got %v", err)
+	}
+	if _, _, _, err := uc.LoginUser(context.Background(), "bob@example.com", "ChosenPass123", "", ""); err != nil {
+		t.Errorf("expected the chosen password to log in, got %v", err)
+	}
+}
+
+func TestImportUsersDryRun(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600, usecase.WithInviteSender(&recordingInviteSender{passwords: make(map[string]string)}))
+
+	input := "name,email\nAlice,alice@example.com\nBob,bob-at-example\n"
+
+	report, err := uc.ImportUsers(context.Background(), strings.NewReader(input), domain.ImportFormatCSV, domain.ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !report.DryRun || report.Valid != 1 || report.Failed != 1 || report.Created != 0 {
+		t.Errorf("expected 1 valid and 1 failed dry run row, got %+v", report)
+	}
+
+	if user, _ := repo.GetUserByEmail(context.Background(), "alice@example.com"); user != nil {
+		t.Error("expected dry run not to create users")
+	}
+}
+
+func TestImportUsersJSONL(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600, usecase.WithInviteSender(&recordingInviteSender{passwords: make(map[string]string)}))
+
+	input := `{"email":"alice@example.com","name":"Alice"}
+
+{"email":"bob@example.com","name":"Bob","password":"plaintext"}
+{not json}
+{"email":"carol@example.com","name":""}
+`
+
+	report, err := uc.ImportUsers(context.Background(), strings.NewReader(input), domain.ImportFormatJSONL, domain.ImportOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.Total != 4 || report.Created != 1 || report.Failed != 3 {
+		t.Fatalf("expected 4 rows with 1 created and 3 failed, got %+v", report)
+	}
+
+	// Blank lines are skipped but still count towards line numbers
+	lines := []int{1, 3, 4, 5}
+	for i, line := range lines {
+		if report.Rows[i].Line != line {
+			t.Errorf("row %d: expected line %d, got %d", i, line, report.Rows[i].Line)
+		}
+	}
+	if report.Rows[3].ErrorCode != domain.ErrCodeInvalidName {
+		t.Errorf("expected INVALID_NAME for empty name, got %s", report.Rows[3].ErrorCode)
+	}
+}
+
+func TestImportUsersInvalidInput(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	inputs := []string{
+		"",
+		"email\nalice@example.com\n",
+		"email,name,password\nalice@example.com,Alice,secret\n",
+	}
+	for _, input := range inputs {
+		_, err := uc.ImportUsers(context.Background(), strings.NewReader(input), domain.ImportFormatCSV, domain.ImportOptions{})
+		if err != domain.ErrInvalidImport {
+			t.Errorf("expected ErrInvalidImport for %q, got %v", input, err)
+		}
+	}
+
+	if _, err := uc.ImportUsers(context.Background(), strings.NewReader(""), "xml", domain.ImportOptions{}); err != domain.ErrInvalidImport {
+		t.Errorf("expected ErrInvalidImport for unknown format, got %v", err)
+	}
+}
+
+func TestImportUsersWithoutInviteSender(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("PresetPass123"), bcrypt.MinCost)
+	input := "email,name,password_hash\nalice@example.com,Alice," + string(hash) + "\nbob@example.com,Bob,\n"
+
+	for _, dryRun := range []bool{true, false} {
+		report, err := uc.ImportUsers(context.Background(), strings.NewReader(input), domain.ImportFormatCSV, domain.ImportOptions{DryRun: dryRun})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if report.Failed != 1 || report.Rows[1].ErrorCode != domain.ErrCodePasswordHashRequired {
+			t.Errorf("dry run %v: expected the row without a hash to fail, got %+v", dryRun, report)
+		}
+	}
+
+	if user, _ := repo.GetUserByEmail(context.Background(), "bob@example.com"); user != nil {
+		t.Error("expected no account with a password nobody knows")
+	}
+	if user, _ := repo.GetUserByEmail(context.Background(), "alice@example.com"); user == nil {
+		t.Error("expected the row with a hash to be imported")
+	}
+}
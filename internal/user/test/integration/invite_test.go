@@ -0,0 +1,137 @@
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	jobsdomain "github.com/zercle/template-go-echo/internal/jobs/domain"
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/internal/user/test/mocks"
+	"github.com/zercle/template-go-echo/internal/user/usecase"
+)
+
+// recordingQueue captures enqueued jobs
+type recordingQueue struct {
+	jobs []*jobsdomain.Job
+	err  error
+}
+
+func (q *recordingQueue) Enqueue(ctx context.Context, jobType string, payload json.RawMessage, opts ...jobsdomain.EnqueueOption) (*jobsdomain.Job, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	job := &jobsdomain.Job{Type: jobType, Payload: payload}
+	q.jobs = append(q.jobs, job)
+	return job, nil
+}
+
+// recordingMailer captures sent emails by recipient
+type recordingMailer struct {
+	bodies map[string]string
+	err    error
+}
+
+func (m *recordingMailer) Send(ctx context.Context, to, subject, body string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.bodies[to] = body
+	return nil
+}
+
+func TestQueuedPasswordInvite(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	queue := &recordingQueue{}
+	uc := usecase.New(repo, 3600, usecase.WithInviteSender(usecase.NewQueuedInviteSender(queue)))
+
+	report, err := uc.ImportUsers(context.Background(), strings.NewReader("email,name\nbob@example.com,Bob\n"), domain.ImportFormatCSV, domain.ImportOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Created != 1 || !report.Rows[0].Invited || len(queue.jobs) != 1 {
+		t.Fatalf("expected one invited row and one queued job, got %+v", report)
+	}
+
+	// The queue only carries the user ID, never a password
+	job := queue.jobs[0]
+	if job.Type != usecase.PasswordInviteJob.Name() || string(job.Payload) != `{"user_id":"`+report.Rows[0].UserID+`"}` {
+		t.Fatalf("unexpected job: %s %s", job.Type, job.Payload)
+	}
+
+	mailer := &recordingMailer{bodies: make(map[string]string)}
+	handler := usecase.PasswordInviteHandler(repo, mailer)
+	if handler.Type != usecase.PasswordInviteJob.Name() {
+		t.Fatalf("unexpected handler type %q", handler.Type)
+	}
+	if err := handler.Handle(context.Background(), job); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	body, ok := mailer.bodies["bob@example.com"]
+	if !ok {
+		t.Fatal("expected an invite email for bob")
+	}
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	temporaryPassword := strings.TrimSpace(lines[len(lines)-1])
+
+	// The emailed password must be reset on first login
+	if _, _, _, err := uc.LoginUser(context.Background(), "bob@example.com", temporaryPassword, "", ""); err != domain.ErrPasswordResetRequired {
+		t.Errorf("expected ErrPasswordResetRequired, got %v", err)
+	}
+	if err := uc.CompletePasswordReset(context.Background(), "bob@example.com", temporaryPassword, "ChosenPass123"); err != nil {
+		t.Fatalf("expected the emailed password to authenticate the reset, got %v", err)
+	}
+
+	// A retried job must not reissue a password once it was reset
+	delete(mailer.bodies, "bob@example.com")
+	if err := handler.Handle(context.Background(), job); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := mailer.bodies["bob@example.com"]; ok {
+		t.Error("expected no invite once the password was reset")
+	}
+	if _, _, _, err := uc.LoginUser(context.Background(), "bob@example.com", "ChosenPass123", "", ""); err != nil {
+		t.Errorf("expected the chosen password to still log in, got %v", err)
+	}
+}
+
+func TestPasswordInviteMailFailure(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	queue := &recordingQueue{}
+	uc := usecase.New(repo, 3600, usecase.WithInviteSender(usecase.NewQueuedInviteSender(queue)))
+
+	if _, err := uc.ImportUsers(context.Background(), strings.NewReader("email,name\nbob@example.com,Bob\n"), domain.ImportFormatCSV, domain.ImportOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A failed send is returned so the worker retries the job
+	sendErr := errors.New("relay unavailable")
+	handler := usecase.PasswordInviteHandler(repo, &recordingMailer{err: sendErr})
+	if err := handler.Handle(context.Background(), queue.jobs[0]); !errors.Is(err, sendErr) {
+		t.Errorf("expected the mail error, got %v", err)
+	}
+}
+
+func TestImportReportsFailedInvites(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	queue := &recordingQueue{err: errors.New("queue unavailable")}
+	uc := usecase.New(repo, 3600, usecase.WithInviteSender(usecase.NewQueuedInviteSender(queue)))
+
+	report, err := uc.ImportUsers(context.Background(), strings.NewReader("email,name\nbob@example.com,Bob\n"), domain.ImportFormatCSV, domain.ImportOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	row := report.Rows[0]
+	if report.Created != 0 || report.Failed != 1 || row.ErrorCode != domain.ErrCodeInviteFailed || row.Invited {
+		t.Fatalf("expected the row to fail with INVITE_FAILED, got %+v", report)
+	}
+
+	// The account exists, so the report names it for a manual reset
+	if user, _ := repo.GetUserByEmail(context.Background(), "bob@example.com"); user == nil || row.UserID != user.ID {
+		t.Errorf("expected the failed row to name the created user, got %+v", row)
+	}
+}
@@ -0,0 +1,46 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/scheduler/domain"
+	userdomain "github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/internal/user/test/mocks"
+	"github.com/zercle/template-go-echo/internal/user/usecase"
+)
+
+func TestSessionCleanupJob(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	user, _ := uc.RegisterUser(context.Background(), "test@example.com", "Test User", "SecurePass123")
+	_, _, _, _ = uc.LoginUser(context.Background(), "test@example.com", "SecurePass123", "", "")
+	_ = repo.CreateSession(context.Background(), &userdomain.UserSession{
+		ID:        "expired",
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(-time.Minute),
+	})
+
+	jobs := usecase.ScheduledJobs(uc, "*/15 * * * *", "@every 1h")
+	if len(jobs) != 2 || jobs[0].Name != usecase.SessionCleanupJobName || jobs[1].Name != usecase.AccountPurgeJobName {
+		t.Fatalf("unexpected jobs: %+v", jobs)
+	}
+	for _, job := range jobs {
+		if _, err := domain.ParseSchedule(job.Schedule); err != nil {
+			t.Errorf("%s: unexpected schedule error: %v", job.Name, err)
+		}
+	}
+
+	if err := jobs[0].Run(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if session, _ := repo.GetSessionByID(context.Background(), "expired"); session != nil {
+		t.Error("expected expired session to be deleted")
+	}
+	if sessions, _ := repo.GetSessionsByUserID(context.Background(), user.ID); len(sessions) != 1 {
+		t.Errorf("expected the active session to be kept, got %d", len(sessions))
+	}
+}
@@ -0,0 +1,96 @@
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/internal/user/test/mocks"
+	"github.com/zercle/template-go-echo/internal/user/usecase"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+func TestListUsersPageCursor(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	for i := 1; i <= 5; i++ {
+		email := fmt.Sprintf("user%d@example.com", i)
+		if _, err := uc.RegisterUser(context.Background(), email, "User", "SecurePass123"); err != nil {
+			t.Fatalf("failed to register user: %v", err)
+		}
+	}
+
+	var emails []string
+	query := domain.UserListQuery{Sort: "email", Limit: 2}
+	for pages := 0; pages < 5; pages++ {
+		page, err := uc.ListUsersPage(context.Background(), query)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, user := range page.Items {
+			emails = append(emails, user.Email)
+		}
+		if !page.HasMore {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	if len(emails) != 5 {
+		t.Fatalf("expected 5 users across pages, got %d", len(emails))
+	}
+	for i, email := range emails {
+		if expected := fmt.Sprintf("user%d@example.com", i+1); email != expected {
+			t.Errorf("position %d: expected %s, got %s", i, expected, email)
+		}
+	}
+}
+
+func TestListUsersPageFilterAndTotal(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	_, _ = uc.RegisterUser(context.Background(), "alice@example.com", "Alice Smith", "SecurePass123")
+	_, _ = uc.RegisterUser(context.Background(), "alan@example.com", "Alan Jones", "SecurePass123")
+	_, _ = uc.RegisterUser(context.Background(), "bob@example.com", "Bob Smith", "SecurePass123")
+
+	page, err := uc.ListUsersPage(context.Background(), domain.UserListQuery{
+		Filter:       domain.UserFilter{EmailPrefix: "al"},
+		IncludeTotal: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(page.Items) != 2 || page.Total == nil || *page.Total != 2 {
+		t.Errorf("expected 2 users matching prefix, got %d", len(page.Items))
+	}
+
+	page, _ = uc.ListUsersPage(context.Background(), domain.UserListQuery{
+		Filter: domain.UserFilter{NameContains: "Smith"},
+	})
+	if len(page.Items) != 2 || page.Total != nil {
+		t.Errorf("expected 2 users matching name without total, got %d", len(page.Items))
+	}
+}
+
+func TestListUsersPageInvalidInput(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo, 3600)
+
+	if _, err := uc.ListUsersPage(context.Background(), domain.UserListQuery{Sort: "password_hash"}); err != pkg.ErrInvalidSort {
+		t.Errorf("expected ErrInvalidSort, got %v", err)
+	}
+
+	if _, err := uc.ListUsersPage(context.Background(), domain.UserListQuery{Cursor: "garbage!"}); err != pkg.ErrInvalidCursor {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+
+	// A cursor issued for one ordering is rejected for another
+	cursor := pkg.EncodeCursor(domain.UserCursor{Sort: "email", Value: "a@example.com", ID: "1"})
+	if _, err := uc.ListUsersPage(context.Background(), domain.UserListQuery{Sort: "name", Cursor: cursor}); err != pkg.ErrInvalidCursor {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+}
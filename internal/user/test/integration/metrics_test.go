@@ -0,0 +1,55 @@
+package integration_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/zercle/template-go-echo/internal/infrastructure/test/sqlitetest"
+	"github.com/zercle/template-go-echo/internal/user/repository"
+	"github.com/zercle/template-go-echo/internal/user/usecase"
+)
+
+func TestUserActivityMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	db := sqlitetest.Open(t)
+	uc := usecase.New(repository.New(db.Querier(), db.DBTX()), 3600,
+		usecase.WithTxManager(db.TxManager()),
+		usecase.WithMetrics(usecase.NewMetrics(registry)),
+	)
+	ctx := context.Background()
+
+	if _, err := uc.RegisterUser(ctx, "metrics@example.com", "Metrics User", "SecurePass123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, _, _, _ = uc.LoginUser(ctx, "nobody@example.com", "SecurePass123", "127.0.0.1", "test-agent")
+	_, _, _, _ = uc.LoginUser(ctx, "metrics@example.com", "WrongPass123", "127.0.0.1", "test-agent")
+	_, _, refreshToken, err := uc.LoginUser(ctx, "metrics@example.com", "SecurePass123", "127.0.0.1", "test-agent")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.RefreshToken(ctx, refreshToken); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, _ = uc.RefreshToken(ctx, "unknown-token")
+
+	expected := `
+# HELP user_logins_total Login attempts by result, and by reason when failed.
+# TYPE user_logins_total counter
+user_logins_total{reason="",result="succeeded"} 1
+user_logins_total{reason="invalid_password",result="failed"} 1
+user_logins_total{reason="unknown_email",result="failed"} 1
+# HELP user_registrations_total Users who registered themselves.
+# TYPE user_registrations_total counter
+user_registrations_total 1
+# HELP user_token_refreshes_total Access token refreshes by result, and by reason when failed.
+# TYPE user_token_refreshes_total counter
+user_token_refreshes_total{reason="",result="succeeded"} 1
+user_token_refreshes_total{reason="session_not_found",result="failed"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
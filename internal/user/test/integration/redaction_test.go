@@ -0,0 +1,141 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/infrastructure/logging"
+	"github.com/zercle/template-go-echo/internal/infrastructure/test/sqlitetest"
+	"github.com/zercle/template-go-echo/internal/middleware"
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/internal/user/handler"
+	"github.com/zercle/template-go-echo/internal/user/repository"
+	"github.com/zercle/template-go-echo/internal/user/usecase"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// loggedServer returns an Echo server for the user routes of uc whose
+// request loggers write to buf through the default redaction settings
+func loggedServer(t *testing.T, uc domain.UserUsecase, buf *bytes.Buffer) *echo.Echo {
+	t.Helper()
+	logHandler, err := logging.NewHandler(&config.LogConfig{
+		Level:          "debug",
+		Format:         logging.FormatJSON,
+		RedactMode:     logging.RedactMask,
+		RedactKeys:     []string{"email", "password", "token", "access_token", "refresh_token", "authorization", "secret", "remote_ip", "ip_address"},
+		RedactPatterns: []string{"email", "ipv4", "jwt", "bearer"},
+	}, buf)
+	if err != nil {
+		t.Fatalf("failed to create log handler: %v", err)
+	}
+	logger := slog.New(logHandler)
+
+	e := echo.New()
+	e.HTTPErrorHandler = middleware.ErrorHandler
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(pkg.WithLogger(req.Context(), logger)))
+			return next(c)
+		}
+	})
+	e.Use(middleware.RequestID())
+	e.Use(middleware.RequestContext())
+	e.Use(middleware.RequestLogger())
+	handler.New(uc).RegisterRoutes(e, &config.JWTConfig{Secret: "test-secret", TTL: 3600})
+	return e
+}
+
+// post sends body as JSON to path from a documentation IP address
+func post(e *echo.Echo, path string, body any) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.RemoteAddr = "192.0.2.10:40000"
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestLogsCarryNoPII(t *testing.T) {
+	db := sqlitetest.Open(t)
+	uc := usecase.New(repository.New(db.Querier(), db.DBTX()), 3600,
+		usecase.WithTxManager(db.TxManager()),
+		usecase.WithMetrics(usecase.NewMetrics(prometheus.NewRegistry())),
+	)
+	var logs bytes.Buffer
+	e := loggedServer(t, uc, &logs)
+
+	if rec := post(e, "/api/v1/users/register", map[string]string{"email": "alice@example.com", "name": "Alice", "password": "SecurePass123"}); rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	post(e, "/api/v1/users/register", map[string]string{"email": "alice@example.com", "name": "Alice", "password": "SecurePass123"})
+	post(e, "/api/v1/users/login", map[string]string{"email": "alice@example.com", "password": "WrongPass123"})
+	post(e, "/api/v1/users/login", map[string]string{"email": "nobody@example.com", "password": "SecurePass123"})
+	rec := post(e, "/api/v1/users/login", map[string]string{"email": "alice@example.com", "password": "SecurePass123"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var login struct {
+		Data handler.LoginResponse `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &login); err != nil {
+		t.Fatalf("failed to decode login: %v", err)
+	}
+	post(e, "/api/v1/users/token/refresh", map[string]string{"refresh_token": login.Data.RefreshToken})
+
+	output := logs.String()
+	if !strings.Contains(output, `"msg":"user registered successfully"`) || !strings.Contains(output, `"msg":"HTTP request"`) {
+		t.Fatalf("expected usecase and request logs, got %s", output)
+	}
+	for _, pii := range []string{"alice", "nobody", "SecurePass123", "WrongPass123", "192.0.2.10", login.Data.RefreshToken, login.Data.AccessToken} {
+		if pii != "" && strings.Contains(output, pii) {
+			t.Errorf("expected %q not to be logged", pii)
+		}
+	}
+}
+
+// failingUsecase fails registration with an error of a lower layer
+type failingUsecase struct {
+	domain.UserUsecase
+}
+
+func (failingUsecase) RegisterUser(ctx context.Context, email, name, password string) (*domain.User, error) {
+	return nil, errors.New(`pq: duplicate key value violates unique constraint "users_email_key" (alice@example.com)`)
+}
+
+func TestInternalErrorsAreNotExposed(t *testing.T) {
+	var logs bytes.Buffer
+	e := loggedServer(t, failingUsecase{}, &logs)
+
+	rec := post(e, "/api/v1/users/register", map[string]string{"email": "alice@example.com", "name": "Alice", "password": "SecurePass123"})
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+	var body pkg.JSendResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Message != "Internal server error" || body.Code != pkg.ErrCodeInternalError {
+		t.Errorf("expected a generic internal error, got %+v", body)
+	}
+	if strings.Contains(rec.Body.String(), "pq:") || strings.Contains(rec.Body.String(), "alice") {
+		t.Errorf("expected the error details not to reach the client, got %s", rec.Body.String())
+	}
+
+	// The details are logged, redacted, for operators
+	if !strings.Contains(logs.String(), "users_email_key") || strings.Contains(logs.String(), "alice") {
+		t.Errorf("expected the redacted error logged, got %s", logs.String())
+	}
+}
@@ -0,0 +1,221 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/infrastructure/test/sqlitetest"
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/internal/user/repository"
+	"github.com/zercle/template-go-echo/internal/user/usecase"
+)
+
+// newSQLiteUsecase runs the usecase on the real repository over a migrated SQLite database
+func newSQLiteUsecase(t *testing.T) (*usecase.UserUsecase, *repository.UserRepository) {
+	db := sqlitetest.Open(t)
+	repo := repository.New(db.Querier(), db.DBTX())
+	return usecase.New(repo, 3600, usecase.WithTxManager(db.TxManager())), repo
+}
+
+func TestSQLiteRegisterAndLogin(t *testing.T) {
+	uc, _ := newSQLiteUsecase(t)
+	ctx := context.Background()
+
+	user, err := uc.RegisterUser(ctx, "Test@Example.com", "Test User", "SecurePass123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Emails are unique regardless of case, as with the MySQL collation
+	if _, err := uc.RegisterUser(ctx, "test@example.com", "Other User", "SecurePass123"); err != domain.ErrUserExists {
+		t.Fatalf("expected ErrUserExists, got %v", err)
+	}
+
+	found, err := uc.GetUserByEmail(ctx, "TEST@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if found.ID != user.ID {
+		t.Errorf("expected user %s, got %s", user.ID, found.ID)
+	}
+	if d := found.CreatedAt.Sub(user.CreatedAt); d < -time.Second || d > time.Second {
+		t.Errorf("expected created at near %v, got %v", user.CreatedAt, found.CreatedAt)
+	}
+
+	loggedIn, accessToken, refreshToken, err := uc.LoginUser(ctx, "test@example.com", "SecurePass123", "127.0.0.1", "test-agent")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loggedIn.ID != user.ID || accessToken == "" || refreshToken == "" {
+		t.Fatalf("unexpected login result: %s %q %q", loggedIn.ID, accessToken, refreshToken)
+	}
+
+	if _, err := uc.RefreshToken(ctx, refreshToken); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.LogoutAllSessions(ctx, user.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.RefreshToken(ctx, refreshToken); err == nil {
+		t.Error("expected refresh with a revoked session to fail")
+	}
+}
+
+func TestSQLiteUpdateUserProfileVersionConflict(t *testing.T) {
+	uc, _ := newSQLiteUsecase(t)
+	ctx := context.Background()
+
+	user, err := uc.RegisterUser(ctx, "test@example.com", "Test User", "SecurePass123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updated, err := uc.UpdateUserProfile(ctx, user.ID, "First Admin", "test@example.com", user.Version)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Version != user.Version+1 {
+		t.Errorf("expected version %d, got %d", user.Version+1, updated.Version)
+	}
+
+	if _, err := uc.UpdateUserProfile(ctx, user.ID, "Second Admin", "test@example.com", user.Version); err != domain.ErrVersionConflict {
+		t.Fatalf("expected ErrVersionConflict, got %v", err)
+	}
+}
+
+func TestSQLiteListUsersPage(t *testing.T) {
+	uc, _ := newSQLiteUsecase(t)
+	ctx := context.Background()
+
+	for _, u := range []struct{ email, name string }{
+		{"alice@example.com", "Alice Smith"},
+		{"bob@example.com", "Bob 100% Jones"},
+		{"carol@example.com", "Carol Smith"},
+		{"dave@example.org", "Dave Brown"},
+	} {
+		if _, err := uc.RegisterUser(ctx, u.email, u.name, "SecurePass123"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	page, err := uc.ListUsersPage(ctx, domain.UserListQuery{
+		Filter:       domain.UserFilter{NameContains: "smith"},
+		Sort:         "email",
+		Limit:        1,
+		IncludeTotal: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Email != "alice@example.com" {
+		t.Fatalf("unexpected first page: %+v", page.Items)
+	}
+	if !page.HasMore || page.Total == nil || *page.Total != 2 {
+		t.Fatalf("expected more items and a total of 2, got %v %v", page.HasMore, page.Total)
+	}
+
+	page, err = uc.ListUsersPage(ctx, domain.UserListQuery{
+		Filter: domain.UserFilter{NameContains: "smith"},
+		Sort:   "email",
+		Cursor: page.NextCursor,
+		Limit:  1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Email != "carol@example.com" || page.HasMore {
+		t.Fatalf("unexpected second page: %+v", page.Items)
+	}
+
+	// Wildcards in the filter match literally
+	page, err = uc.ListUsersPage(ctx, domain.UserListQuery{Filter: domain.UserFilter{NameContains: "100%"}, Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Email != "bob@example.com" {
+		t.Fatalf("unexpected wildcard result: %+v", page.Items)
+	}
+
+	page, err = uc.ListUsersPage(ctx, domain.UserListQuery{Filter: domain.UserFilter{EmailPrefix: "DAVE"}, Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Email != "dave@example.org" {
+		t.Fatalf("unexpected prefix result: %+v", page.Items)
+	}
+}
+
+func TestSQLiteDeleteAndRestoreUser(t *testing.T) {
+	uc, repo := newSQLiteUsecase(t)
+	ctx := context.Background()
+
+	user, err := uc.RegisterUser(ctx, "test@example.com", "Test User", "SecurePass123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.DeleteUser(ctx, user.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := uc.GetUser(ctx, user.ID); err != domain.ErrUserNotFound {
+		t.Fatalf("expected ErrUserNotFound, got %v", err)
+	}
+	pending, total, err := uc.ListPendingDeletion(ctx, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 1 || len(pending) != 1 || pending[0].ID != user.ID {
+		t.Fatalf("expected the deleted user pending deletion, got %d %+v", total, pending)
+	}
+
+	if _, err := uc.RestoreUser(ctx, "test@example.com", "SecurePass123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.GetUser(ctx, user.ID); err != nil {
+		t.Fatalf("expected the restored user, got %v", err)
+	}
+
+	if err := uc.DeleteUser(ctx, user.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	purged, err := repo.PurgeDeletedUsers(ctx, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("expected 1 purged user, got %d", purged)
+	}
+}
+
+func TestSQLitePurgeDeletedUser(t *testing.T) {
+	uc, repo := newSQLiteUsecase(t)
+	ctx := context.Background()
+
+	deleted, _ := uc.RegisterUser(ctx, "deleted@example.com", "Deleted", "SecurePass123")
+	active, _ := uc.RegisterUser(ctx, "active@example.com", "Active", "SecurePass123")
+	if err := uc.DeleteUser(ctx, deleted.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Active users and users deleted after the cutoff are never purged
+	if err := repo.PurgeDeletedUser(ctx, active.ID, time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.PurgeDeletedUser(ctx, deleted.ID, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user, _ := repo.GetDeletedUserByEmail(ctx, "deleted@example.com"); user == nil {
+		t.Fatal("expected a user deleted after the cutoff to be kept")
+	}
+	if user, _ := repo.GetUserByEmail(ctx, "active@example.com"); user == nil {
+		t.Fatal("expected an active user to be kept")
+	}
+
+	if err := repo.PurgeDeletedUser(ctx, deleted.ID, time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user, _ := repo.GetDeletedUserByEmail(ctx, "deleted@example.com"); user != nil {
+		t.Error("expected the deleted user to be purged")
+	}
+}
@@ -0,0 +1,95 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/infrastructure/database"
+	"github.com/zercle/template-go-echo/internal/infrastructure/test/sqlitetest"
+	"github.com/zercle/template-go-echo/internal/infrastructure/tracing"
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/internal/user/repository"
+	"github.com/zercle/template-go-echo/internal/user/usecase"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingNestsUsecaseRepositoryAndQuerySpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := tracing.NewProvider(&config.TracingConfig{ServiceName: "test", SampleRatio: 1},
+		sdktrace.WithSpanProcessor(recorder))
+	db := sqlitetest.Open(t,
+		database.WithTracerProvider(provider),
+		database.WithQueryMetrics(database.NewQueryMetrics(prometheus.NewRegistry())),
+	)
+	repo := repository.NewTraced(repository.New(db.Querier(), db.DBTX()), provider)
+	uc := usecase.NewTraced(usecase.New(repo, 3600, usecase.WithMetrics(usecase.NewMetrics(prometheus.NewRegistry()))), provider)
+	ctx := context.Background()
+
+	_, err := uc.RegisterUser(ctx, "traced@example.com", "Traced User", "SecurePass123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+
+	root, ok := spans["UserUsecase.RegisterUser"]
+	if !ok {
+		t.Fatalf("expected a usecase span, got %v", spans)
+	}
+	if root.Parent().IsValid() {
+		t.Error("expected the usecase span to be the root")
+	}
+	create, ok := spans["UserRepository.CreateUser"]
+	if !ok {
+		t.Fatalf("expected a repository span, got %v", spans)
+	}
+	if create.Parent().SpanID() != root.SpanContext().SpanID() {
+		t.Error("expected the repository span to be a child of the usecase span")
+	}
+	query, ok := spans["CreateUser"]
+	if !ok {
+		t.Fatalf("expected a query span, got %v", spans)
+	}
+	if query.Parent().SpanID() != create.SpanContext().SpanID() {
+		t.Error("expected the query span to be a child of the repository span")
+	}
+	for _, span := range []sdktrace.ReadOnlySpan{root, create, query} {
+		if span.SpanContext().TraceID() != root.SpanContext().TraceID() {
+			t.Errorf("expected %s in the trace of the usecase span", span.Name())
+		}
+	}
+
+	// A domain error is recorded with its code without failing the span
+	recorder = tracetest.NewSpanRecorder()
+	provider.RegisterSpanProcessor(recorder)
+	if _, err := uc.GetUser(ctx, "missing"); err == nil {
+		t.Fatal("expected the missing user not to be found")
+	}
+	ended := recorder.Ended()
+	get := ended[len(ended)-1]
+	if get.Name() != "UserUsecase.GetUser" {
+		t.Fatalf("expected the usecase span to end last, got %s", get.Name())
+	}
+	if get.Status().Code == codes.Error {
+		t.Error("expected a domain error not to fail the span")
+	}
+	if got := errorType(get); got != domain.ErrCodeUserNotFound {
+		t.Errorf("expected error.type %s, got %q", domain.ErrCodeUserNotFound, got)
+	}
+}
+
+// errorType returns the error.type attribute of span
+func errorType(span sdktrace.ReadOnlySpan) string {
+	for _, attr := range span.Attributes() {
+		if attr.Key == "error.type" {
+			return attr.Value.AsString()
+		}
+	}
+	return ""
+}
@@ -2,14 +2,19 @@ package mocks
 
 import (
 	"context"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/pkg"
 )
 
 // MockUserRepository is a simple mock for testing
 type MockUserRepository struct {
 	users    map[string]*domain.User
 	sessions map[string]*domain.UserSession
+	events   []pkg.DomainEvent
 }
 
 // NewMockRepository creates a new mock repository
@@ -20,11 +25,46 @@ func NewMockRepository() *MockUserRepository {
 	}
 }
 
-func (m *MockUserRepository) CreateUser(ctx context.Context, user *domain.User) error {
+// Events returns the domain events appended to the outbox, in order
+func (m *MockUserRepository) Events() []pkg.DomainEvent {
+	return m.events
+}
+
+func (m *MockUserRepository) CreateUser(ctx context.Context, user *domain.User, events ...pkg.DomainEvent) error {
+	if m.emailTaken(user.Email) {
+		return domain.ErrUserExists
+	}
 	m.users[user.ID] = user
+	m.events = append(m.events, events...)
+	return nil
+}
+
+func (m *MockUserRepository) CreateUsers(ctx context.Context, users []*domain.User, events ...pkg.DomainEvent) error {
+	seen := make(map[string]bool, len(users))
+	for _, user := range users {
+		email := strings.ToLower(user.Email)
+		if m.emailTaken(user.Email) || seen[email] {
+			return domain.ErrUserExists
+		}
+		seen[email] = true
+	}
+	for _, user := range users {
+		m.users[user.ID] = user
+	}
+	m.events = append(m.events, events...)
 	return nil
 }
 
+// emailTaken mirrors the unique email constraint, which also covers soft deleted rows
+func (m *MockUserRepository) emailTaken(email string) bool {
+	for _, user := range m.users {
+		if strings.EqualFold(user.Email, email) {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *MockUserRepository) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
 	return m.users[id], nil
 }
@@ -38,16 +78,25 @@ func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (
 	return nil, nil
 }
 
-func (m *MockUserRepository) UpdateUser(ctx context.Context, user *domain.User) error {
+func (m *MockUserRepository) UpdateUser(ctx context.Context, user *domain.User, events ...pkg.DomainEvent) error {
+	stored := m.users[user.ID]
+	if stored == nil || stored.IsDeleted() || stored.Version != user.Version {
+		return domain.ErrVersionConflict
+	}
+	user.Version++
 	m.users[user.ID] = user
+	m.events = append(m.events, events...)
 	return nil
 }
 
-func (m *MockUserRepository) DeleteUser(ctx context.Context, id string) error {
+func (m *MockUserRepository) DeleteUser(ctx context.Context, id string, events ...pkg.DomainEvent) error {
 	user := m.users[id]
-	if user != nil {
-		user.DeletedAt = nil // Mock soft delete
+	if user != nil && user.DeletedAt == nil {
+		now := time.Now()
+		user.DeletedAt = &now
+		user.Version++
 	}
+	m.events = append(m.events, events...)
 	return nil
 }
 
@@ -90,13 +139,21 @@ func (m *MockUserRepository) GetSessionsByUserID(ctx context.Context, userID str
 	return sessions, nil
 }
 
-func (m *MockUserRepository) DeleteSession(ctx context.Context, id string) error {
+func (m *MockUserRepository) DeleteSession(ctx context.Context, id string, events ...pkg.DomainEvent) error {
 	delete(m.sessions, id)
+	m.events = append(m.events, events...)
 	return nil
 }
 
-func (m *MockUserRepository) DeleteExpiredSessions(ctx context.Context) error {
-	return nil
+func (m *MockUserRepository) DeleteExpiredSessions(ctx context.Context) (int, error) {
+	deleted := 0
+	for id, session := range m.sessions {
+		if session.IsExpired() {
+			delete(m.sessions, id)
+			deleted++
+		}
+	}
+	return deleted, nil
 }
 
 func (m *MockUserRepository) GetSessionByTokenHash(ctx context.Context, tokenHash string) (*domain.UserSession, error) {
@@ -107,3 +164,210 @@ func (m *MockUserRepository) GetSessionByTokenHash(ctx context.Context, tokenHas
 	}
 	return nil, nil
 }
+
+func (m *MockUserRepository) GetDeletedUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	var latest *domain.User
+	for _, user := range m.users {
+		if user.Email == email && user.IsDeleted() && (latest == nil || user.DeletedAt.After(*latest.DeletedAt)) {
+			latest = user
+		}
+	}
+	return latest, nil
+}
+
+func (m *MockUserRepository) RestoreUser(ctx context.Context, id string) error {
+	if user := m.users[id]; user != nil {
+		user.DeletedAt = nil
+		user.Version++
+	}
+	return nil
+}
+
+func (m *MockUserRepository) ListDeletedUsers(ctx context.Context, limit, offset int) ([]*domain.User, error) {
+	var users []*domain.User
+	for _, user := range m.users {
+		if user.IsDeleted() {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (m *MockUserRepository) GetDeletedUserCount(ctx context.Context) (int, error) {
+	count := 0
+	for _, user := range m.users {
+		if user.IsDeleted() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockUserRepository) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int, error) {
+	purged := 0
+	for id, user := range m.users {
+		if user.IsDeleted() && user.DeletedAt.Before(deletedBefore) {
+			delete(m.users, id)
+			for sessionID, session := range m.sessions {
+				if session.UserID == id {
+					delete(m.sessions, sessionID)
+				}
+			}
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (m *MockUserRepository) PurgeDeletedUser(ctx context.Context, id string, deletedBefore time.Time) error {
+	user, ok := m.users[id]
+	if !ok || !user.IsDeleted() || !user.DeletedAt.Before(deletedBefore) {
+		return nil
+	}
+	delete(m.users, id)
+	for sessionID, session := range m.sessions {
+		if session.UserID == id {
+			delete(m.sessions, sessionID)
+		}
+	}
+	return nil
+}
+
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, id, passwordHash string, resetRequired bool) error {
+	if user := m.users[id]; user != nil {
+		user.PasswordHash = passwordHash
+		user.PasswordResetRequired = resetRequired
+		user.Version++
+	}
+	return nil
+}
+
+func (m *MockUserRepository) UpdateUserRole(ctx context.Context, id, role string) error {
+	if user := m.users[id]; user != nil {
+		user.Role = role
+		user.Version++
+	}
+	return nil
+}
+
+func (m *MockUserRepository) SuspendUser(ctx context.Context, id, reason string, until *time.Time) error {
+	if user := m.users[id]; user != nil {
+		now := time.Now()
+		user.IsActive = false
+		user.SuspendedAt = &now
+		user.SuspendedUntil = until
+		user.SuspensionReason = reason
+		user.Version++
+	}
+	return nil
+}
+
+func (m *MockUserRepository) ReactivateUser(ctx context.Context, id string) error {
+	if user := m.users[id]; user != nil {
+		user.IsActive = true
+		user.SuspendedAt = nil
+		user.SuspendedUntil = nil
+		user.SuspensionReason = ""
+		user.Version++
+	}
+	return nil
+}
+
+func (m *MockUserRepository) DeleteSessionsByUserID(ctx context.Context, userID string, events ...pkg.DomainEvent) (int, error) {
+	deleted := 0
+	for id, session := range m.sessions {
+		if session.UserID == userID {
+			delete(m.sessions, id)
+			deleted++
+		}
+	}
+	m.events = append(m.events, events...)
+	return deleted, nil
+}
+
+func (m *MockUserRepository) ListUsersPage(ctx context.Context, filter domain.UserFilter, order pkg.Sort, after *domain.UserCursor, limit int) ([]*domain.User, error) {
+	var users []*domain.User
+	for _, user := range m.users {
+		if !user.IsDeleted() && matchesFilter(user, filter) {
+			users = append(users, user)
+		}
+	}
+
+	// Compare users by the sort value, breaking ties by ID
+	less := func(a, b domain.UserCursor) bool {
+		if a.Value != b.Value {
+			return a.Value < b.Value
+		}
+		return a.ID < b.ID
+	}
+	sort.Slice(users, func(i, j int) bool {
+		a, b := domain.NewUserCursor(users[i], order), domain.NewUserCursor(users[j], order)
+		if order.Direction == pkg.SortDesc {
+			return less(b, a)
+		}
+		return less(a, b)
+	})
+
+	var page []*domain.User
+	for _, user := range users {
+		if after != nil {
+			position := domain.NewUserCursor(user, order)
+			if order.Direction == pkg.SortDesc && !less(position, *after) {
+				continue
+			}
+			if order.Direction == pkg.SortAsc && !less(*after, position) {
+				continue
+			}
+		}
+		if len(page) == limit {
+			break
+		}
+		page = append(page, user)
+	}
+	return page, nil
+}
+
+func (m *MockUserRepository) StreamUsers(ctx context.Context, filter domain.UserFilter, order pkg.Sort, fn func(*domain.User) error) error {
+	users, err := m.ListUsersPage(ctx, filter, order, nil, len(m.users))
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockUserRepository) CountUsers(ctx context.Context, filter domain.UserFilter) (int, error) {
+	count := 0
+	for _, user := range m.users {
+		if !user.IsDeleted() && matchesFilter(user, filter) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func matchesFilter(user *domain.User, filter domain.UserFilter) bool {
+	if filter.EmailPrefix != "" && !strings.HasPrefix(user.Email, filter.EmailPrefix) {
+		return false
+	}
+	if filter.NameContains != "" && !strings.Contains(user.Name, filter.NameContains) {
+		return false
+	}
+	if filter.IsActive != nil && user.IsActive != *filter.IsActive {
+		return false
+	}
+	if filter.CreatedAfter != nil && user.CreatedAt.Before(*filter.CreatedAfter) {
+		return false
+	}
+	if filter.CreatedBefore != nil && !user.CreatedAt.Before(*filter.CreatedBefore) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,18 @@
+package mocks
+
+import "context"
+
+// MockTxManager runs units of work directly, counting them and recording
+// whether their last error would have rolled the transaction back
+type MockTxManager struct {
+	Calls      int
+	RolledBack bool
+}
+
+// WithinTx runs fn and records its outcome
+func (m *MockTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.Calls++
+	err := fn(ctx)
+	m.RolledBack = err != nil
+	return err
+}
@@ -42,3 +42,27 @@ func TestUserSessionIsExpired(t *testing.T) {
 		t.Error("expected session to be expired")
 	}
 }
+
+func TestUserIsRestorable(t *testing.T) {
+	user := &domain.User{ID: "user-1"}
+	if user.IsRestorable(time.Hour) {
+		t.Error("expected active user to not be restorable")
+	}
+
+	// Deleted within grace period
+	recent := time.Now().Add(-30 * time.Minute)
+	user.DeletedAt = &recent
+	if !user.IsRestorable(time.Hour) {
+		t.Error("expected recently deleted user to be restorable")
+	}
+	if !user.PurgeAt(time.Hour).Equal(recent.Add(time.Hour)) {
+		t.Error("expected purge time to be deletion time plus grace period")
+	}
+
+	// Deleted before grace period
+	old := time.Now().Add(-2 * time.Hour)
+	user.DeletedAt = &old
+	if user.IsRestorable(time.Hour) {
+		t.Error("expected user past grace period to not be restorable")
+	}
+}
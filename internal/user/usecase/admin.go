@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	auditdomain "github.com/zercle/template-go-echo/internal/audit/domain"
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// AdminCreateUser creates a user with the given role on behalf of an administrator
+func (u *UserUsecase) AdminCreateUser(ctx context.Context, email, name, password, role string) (*domain.User, error) {
+	if role == "" {
+		role = domain.RoleUser
+	}
+	if !domain.IsValidRole(role) {
+		return nil, domain.ErrInvalidRole
+	}
+
+	user, err := u.createUser(ctx, email, name, password, role)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg.Logger(ctx).InfoContext(ctx, "user created by admin", slog.String("user_id", user.ID), slog.String("role", role))
+	u.record(ctx, auditdomain.ActionUserCreated, "", user.ID, map[string]interface{}{"role": role})
+	return user, nil
+}
+
+// SuspendUser suspends an account with a reason and optional expiry, revoking its sessions
+func (u *UserUsecase) SuspendUser(ctx context.Context, actorID, id, reason string, until *time.Time) (*domain.User, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len(reason) > domain.MaxSuspensionReasonLength {
+		return nil, domain.ErrInvalidSuspension
+	}
+	if until != nil && !until.After(time.Now()) {
+		return nil, domain.ErrInvalidSuspension
+	}
+	if actorID == id {
+		return nil, domain.ErrSelfModification
+	}
+
+	user, err := u.repo.GetUserByID(ctx, id)
+	if err != nil || user == nil || user.IsDeleted() {
+		return nil, domain.ErrUserNotFound
+	}
+
+	// Suspend the user and revoke its sessions together
+	err = u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.repo.SuspendUser(ctx, id, reason, until); err != nil {
+			return err
+		}
+		_, err := u.revokeSessions(ctx, id, domain.RevokeReasonSuspended)
+		return err
+	})
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to suspend user", slog.String("error", err.Error()))
+		return nil, pkg.ErrInternalError
+	}
+
+	now := time.Now()
+	user.IsActive = false
+	user.SuspendedAt = &now
+	user.SuspendedUntil = until
+	user.SuspensionReason = reason
+	user.UpdatedAt = now
+
+	pkg.Logger(ctx).InfoContext(ctx, "user suspended", slog.String("user_id", id), slog.String("actor_id", actorID))
+	u.record(ctx, auditdomain.ActionUserSuspended, actorID, id, map[string]interface{}{"reason": reason, "until": until})
+	return user, nil
+}
+
+// ReactivateUser lifts the suspension of an account
+func (u *UserUsecase) ReactivateUser(ctx context.Context, id string) (*domain.User, error) {
+	user, err := u.repo.GetUserByID(ctx, id)
+	if err != nil || user == nil || user.IsDeleted() {
+		return nil, domain.ErrUserNotFound
+	}
+
+	if err := u.repo.ReactivateUser(ctx, id); err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to reactivate user", slog.String("error", err.Error()))
+		return nil, pkg.ErrInternalError
+	}
+
+	user.IsActive = true
+	user.SuspendedAt = nil
+	user.SuspendedUntil = nil
+	user.SuspensionReason = ""
+	user.UpdatedAt = time.Now()
+
+	pkg.Logger(ctx).InfoContext(ctx, "user reactivated", slog.String("user_id", id))
+	u.record(ctx, auditdomain.ActionUserReactivated, "", id, nil)
+	return user, nil
+}
+
+// ForcePasswordReset requires a password change and revokes all sessions of an account
+func (u *UserUsecase) ForcePasswordReset(ctx context.Context, id string) error {
+	user, err := u.repo.GetUserByID(ctx, id)
+	if err != nil || user == nil || user.IsDeleted() {
+		return domain.ErrUserNotFound
+	}
+
+	// Flag the reset and revoke the sessions together
+	err = u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.repo.UpdatePassword(ctx, id, user.PasswordHash, true); err != nil {
+			return err
+		}
+		_, err := u.revokeSessions(ctx, id, domain.RevokeReasonPasswordReset)
+		return err
+	})
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to force password reset", slog.String("error", err.Error()))
+		return pkg.ErrInternalError
+	}
+
+	pkg.Logger(ctx).InfoContext(ctx, "password reset forced", slog.String("user_id", id))
+	u.record(ctx, auditdomain.ActionUserPasswordResetForced, "", id, nil)
+	return nil
+}
+
+// RevokeSessions revokes all sessions of an account and returns how many were removed
+func (u *UserUsecase) RevokeSessions(ctx context.Context, id string) (int, error) {
+	user, err := u.repo.GetUserByID(ctx, id)
+	if err != nil || user == nil || user.IsDeleted() {
+		return 0, domain.ErrUserNotFound
+	}
+
+	revoked, err := u.revokeSessions(ctx, id, domain.RevokeReasonAdmin)
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to revoke sessions", slog.String("error", err.Error()))
+		return 0, pkg.ErrInternalError
+	}
+
+	pkg.Logger(ctx).InfoContext(ctx, "sessions revoked", slog.String("user_id", id), slog.Int("count", revoked))
+	u.record(ctx, auditdomain.ActionUserSessionsRevoked, "", id, map[string]interface{}{"count": revoked})
+	return revoked, nil
+}
+
+// revokeSessions deletes all sessions of a user, publishing a SessionRevoked
+// event for each, and returns how many were removed
+func (u *UserUsecase) revokeSessions(ctx context.Context, userID, reason string) (int, error) {
+	sessions, err := u.repo.GetSessionsByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	events := make([]pkg.DomainEvent, len(sessions))
+	for i, session := range sessions {
+		events[i] = domain.NewSessionRevoked(session, reason)
+	}
+
+	return u.repo.DeleteSessionsByUserID(ctx, userID, events...)
+}
+
+// SetUserRole changes the role of an account
+func (u *UserUsecase) SetUserRole(ctx context.Context, actorID, id, role string) (*domain.User, error) {
+	if !domain.IsValidRole(role) {
+		return nil, domain.ErrInvalidRole
+	}
+	if actorID == id && role != domain.RoleAdmin {
+		return nil, domain.ErrSelfModification
+	}
+
+	user, err := u.repo.GetUserByID(ctx, id)
+	if err != nil || user == nil || user.IsDeleted() {
+		return nil, domain.ErrUserNotFound
+	}
+	previousRole := user.Role
+
+	if err := u.repo.UpdateUserRole(ctx, id, role); err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to update user role", slog.String("error", err.Error()))
+		return nil, pkg.ErrInternalError
+	}
+
+	user.Role = role
+	user.UpdatedAt = time.Now()
+
+	pkg.Logger(ctx).InfoContext(ctx, "user role changed", slog.String("user_id", id), slog.String("role", role))
+	u.record(ctx, auditdomain.ActionUserRoleChanged, actorID, id, map[string]interface{}{"from": previousRole, "to": role})
+	return user, nil
+}
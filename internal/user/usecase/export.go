@@ -0,0 +1,353 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	auditdomain "github.com/zercle/template-go-echo/internal/audit/domain"
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// exportFlushInterval is the number of rows written between flushes to the client
+const exportFlushInterval = 500
+
+// ExportUsers streams the users and columns selected by query to w and returns the row
+// count. Rows are read from a database cursor and flushed periodically, so memory use does
+// not grow with the export. The query is validated before anything is written to w.
+func (u *UserUsecase) ExportUsers(ctx context.Context, w io.Writer, format domain.ExportFormat, query domain.UserExportQuery) (int, error) {
+	sort, err := pkg.ParseSort(query.Sort, domain.UserSortFields, domain.DefaultUserSort)
+	if err != nil {
+		return 0, err
+	}
+
+	columns, err := resolveExportColumns(query.Columns)
+	if err != nil {
+		return 0, err
+	}
+
+	encoder, err := newExportEncoder(w, format, columns)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err = u.repo.StreamUsers(ctx, query.Filter, sort, func(user *domain.User) error {
+		if format == domain.ExportFormatXLSX && count >= domain.MaxXLSXExportRows {
+			return domain.ErrExportTooLarge
+		}
+		if err := encoder.Write(exportValues(user, columns)); err != nil {
+			return err
+		}
+		count++
+		if count%exportFlushInterval == 0 {
+			return encoder.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		encoder.Discard()
+		pkg.Logger(ctx).WarnContext(ctx, "user export aborted", slog.Int("rows", count), slog.String("error", err.Error()))
+		return count, err
+	}
+
+	if err := encoder.Close(); err != nil {
+		pkg.Logger(ctx).WarnContext(ctx, "user export aborted", slog.Int("rows", count), slog.String("error", err.Error()))
+		return count, err
+	}
+
+	pkg.Logger(ctx).InfoContext(ctx, "users exported", slog.String("format", string(format)), slog.Int("rows", count))
+	u.record(ctx, auditdomain.ActionUsersExported, "", "", map[string]interface{}{
+		"format":  format,
+		"columns": columns,
+		"rows":    count,
+	})
+	return count, nil
+}
+
+// resolveExportColumns validates requested columns, defaulting to the non-sensitive ones
+func resolveExportColumns(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return domain.DefaultExportColumns(), nil
+	}
+
+	known := make(map[string]bool, len(domain.ExportColumns))
+	for _, column := range domain.ExportColumns {
+		known[column] = true
+	}
+
+	columns := make([]string, 0, len(requested))
+	seen := make(map[string]bool, len(requested))
+	for _, column := range requested {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !known[column] || seen[column] {
+			return nil, domain.ErrInvalidExport
+		}
+		seen[column] = true
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// exportValues extracts the selected columns of a user; times are RFC3339 in UTC
+// and unset optional values are nil
+func exportValues(user *domain.User, columns []string) []interface{} {
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		switch column {
+		case domain.ExportColumnID:
+			values[i] = user.ID
+		case domain.ExportColumnEmail:
+			values[i] = user.Email
+		case domain.ExportColumnName:
+			values[i] = user.Name
+		case domain.ExportColumnRole:
+			values[i] = user.Role
+		case domain.ExportColumnIsActive:
+			values[i] = user.IsActive
+		case domain.ExportColumnPasswordResetRequired:
+			values[i] = user.PasswordResetRequired
+		case domain.ExportColumnSuspendedAt:
+			values[i] = exportTime(user.SuspendedAt)
+		case domain.ExportColumnSuspendedUntil:
+			values[i] = exportTime(user.SuspendedUntil)
+		case domain.ExportColumnSuspensionReason:
+			if user.SuspensionReason != "" {
+				values[i] = user.SuspensionReason
+			}
+		case domain.ExportColumnPasswordHash:
+			values[i] = user.PasswordHash
+		case domain.ExportColumnCreatedAt:
+			values[i] = exportTime(&user.CreatedAt)
+		case domain.ExportColumnUpdatedAt:
+			values[i] = exportTime(&user.UpdatedAt)
+		}
+	}
+	return values
+}
+
+// exportTime formats an optional timestamp for export
+func exportTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// exportEncoder writes export rows in a specific format
+type exportEncoder interface {
+	// Write encodes a single row
+	Write(values []interface{}) error
+
+	// Flush pushes buffered rows to the client where the format allows it
+	Flush() error
+
+	// Close writes any trailing data and flushes
+	Close() error
+
+	// Discard releases resources of an abandoned export without writing more data
+	Discard()
+}
+
+// newExportEncoder creates an encoder for format and writes the header where the format has one
+func newExportEncoder(w io.Writer, format domain.ExportFormat, columns []string) (exportEncoder, error) {
+	switch format {
+	case domain.ExportFormatCSV:
+		return newCSVExportEncoder(w, columns)
+	case domain.ExportFormatJSONL:
+		return newJSONLExportEncoder(w, columns), nil
+	case domain.ExportFormatXLSX:
+		return newXLSXExportEncoder(w, columns)
+	default:
+		return nil, domain.ErrInvalidExport
+	}
+}
+
+// flushWriter flushes w when it is an HTTP response or another flusher
+func flushWriter(w io.Writer) {
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// csvExportEncoder writes RFC 4180 CSV with a header row
+type csvExportEncoder struct {
+	dst    io.Writer
+	writer *csv.Writer
+	record []string
+}
+
+func newCSVExportEncoder(w io.Writer, columns []string) (*csvExportEncoder, error) {
+	e := &csvExportEncoder{dst: w, writer: csv.NewWriter(w), record: make([]string, len(columns))}
+	if err := e.writer.Write(columns); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// csvFormulaPrefixes start cells that spreadsheet applications evaluate as formulas
+const csvFormulaPrefixes = "=+-@\t\r"
+
+func (e *csvExportEncoder) Write(values []interface{}) error {
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+			e.record[i] = ""
+		case bool:
+			e.record[i] = strconv.FormatBool(v)
+		case string:
+			// Neutralise user supplied values that would run as formulas when opened
+			if v != "" && strings.ContainsRune(csvFormulaPrefixes, rune(v[0])) {
+				v = "'" + v
+			}
+			e.record[i] = v
+		}
+	}
+	return e.writer.Write(e.record)
+}
+
+func (e *csvExportEncoder) Flush() error {
+	e.writer.Flush()
+	if err := e.writer.Error(); err != nil {
+		return err
+	}
+	flushWriter(e.dst)
+	return nil
+}
+
+func (e *csvExportEncoder) Close() error {
+	return e.Flush()
+}
+
+func (e *csvExportEncoder) Discard() {}
+
+// jsonlExportEncoder writes one JSON object per line with keys in column order
+type jsonlExportEncoder struct {
+	dst     io.Writer
+	writer  *bufio.Writer
+	keys    [][]byte
+	scratch bytes.Buffer
+}
+
+func newJSONLExportEncoder(w io.Writer, columns []string) *jsonlExportEncoder {
+	keys := make([][]byte, len(columns))
+	for i, column := range columns {
+		key, _ := json.Marshal(column)
+		keys[i] = append(key, ':')
+	}
+	return &jsonlExportEncoder{dst: w, writer: bufio.NewWriter(w), keys: keys}
+}
+
+func (e *jsonlExportEncoder) Write(values []interface{}) error {
+	e.scratch.Reset()
+	e.scratch.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			e.scratch.WriteByte(',')
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		e.scratch.Write(e.keys[i])
+		e.scratch.Write(encoded)
+	}
+	e.scratch.WriteString("}\n")
+
+	_, err := e.writer.Write(e.scratch.Bytes())
+	return err
+}
+
+func (e *jsonlExportEncoder) Flush() error {
+	if err := e.writer.Flush(); err != nil {
+		return err
+	}
+	flushWriter(e.dst)
+	return nil
+}
+
+func (e *jsonlExportEncoder) Close() error {
+	return e.Flush()
+}
+
+func (e *jsonlExportEncoder) Discard() {}
+
+// xlsxExportEncoder writes a single worksheet workbook. The workbook is a zip
+// archive that can only be written once complete, so rows are spooled by the
+// excelize stream writer (to a temporary file beyond its memory threshold) and
+// sent to the client on Close.
+type xlsxExportEncoder struct {
+	dst    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+// xlsxSheetName is the name of the exported worksheet
+const xlsxSheetName = "Users"
+
+func newXLSXExportEncoder(w io.Writer, columns []string) (*xlsxExportEncoder, error) {
+	file := excelize.NewFile()
+	if err := file.SetSheetName("Sheet1", xlsxSheetName); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	stream, err := file.NewStreamWriter(xlsxSheetName)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	e := &xlsxExportEncoder{dst: w, file: file, stream: stream}
+	header := make([]interface{}, len(columns))
+	for i, column := range columns {
+		header[i] = column
+	}
+	if err := e.Write(header); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *xlsxExportEncoder) Write(values []interface{}) error {
+	e.row++
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+	return e.stream.SetRow(cell, values)
+}
+
+// Flush is a no-op because the workbook cannot be sent until it is complete
+func (e *xlsxExportEncoder) Flush() error {
+	return nil
+}
+
+func (e *xlsxExportEncoder) Close() error {
+	defer func() { _ = e.file.Close() }()
+
+	if err := e.stream.Flush(); err != nil {
+		return err
+	}
+	if _, err := e.file.WriteTo(e.dst); err != nil {
+		return err
+	}
+	flushWriter(e.dst)
+	return nil
+}
+
+func (e *xlsxExportEncoder) Discard() {
+	_ = e.file.Close()
+}
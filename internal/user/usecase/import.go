@@ -0,0 +1,409 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	auditdomain "github.com/zercle/template-go-echo/internal/audit/domain"
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/pkg"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ImportUsers creates users from a CSV or JSONL stream and reports the outcome of every row.
+// Rows are validated with the registration rules and inserted in batched transactions; a
+// failed batch is retried row by row so that one bad row does not reject its neighbours.
+func (u *UserUsecase) ImportUsers(ctx context.Context, r io.Reader, format domain.ImportFormat, opts domain.ImportOptions) (*domain.ImportReport, error) {
+	next, err := newImportReader(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = domain.DefaultImportBatchSize
+	}
+	if batchSize > domain.MaxImportBatchSize {
+		batchSize = domain.MaxImportBatchSize
+	}
+
+	imp := &userImport{
+		uc:        u,
+		dryRun:    opts.DryRun,
+		batchSize: batchSize,
+		seen:      make(map[string]int),
+		report:    &domain.ImportReport{DryRun: opts.DryRun, Rows: []domain.ImportRowResult{}},
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		row, rowErr, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			pkg.Logger(ctx).WarnContext(ctx, "user import aborted: unreadable input", slog.String("error", err.Error()))
+			return nil, domain.ErrInvalidImport
+		}
+
+		if rowErr != nil {
+			imp.fail(imp.addRow(row), rowErr)
+			continue
+		}
+		imp.add(ctx, row)
+	}
+	imp.flush(ctx)
+
+	pkg.Logger(ctx).InfoContext(ctx, "user import finished",
+		slog.Bool("dry_run", opts.DryRun),
+		slog.Int("total", imp.report.Total),
+		slog.Int("created", imp.report.Created),
+		slog.Int("failed", imp.report.Failed),
+	)
+	u.record(ctx, auditdomain.ActionUsersImported, "", "", map[string]interface{}{
+		"dry_run": opts.DryRun,
+		"total":   imp.report.Total,
+		"created": imp.report.Created,
+		"failed":  imp.report.Failed,
+	})
+	return imp.report, nil
+}
+
+// userImport accumulates the state of a single import run
+type userImport struct {
+	uc        *UserUsecase
+	dryRun    bool
+	batchSize int
+	seen      map[string]int
+	pending   []pendingImport
+	report    *domain.ImportReport
+}
+
+// pendingImport is a validated row waiting for its batch to be written
+type pendingImport struct {
+	result            int
+	user              *domain.User
+	temporaryPassword string
+	expiredUserID     string
+}
+
+// addRow appends a report entry for a row and returns its index
+func (imp *userImport) addRow(row domain.ImportRow) int {
+	imp.report.Total++
+	imp.report.Rows = append(imp.report.Rows, domain.ImportRowResult{
+		Line:  row.Line,
+		Email: strings.TrimSpace(row.Email),
+	})
+	return len(imp.report.Rows) - 1
+}
+
+// fail marks a report entry as failed with the given error
+func (imp *userImport) fail(result int, err error) {
+	domainErr, ok := err.(*pkg.DomainError)
+	if !ok {
+		domainErr = pkg.ErrInternalError
+	}
+
+	imp.report.Failed++
+	imp.report.Rows[result].Status = domain.ImportStatusFailed
+	imp.report.Rows[result].ErrorCode = domainErr.Code
+	imp.report.Rows[result].Error = domainErr.Message
+}
+
+// add validates a row and queues it for the next batch
+func (imp *userImport) add(ctx context.Context, row domain.ImportRow) {
+	result := imp.addRow(row)
+
+	p, err := imp.prepare(ctx, row)
+	if err != nil {
+		imp.fail(result, err)
+		return
+	}
+	p.result = result
+	imp.seen[strings.ToLower(p.user.Email)] = row.Line
+
+	if imp.dryRun {
+		imp.report.Valid++
+		imp.report.Rows[result].Status = domain.ImportStatusValid
+		return
+	}
+
+	imp.pending = append(imp.pending, p)
+	if len(imp.pending) >= imp.batchSize {
+		imp.flush(ctx)
+	}
+}
+
+// prepare validates a row and builds the user to insert, generating a
+// temporary password when no hash is preset. Without an invite sender nobody
+// could learn a generated password, so such rows are rejected.
+func (imp *userImport) prepare(ctx context.Context, row domain.ImportRow) (pendingImport, error) {
+	email := strings.TrimSpace(row.Email)
+	name := strings.TrimSpace(row.Name)
+	if err := validateNewUser(email, name); err != nil {
+		return pendingImport{}, err
+	}
+
+	role := strings.TrimSpace(row.Role)
+	if role == "" {
+		role = domain.RoleUser
+	}
+	if !domain.IsValidRole(role) {
+		return pendingImport{}, domain.ErrInvalidRole
+	}
+
+	if _, ok := imp.seen[strings.ToLower(email)]; ok {
+		return pendingImport{}, domain.ErrDuplicateImportRow
+	}
+	expiredUserID, err := imp.uc.checkEmailAvailable(ctx, email)
+	if err != nil {
+		return pendingImport{}, err
+	}
+
+	now := time.Now()
+	user := &domain.User{
+		ID:        uuid.New().String(),
+		Email:     email,
+		Name:      name,
+		IsActive:  true,
+		Role:      role,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Version:   domain.InitialVersion,
+	}
+
+	if row.PasswordHash != "" {
+		if _, err := bcrypt.Cost([]byte(row.PasswordHash)); err != nil {
+			return pendingImport{}, domain.ErrInvalidPasswordHash
+		}
+		user.PasswordHash = row.PasswordHash
+		return pendingImport{user: user, expiredUserID: expiredUserID}, nil
+	}
+
+	if imp.uc.inviteSender == nil {
+		return pendingImport{}, domain.ErrPasswordHashRequired
+	}
+
+	// Dry runs never persist users, so skip generating a password
+	user.PasswordResetRequired = true
+	if imp.dryRun {
+		return pendingImport{user: user}, nil
+	}
+
+	temporaryPassword, passwordHash, err := generateTemporaryPassword()
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to generate temporary password", slog.String("error", err.Error()))
+		return pendingImport{}, pkg.ErrInternalError
+	}
+	user.PasswordHash = passwordHash
+	return pendingImport{user: user, temporaryPassword: temporaryPassword, expiredUserID: expiredUserID}, nil
+}
+
+// flush writes the queued rows in one transaction, falling back to
+// individual inserts when the batch is rejected
+func (imp *userImport) flush(ctx context.Context) {
+	// Free emails held by accounts past their deletion grace period
+	pending := imp.pending[:0]
+	for _, p := range imp.pending {
+		if p.expiredUserID != "" {
+			if err := imp.uc.purgeExpiredUser(ctx, p.expiredUserID); err != nil {
+				imp.fail(p.result, err)
+				continue
+			}
+		}
+		pending = append(pending, p)
+	}
+	imp.pending = pending
+	if len(imp.pending) == 0 {
+		return
+	}
+
+	users := make([]*domain.User, len(imp.pending))
+	events := make([]pkg.DomainEvent, len(imp.pending))
+	for i, p := range imp.pending {
+		users[i] = p.user
+		events[i] = domain.NewUserRegistered(p.user)
+	}
+
+	if err := imp.uc.repo.CreateUsers(ctx, users, events...); err != nil {
+		pkg.Logger(ctx).WarnContext(ctx, "user import batch rejected, retrying rows individually",
+			slog.Int("rows", len(users)),
+			slog.String("error", err.Error()),
+		)
+		for _, p := range imp.pending {
+			if err := imp.uc.repo.CreateUser(ctx, p.user, domain.NewUserRegistered(p.user)); err != nil {
+				imp.fail(p.result, err)
+				continue
+			}
+			imp.created(ctx, p)
+		}
+	} else {
+		for _, p := range imp.pending {
+			imp.created(ctx, p)
+		}
+	}
+
+	imp.pending = imp.pending[:0]
+}
+
+// created records a persisted row and invites users with generated passwords.
+// A user whose invite failed cannot log in, so the row is reported as failed
+// while keeping the ID of the user that was created.
+func (imp *userImport) created(ctx context.Context, p pendingImport) {
+	result := &imp.report.Rows[p.result]
+	result.UserID = p.user.ID
+
+	if p.temporaryPassword != "" {
+		if err := imp.uc.inviteSender.SendPasswordInvite(ctx, p.user, p.temporaryPassword); err != nil {
+			pkg.Logger(ctx).ErrorContext(ctx, "failed to send password invite", slog.String("user_id", p.user.ID), slog.String("error", err.Error()))
+			imp.fail(p.result, domain.ErrInviteFailed)
+			return
+		}
+		result.Invited = true
+	}
+
+	imp.report.Created++
+	result.Status = domain.ImportStatusCreated
+}
+
+// generateTemporaryPassword returns a random password and its bcrypt hash. The
+// password carries 144 bits of entropy and must be changed on first login, so
+// the minimum bcrypt cost keeps large imports fast without weakening it.
+func generateTemporaryPassword() (string, string, error) {
+	raw := make([]byte, domain.GeneratedPasswordLength*3/4)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	password := base64.RawURLEncoding.EncodeToString(raw)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		return "", "", err
+	}
+	return password, string(hash), nil
+}
+
+// importReader yields decoded rows until io.EOF. Malformed rows are reported
+// through the row error so that the import can continue past them.
+type importReader func() (row domain.ImportRow, rowErr error, err error)
+
+// newImportReader creates a streaming reader for the given format
+func newImportReader(r io.Reader, format domain.ImportFormat) (importReader, error) {
+	switch format {
+	case domain.ImportFormatCSV:
+		return newCSVImportReader(r)
+	case domain.ImportFormatJSONL:
+		return newJSONLImportReader(r), nil
+	default:
+		return nil, domain.ErrInvalidImport
+	}
+}
+
+// newCSVImportReader reads a CSV stream whose header names the email, name,
+// role and password_hash columns in any order
+func newCSVImportReader(r io.Reader) (importReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, domain.ErrInvalidImport
+	}
+
+	columns := make([]string, len(header))
+	present := make(map[string]bool, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		switch name {
+		case "email", "name", "role", "password_hash":
+		default:
+			return nil, domain.ErrInvalidImport
+		}
+		if present[name] {
+			return nil, domain.ErrInvalidImport
+		}
+		columns[i] = name
+		present[name] = true
+	}
+	if !present["email"] || !present["name"] {
+		return nil, domain.ErrInvalidImport
+	}
+
+	return func() (domain.ImportRow, error, error) {
+		record, err := reader.Read()
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return domain.ImportRow{Line: parseErr.StartLine}, domain.ErrInvalidImportRow, nil
+			}
+			return domain.ImportRow{}, nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := domain.ImportRow{Line: line}
+		if len(record) != len(columns) {
+			return row, domain.ErrInvalidImportRow, nil
+		}
+
+		for i, value := range record {
+			switch columns[i] {
+			case "email":
+				row.Email = value
+			case "name":
+				row.Name = value
+			case "role":
+				row.Role = value
+			case "password_hash":
+				row.PasswordHash = value
+			}
+		}
+		return row, nil, nil
+	}, nil
+}
+
+// newJSONLImportReader reads one JSON object per line, skipping blank lines
+func newJSONLImportReader(r io.Reader) importReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), domain.MaxImportLineBytes)
+	line := 0
+
+	return func() (domain.ImportRow, error, error) {
+		for scanner.Scan() {
+			line++
+			data := bytes.TrimSpace(scanner.Bytes())
+			if len(data) == 0 {
+				continue
+			}
+
+			row := domain.ImportRow{}
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&row); err != nil || decoder.More() {
+				return domain.ImportRow{Line: line}, domain.ErrInvalidImportRow, nil
+			}
+			row.Line = line
+			return row, nil, nil
+		}
+
+		if err := scanner.Err(); err != nil {
+			return domain.ImportRow{}, nil, err
+		}
+		return domain.ImportRow{}, nil, io.EOF
+	}
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	jobsdomain "github.com/zercle/template-go-echo/internal/jobs/domain"
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// PasswordInviteJob emails an imported user a temporary password
+var PasswordInviteJob = jobsdomain.NewType[PasswordInvite]("user.send_password_invite")
+
+// PasswordInvite is the payload of PasswordInviteJob. It holds no password:
+// the job issues a new temporary password when it runs, so that none is ever
+// stored in the queue.
+type PasswordInvite struct {
+	UserID string `json:"user_id"`
+}
+
+// QueuedInviteSender implements domain.InviteSender by enqueueing a
+// PasswordInviteJob, so that invites are retried by the job worker when the
+// mail relay is unavailable
+type QueuedInviteSender struct {
+	queue jobsdomain.Enqueuer
+}
+
+// NewQueuedInviteSender creates an invite sender enqueueing on queue
+func NewQueuedInviteSender(queue jobsdomain.Enqueuer) *QueuedInviteSender {
+	return &QueuedInviteSender{queue: queue}
+}
+
+// SendPasswordInvite queues an invite for user. The temporary password is
+// dropped; the job replaces it with one only the email carries.
+func (s *QueuedInviteSender) SendPasswordInvite(ctx context.Context, user *domain.User, _ string) error {
+	_, err := PasswordInviteJob.Enqueue(ctx, s.queue, PasswordInvite{UserID: user.ID})
+	return err
+}
+
+// PasswordInviteHandler returns the handler of PasswordInviteJob. It sets a
+// new temporary password, still to be reset on first login, and emails it
+// with mailer. Users that were deleted or no longer need a reset are skipped.
+func PasswordInviteHandler(repo domain.UserRepository, mailer domain.Mailer) jobsdomain.Handler {
+	return PasswordInviteJob.Handler(func(ctx context.Context, invite PasswordInvite) error {
+		user, err := repo.GetUserByID(ctx, invite.UserID)
+		if err != nil {
+			return err
+		}
+		if user == nil || user.IsDeleted() || !user.PasswordResetRequired {
+			pkg.Logger(ctx).InfoContext(ctx, "password invite skipped", slog.String("user_id", invite.UserID))
+			return nil
+		}
+
+		temporaryPassword, passwordHash, err := generateTemporaryPassword()
+		if err != nil {
+			return err
+		}
+		if err := repo.UpdatePassword(ctx, user.ID, passwordHash, true); err != nil {
+			return err
+		}
+
+		body := fmt.Sprintf("Hello %s,\n\n"+
+			"An account was created for you. Log in with this temporary password,\n"+
+			"which you will be asked to change right away:\n\n"+
+			"    %s\n", user.Name, temporaryPassword)
+		if err := mailer.Send(ctx, user.Email, "Your new account", body); err != nil {
+			return err
+		}
+
+		pkg.Logger(ctx).InfoContext(ctx, "password invite sent", slog.String("user_id", user.ID))
+		return nil
+	})
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	schedulerdomain "github.com/zercle/template-go-echo/internal/scheduler/domain"
+	"github.com/zercle/template-go-echo/internal/user/domain"
+)
+
+// Names of the user module maintenance jobs
+const (
+	SessionCleanupJobName = "user.session_cleanup"
+	AccountPurgeJobName   = "user.account_purge"
+)
+
+// ScheduledJobs returns the maintenance jobs of the user module: deleting
+// expired sessions and purging accounts past their deletion grace period
+func ScheduledJobs(uc domain.UserUsecase, sessionCleanupSchedule, accountPurgeSchedule string) []schedulerdomain.Job {
+	return []schedulerdomain.Job{
+		{
+			Name:     SessionCleanupJobName,
+			Schedule: sessionCleanupSchedule,
+			Jitter:   30 * time.Second,
+			Timeout:  5 * time.Minute,
+			Run: func(ctx context.Context) error {
+				_, err := uc.CleanupExpiredSessions(ctx)
+				return err
+			},
+		},
+		{
+			Name:     AccountPurgeJobName,
+			Schedule: accountPurgeSchedule,
+			Jitter:   time.Minute,
+			Timeout:  10 * time.Minute,
+			Run: func(ctx context.Context) error {
+				_, err := uc.PurgeDeletedUsers(ctx)
+				return err
+			},
+		},
+	}
+}
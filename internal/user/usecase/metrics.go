@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// Results recorded by the login and token refresh counters
+const (
+	ResultSucceeded = "succeeded"
+	ResultFailed    = "failed"
+)
+
+// Reasons recorded for failed logins and token refreshes
+const (
+	ReasonUnknownEmail    = "unknown_email"
+	ReasonInvalidPassword = "invalid_password"
+	ReasonSuspended       = "suspended"
+	ReasonPasswordReset   = "password_reset_required"
+	ReasonSessionNotFound = "session_not_found"
+	ReasonSessionExpired  = "session_expired"
+	ReasonUserNotFound    = "user_not_found"
+	ReasonInternalError   = "internal_error"
+)
+
+// Metrics counts account activity
+type Metrics struct {
+	registrations  prometheus.Counter
+	logins         *prometheus.CounterVec
+	tokenRefreshes *prometheus.CounterVec
+}
+
+// NewMetrics creates the user_* counters and registers them with reg. When
+// reg already holds them, the registered ones are shared.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	return &Metrics{
+		registrations: pkg.RegisterCollector(reg, prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "user_registrations_total",
+			Help: "Users who registered themselves.",
+		})),
+		logins: pkg.RegisterCollector(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "user_logins_total",
+			Help: "Login attempts by result, and by reason when failed.",
+		}, []string{"result", "reason"})),
+		tokenRefreshes: pkg.RegisterCollector(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "user_token_refreshes_total",
+			Help: "Access token refreshes by result, and by reason when failed.",
+		}, []string{"result", "reason"})),
+	}
+}
+
+// login counts a login attempt; reason is empty when it succeeded
+func (m *Metrics) login(reason string) {
+	m.logins.WithLabelValues(result(reason), reason).Inc()
+}
+
+// tokenRefresh counts a token refresh; reason is empty when it succeeded
+func (m *Metrics) tokenRefresh(reason string) {
+	m.tokenRefreshes.WithLabelValues(result(reason), reason).Inc()
+}
+
+// result returns the result of an attempt failing for reason, if any
+func result(reason string) string {
+	if reason == "" {
+		return ResultSucceeded
+	}
+	return ResultFailed
+}
@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/pkg"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the usecase spans
+const tracerName = "github.com/zercle/template-go-echo/internal/user/usecase"
+
+// TracedUsecase decorates a domain.UserUsecase with a span per call, named
+// UserUsecase.<Method>, so that traces show the business operation between
+// the request span and the repository and query spans below it.
+type TracedUsecase struct {
+	usecase domain.UserUsecase
+	tracer  trace.Tracer
+}
+
+// NewTraced wraps uc, taking spans from provider
+func NewTraced(uc domain.UserUsecase, provider trace.TracerProvider) *TracedUsecase {
+	return &TracedUsecase{usecase: uc, tracer: provider.Tracer(tracerName)}
+}
+
+// start opens the span of method
+func (u *TracedUsecase) start(ctx context.Context, method string) (context.Context, trace.Span) {
+	return u.tracer.Start(ctx, "UserUsecase."+method)
+}
+
+func (u *TracedUsecase) RegisterUser(ctx context.Context, email, name, password string) (*domain.User, error) {
+	ctx, span := u.start(ctx, "RegisterUser")
+	user, err := u.usecase.RegisterUser(ctx, email, name, password)
+	pkg.EndSpan(span, err)
+	return user, err
+}
+
+func (u *TracedUsecase) LoginUser(ctx context.Context, email, password string, ipAddress, userAgent string) (*domain.User, string, string, error) {
+	ctx, span := u.start(ctx, "LoginUser")
+	user, accessToken, refreshToken, err := u.usecase.LoginUser(ctx, email, password, ipAddress, userAgent)
+	pkg.EndSpan(span, err)
+	return user, accessToken, refreshToken, err
+}
+
+func (u *TracedUsecase) GetUser(ctx context.Context, id string) (*domain.User, error) {
+	ctx, span := u.start(ctx, "GetUser")
+	user, err := u.usecase.GetUser(ctx, id)
+	pkg.EndSpan(span, err)
+	return user, err
+}
+
+func (u *TracedUsecase) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	ctx, span := u.start(ctx, "GetUserByEmail")
+	user, err := u.usecase.GetUserByEmail(ctx, email)
+	pkg.EndSpan(span, err)
+	return user, err
+}
+
+func (u *TracedUsecase) UpdateUserProfile(ctx context.Context, id, name, email string, expectedVersion int64) (*domain.User, error) {
+	ctx, span := u.start(ctx, "UpdateUserProfile")
+	user, err := u.usecase.UpdateUserProfile(ctx, id, name, email, expectedVersion)
+	pkg.EndSpan(span, err)
+	return user, err
+}
+
+func (u *TracedUsecase) ChangePassword(ctx context.Context, id, oldPassword, newPassword string) error {
+	ctx, span := u.start(ctx, "ChangePassword")
+	err := u.usecase.ChangePassword(ctx, id, oldPassword, newPassword)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (u *TracedUsecase) CompletePasswordReset(ctx context.Context, email, oldPassword, newPassword string) error {
+	ctx, span := u.start(ctx, "CompletePasswordReset")
+	err := u.usecase.CompletePasswordReset(ctx, email, oldPassword, newPassword)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (u *TracedUsecase) DeleteUser(ctx context.Context, id string) error {
+	ctx, span := u.start(ctx, "DeleteUser")
+	err := u.usecase.DeleteUser(ctx, id)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (u *TracedUsecase) ListUsers(ctx context.Context, limit, offset int) ([]*domain.User, int, error) {
+	ctx, span := u.start(ctx, "ListUsers")
+	users, total, err := u.usecase.ListUsers(ctx, limit, offset)
+	pkg.EndSpan(span, err)
+	return users, total, err
+}
+
+func (u *TracedUsecase) ListUsersPage(ctx context.Context, query domain.UserListQuery) (pkg.CursorPage[*domain.User], error) {
+	ctx, span := u.start(ctx, "ListUsersPage")
+	page, err := u.usecase.ListUsersPage(ctx, query)
+	pkg.EndSpan(span, err)
+	return page, err
+}
+
+func (u *TracedUsecase) RefreshToken(ctx context.Context, refreshToken string) (string, error) {
+	ctx, span := u.start(ctx, "RefreshToken")
+	accessToken, err := u.usecase.RefreshToken(ctx, refreshToken)
+	pkg.EndSpan(span, err)
+	return accessToken, err
+}
+
+func (u *TracedUsecase) LogoutUser(ctx context.Context, sessionID string) error {
+	ctx, span := u.start(ctx, "LogoutUser")
+	err := u.usecase.LogoutUser(ctx, sessionID)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (u *TracedUsecase) LogoutAllSessions(ctx context.Context, userID string) error {
+	ctx, span := u.start(ctx, "LogoutAllSessions")
+	err := u.usecase.LogoutAllSessions(ctx, userID)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (u *TracedUsecase) RestoreUser(ctx context.Context, email, password string) (*domain.User, error) {
+	ctx, span := u.start(ctx, "RestoreUser")
+	user, err := u.usecase.RestoreUser(ctx, email, password)
+	pkg.EndSpan(span, err)
+	return user, err
+}
+
+func (u *TracedUsecase) ListPendingDeletion(ctx context.Context, limit, offset int) ([]*domain.User, int, error) {
+	ctx, span := u.start(ctx, "ListPendingDeletion")
+	users, total, err := u.usecase.ListPendingDeletion(ctx, limit, offset)
+	pkg.EndSpan(span, err)
+	return users, total, err
+}
+
+func (u *TracedUsecase) PurgeDeletedUsers(ctx context.Context) (int, error) {
+	ctx, span := u.start(ctx, "PurgeDeletedUsers")
+	purged, err := u.usecase.PurgeDeletedUsers(ctx)
+	pkg.EndSpan(span, err)
+	return purged, err
+}
+
+func (u *TracedUsecase) CleanupExpiredSessions(ctx context.Context) (int, error) {
+	ctx, span := u.start(ctx, "CleanupExpiredSessions")
+	deleted, err := u.usecase.CleanupExpiredSessions(ctx)
+	pkg.EndSpan(span, err)
+	return deleted, err
+}
+
+// DeletionGracePeriod returns the grace period of the wrapped usecase; it
+// does no work worth a span
+func (u *TracedUsecase) DeletionGracePeriod() time.Duration {
+	return u.usecase.DeletionGracePeriod()
+}
+
+func (u *TracedUsecase) AdminCreateUser(ctx context.Context, email, name, password, role string) (*domain.User, error) {
+	ctx, span := u.start(ctx, "AdminCreateUser")
+	user, err := u.usecase.AdminCreateUser(ctx, email, name, password, role)
+	pkg.EndSpan(span, err)
+	return user, err
+}
+
+func (u *TracedUsecase) SuspendUser(ctx context.Context, actorID, id, reason string, until *time.Time) (*domain.User, error) {
+	ctx, span := u.start(ctx, "SuspendUser")
+	user, err := u.usecase.SuspendUser(ctx, actorID, id, reason, until)
+	pkg.EndSpan(span, err)
+	return user, err
+}
+
+func (u *TracedUsecase) ReactivateUser(ctx context.Context, id string) (*domain.User, error) {
+	ctx, span := u.start(ctx, "ReactivateUser")
+	user, err := u.usecase.ReactivateUser(ctx, id)
+	pkg.EndSpan(span, err)
+	return user, err
+}
+
+func (u *TracedUsecase) ForcePasswordReset(ctx context.Context, id string) error {
+	ctx, span := u.start(ctx, "ForcePasswordReset")
+	err := u.usecase.ForcePasswordReset(ctx, id)
+	pkg.EndSpan(span, err)
+	return err
+}
+
+func (u *TracedUsecase) RevokeSessions(ctx context.Context, id string) (int, error) {
+	ctx, span := u.start(ctx, "RevokeSessions")
+	revoked, err := u.usecase.RevokeSessions(ctx, id)
+	pkg.EndSpan(span, err)
+	return revoked, err
+}
+
+func (u *TracedUsecase) SetUserRole(ctx context.Context, actorID, id, role string) (*domain.User, error) {
+	ctx, span := u.start(ctx, "SetUserRole")
+	user, err := u.usecase.SetUserRole(ctx, actorID, id, role)
+	pkg.EndSpan(span, err)
+	return user, err
+}
+
+func (u *TracedUsecase) ImportUsers(ctx context.Context, r io.Reader, format domain.ImportFormat, opts domain.ImportOptions) (*domain.ImportReport, error) {
+	ctx, span := u.start(ctx, "ImportUsers")
+	report, err := u.usecase.ImportUsers(ctx, r, format, opts)
+	pkg.EndSpan(span, err)
+	return report, err
+}
+
+func (u *TracedUsecase) ExportUsers(ctx context.Context, w io.Writer, format domain.ExportFormat, query domain.UserExportQuery) (int, error) {
+	ctx, span := u.start(ctx, "ExportUsers")
+	rows, err := u.usecase.ExportUsers(ctx, w, format, query)
+	pkg.EndSpan(span, err)
+	return rows, err
+}
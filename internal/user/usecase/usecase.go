@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	auditdomain "github.com/zercle/template-go-echo/internal/audit/domain"
 	"github.com/zercle/template-go-echo/internal/user/domain"
 	"github.com/zercle/template-go-echo/pkg"
 	"golang.org/x/crypto/bcrypt"
@@ -16,43 +18,99 @@ import (
 
 // UserUsecase implements domain.UserUsecase
 type UserUsecase struct {
-	repo   domain.UserRepository
-	tokenTTL int
+	repo                domain.UserRepository
+	tokenTTL            int
+	deletionGracePeriod time.Duration
+	inviteSender        domain.InviteSender
+	audit               auditdomain.Recorder
+	tx                  pkg.TxManager
+	metrics             *Metrics
 }
 
-// New creates a new user usecase
-func New(repo domain.UserRepository, tokenTTL int) *UserUsecase {
-	return &UserUsecase{
-		repo:     repo,
-		tokenTTL: tokenTTL,
+// Option configures optional UserUsecase settings
+type Option func(*UserUsecase)
+
+// WithInviteSender sets how imported users with generated passwords are invited
+func WithInviteSender(sender domain.InviteSender) Option {
+	return func(u *UserUsecase) {
+		u.inviteSender = sender
+	}
+}
+
+// WithAuditRecorder sets where security and administrative actions are recorded
+func WithAuditRecorder(recorder auditdomain.Recorder) Option {
+	return func(u *UserUsecase) {
+		u.audit = recorder
 	}
 }
 
+// WithTxManager sets the transaction manager making multi-step operations
+// atomic; without it each repository call commits on its own
+func WithTxManager(tx pkg.TxManager) Option {
+	return func(u *UserUsecase) {
+		u.tx = tx
+	}
+}
+
+// WithMetrics sets the counters account activity is recorded in
+func WithMetrics(metrics *Metrics) Option {
+	return func(u *UserUsecase) {
+		u.metrics = metrics
+	}
+}
+
+// WithDeletionGracePeriod sets how long soft deleted accounts remain restorable
+func WithDeletionGracePeriod(gracePeriod time.Duration) Option {
+	return func(u *UserUsecase) {
+		u.deletionGracePeriod = gracePeriod
+	}
+}
+
+// New creates a new user usecase. Account activity is counted in metrics
+// registered with the default Prometheus registry unless set by WithMetrics.
+func New(repo domain.UserRepository, tokenTTL int, opts ...Option) *UserUsecase {
+	u := &UserUsecase{
+		repo:                repo,
+		tokenTTL:            tokenTTL,
+		deletionGracePeriod: domain.DefaultDeletionGracePeriod,
+		tx:                  pkg.NoTx{},
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	if u.metrics == nil {
+		u.metrics = NewMetrics(prometheus.DefaultRegisterer)
+	}
+	return u
+}
+
 // RegisterUser creates a new user with validation
 func (u *UserUsecase) RegisterUser(ctx context.Context, email, name, password string) (*domain.User, error) {
-	// Validate inputs
-	validator := pkg.NewValidator()
-	if validator.IsEmpty("email", email) || !validator.IsValidEmail("email", email) {
-		return nil, domain.ErrInvalidEmail
+	user, err := u.createUser(ctx, email, name, password, domain.RoleUser)
+	if err != nil {
+		return nil, err
 	}
-	if validator.IsEmpty("name", name) || !validator.IsMaxLength("name", name, domain.MaxNameLength) {
-		return nil, domain.ErrInvalidName
+
+	pkg.Logger(ctx).InfoContext(ctx, "user registered successfully", slog.String("user_id", user.ID), slog.String("email", user.Email))
+	u.metrics.registrations.Inc()
+	u.record(ctx, auditdomain.ActionUserRegistered, user.ID, user.ID, nil)
+	return user, nil
+}
+
+// createUser validates input and persists a new active user with the given role
+func (u *UserUsecase) createUser(ctx context.Context, email, name, password, role string) (*domain.User, error) {
+	// Validate inputs
+	if err := validateNewUser(email, name); err != nil {
+		return nil, err
 	}
 	if len(password) < domain.MinPasswordLength || len(password) > domain.MaxPasswordLength {
 		return nil, domain.ErrInvalidPassword
 	}
 
-	// Check if user already exists
-	existingUser, err := u.repo.GetUserByEmail(ctx, email)
-	if err == nil && existingUser != nil && !existingUser.IsDeleted() {
-		slog.Warn("attempted to register existing email", slog.String("email", email))
-		return nil, domain.ErrUserExists
-	}
-
-	// Hash password
+	// Hash password before the transaction so no locks are held meanwhile
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		slog.Error("failed to hash password", slog.String("error", err.Error()))
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to hash password", slog.String("error", err.Error()))
 		return nil, pkg.ErrInternalError
 	}
 
@@ -63,38 +121,147 @@ func (u *UserUsecase) RegisterUser(ctx context.Context, email, name, password st
 		Name:         name,
 		PasswordHash: string(passwordHash),
 		IsActive:     true,
+		Role:         role,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
+		Version:      domain.InitialVersion,
 	}
 
-	if err := u.repo.CreateUser(ctx, user); err != nil {
-		slog.Error("failed to create user", slog.String("error", err.Error()))
+	// Check and insert in one transaction; the unique email index settles
+	// concurrent registrations
+	err = u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		expiredID, err := u.checkEmailAvailable(ctx, email)
+		if err != nil {
+			return err
+		}
+		if expiredID != "" {
+			if err := u.purgeExpiredUser(ctx, expiredID); err != nil {
+				return err
+			}
+		}
+		return u.repo.CreateUser(ctx, user, domain.NewUserRegistered(user))
+	})
+	if err != nil {
+		if err == domain.ErrUserExists || err == domain.ErrPendingDeletion {
+			return nil, err
+		}
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to create user", slog.String("error", err.Error()))
 		return nil, pkg.ErrInternalError
 	}
 
-	slog.Info("user registered successfully", slog.String("user_id", user.ID), slog.String("email", user.Email))
 	return user, nil
 }
 
+// validateNewUser applies the registration rules for email and name
+func validateNewUser(email, name string) error {
+	validator := pkg.NewValidator()
+	if validator.IsEmpty("email", email) || !validator.IsValidEmail("email", email) {
+		return domain.ErrInvalidEmail
+	}
+	if validator.IsEmpty("name", name) || !validator.IsMaxLength("name", name, domain.MaxNameLength) {
+		return domain.ErrInvalidName
+	}
+	return nil
+}
+
+// checkEmailAvailable rejects emails held by active accounts or accounts
+// pending deletion. It never deletes anything: when the email is held by a
+// deleted account past its grace period, that account's ID is returned for
+// the caller to purge right before inserting the new user.
+func (u *UserUsecase) checkEmailAvailable(ctx context.Context, email string) (string, error) {
+	// Check if user already exists
+	existingUser, err := u.repo.GetUserByEmail(ctx, email)
+	if err == nil && existingUser != nil && !existingUser.IsDeleted() {
+		pkg.Logger(ctx).WarnContext(ctx, "attempted to register existing email", slog.String("email", email))
+		return "", domain.ErrUserExists
+	}
+
+	// Deleted accounts keep their email until purged and must be restored
+	// instead, unless the grace period has elapsed
+	deletedUser, err := u.repo.GetDeletedUserByEmail(ctx, email)
+	if err == nil && deletedUser != nil {
+		if deletedUser.IsRestorable(u.deletionGracePeriod) {
+			pkg.Logger(ctx).WarnContext(ctx, "attempted to register email pending deletion", slog.String("email", email))
+			return "", domain.ErrPendingDeletion
+		}
+		return deletedUser.ID, nil
+	}
+
+	return "", nil
+}
+
+// purgeExpiredUser hard deletes a deleted account past its grace period,
+// freeing its email ahead of the scheduled purge
+func (u *UserUsecase) purgeExpiredUser(ctx context.Context, id string) error {
+	if err := u.repo.PurgeDeletedUser(ctx, id, time.Now().Add(-u.deletionGracePeriod)); err != nil {
+		return err
+	}
+
+	pkg.Logger(ctx).InfoContext(ctx, "purged expired account to reuse its email", slog.String("user_id", id))
+	return nil
+}
+
 // LoginUser authenticates a user and returns tokens
 func (u *UserUsecase) LoginUser(ctx context.Context, email, password string, ipAddress, userAgent string) (*domain.User, string, string, error) {
-	// Get user by email
+	// Get user by email, falling back to accounts still within the deletion grace period
 	user, err := u.repo.GetUserByEmail(ctx, email)
-	if err != nil || user == nil || user.IsDeleted() {
-		slog.Warn("login failed: user not found", slog.String("email", email))
+	if err == nil && user == nil {
+		user, err = u.repo.GetDeletedUserByEmail(ctx, email)
+		if user != nil && !user.IsRestorable(u.deletionGracePeriod) {
+			user = nil
+		}
+	}
+	if err != nil || user == nil {
+		pkg.Logger(ctx).WarnContext(ctx, "login failed: user not found", slog.String("email", email))
+		u.metrics.login(ReasonUnknownEmail)
+		u.record(ctx, auditdomain.ActionUserLoginFailed, "", "", map[string]interface{}{"email": email, "reason": "unknown_email"})
 		return nil, "", "", domain.ErrInvalidCredentials
 	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
-		slog.Warn("login failed: invalid password", slog.String("email", email))
+		pkg.Logger(ctx).WarnContext(ctx, "login failed: invalid password", slog.String("email", email))
+		u.metrics.login(ReasonInvalidPassword)
+		u.record(ctx, auditdomain.ActionUserLoginFailed, "", user.ID, map[string]interface{}{"reason": "invalid_password"})
 		return nil, "", "", domain.ErrInvalidCredentials
 	}
 
-	// Check if user is active
+	// Check if user is suspended, lifting suspensions that have expired
+	if user.IsSuspended() {
+		pkg.Logger(ctx).WarnContext(ctx, "login failed: user suspended", slog.String("user_id", user.ID))
+		u.metrics.login(ReasonSuspended)
+		u.record(ctx, auditdomain.ActionUserLoginFailed, "", user.ID, map[string]interface{}{"reason": "suspended"})
+		return nil, "", "", domain.ErrAccountSuspended
+	}
+
+	// A forced password reset must be completed before any session is issued
+	if user.PasswordResetRequired {
+		pkg.Logger(ctx).WarnContext(ctx, "login refused: password reset required", slog.String("user_id", user.ID))
+		u.metrics.login(ReasonPasswordReset)
+		u.record(ctx, auditdomain.ActionUserLoginFailed, "", user.ID, map[string]interface{}{"reason": "password_reset_required"})
+		return nil, "", "", domain.ErrPasswordResetRequired
+	}
 	if !user.IsActive {
-		slog.Warn("login failed: user inactive", slog.String("user_id", user.ID))
-		return nil, "", "", domain.ErrUnauthorized
+		if err := u.repo.ReactivateUser(ctx, user.ID); err != nil {
+			pkg.Logger(ctx).ErrorContext(ctx, "failed to lift expired suspension", slog.String("error", err.Error()))
+			u.metrics.login(ReasonInternalError)
+			return nil, "", "", pkg.ErrInternalError
+		}
+		user.IsActive = true
+		user.SuspendedAt, user.SuspendedUntil, user.SuspensionReason = nil, nil, ""
+		pkg.Logger(ctx).InfoContext(ctx, "expired suspension lifted", slog.String("user_id", user.ID))
+	}
+
+	// Logging in reactivates an account pending deletion
+	if user.IsDeleted() {
+		if err := u.repo.RestoreUser(ctx, user.ID); err != nil {
+			pkg.Logger(ctx).ErrorContext(ctx, "failed to restore user", slog.String("error", err.Error()))
+			u.metrics.login(ReasonInternalError)
+			return nil, "", "", pkg.ErrInternalError
+		}
+		user.DeletedAt = nil
+		pkg.Logger(ctx).InfoContext(ctx, "user restored on login", slog.String("user_id", user.ID))
+		u.record(ctx, auditdomain.ActionUserRestored, user.ID, user.ID, map[string]interface{}{"via": "login"})
 	}
 
 	// Generate tokens
@@ -114,11 +281,14 @@ func (u *UserUsecase) LoginUser(ctx context.Context, email, password string, ipA
 	}
 
 	if err := u.repo.CreateSession(ctx, session); err != nil {
-		slog.Error("failed to create session", slog.String("error", err.Error()))
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to create session", slog.String("error", err.Error()))
+		u.metrics.login(ReasonInternalError)
 		return nil, "", "", pkg.ErrInternalError
 	}
 
-	slog.Info("user logged in successfully", slog.String("user_id", user.ID))
+	pkg.Logger(ctx).InfoContext(ctx, "user logged in successfully", slog.String("user_id", user.ID))
+	u.metrics.login("")
+	u.record(ctx, auditdomain.ActionUserLogin, user.ID, user.ID, map[string]interface{}{"session_id": session.ID})
 	return user, accessToken, refreshToken, nil
 }
 
@@ -140,8 +310,10 @@ func (u *UserUsecase) GetUserByEmail(ctx context.Context, email string) (*domain
 	return user, nil
 }
 
-// UpdateUserProfile updates user information
-func (u *UserUsecase) UpdateUserProfile(ctx context.Context, id, name, email string) (*domain.User, error) {
+// UpdateUserProfile updates user information. A non-zero expectedVersion must equal the
+// current version of the user; the update itself is guarded by the version that was read,
+// so a concurrent change between reading and writing is reported as a conflict too.
+func (u *UserUsecase) UpdateUserProfile(ctx context.Context, id, name, email string, expectedVersion int64) (*domain.User, error) {
 	// Validate inputs
 	if name == "" || len(name) > domain.MaxNameLength {
 		return nil, domain.ErrInvalidName
@@ -156,6 +328,9 @@ func (u *UserUsecase) UpdateUserProfile(ctx context.Context, id, name, email str
 	if err != nil || user == nil || user.IsDeleted() {
 		return nil, domain.ErrUserNotFound
 	}
+	if expectedVersion != 0 && user.Version != expectedVersion {
+		return nil, domain.ErrVersionConflict
+	}
 
 	// Check if email is already in use by another user
 	if email != user.Email {
@@ -163,19 +338,46 @@ func (u *UserUsecase) UpdateUserProfile(ctx context.Context, id, name, email str
 		if existingUser != nil && !existingUser.IsDeleted() && existingUser.ID != id {
 			return nil, domain.ErrUserExists
 		}
+		deletedUser, _ := u.repo.GetDeletedUserByEmail(ctx, email)
+		if deletedUser != nil {
+			return nil, domain.ErrUserExists
+		}
 	}
 
 	// Update user
+	now := time.Now()
+	var events []pkg.DomainEvent
+	emailChanged := email != user.Email
+	if emailChanged || name != user.Name {
+		events = append(events, domain.UserUpdated{
+			UserID:    id,
+			Email:     email,
+			Name:      name,
+			UpdatedAt: now,
+		})
+	}
+	if emailChanged {
+		events = append(events, domain.UserEmailChanged{
+			UserID:    id,
+			OldEmail:  user.Email,
+			NewEmail:  email,
+			ChangedAt: now,
+		})
+	}
 	user.Name = name
 	user.Email = email
-	user.UpdatedAt = time.Now()
+	user.UpdatedAt = now
 
-	if err := u.repo.UpdateUser(ctx, user); err != nil {
-		slog.Error("failed to update user", slog.String("error", err.Error()))
+	if err := u.repo.UpdateUser(ctx, user, events...); err != nil {
+		if err == domain.ErrVersionConflict {
+			return nil, err
+		}
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to update user", slog.String("error", err.Error()))
 		return nil, pkg.ErrInternalError
 	}
 
-	slog.Info("user profile updated", slog.String("user_id", id))
+	pkg.Logger(ctx).InfoContext(ctx, "user profile updated", slog.String("user_id", id))
+	u.record(ctx, auditdomain.ActionUserProfileUpdated, "", id, map[string]interface{}{"email_changed": emailChanged})
 	return user, nil
 }
 
@@ -189,7 +391,7 @@ func (u *UserUsecase) ChangePassword(ctx context.Context, id, oldPassword, newPa
 
 	// Verify old password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(oldPassword)); err != nil {
-		slog.Warn("password change failed: invalid old password", slog.String("user_id", id))
+		pkg.Logger(ctx).WarnContext(ctx, "password change failed: invalid old password", slog.String("user_id", id))
 		return pkg.NewDomainError(domain.ErrCodeInvalidPassword, domain.ValidationMessages["old_password_invalid"])
 	}
 
@@ -201,23 +403,48 @@ func (u *UserUsecase) ChangePassword(ctx context.Context, id, oldPassword, newPa
 	// Hash new password
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
 	if err != nil {
-		slog.Error("failed to hash new password", slog.String("error", err.Error()))
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to hash new password", slog.String("error", err.Error()))
 		return pkg.ErrInternalError
 	}
 
-	// Update password
+	// Update password, clearing any forced reset
 	user.PasswordHash = string(passwordHash)
+	user.PasswordResetRequired = false
 	user.UpdatedAt = time.Now()
 
-	if err := u.repo.UpdateUser(ctx, user); err != nil {
-		slog.Error("failed to update password", slog.String("error", err.Error()))
+	if err := u.repo.UpdatePassword(ctx, user.ID, user.PasswordHash, false); err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to update password", slog.String("error", err.Error()))
 		return pkg.ErrInternalError
 	}
 
-	slog.Info("password changed successfully", slog.String("user_id", id))
+	pkg.Logger(ctx).InfoContext(ctx, "password changed successfully", slog.String("user_id", id))
+	u.record(ctx, auditdomain.ActionUserPasswordChanged, "", id, nil)
 	return nil
 }
 
+// CompletePasswordReset changes the password of an account whose password
+// reset was forced. Such accounts get no session until then, so the old
+// password authenticates the change in place of a token.
+func (u *UserUsecase) CompletePasswordReset(ctx context.Context, email, oldPassword, newPassword string) error {
+	user, err := u.repo.GetUserByEmail(ctx, email)
+	if err != nil || user == nil || user.IsDeleted() {
+		pkg.Logger(ctx).WarnContext(ctx, "password reset failed: user not found", slog.String("email", email))
+		return domain.ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(oldPassword)); err != nil {
+		pkg.Logger(ctx).WarnContext(ctx, "password reset failed: invalid password", slog.String("user_id", user.ID))
+		return domain.ErrInvalidCredentials
+	}
+	if user.IsSuspended() {
+		return domain.ErrAccountSuspended
+	}
+	if !user.PasswordResetRequired {
+		return domain.ErrPasswordResetNotRequired
+	}
+
+	return u.ChangePassword(ctx, user.ID, oldPassword, newPassword)
+}
+
 // DeleteUser deletes a user account
 func (u *UserUsecase) DeleteUser(ctx context.Context, id string) error {
 	// Get user
@@ -226,16 +453,23 @@ func (u *UserUsecase) DeleteUser(ctx context.Context, id string) error {
 		return domain.ErrUserNotFound
 	}
 
-	// Soft delete user
-	if err := u.repo.DeleteUser(ctx, id); err != nil {
-		slog.Error("failed to delete user", slog.String("error", err.Error()))
+	// Soft delete user and revoke its sessions together
+	deleted := domain.UserDeleted{UserID: id, Email: user.Email, DeletedAt: time.Now()}
+	var revoked int
+	err = u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.repo.DeleteUser(ctx, id, deleted); err != nil {
+			return err
+		}
+		revoked, err = u.revokeSessions(ctx, id, domain.RevokeReasonDeleted)
+		return err
+	})
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to delete user", slog.String("error", err.Error()))
 		return pkg.ErrInternalError
 	}
 
-	// Logout all sessions
-	_ = u.LogoutAllSessions(ctx, id)
-
-	slog.Info("user deleted", slog.String("user_id", id))
+	pkg.Logger(ctx).InfoContext(ctx, "user deleted", slog.String("user_id", id), slog.Int("sessions_revoked", revoked))
+	u.record(ctx, auditdomain.ActionUserDeleted, "", id, nil)
 	return nil
 }
 
@@ -251,19 +485,63 @@ func (u *UserUsecase) ListUsers(ctx context.Context, limit, offset int) ([]*doma
 
 	users, err := u.repo.ListUsers(ctx, limit, offset)
 	if err != nil {
-		slog.Error("failed to list users", slog.String("error", err.Error()))
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to list users", slog.String("error", err.Error()))
 		return nil, 0, pkg.ErrInternalError
 	}
 
 	count, err := u.repo.GetUserCount(ctx)
 	if err != nil {
-		slog.Error("failed to get user count", slog.String("error", err.Error()))
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to get user count", slog.String("error", err.Error()))
 		return nil, 0, pkg.ErrInternalError
 	}
 
 	return users, count, nil
 }
 
+// ListUsersPage retrieves a filtered, sorted, cursor paginated list of users
+func (u *UserUsecase) ListUsersPage(ctx context.Context, query domain.UserListQuery) (pkg.CursorPage[*domain.User], error) {
+	sort, err := pkg.ParseSort(query.Sort, domain.UserSortFields, domain.DefaultUserSort)
+	if err != nil {
+		return pkg.CursorPage[*domain.User]{}, err
+	}
+
+	// Cursors are only valid for the ordering they were issued for
+	var after *domain.UserCursor
+	if query.Cursor != "" {
+		cursor, err := pkg.DecodeCursor[domain.UserCursor](query.Cursor)
+		if err != nil || cursor.Sort != sort.String() {
+			return pkg.CursorPage[*domain.User]{}, pkg.ErrInvalidCursor
+		}
+		after = &cursor
+	}
+
+	// Fetch one extra row to detect whether another page exists
+	limit := pkg.ClampLimit(query.Limit, domain.DefaultPageSize, domain.MaxPageSize)
+	users, err := u.repo.ListUsersPage(ctx, query.Filter, sort, after, limit+1)
+	if err != nil {
+		if domainErr, ok := err.(*pkg.DomainError); ok {
+			return pkg.CursorPage[*domain.User]{}, domainErr
+		}
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to list users page", slog.String("error", err.Error()))
+		return pkg.CursorPage[*domain.User]{}, pkg.ErrInternalError
+	}
+
+	page := pkg.NewCursorPage(users, limit, func(user *domain.User) any {
+		return domain.NewUserCursor(user, sort)
+	})
+
+	if query.IncludeTotal {
+		count, err := u.repo.CountUsers(ctx, query.Filter)
+		if err != nil {
+			pkg.Logger(ctx).ErrorContext(ctx, "failed to count users", slog.String("error", err.Error()))
+			return pkg.CursorPage[*domain.User]{}, pkg.ErrInternalError
+		}
+		page.Total = &count
+	}
+
+	return page, nil
+}
+
 // RefreshToken generates a new access token from refresh token
 func (u *UserUsecase) RefreshToken(ctx context.Context, refreshToken string) (string, error) {
 	// Hash the refresh token to find the session
@@ -272,25 +550,37 @@ func (u *UserUsecase) RefreshToken(ctx context.Context, refreshToken string) (st
 	// Find session by token hash
 	session, err := u.repo.GetSessionByTokenHash(ctx, tokenHash)
 	if err != nil || session == nil {
+		u.metrics.tokenRefresh(ReasonSessionNotFound)
 		return "", domain.ErrSessionNotFound
 	}
 
 	// Check if session is expired
 	if session.IsExpired() {
 		_ = u.repo.DeleteSession(ctx, session.ID)
+		u.metrics.tokenRefresh(ReasonSessionExpired)
 		return "", domain.ErrSessionExpired
 	}
 
 	// Get user
 	user, err := u.repo.GetUserByID(ctx, session.UserID)
 	if err != nil || user == nil || user.IsDeleted() {
+		u.metrics.tokenRefresh(ReasonUserNotFound)
 		return "", domain.ErrUserNotFound
 	}
+	if user.IsSuspended() {
+		u.metrics.tokenRefresh(ReasonSuspended)
+		return "", domain.ErrAccountSuspended
+	}
+	if user.PasswordResetRequired {
+		u.metrics.tokenRefresh(ReasonPasswordReset)
+		return "", domain.ErrPasswordResetRequired
+	}
 
 	// Generate new access token
 	accessToken := u.generateToken(user.ID, user.Email)
 
-	slog.Info("token refreshed", slog.String("user_id", user.ID))
+	pkg.Logger(ctx).InfoContext(ctx, "token refreshed", slog.String("user_id", user.ID))
+	u.metrics.tokenRefresh("")
 	return accessToken, nil
 }
 
@@ -303,31 +593,115 @@ func (u *UserUsecase) LogoutUser(ctx context.Context, sessionID string) error {
 	}
 
 	// Delete session
-	if err := u.repo.DeleteSession(ctx, sessionID); err != nil {
-		slog.Error("failed to delete session", slog.String("error", err.Error()))
+	if err := u.repo.DeleteSession(ctx, sessionID, domain.NewSessionRevoked(session, domain.RevokeReasonLogout)); err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to delete session", slog.String("error", err.Error()))
 		return pkg.ErrInternalError
 	}
 
-	slog.Info("user logged out", slog.String("user_id", session.UserID))
+	pkg.Logger(ctx).InfoContext(ctx, "user logged out", slog.String("user_id", session.UserID))
+	u.record(ctx, auditdomain.ActionUserLogout, "", session.UserID, map[string]interface{}{"session_id": sessionID})
 	return nil
 }
 
 // LogoutAllSessions invalidates all sessions for a user
 func (u *UserUsecase) LogoutAllSessions(ctx context.Context, userID string) error {
-	// Get all active sessions for user
-	sessions, err := u.repo.GetSessionsByUserID(ctx, userID)
+	revoked, err := u.revokeSessions(ctx, userID, domain.RevokeReasonLogoutAll)
 	if err != nil {
-		slog.Error("failed to get user sessions", slog.String("error", err.Error()))
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to delete user sessions", slog.String("error", err.Error()))
 		return pkg.ErrInternalError
 	}
 
-	// Delete all sessions
-	for _, session := range sessions {
-		_ = u.repo.DeleteSession(ctx, session.ID)
+	pkg.Logger(ctx).InfoContext(ctx, "all sessions deleted for user", slog.String("user_id", userID), slog.Int("count", revoked))
+	u.record(ctx, auditdomain.ActionUserLogoutAll, "", userID, map[string]interface{}{"count": revoked})
+	return nil
+}
+
+// RestoreUser reactivates a soft deleted account within the grace period
+func (u *UserUsecase) RestoreUser(ctx context.Context, email, password string) (*domain.User, error) {
+	// Get deleted user by email
+	user, err := u.repo.GetDeletedUserByEmail(ctx, email)
+	if err != nil || user == nil || !user.IsRestorable(u.deletionGracePeriod) {
+		pkg.Logger(ctx).WarnContext(ctx, "restore failed: no restorable account", slog.String("email", email))
+		return nil, domain.ErrUserNotFound
 	}
 
-	slog.Info("all sessions deleted for user", slog.String("user_id", userID), slog.Int("count", len(sessions)))
-	return nil
+	// Verify password
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		pkg.Logger(ctx).WarnContext(ctx, "restore failed: invalid password", slog.String("user_id", user.ID))
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	if err := u.repo.RestoreUser(ctx, user.ID); err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to restore user", slog.String("error", err.Error()))
+		return nil, pkg.ErrInternalError
+	}
+
+	user.DeletedAt = nil
+	user.UpdatedAt = time.Now()
+
+	pkg.Logger(ctx).InfoContext(ctx, "user restored", slog.String("user_id", user.ID))
+	u.record(ctx, auditdomain.ActionUserRestored, user.ID, user.ID, nil)
+	return user, nil
+}
+
+// ListPendingDeletion retrieves a paginated list of accounts awaiting purge
+func (u *UserUsecase) ListPendingDeletion(ctx context.Context, limit, offset int) ([]*domain.User, int, error) {
+	// Validate pagination
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	users, err := u.repo.ListDeletedUsers(ctx, limit, offset)
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to list deleted users", slog.String("error", err.Error()))
+		return nil, 0, pkg.ErrInternalError
+	}
+
+	count, err := u.repo.GetDeletedUserCount(ctx)
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to get deleted user count", slog.String("error", err.Error()))
+		return nil, 0, pkg.ErrInternalError
+	}
+
+	return users, count, nil
+}
+
+// PurgeDeletedUsers hard deletes accounts whose grace period has elapsed
+func (u *UserUsecase) PurgeDeletedUsers(ctx context.Context) (int, error) {
+	cutoff := time.Now().Add(-u.deletionGracePeriod)
+
+	purged, err := u.repo.PurgeDeletedUsers(ctx, cutoff)
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to purge deleted users", slog.String("error", err.Error()))
+		return 0, pkg.ErrInternalError
+	}
+
+	if purged > 0 {
+		pkg.Logger(ctx).InfoContext(ctx, "purged deleted users", slog.Int("count", purged), slog.Time("cutoff", cutoff))
+	}
+	return purged, nil
+}
+
+// CleanupExpiredSessions deletes sessions past their expiry
+func (u *UserUsecase) CleanupExpiredSessions(ctx context.Context) (int, error) {
+	deleted, err := u.repo.DeleteExpiredSessions(ctx)
+	if err != nil {
+		pkg.Logger(ctx).ErrorContext(ctx, "failed to clean up expired sessions", slog.String("error", err.Error()))
+		return 0, pkg.ErrInternalError
+	}
+
+	if deleted > 0 {
+		pkg.Logger(ctx).InfoContext(ctx, "deleted expired sessions", slog.Int("count", deleted))
+	}
+	return deleted, nil
+}
+
+// DeletionGracePeriod returns the configured restore window for deleted accounts
+func (u *UserUsecase) DeletionGracePeriod() time.Duration {
+	return u.deletionGracePeriod
 }
 
 // generateToken creates a simple JWT token (in production, use proper JWT library)
@@ -348,3 +722,22 @@ func (u *UserUsecase) hashToken(token string) string {
 	hash := sha256.Sum256([]byte(token))
 	return hex.EncodeToString(hash[:])
 }
+
+// record sends an audit event about a user to the configured recorder. An
+// empty actorID is resolved from the authenticated user carried by ctx.
+func (u *UserUsecase) record(ctx context.Context, action, actorID, targetID string, metadata map[string]interface{}) {
+	if u.audit == nil {
+		return
+	}
+
+	event := auditdomain.AuditEvent{
+		ActorID:  actorID,
+		Action:   action,
+		Metadata: metadata,
+	}
+	if targetID != "" {
+		event.TargetType = auditdomain.TargetUser
+		event.TargetID = targetID
+	}
+	u.audit.Record(ctx, event)
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"time"
+
+	userdomain "github.com/zercle/template-go-echo/internal/user/domain"
+)
+
+const (
+	// Pagination constraints
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+
+	// Subscription constraints
+	MaxURLLength     = 2048
+	MinSecretLength  = 16
+	MaxSecretLength  = 255
+	SecretByteLength = 32
+	SecretPrefix     = "whsec_"
+
+	// Delivery defaults
+	DefaultPollInterval   = time.Second
+	DefaultBatchSize      = 50
+	DefaultConcurrency    = 4
+	DefaultRequestTimeout = 10 * time.Second
+	DefaultMaxAttempts    = 10
+
+	// Consecutive failed attempts after which a subscription is disabled
+	DefaultDisableAfter = 20
+
+	// Delivery retry backoff bounds
+	MinRetryBackoff = 30 * time.Second
+	MaxRetryBackoff = 6 * time.Hour
+
+	// Column limits; longer values are truncated
+	MaxResponseLength = 1024
+	MaxErrorLength    = 1024
+
+	// Maximum age of a delivery timestamp accepted by VerifySignature
+	SignatureTolerance = 5 * time.Minute
+
+	// UserAgent identifies delivery requests
+	UserAgent = "template-go-echo-webhooks/1.0"
+)
+
+// Delivery statuses
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusFailed    = "failed"
+)
+
+// Request headers sent with every delivery
+const (
+	HeaderWebhookID        = "X-Webhook-ID"
+	HeaderWebhookEvent     = "X-Webhook-Event"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	HeaderWebhookSignature = "X-Webhook-Signature"
+)
+
+// AllEvents subscribes to every event type
+const AllEvents = "*"
+
+// EventTypes lists the event types that can be subscribed to
+var EventTypes = []string{
+	userdomain.EventUserRegistered,
+	userdomain.EventUserUpdated,
+	userdomain.EventUserEmailChanged,
+	userdomain.EventUserDeleted,
+	userdomain.EventSessionRevoked,
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Subscription is an endpoint receiving HTTP callbacks for selected event types
+type Subscription struct {
+	ID                  string     `json:"id"`
+	URL                 string     `json:"url"`
+	EventTypes          []string   `json:"event_types"`
+	Secret              string     `json:"-"`
+	Enabled             bool       `json:"enabled"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// Subscribes reports whether the subscription receives events of eventType
+func (s *Subscription) Subscribes(eventType string) bool {
+	for _, subscribed := range s.EventTypes {
+		if subscribed == AllEvents || subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// SubscriptionInput holds the fields of a subscription set by an administrator.
+// An empty secret is generated on create and left unchanged on update.
+type SubscriptionInput struct {
+	URL        string
+	EventTypes []string
+	Secret     string
+	Enabled    *bool
+}
+
+// Delivery is the attempt to send one event to one subscription, with the
+// outcome of its latest attempt
+type Delivery struct {
+	ID             string          `json:"id"`
+	SubscriptionID string          `json:"subscription_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastResponse   string          `json:"last_response,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	LastDuration   time.Duration   `json:"last_duration,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// PendingDelivery is a due delivery together with the endpoint it is sent to
+type PendingDelivery struct {
+	ID             string
+	SubscriptionID string
+	EventID        string
+	EventType      string
+	Payload        json.RawMessage
+	Attempts       int
+	URL            string
+	Secret         string
+}
+
+// AttemptResult is the outcome of sending a delivery once
+type AttemptResult struct {
+	StatusCode int
+	Response   string
+	Error      string
+	Duration   time.Duration
+}
+
+// Succeeded reports whether the endpoint accepted the delivery
+func (r AttemptResult) Succeeded() bool {
+	return r.Error == "" && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Envelope is the JSON request body of a delivery
+type Envelope struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// DeliveryQuery describes a cursor paginated delivery log listing, newest first
+type DeliveryQuery struct {
+	SubscriptionID string
+	Status         string
+	Cursor         string
+	Limit          int
+}
+
+// DeliveryCursor is the keyset position of a delivery in a listing
+type DeliveryCursor struct {
+	CreatedAt string `json:"t"`
+	ID        string `json:"id"`
+}
+
+// NewDeliveryCursor returns the keyset position of delivery
+func NewDeliveryCursor(delivery *Delivery) DeliveryCursor {
+	return DeliveryCursor{
+		CreatedAt: delivery.CreatedAt.UTC().Format(time.RFC3339Nano),
+		ID:        delivery.ID,
+	}
+}
+
+// IsValidDeliveryStatus reports whether status is a known delivery status
+func IsValidDeliveryStatus(status string) bool {
+	switch status {
+	case DeliveryStatusPending, DeliveryStatusSucceeded, DeliveryStatusFailed:
+		return true
+	}
+	return false
+}
+
+// IsValidEventType reports whether eventType can be subscribed to
+func IsValidEventType(eventType string) bool {
+	if eventType == AllEvents {
+		return true
+	}
+	for _, supported := range EventTypes {
+		if supported == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryBackoff returns how long to wait before the next attempt after the given
+// number of failed attempts, doubling from MinRetryBackoff up to MaxRetryBackoff
+func RetryBackoff(attempts int) time.Duration {
+	backoff := MinRetryBackoff
+	for i := 1; i < attempts && backoff < MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > MaxRetryBackoff {
+		backoff = MaxRetryBackoff
+	}
+	return backoff
+}
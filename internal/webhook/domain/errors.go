@@ -0,0 +1,46 @@
+package domain
+
+import "github.com/zercle/template-go-echo/pkg"
+
+// Webhook domain-specific error codes
+const (
+	ErrCodeSubscriptionNotFound = "WEBHOOK_NOT_FOUND"
+	ErrCodeDeliveryNotFound     = "WEBHOOK_DELIVERY_NOT_FOUND"
+	ErrCodeInvalidWebhookURL    = "INVALID_WEBHOOK_URL"
+	ErrCodeInvalidEventTypes    = "INVALID_EVENT_TYPES"
+	ErrCodeInvalidSecret        = "INVALID_WEBHOOK_SECRET"
+	ErrCodeInvalidStatus        = "INVALID_DELIVERY_STATUS"
+)
+
+// Webhook domain errors
+var (
+	ErrSubscriptionNotFound = pkg.NewDomainError(
+		ErrCodeSubscriptionNotFound,
+		"webhook subscription not found",
+	)
+
+	ErrDeliveryNotFound = pkg.NewDomainError(
+		ErrCodeDeliveryNotFound,
+		"webhook delivery not found",
+	)
+
+	ErrInvalidWebhookURL = pkg.NewDomainError(
+		ErrCodeInvalidWebhookURL,
+		"url must be an absolute http or https URL of at most 2048 characters",
+	)
+
+	ErrInvalidEventTypes = pkg.NewDomainError(
+		ErrCodeInvalidEventTypes,
+		"event_types must list at least one supported event type or *",
+	)
+
+	ErrInvalidSecret = pkg.NewDomainError(
+		ErrCodeInvalidSecret,
+		"secret must be between 16 and 255 characters",
+	)
+
+	ErrInvalidStatus = pkg.NewDomainError(
+		ErrCodeInvalidStatus,
+		"status must be pending, succeeded or failed",
+	)
+)
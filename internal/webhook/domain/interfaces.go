@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// WebhookRepository defines database operations for webhook subscriptions and deliveries
+type WebhookRepository interface {
+	// CreateSubscription creates a new subscription
+	CreateSubscription(ctx context.Context, subscription *Subscription) error
+
+	// GetSubscription retrieves a subscription by ID
+	GetSubscription(ctx context.Context, id string) (*Subscription, error)
+
+	// ListSubscriptions retrieves all subscriptions, oldest first
+	ListSubscriptions(ctx context.Context) ([]*Subscription, error)
+
+	// ListEnabledSubscriptions retrieves the subscriptions that receive deliveries
+	ListEnabledSubscriptions(ctx context.Context) ([]*Subscription, error)
+
+	// UpdateSubscription updates an existing subscription
+	UpdateSubscription(ctx context.Context, subscription *Subscription) error
+
+	// DeleteSubscription deletes a subscription and its delivery log, reporting whether it existed
+	DeleteSubscription(ctx context.Context, id string) (bool, error)
+
+	// RecordSuccess resets the consecutive failure count of a subscription
+	RecordSuccess(ctx context.Context, subscriptionID string) error
+
+	// RecordFailure increments the consecutive failure count of a subscription and
+	// disables it once the count reaches disableAfter, reporting whether it was disabled
+	RecordFailure(ctx context.Context, subscriptionID string, disableAfter int) (bool, error)
+
+	// CreateDelivery queues a delivery unless one exists for the same subscription and
+	// event, reporting whether it was created
+	CreateDelivery(ctx context.Context, delivery *Delivery) (bool, error)
+
+	// GetDelivery retrieves a delivery by ID
+	GetDelivery(ctx context.Context, id string) (*Delivery, error)
+
+	// ListDeliveries retrieves up to limit deliveries of a subscription, newest first,
+	// optionally filtered by status and starting after the given keyset position
+	ListDeliveries(ctx context.Context, subscriptionID, status string, after *DeliveryCursor, limit int) ([]*Delivery, error)
+
+	// ListDueDeliveries retrieves up to limit pending deliveries due at now for enabled subscriptions
+	ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*PendingDelivery, error)
+
+	// ClaimDelivery leases a due delivery until leaseUntil, reporting whether this caller won it
+	ClaimDelivery(ctx context.Context, id string, now, leaseUntil time.Time) (bool, error)
+
+	// MarkDelivered records a successful attempt
+	MarkDelivered(ctx context.Context, id string, result AttemptResult, deliveredAt time.Time) error
+
+	// MarkAttemptFailed records a failed attempt with the resulting status and next attempt time
+	MarkAttemptFailed(ctx context.Context, id, status string, result AttemptResult, nextAttemptAt time.Time) error
+
+	// ResetDelivery makes a delivery pending again with a fresh attempt count
+	ResetDelivery(ctx context.Context, id string, nextAttemptAt time.Time) error
+}
+
+// WebhookUsecase defines business logic for managing webhooks
+type WebhookUsecase interface {
+	// CreateSubscription validates and creates a subscription, generating a secret when none is given
+	CreateSubscription(ctx context.Context, input SubscriptionInput) (*Subscription, error)
+
+	// GetSubscription retrieves a subscription by ID
+	GetSubscription(ctx context.Context, id string) (*Subscription, error)
+
+	// ListSubscriptions retrieves all subscriptions
+	ListSubscriptions(ctx context.Context) ([]*Subscription, error)
+
+	// UpdateSubscription replaces the URL, event types and enabled status of a subscription;
+	// re-enabling clears its failure count
+	UpdateSubscription(ctx context.Context, id string, input SubscriptionInput) (*Subscription, error)
+
+	// DeleteSubscription deletes a subscription and its delivery log
+	DeleteSubscription(ctx context.Context, id string) error
+
+	// ListDeliveries retrieves the delivery log of a subscription, newest first
+	ListDeliveries(ctx context.Context, query DeliveryQuery) (pkg.CursorPage[*Delivery], error)
+
+	// Redeliver queues a delivery to be sent again immediately
+	Redeliver(ctx context.Context, subscriptionID, deliveryID string) (*Delivery, error)
+}
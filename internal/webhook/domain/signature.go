@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// signatureVersion prefixes signatures so the scheme can evolve
+const signatureVersion = "v1="
+
+// Signature verification errors
+var (
+	ErrSignatureMismatch = errors.New("webhook signature does not match")
+	ErrSignatureExpired  = errors.New("webhook timestamp is outside the tolerance")
+)
+
+// Sign returns the X-Webhook-Signature value for a delivery: the hex encoded
+// HMAC-SHA256 of "<timestamp>.<body>" keyed by the subscription secret
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signatureVersion + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks the signature and timestamp headers of a received
+// delivery. Receivers should reject timestamps older than SignatureTolerance
+// to prevent replays.
+func VerifySignature(secret, timestamp, signature string, body []byte, now time.Time) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrSignatureMismatch
+	}
+
+	age := now.Sub(time.Unix(ts, 0))
+	if age > SignatureTolerance || age < -SignatureTolerance {
+		return ErrSignatureExpired
+	}
+
+	if !strings.HasPrefix(signature, signatureVersion) ||
+		!hmac.Equal([]byte(Sign(secret, ts, body)), []byte(signature)) {
+		return ErrSignatureMismatch
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SubscriptionRequest is the request body for creating or updating a webhook subscription
+type SubscriptionRequest struct {
+	URL        string   `json:"url" validate:"required,url,max=2048"`
+	EventTypes []string `json:"event_types" validate:"required,min=1"`
+	Secret     string   `json:"secret,omitempty" validate:"omitempty,min=16,max=255"`
+	Enabled    *bool    `json:"enabled,omitempty"`
+}
+
+// SubscriptionResponse is the response body for a webhook subscription
+type SubscriptionResponse struct {
+	ID                  string     `json:"id"`
+	URL                 string     `json:"url"`
+	EventTypes          []string   `json:"event_types"`
+	Secret              string     `json:"secret,omitempty"`
+	Enabled             bool       `json:"enabled"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// SubscriptionListResponse is the response body for a webhook subscription listing
+type SubscriptionListResponse struct {
+	Subscriptions []*SubscriptionResponse `json:"subscriptions"`
+}
+
+// DeliveryResponse is the response body for a webhook delivery
+type DeliveryResponse struct {
+	ID             string          `json:"id"`
+	SubscriptionID string          `json:"subscription_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastResponse   string          `json:"last_response,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	LastDurationMs int64           `json:"last_duration_ms,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// DeliveryPageResponse is the response body for a cursor paginated delivery log listing
+type DeliveryPageResponse struct {
+	Deliveries []*DeliveryResponse `json:"deliveries"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+	HasMore    bool                `json:"has_more"`
+}
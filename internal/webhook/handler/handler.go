@@ -0,0 +1,292 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zercle/template-go-echo/internal/config"
+	"github.com/zercle/template-go-echo/internal/middleware"
+	userdomain "github.com/zercle/template-go-echo/internal/user/domain"
+	"github.com/zercle/template-go-echo/internal/webhook/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// Handler handles webhook HTTP requests
+type Handler struct {
+	usecase domain.WebhookUsecase
+}
+
+// New creates a new webhook handler
+func New(usecase domain.WebhookUsecase) *Handler {
+	return &Handler{
+		usecase: usecase,
+	}
+}
+
+// RegisterRoutes registers administrator-only webhook routes
+func (h *Handler) RegisterRoutes(e *echo.Echo, jwtCfg *config.JWTConfig) {
+	group := e.Group("/api/v1/admin/webhooks", middleware.JWTAuth(jwtCfg), middleware.RequireRole(userdomain.RoleAdmin))
+
+	group.POST("", h.CreateSubscription)
+	group.GET("", h.ListSubscriptions)
+	group.GET("/:id", h.GetSubscription)
+	group.PUT("/:id", h.UpdateSubscription)
+	group.DELETE("/:id", h.DeleteSubscription)
+	group.GET("/:id/deliveries", h.ListDeliveries)
+	group.POST("/:id/deliveries/:delivery_id/redeliver", h.Redeliver)
+}
+
+// CreateSubscription creates a webhook subscription
+// @Summary Create webhook
+// @Description Subscribe an endpoint to event types. The signing secret is generated when omitted and only returned by this call.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body SubscriptionRequest true "Subscription request"
+// @Success 201 {object} pkg.JSendResponse{data=SubscriptionResponse}
+// @Failure 400 {object} pkg.JSendResponse
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 500 {object} pkg.JSendResponse
+// @Router /api/v1/admin/webhooks [post]
+func (h *Handler) CreateSubscription(c echo.Context) error {
+	req := &SubscriptionRequest{}
+	if err := c.Bind(req); err != nil {
+		return pkg.Fail(c, http.StatusBadRequest, nil, "invalid request body")
+	}
+
+	subscription, err := h.usecase.CreateSubscription(c.Request().Context(), toSubscriptionInput(req))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	resp := toSubscriptionResponse(subscription)
+	resp.Secret = subscription.Secret
+	return pkg.Success(c, http.StatusCreated, resp)
+}
+
+// ListSubscriptions retrieves all webhook subscriptions
+// @Summary List webhooks
+// @Description Retrieve all webhook subscriptions
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} pkg.JSendResponse{data=SubscriptionListResponse}
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 500 {object} pkg.JSendResponse
+// @Router /api/v1/admin/webhooks [get]
+func (h *Handler) ListSubscriptions(c echo.Context) error {
+	subscriptions, err := h.usecase.ListSubscriptions(c.Request().Context())
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	resp := &SubscriptionListResponse{Subscriptions: make([]*SubscriptionResponse, len(subscriptions))}
+	for i, subscription := range subscriptions {
+		resp.Subscriptions[i] = toSubscriptionResponse(subscription)
+	}
+
+	return pkg.Success(c, http.StatusOK, resp)
+}
+
+// GetSubscription retrieves a webhook subscription
+// @Summary Get webhook
+// @Description Retrieve a webhook subscription by ID
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} pkg.JSendResponse{data=SubscriptionResponse}
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 404 {object} pkg.JSendResponse
+// @Router /api/v1/admin/webhooks/{id} [get]
+func (h *Handler) GetSubscription(c echo.Context) error {
+	subscription, err := h.usecase.GetSubscription(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return pkg.Success(c, http.StatusOK, toSubscriptionResponse(subscription))
+}
+
+// UpdateSubscription updates a webhook subscription
+// @Summary Update webhook
+// @Description Replace the URL and event types of a subscription. An omitted secret is kept; enabling a disabled subscription clears its failure count.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Param request body SubscriptionRequest true "Subscription request"
+// @Success 200 {object} pkg.JSendResponse{data=SubscriptionResponse}
+// @Failure 400 {object} pkg.JSendResponse
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 404 {object} pkg.JSendResponse
+// @Router /api/v1/admin/webhooks/{id} [put]
+func (h *Handler) UpdateSubscription(c echo.Context) error {
+	req := &SubscriptionRequest{}
+	if err := c.Bind(req); err != nil {
+		return pkg.Fail(c, http.StatusBadRequest, nil, "invalid request body")
+	}
+
+	subscription, err := h.usecase.UpdateSubscription(c.Request().Context(), c.Param("id"), toSubscriptionInput(req))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return pkg.Success(c, http.StatusOK, toSubscriptionResponse(subscription))
+}
+
+// DeleteSubscription deletes a webhook subscription
+// @Summary Delete webhook
+// @Description Delete a webhook subscription and its delivery log
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} pkg.JSendResponse
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 404 {object} pkg.JSendResponse
+// @Router /api/v1/admin/webhooks/{id} [delete]
+func (h *Handler) DeleteSubscription(c echo.Context) error {
+	if err := h.usecase.DeleteSubscription(c.Request().Context(), c.Param("id")); err != nil {
+		return errorResponse(c, err)
+	}
+
+	return pkg.SuccessWithMessage(c, http.StatusOK, nil, "webhook deleted")
+}
+
+// ListDeliveries retrieves the delivery log of a webhook subscription
+// @Summary List webhook deliveries
+// @Description Retrieve deliveries of a subscription newest first, with the outcome of their latest attempt
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Param status query string false "Filter by status: pending, succeeded or failed"
+// @Param limit query int false "Page limit (default: 20, max: 100)"
+// @Param cursor query string false "Opaque cursor from a previous page"
+// @Success 200 {object} pkg.JSendResponse{data=DeliveryPageResponse}
+// @Failure 400 {object} pkg.JSendResponse
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 404 {object} pkg.JSendResponse
+// @Router /api/v1/admin/webhooks/{id}/deliveries [get]
+func (h *Handler) ListDeliveries(c echo.Context) error {
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	query := domain.DeliveryQuery{
+		SubscriptionID: c.Param("id"),
+		Status:         c.QueryParam("status"),
+		Cursor:         c.QueryParam("cursor"),
+		Limit:          limit,
+	}
+
+	page, err := h.usecase.ListDeliveries(c.Request().Context(), query)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	resp := &DeliveryPageResponse{
+		Deliveries: make([]*DeliveryResponse, len(page.Items)),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+	for i, delivery := range page.Items {
+		resp.Deliveries[i] = toDeliveryResponse(delivery)
+	}
+
+	return pkg.Success(c, http.StatusOK, resp)
+}
+
+// Redeliver queues a webhook delivery to be sent again
+// @Summary Redeliver webhook
+// @Description Queue a delivery to be sent again immediately with a fresh attempt count
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Param delivery_id path string true "Delivery ID"
+// @Success 202 {object} pkg.JSendResponse{data=DeliveryResponse}
+// @Failure 401 {object} pkg.JSendResponse
+// @Failure 403 {object} pkg.JSendResponse
+// @Failure 404 {object} pkg.JSendResponse
+// @Router /api/v1/admin/webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (h *Handler) Redeliver(c echo.Context) error {
+	delivery, err := h.usecase.Redeliver(c.Request().Context(), c.Param("id"), c.Param("delivery_id"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return pkg.Success(c, http.StatusAccepted, toDeliveryResponse(delivery))
+}
+
+// errorResponse maps webhook errors to responses; internal errors are left to
+// the Echo error handler, which never exposes their details
+func errorResponse(c echo.Context, err error) error {
+	domainErr, ok := err.(*pkg.DomainError)
+	if !ok || domainErr.Code == pkg.ErrCodeInternalError {
+		return err
+	}
+
+	status := http.StatusBadRequest
+	switch domainErr.Code {
+	case domain.ErrCodeSubscriptionNotFound, domain.ErrCodeDeliveryNotFound:
+		status = http.StatusNotFound
+	}
+	return pkg.Error(c, status, domainErr.Message, domainErr.Code)
+}
+
+// toSubscriptionInput converts a request body to usecase input
+func toSubscriptionInput(req *SubscriptionRequest) domain.SubscriptionInput {
+	return domain.SubscriptionInput{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+		Enabled:    req.Enabled,
+	}
+}
+
+// toSubscriptionResponse converts a domain subscription to its response representation without the secret
+func toSubscriptionResponse(subscription *domain.Subscription) *SubscriptionResponse {
+	return &SubscriptionResponse{
+		ID:                  subscription.ID,
+		URL:                 subscription.URL,
+		EventTypes:          subscription.EventTypes,
+		Enabled:             subscription.Enabled,
+		ConsecutiveFailures: subscription.ConsecutiveFailures,
+		DisabledAt:          subscription.DisabledAt,
+		CreatedAt:           subscription.CreatedAt,
+		UpdatedAt:           subscription.UpdatedAt,
+	}
+}
+
+// toDeliveryResponse converts a domain delivery to its response representation
+func toDeliveryResponse(delivery *domain.Delivery) *DeliveryResponse {
+	return &DeliveryResponse{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastResponse:   delivery.LastResponse,
+		LastError:      delivery.LastError,
+		LastDurationMs: delivery.LastDuration.Milliseconds(),
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
@@ -0,0 +1,424 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-echo/internal/webhook/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// webhookDeliveryColumns lists delivery columns in sqlc.WebhookDeliveries scan order
+const webhookDeliveryColumns = "id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_response, last_error, last_duration_ms, delivered_at, created_at"
+
+// WebhookRepository implements domain.WebhookRepository using sqlc generated code
+type WebhookRepository struct {
+	q  sqlc.Querier
+	db sqlc.DBTX
+}
+
+// New creates a new webhook repository with sqlc querier. The db connection
+// backs the filtered delivery log listing.
+func New(q sqlc.Querier, db sqlc.DBTX) *WebhookRepository {
+	return &WebhookRepository{q: q, db: db}
+}
+
+// CreateSubscription creates a new subscription
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, subscription *domain.Subscription) error {
+	eventTypes, err := json.Marshal(subscription.EventTypes)
+	if err != nil {
+		return err
+	}
+
+	params := sqlc.CreateWebhookSubscriptionParams{
+		ID:         subscription.ID,
+		Url:        subscription.URL,
+		EventTypes: eventTypes,
+		Secret:     subscription.Secret,
+		Enabled:    subscription.Enabled,
+	}
+
+	if err := r.q.CreateWebhookSubscription(ctx, params); err != nil {
+		slog.Error("failed to create webhook subscription", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// GetSubscription retrieves a subscription by ID
+func (r *WebhookRepository) GetSubscription(ctx context.Context, id string) (*domain.Subscription, error) {
+	row, err := r.q.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		slog.Error("failed to get webhook subscription", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return sqlcSubscriptionToDomain(&row), nil
+}
+
+// ListSubscriptions retrieves all subscriptions, oldest first
+func (r *WebhookRepository) ListSubscriptions(ctx context.Context) ([]*domain.Subscription, error) {
+	rows, err := r.q.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		slog.Error("failed to list webhook subscriptions", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return sqlcSubscriptionsToDomain(rows), nil
+}
+
+// ListEnabledSubscriptions retrieves the subscriptions that receive deliveries
+func (r *WebhookRepository) ListEnabledSubscriptions(ctx context.Context) ([]*domain.Subscription, error) {
+	rows, err := r.q.ListEnabledWebhookSubscriptions(ctx)
+	if err != nil {
+		slog.Error("failed to list enabled webhook subscriptions", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return sqlcSubscriptionsToDomain(rows), nil
+}
+
+// UpdateSubscription updates an existing subscription
+func (r *WebhookRepository) UpdateSubscription(ctx context.Context, subscription *domain.Subscription) error {
+	eventTypes, err := json.Marshal(subscription.EventTypes)
+	if err != nil {
+		return err
+	}
+
+	params := sqlc.UpdateWebhookSubscriptionParams{
+		Url:                 subscription.URL,
+		EventTypes:          eventTypes,
+		Secret:              subscription.Secret,
+		Enabled:             subscription.Enabled,
+		ConsecutiveFailures: uint32(subscription.ConsecutiveFailures),
+		ID:                  subscription.ID,
+	}
+	if subscription.DisabledAt != nil {
+		params.DisabledAt = sql.NullTime{Time: *subscription.DisabledAt, Valid: true}
+	}
+
+	if err := r.q.UpdateWebhookSubscription(ctx, params); err != nil {
+		slog.Error("failed to update webhook subscription", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSubscription deletes a subscription and its delivery log, reporting whether it existed
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id string) (bool, error) {
+	deleted, err := r.q.DeleteWebhookSubscription(ctx, id)
+	if err != nil {
+		slog.Error("failed to delete webhook subscription", slog.String("error", err.Error()))
+		return false, err
+	}
+
+	return deleted > 0, nil
+}
+
+// RecordSuccess resets the consecutive failure count of a subscription
+func (r *WebhookRepository) RecordSuccess(ctx context.Context, subscriptionID string) error {
+	if err := r.q.RecordWebhookSuccess(ctx, subscriptionID); err != nil {
+		slog.Error("failed to record webhook success", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// RecordFailure increments the consecutive failure count of a subscription and
+// disables it once the count reaches disableAfter, reporting whether it was disabled
+func (r *WebhookRepository) RecordFailure(ctx context.Context, subscriptionID string, disableAfter int) (bool, error) {
+	if err := r.q.RecordWebhookFailure(ctx, subscriptionID); err != nil {
+		slog.Error("failed to record webhook failure", slog.String("error", err.Error()))
+		return false, err
+	}
+
+	params := sqlc.DisableWebhookSubscriptionParams{
+		ID:                  subscriptionID,
+		ConsecutiveFailures: uint32(disableAfter),
+	}
+
+	disabled, err := r.q.DisableWebhookSubscription(ctx, params)
+	if err != nil {
+		slog.Error("failed to disable webhook subscription", slog.String("error", err.Error()))
+		return false, err
+	}
+
+	return disabled > 0, nil
+}
+
+// CreateDelivery queues a delivery unless one exists for the same subscription and
+// event, reporting whether it was created
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, delivery *domain.Delivery) (bool, error) {
+	params := sqlc.CreateWebhookDeliveryParams{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		NextAttemptAt:  delivery.NextAttemptAt,
+	}
+
+	created, err := r.q.CreateWebhookDelivery(ctx, params)
+	if err != nil {
+		slog.Error("failed to create webhook delivery", slog.String("error", err.Error()))
+		return false, err
+	}
+
+	return created > 0, nil
+}
+
+// GetDelivery retrieves a delivery by ID
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id string) (*domain.Delivery, error) {
+	row, err := r.q.GetWebhookDelivery(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		slog.Error("failed to get webhook delivery", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return sqlcDeliveryToDomain(&row), nil
+}
+
+// ListDeliveries retrieves up to limit deliveries of a subscription, newest first,
+// optionally filtered by status and starting after the given keyset position
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, subscriptionID, status string, after *domain.DeliveryCursor, limit int) ([]*domain.Delivery, error) {
+	where := "subscription_id = ?"
+	args := []interface{}{subscriptionID}
+	if status != "" {
+		where += " AND status = ?"
+		args = append(args, status)
+	}
+	if after != nil {
+		createdAt, err := time.Parse(time.RFC3339Nano, after.CreatedAt)
+		if err != nil {
+			return nil, pkg.ErrInvalidCursor
+		}
+		where += " AND (created_at < ? OR (created_at = ? AND id < ?))"
+		args = append(args, createdAt, createdAt, after.ID)
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf("-- name: ListWebhookDeliveriesPage :many\nSELECT %s FROM webhook_deliveries WHERE %s ORDER BY created_at DESC, id DESC LIMIT ?",
+		webhookDeliveryColumns, where)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		slog.Error("failed to list webhook deliveries", slog.String("error", err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*domain.Delivery
+	for rows.Next() {
+		var i sqlc.WebhookDeliveries
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubscriptionID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastStatusCode,
+			&i.LastResponse,
+			&i.LastError,
+			&i.LastDurationMs,
+			&i.DeliveredAt,
+			&i.CreatedAt,
+		); err != nil {
+			slog.Error("failed to scan webhook delivery", slog.String("error", err.Error()))
+			return nil, err
+		}
+		deliveries = append(deliveries, sqlcDeliveryToDomain(&i))
+	}
+	if err := rows.Err(); err != nil {
+		slog.Error("failed to list webhook deliveries", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// ListDueDeliveries retrieves up to limit pending deliveries due at now for enabled subscriptions
+func (r *WebhookRepository) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.PendingDelivery, error) {
+	params := sqlc.ListDueWebhookDeliveriesParams{
+		NextAttemptAt: now,
+		Limit:         int32(limit),
+	}
+
+	rows, err := r.q.ListDueWebhookDeliveries(ctx, params)
+	if err != nil {
+		slog.Error("failed to list due webhook deliveries", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	deliveries := make([]*domain.PendingDelivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = &domain.PendingDelivery{
+			ID:             row.ID,
+			SubscriptionID: row.SubscriptionID,
+			EventID:        row.EventID,
+			EventType:      row.EventType,
+			Payload:        row.Payload,
+			Attempts:       int(row.Attempts),
+			URL:            row.Url,
+			Secret:         row.Secret,
+		}
+	}
+
+	return deliveries, nil
+}
+
+// ClaimDelivery leases a due delivery until leaseUntil, reporting whether this caller won it
+func (r *WebhookRepository) ClaimDelivery(ctx context.Context, id string, now, leaseUntil time.Time) (bool, error) {
+	params := sqlc.ClaimWebhookDeliveryParams{
+		LeaseUntil: leaseUntil,
+		ID:         id,
+		Now:        now,
+	}
+
+	claimed, err := r.q.ClaimWebhookDelivery(ctx, params)
+	if err != nil {
+		slog.Error("failed to claim webhook delivery", slog.String("error", err.Error()))
+		return false, err
+	}
+
+	return claimed > 0, nil
+}
+
+// MarkDelivered records a successful attempt
+func (r *WebhookRepository) MarkDelivered(ctx context.Context, id string, result domain.AttemptResult, deliveredAt time.Time) error {
+	params := sqlc.MarkWebhookDeliverySucceededParams{
+		LastStatusCode: sql.NullInt32{Int32: int32(result.StatusCode), Valid: result.StatusCode != 0},
+		LastResponse:   truncatedNullString(result.Response, domain.MaxResponseLength),
+		LastDurationMs: sql.NullInt32{Int32: int32(result.Duration.Milliseconds()), Valid: true},
+		DeliveredAt:    sql.NullTime{Time: deliveredAt, Valid: true},
+		ID:             id,
+	}
+
+	if err := r.q.MarkWebhookDeliverySucceeded(ctx, params); err != nil {
+		slog.Error("failed to mark webhook delivery succeeded", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// MarkAttemptFailed records a failed attempt with the resulting status and next attempt time
+func (r *WebhookRepository) MarkAttemptFailed(ctx context.Context, id, status string, result domain.AttemptResult, nextAttemptAt time.Time) error {
+	params := sqlc.MarkWebhookDeliveryFailedParams{
+		Status:         status,
+		NextAttemptAt:  nextAttemptAt,
+		LastStatusCode: sql.NullInt32{Int32: int32(result.StatusCode), Valid: result.StatusCode != 0},
+		LastResponse:   truncatedNullString(result.Response, domain.MaxResponseLength),
+		LastError:      truncatedNullString(result.Error, domain.MaxErrorLength),
+		LastDurationMs: sql.NullInt32{Int32: int32(result.Duration.Milliseconds()), Valid: true},
+		ID:             id,
+	}
+
+	if err := r.q.MarkWebhookDeliveryFailed(ctx, params); err != nil {
+		slog.Error("failed to mark webhook delivery failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// ResetDelivery makes a delivery pending again with a fresh attempt count
+func (r *WebhookRepository) ResetDelivery(ctx context.Context, id string, nextAttemptAt time.Time) error {
+	params := sqlc.ResetWebhookDeliveryParams{
+		NextAttemptAt: nextAttemptAt,
+		ID:            id,
+	}
+
+	if err := r.q.ResetWebhookDelivery(ctx, params); err != nil {
+		slog.Error("failed to reset webhook delivery", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// truncatedNullString converts s to a nullable column value of at most limit bytes
+func truncatedNullString(s string, limit int) sql.NullString {
+	if len(s) > limit {
+		s = s[:limit]
+	}
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// Helper functions to convert sqlc types to domain types
+
+func sqlcSubscriptionsToDomain(rows []sqlc.WebhookSubscriptions) []*domain.Subscription {
+	subscriptions := make([]*domain.Subscription, len(rows))
+	for i := range rows {
+		subscriptions[i] = sqlcSubscriptionToDomain(&rows[i])
+	}
+	return subscriptions
+}
+
+func sqlcSubscriptionToDomain(row *sqlc.WebhookSubscriptions) *domain.Subscription {
+	subscription := &domain.Subscription{
+		ID:                  row.ID,
+		URL:                 row.Url,
+		Secret:              row.Secret,
+		Enabled:             row.Enabled,
+		ConsecutiveFailures: int(row.ConsecutiveFailures),
+	}
+
+	if err := json.Unmarshal(row.EventTypes, &subscription.EventTypes); err != nil {
+		slog.Warn("failed to decode webhook event types", slog.String("subscription_id", row.ID), slog.String("error", err.Error()))
+	}
+
+	if row.DisabledAt.Valid {
+		subscription.DisabledAt = &row.DisabledAt.Time
+	}
+
+	if row.CreatedAt.Valid {
+		subscription.CreatedAt = row.CreatedAt.Time
+	}
+
+	if row.UpdatedAt.Valid {
+		subscription.UpdatedAt = row.UpdatedAt.Time
+	}
+
+	return subscription
+}
+
+func sqlcDeliveryToDomain(row *sqlc.WebhookDeliveries) *domain.Delivery {
+	delivery := &domain.Delivery{
+		ID:             row.ID,
+		SubscriptionID: row.SubscriptionID,
+		EventID:        row.EventID,
+		EventType:      row.EventType,
+		Payload:        row.Payload,
+		Status:         row.Status,
+		Attempts:       int(row.Attempts),
+		NextAttemptAt:  row.NextAttemptAt,
+		LastStatusCode: int(row.LastStatusCode.Int32),
+		LastResponse:   row.LastResponse.String,
+		LastError:      row.LastError.String,
+		LastDuration:   time.Duration(row.LastDurationMs.Int32) * time.Millisecond,
+		CreatedAt:      row.CreatedAt,
+	}
+
+	if row.DeliveredAt.Valid {
+		delivery.DeliveredAt = &row.DeliveredAt.Time
+	}
+
+	return delivery
+}
@@ -0,0 +1,229 @@
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	outboxdomain "github.com/zercle/template-go-echo/internal/outbox/domain"
+	"github.com/zercle/template-go-echo/internal/webhook/domain"
+	"github.com/zercle/template-go-echo/internal/webhook/test/mocks"
+	"github.com/zercle/template-go-echo/internal/webhook/usecase"
+)
+
+// endpoint is a webhook receiver that verifies signatures and answers with a configurable status
+type endpoint struct {
+	mu       sync.Mutex
+	secret   string
+	status   int
+	received []domain.Envelope
+	invalid  int
+}
+
+func (e *endpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	err := domain.VerifySignature(e.secret,
+		r.Header.Get(domain.HeaderWebhookTimestamp),
+		r.Header.Get(domain.HeaderWebhookSignature),
+		body, time.Now())
+	if err != nil {
+		e.invalid++
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var envelope domain.Envelope
+	_ = json.Unmarshal(body, &envelope)
+	if r.Header.Get(domain.HeaderWebhookEvent) != envelope.Type {
+		e.invalid++
+	}
+	e.received = append(e.received, envelope)
+	w.WriteHeader(e.status)
+	_, _ = w.Write([]byte("ok"))
+}
+
+func (e *endpoint) setStatus(status int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.status = status
+}
+
+func newMessage(eventType string) *outboxdomain.Message {
+	return &outboxdomain.Message{
+		EventID:       uuid.New().String(),
+		AggregateType: "user",
+		AggregateID:   "user-1",
+		EventType:     eventType,
+		Payload:       json.RawMessage(`{"user_id":"user-1"}`),
+		OccurredAt:    time.Now(),
+	}
+}
+
+// setup creates a subscription pointing at a test endpoint
+func setup(t *testing.T, eventTypes ...string) (*mocks.MockWebhookRepository, *endpoint, *domain.Subscription) {
+	t.Helper()
+	repo := mocks.NewMockRepository()
+	receiver := &endpoint{status: http.StatusOK}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	subscription, err := usecase.New(repo).CreateSubscription(context.Background(), domain.SubscriptionInput{
+		URL:        server.URL,
+		EventTypes: eventTypes,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	receiver.secret = subscription.Secret
+	return repo, receiver, subscription
+}
+
+func TestDeliverSignedEvent(t *testing.T) {
+	repo, receiver, _ := setup(t, "user.registered")
+	dispatcher := usecase.NewDispatcher(repo)
+	deliverer := usecase.NewDeliverer(repo)
+
+	message := newMessage("user.registered")
+	if err := dispatcher.Publish(context.Background(), message); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Unsubscribed events and relay retries do not queue deliveries
+	_ = dispatcher.Publish(context.Background(), newMessage("user.deleted"))
+	_ = dispatcher.Publish(context.Background(), message)
+
+	attempted, err := deliverer.DeliverOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempted != 1 {
+		t.Fatalf("expected 1 delivery attempt, got %d", attempted)
+	}
+
+	if receiver.invalid != 0 || len(receiver.received) != 1 {
+		t.Fatalf("expected 1 valid delivery, got %d valid and %d invalid", len(receiver.received), receiver.invalid)
+	}
+	envelope := receiver.received[0]
+	if envelope.ID != message.EventID || envelope.Type != message.EventType || string(envelope.Data) != string(message.Payload) {
+		t.Errorf("unexpected envelope: %+v", envelope)
+	}
+
+	delivery := repo.Deliveries()[0]
+	if delivery.Status != domain.DeliveryStatusSucceeded || delivery.Attempts != 1 || delivery.DeliveredAt == nil {
+		t.Errorf("expected succeeded delivery, got %+v", delivery)
+	}
+	if delivery.LastStatusCode != http.StatusOK || delivery.LastResponse != "ok" {
+		t.Errorf("expected response to be logged, got %d %q", delivery.LastStatusCode, delivery.LastResponse)
+	}
+}
+
+func TestDeliverRetriesWithBackoff(t *testing.T) {
+	repo, receiver, subscription := setup(t, "*")
+	receiver.setStatus(http.StatusInternalServerError)
+	deliverer := usecase.NewDeliverer(repo, usecase.WithMaxAttempts(3))
+
+	_ = usecase.NewDispatcher(repo).Publish(context.Background(), newMessage("user.deleted"))
+
+	before := time.Now()
+	_, _ = deliverer.DeliverOnce(context.Background())
+
+	delivery := repo.Deliveries()[0]
+	if delivery.Status != domain.DeliveryStatusPending || delivery.Attempts != 1 {
+		t.Fatalf("expected pending delivery after 1 attempt, got %+v", delivery)
+	}
+	if delivery.NextAttemptAt.Before(before.Add(domain.MinRetryBackoff)) {
+		t.Errorf("expected next attempt after backoff, got %v", delivery.NextAttemptAt)
+	}
+
+	// Not due yet
+	if attempted, _ := deliverer.DeliverOnce(context.Background()); attempted != 0 {
+		t.Errorf("expected no attempt during backoff, got %d", attempted)
+	}
+
+	for i := 0; i < 2; i++ {
+		repo.SetNextAttempt(delivery.ID, time.Now())
+		_, _ = deliverer.DeliverOnce(context.Background())
+	}
+
+	delivery = repo.Deliveries()[0]
+	if delivery.Status != domain.DeliveryStatusFailed || delivery.Attempts != 3 {
+		t.Errorf("expected failed delivery after max attempts, got %+v", delivery)
+	}
+
+	// Redelivery resets the attempt count and succeeds once the endpoint recovers
+	receiver.setStatus(http.StatusNoContent)
+	redelivered, err := usecase.New(repo).Redeliver(context.Background(), subscription.ID, delivery.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if redelivered.Status != domain.DeliveryStatusPending || redelivered.Attempts != 0 {
+		t.Errorf("expected reset delivery, got %+v", redelivered)
+	}
+	_, _ = deliverer.DeliverOnce(context.Background())
+
+	if delivery = repo.Deliveries()[0]; delivery.Status != domain.DeliveryStatusSucceeded {
+		t.Errorf("expected redelivery to succeed, got %+v", delivery)
+	}
+	if _, err := usecase.New(repo).Redeliver(context.Background(), subscription.ID, "missing"); err != domain.ErrDeliveryNotFound {
+		t.Errorf("expected ErrDeliveryNotFound, got %v", err)
+	}
+}
+
+func TestDeliverDisablesFailingSubscription(t *testing.T) {
+	repo, receiver, subscription := setup(t, "*")
+	receiver.setStatus(http.StatusServiceUnavailable)
+	deliverer := usecase.NewDeliverer(repo, usecase.WithDisableAfter(2))
+	dispatcher := usecase.NewDispatcher(repo)
+
+	for i := 0; i < 3; i++ {
+		_ = dispatcher.Publish(context.Background(), newMessage("user.registered"))
+	}
+	_, _ = deliverer.DeliverOnce(context.Background())
+
+	disabled, _ := repo.GetSubscription(context.Background(), subscription.ID)
+	if disabled.Enabled || disabled.DisabledAt == nil {
+		t.Fatalf("expected subscription to be disabled, got %+v", disabled)
+	}
+
+	// Disabled subscriptions receive no new deliveries and pending ones are held
+	_ = dispatcher.Publish(context.Background(), newMessage("user.registered"))
+	if got := len(repo.Deliveries()); got != 3 {
+		t.Errorf("expected no deliveries queued while disabled, got %d", got)
+	}
+	for _, delivery := range repo.Deliveries() {
+		repo.SetNextAttempt(delivery.ID, time.Now())
+	}
+	if attempted, _ := deliverer.DeliverOnce(context.Background()); attempted != 0 {
+		t.Errorf("expected no attempts while disabled, got %d", attempted)
+	}
+}
+
+func TestDeliverDoesNotFollowRedirects(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected redirect not to be followed")
+	}))
+	defer target.Close()
+	redirect := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusFound))
+	defer redirect.Close()
+
+	_, _ = usecase.New(repo).CreateSubscription(context.Background(), domain.SubscriptionInput{
+		URL:        redirect.URL,
+		EventTypes: []string{"*"},
+	})
+	_ = usecase.NewDispatcher(repo).Publish(context.Background(), newMessage("user.registered"))
+	_, _ = usecase.NewDeliverer(repo).DeliverOnce(context.Background())
+
+	delivery := repo.Deliveries()[0]
+	if delivery.Status != domain.DeliveryStatusPending || delivery.LastStatusCode != http.StatusFound {
+		t.Errorf("expected redirect to count as a failed attempt, got %+v", delivery)
+	}
+}
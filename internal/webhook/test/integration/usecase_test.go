@@ -0,0 +1,148 @@
+package integration_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zercle/template-go-echo/internal/webhook/domain"
+	"github.com/zercle/template-go-echo/internal/webhook/test/mocks"
+	"github.com/zercle/template-go-echo/internal/webhook/usecase"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+func TestCreateSubscription(t *testing.T) {
+	uc := usecase.New(mocks.NewMockRepository())
+
+	subscription, err := uc.CreateSubscription(context.Background(), domain.SubscriptionInput{
+		URL:        "https://example.com/hooks",
+		EventTypes: []string{"user.registered", "user.deleted", "user.registered"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !subscription.Enabled {
+		t.Error("expected subscription to be enabled by default")
+	}
+	if len(subscription.EventTypes) != 2 {
+		t.Errorf("expected duplicate event types to be removed, got %v", subscription.EventTypes)
+	}
+	if !strings.HasPrefix(subscription.Secret, domain.SecretPrefix) {
+		t.Errorf("expected generated secret, got %q", subscription.Secret)
+	}
+}
+
+func TestCreateSubscriptionValidation(t *testing.T) {
+	uc := usecase.New(mocks.NewMockRepository())
+
+	cases := []struct {
+		name  string
+		input domain.SubscriptionInput
+		want  error
+	}{
+		{"relative url", domain.SubscriptionInput{URL: "/hooks", EventTypes: []string{"*"}}, domain.ErrInvalidWebhookURL},
+		{"unsupported scheme", domain.SubscriptionInput{URL: "ftp://example.com", EventTypes: []string{"*"}}, domain.ErrInvalidWebhookURL},
+		{"no event types", domain.SubscriptionInput{URL: "https://example.com"}, domain.ErrInvalidEventTypes},
+		{"unknown event type", domain.SubscriptionInput{URL: "https://example.com", EventTypes: []string{"order.created"}}, domain.ErrInvalidEventTypes},
+		{"short secret", domain.SubscriptionInput{URL: "https://example.com", EventTypes: []string{"*"}, Secret: "short"}, domain.ErrInvalidSecret},
+	}
+	for _, tc := range cases {
+		if _, err := uc.CreateSubscription(context.Background(), tc.input); err != tc.want {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+}
+
+func TestUpdateSubscriptionReenables(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo)
+
+	subscription, _ := uc.CreateSubscription(context.Background(), domain.SubscriptionInput{
+		URL:        "https://example.com/hooks",
+		EventTypes: []string{"*"},
+	})
+	for i := 0; i < 3; i++ {
+		_, _ = repo.RecordFailure(context.Background(), subscription.ID, 3)
+	}
+
+	enabled := true
+	updated, err := uc.UpdateSubscription(context.Background(), subscription.ID, domain.SubscriptionInput{
+		URL:        "https://example.com/v2/hooks",
+		EventTypes: []string{"user.deleted"},
+		Enabled:    &enabled,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !updated.Enabled || updated.ConsecutiveFailures != 0 || updated.DisabledAt != nil {
+		t.Errorf("expected re-enabled subscription with cleared failures, got %+v", updated)
+	}
+	if updated.Secret != subscription.Secret {
+		t.Error("expected secret to be kept when omitted")
+	}
+
+	if _, err := uc.UpdateSubscription(context.Background(), "missing", domain.SubscriptionInput{
+		URL:        "https://example.com",
+		EventTypes: []string{"*"},
+	}); err != domain.ErrSubscriptionNotFound {
+		t.Errorf("expected ErrSubscriptionNotFound, got %v", err)
+	}
+}
+
+func TestDeleteSubscription(t *testing.T) {
+	uc := usecase.New(mocks.NewMockRepository())
+
+	subscription, _ := uc.CreateSubscription(context.Background(), domain.SubscriptionInput{
+		URL:        "https://example.com/hooks",
+		EventTypes: []string{"*"},
+	})
+
+	if err := uc.DeleteSubscription(context.Background(), subscription.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.DeleteSubscription(context.Background(), subscription.ID); err != domain.ErrSubscriptionNotFound {
+		t.Errorf("expected ErrSubscriptionNotFound, got %v", err)
+	}
+}
+
+func TestListDeliveriesPagination(t *testing.T) {
+	repo := mocks.NewMockRepository()
+	uc := usecase.New(repo)
+	dispatcher := usecase.NewDispatcher(repo)
+
+	subscription, _ := uc.CreateSubscription(context.Background(), domain.SubscriptionInput{
+		URL:        "https://example.com/hooks",
+		EventTypes: []string{"*"},
+	})
+	for i := 0; i < 5; i++ {
+		_ = dispatcher.Publish(context.Background(), newMessage("user.registered"))
+	}
+
+	first, err := uc.ListDeliveries(context.Background(), domain.DeliveryQuery{SubscriptionID: subscription.ID, Limit: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(first.Items) != 3 || !first.HasMore || first.NextCursor == "" {
+		t.Fatalf("expected a full first page with a cursor, got %+v", first)
+	}
+
+	second, err := uc.ListDeliveries(context.Background(), domain.DeliveryQuery{SubscriptionID: subscription.ID, Limit: 3, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(second.Items) != 2 || second.HasMore {
+		t.Errorf("expected 2 remaining deliveries, got %+v", second)
+	}
+
+	if _, err := uc.ListDeliveries(context.Background(), domain.DeliveryQuery{SubscriptionID: subscription.ID, Status: "unknown"}); err != domain.ErrInvalidStatus {
+		t.Errorf("expected ErrInvalidStatus, got %v", err)
+	}
+	if _, err := uc.ListDeliveries(context.Background(), domain.DeliveryQuery{SubscriptionID: subscription.ID, Cursor: "not-a-cursor"}); err != pkg.ErrInvalidCursor {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+	if _, err := uc.ListDeliveries(context.Background(), domain.DeliveryQuery{SubscriptionID: "missing"}); err != domain.ErrSubscriptionNotFound {
+		t.Errorf("expected ErrSubscriptionNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,306 @@
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/webhook/domain"
+)
+
+// MockWebhookRepository is a simple in-memory webhook store for testing
+type MockWebhookRepository struct {
+	mu            sync.Mutex
+	subscriptions map[string]*domain.Subscription
+	deliveries    []*domain.Delivery
+}
+
+// NewMockRepository creates a new mock repository
+func NewMockRepository() *MockWebhookRepository {
+	return &MockWebhookRepository{
+		subscriptions: make(map[string]*domain.Subscription),
+	}
+}
+
+// CreateSubscription creates a new subscription
+func (m *MockWebhookRepository) CreateSubscription(ctx context.Context, subscription *domain.Subscription) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copied := *subscription
+	m.subscriptions[subscription.ID] = &copied
+	return nil
+}
+
+// GetSubscription retrieves a subscription by ID
+func (m *MockWebhookRepository) GetSubscription(ctx context.Context, id string) (*domain.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	subscription, ok := m.subscriptions[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *subscription
+	return &copied, nil
+}
+
+// ListSubscriptions retrieves all subscriptions, oldest first
+func (m *MockWebhookRepository) ListSubscriptions(ctx context.Context) ([]*domain.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	subscriptions := make([]*domain.Subscription, 0, len(m.subscriptions))
+	for _, subscription := range m.subscriptions {
+		copied := *subscription
+		subscriptions = append(subscriptions, &copied)
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		if !subscriptions[i].CreatedAt.Equal(subscriptions[j].CreatedAt) {
+			return subscriptions[i].CreatedAt.Before(subscriptions[j].CreatedAt)
+		}
+		return subscriptions[i].ID < subscriptions[j].ID
+	})
+	return subscriptions, nil
+}
+
+// ListEnabledSubscriptions retrieves the subscriptions that receive deliveries
+func (m *MockWebhookRepository) ListEnabledSubscriptions(ctx context.Context) ([]*domain.Subscription, error) {
+	subscriptions, _ := m.ListSubscriptions(ctx)
+	var enabled []*domain.Subscription
+	for _, subscription := range subscriptions {
+		if subscription.Enabled {
+			enabled = append(enabled, subscription)
+		}
+	}
+	return enabled, nil
+}
+
+// UpdateSubscription updates an existing subscription
+func (m *MockWebhookRepository) UpdateSubscription(ctx context.Context, subscription *domain.Subscription) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.subscriptions[subscription.ID]; ok {
+		copied := *subscription
+		m.subscriptions[subscription.ID] = &copied
+	}
+	return nil
+}
+
+// DeleteSubscription deletes a subscription and its deliveries
+func (m *MockWebhookRepository) DeleteSubscription(ctx context.Context, id string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.subscriptions[id]; !ok {
+		return false, nil
+	}
+	delete(m.subscriptions, id)
+
+	deliveries := m.deliveries[:0]
+	for _, delivery := range m.deliveries {
+		if delivery.SubscriptionID != id {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	m.deliveries = deliveries
+	return true, nil
+}
+
+// RecordSuccess resets the consecutive failure count of a subscription
+func (m *MockWebhookRepository) RecordSuccess(ctx context.Context, subscriptionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if subscription, ok := m.subscriptions[subscriptionID]; ok {
+		subscription.ConsecutiveFailures = 0
+	}
+	return nil
+}
+
+// RecordFailure increments the failure count and disables the subscription at disableAfter
+func (m *MockWebhookRepository) RecordFailure(ctx context.Context, subscriptionID string, disableAfter int) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	subscription, ok := m.subscriptions[subscriptionID]
+	if !ok {
+		return false, nil
+	}
+	subscription.ConsecutiveFailures++
+	if disableAfter > 0 && subscription.Enabled && subscription.ConsecutiveFailures >= disableAfter {
+		now := time.Now()
+		subscription.Enabled = false
+		subscription.DisabledAt = &now
+		return true, nil
+	}
+	return false, nil
+}
+
+// CreateDelivery queues a delivery unless one exists for the same subscription and event
+func (m *MockWebhookRepository) CreateDelivery(ctx context.Context, delivery *domain.Delivery) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, existing := range m.deliveries {
+		if existing.SubscriptionID == delivery.SubscriptionID && existing.EventID == delivery.EventID {
+			return false, nil
+		}
+	}
+	copied := *delivery
+	copied.Status = domain.DeliveryStatusPending
+	copied.CreatedAt = time.Now()
+	m.deliveries = append(m.deliveries, &copied)
+	return true, nil
+}
+
+// GetDelivery retrieves a delivery by ID
+func (m *MockWebhookRepository) GetDelivery(ctx context.Context, id string) (*domain.Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if delivery := m.find(id); delivery != nil {
+		copied := *delivery
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+// ListDeliveries retrieves deliveries of a subscription, newest first
+func (m *MockWebhookRepository) ListDeliveries(ctx context.Context, subscriptionID, status string, after *domain.DeliveryCursor, limit int) ([]*domain.Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var deliveries []*domain.Delivery
+	for i := len(m.deliveries) - 1; i >= 0; i-- {
+		delivery := m.deliveries[i]
+		if delivery.SubscriptionID != subscriptionID || (status != "" && delivery.Status != status) {
+			continue
+		}
+		copied := *delivery
+		deliveries = append(deliveries, &copied)
+	}
+
+	// Deliveries are stored in creation order, so the cursor ID marks the position
+	if after != nil {
+		for i, delivery := range deliveries {
+			if delivery.ID == after.ID {
+				deliveries = deliveries[i+1:]
+				break
+			}
+		}
+	}
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+// ListDueDeliveries retrieves pending deliveries due at now for enabled subscriptions
+func (m *MockWebhookRepository) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.PendingDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var due []*domain.PendingDelivery
+	for _, delivery := range m.deliveries {
+		subscription := m.subscriptions[delivery.SubscriptionID]
+		if delivery.Status != domain.DeliveryStatusPending || delivery.NextAttemptAt.After(now) || subscription == nil || !subscription.Enabled {
+			continue
+		}
+		due = append(due, &domain.PendingDelivery{
+			ID:             delivery.ID,
+			SubscriptionID: delivery.SubscriptionID,
+			EventID:        delivery.EventID,
+			EventType:      delivery.EventType,
+			Payload:        delivery.Payload,
+			Attempts:       delivery.Attempts,
+			URL:            subscription.URL,
+			Secret:         subscription.Secret,
+		})
+		if len(due) == limit {
+			break
+		}
+	}
+	return due, nil
+}
+
+// ClaimDelivery leases a due delivery until leaseUntil
+func (m *MockWebhookRepository) ClaimDelivery(ctx context.Context, id string, now, leaseUntil time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delivery := m.find(id)
+	if delivery == nil || delivery.Status != domain.DeliveryStatusPending || delivery.NextAttemptAt.After(now) {
+		return false, nil
+	}
+	delivery.NextAttemptAt = leaseUntil
+	return true, nil
+}
+
+// MarkDelivered records a successful attempt
+func (m *MockWebhookRepository) MarkDelivered(ctx context.Context, id string, result domain.AttemptResult, deliveredAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if delivery := m.find(id); delivery != nil {
+		delivery.Status = domain.DeliveryStatusSucceeded
+		delivery.Attempts++
+		delivery.LastStatusCode = result.StatusCode
+		delivery.LastResponse = result.Response
+		delivery.LastError = ""
+		delivery.LastDuration = result.Duration
+		delivery.DeliveredAt = &deliveredAt
+	}
+	return nil
+}
+
+// MarkAttemptFailed records a failed attempt
+func (m *MockWebhookRepository) MarkAttemptFailed(ctx context.Context, id, status string, result domain.AttemptResult, nextAttemptAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if delivery := m.find(id); delivery != nil {
+		delivery.Status = status
+		delivery.Attempts++
+		delivery.NextAttemptAt = nextAttemptAt
+		delivery.LastStatusCode = result.StatusCode
+		delivery.LastResponse = result.Response
+		delivery.LastError = result.Error
+		delivery.LastDuration = result.Duration
+	}
+	return nil
+}
+
+// ResetDelivery makes a delivery pending again with a fresh attempt count
+func (m *MockWebhookRepository) ResetDelivery(ctx context.Context, id string, nextAttemptAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if delivery := m.find(id); delivery != nil {
+		delivery.Status = domain.DeliveryStatusPending
+		delivery.Attempts = 0
+		delivery.NextAttemptAt = nextAttemptAt
+		delivery.DeliveredAt = nil
+	}
+	return nil
+}
+
+// SetNextAttempt moves the next attempt of a delivery, letting tests skip its backoff
+func (m *MockWebhookRepository) SetNextAttempt(id string, at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if delivery := m.find(id); delivery != nil {
+		delivery.NextAttemptAt = at
+	}
+}
+
+// Deliveries returns copies of all deliveries in creation order
+func (m *MockWebhookRepository) Deliveries() []*domain.Delivery {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	deliveries := make([]*domain.Delivery, len(m.deliveries))
+	for i, delivery := range m.deliveries {
+		copied := *delivery
+		deliveries[i] = &copied
+	}
+	return deliveries
+}
+
+// find returns the stored delivery with the given ID; callers hold the lock
+func (m *MockWebhookRepository) find(id string) *domain.Delivery {
+	for _, delivery := range m.deliveries {
+		if delivery.ID == id {
+			return delivery
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package unit_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/webhook/domain"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"id":"event-1"}`)
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := domain.Sign("whsec_secret", now.Unix(), body)
+
+	if err := domain.VerifySignature("whsec_secret", timestamp, signature, body, now); err != nil {
+		t.Errorf("expected signature to verify, got %v", err)
+	}
+	if err := domain.VerifySignature("other-secret", timestamp, signature, body, now); err != domain.ErrSignatureMismatch {
+		t.Errorf("expected mismatch for wrong secret, got %v", err)
+	}
+	if err := domain.VerifySignature("whsec_secret", timestamp, signature, []byte(`{"id":"event-2"}`), now); err != domain.ErrSignatureMismatch {
+		t.Errorf("expected mismatch for tampered body, got %v", err)
+	}
+
+	// Replaying the same request later is rejected
+	later := now.Add(domain.SignatureTolerance + time.Second)
+	if err := domain.VerifySignature("whsec_secret", timestamp, signature, body, later); err != domain.ErrSignatureExpired {
+		t.Errorf("expected expired signature, got %v", err)
+	}
+}
+
+func TestSubscribes(t *testing.T) {
+	subscription := &domain.Subscription{EventTypes: []string{"user.registered"}}
+	if !subscription.Subscribes("user.registered") || subscription.Subscribes("user.deleted") {
+		t.Error("expected subscription to match only its event types")
+	}
+
+	all := &domain.Subscription{EventTypes: []string{domain.AllEvents}}
+	if !all.Subscribes("user.deleted") {
+		t.Error("expected * to match every event type")
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	cases := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, domain.MinRetryBackoff},
+		{2, 2 * domain.MinRetryBackoff},
+		{3, 4 * domain.MinRetryBackoff},
+		{50, domain.MaxRetryBackoff},
+	}
+	for _, tc := range cases {
+		if got := domain.RetryBackoff(tc.attempts); got != tc.want {
+			t.Errorf("RetryBackoff(%d) = %v, want %v", tc.attempts, got, tc.want)
+		}
+	}
+}
+
+func TestAttemptResultSucceeded(t *testing.T) {
+	cases := []struct {
+		result domain.AttemptResult
+		want   bool
+	}{
+		{domain.AttemptResult{StatusCode: 200}, true},
+		{domain.AttemptResult{StatusCode: 204}, true},
+		{domain.AttemptResult{StatusCode: 301}, false},
+		{domain.AttemptResult{StatusCode: 500}, false},
+		{domain.AttemptResult{Error: "connection refused"}, false},
+	}
+	for _, tc := range cases {
+		if got := tc.result.Succeeded(); got != tc.want {
+			t.Errorf("%+v: expected %v, got %v", tc.result, tc.want, got)
+		}
+	}
+}
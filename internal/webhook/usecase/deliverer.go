@@ -0,0 +1,260 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/zercle/template-go-echo/internal/webhook/domain"
+)
+
+// Deliverer sends queued deliveries to subscription endpoints, retrying failed
+// attempts with exponential backoff. Deliveries are leased before they are sent,
+// so several deliverers may share a database.
+type Deliverer struct {
+	repo           domain.WebhookRepository
+	client         *http.Client
+	pollInterval   time.Duration
+	batchSize      int
+	concurrency    int
+	requestTimeout time.Duration
+	maxAttempts    int
+	disableAfter   int
+}
+
+// DelivererOption configures optional Deliverer settings
+type DelivererOption func(*Deliverer)
+
+// WithPollInterval sets how often due deliveries are checked
+func WithPollInterval(interval time.Duration) DelivererOption {
+	return func(d *Deliverer) {
+		d.pollInterval = interval
+	}
+}
+
+// WithBatchSize sets the maximum number of deliveries read per poll
+func WithBatchSize(size int) DelivererOption {
+	return func(d *Deliverer) {
+		d.batchSize = size
+	}
+}
+
+// WithConcurrency sets how many deliveries are sent at the same time
+func WithConcurrency(concurrency int) DelivererOption {
+	return func(d *Deliverer) {
+		d.concurrency = concurrency
+	}
+}
+
+// WithRequestTimeout sets how long an endpoint may take to respond
+func WithRequestTimeout(timeout time.Duration) DelivererOption {
+	return func(d *Deliverer) {
+		d.requestTimeout = timeout
+	}
+}
+
+// WithMaxAttempts sets how many attempts are made before a delivery fails for good
+func WithMaxAttempts(attempts int) DelivererOption {
+	return func(d *Deliverer) {
+		d.maxAttempts = attempts
+	}
+}
+
+// WithDisableAfter sets how many consecutive failed attempts disable a subscription;
+// zero never disables
+func WithDisableAfter(failures int) DelivererOption {
+	return func(d *Deliverer) {
+		d.disableAfter = failures
+	}
+}
+
+// WithHTTPClient sets the client used to send deliveries
+func WithHTTPClient(client *http.Client) DelivererOption {
+	return func(d *Deliverer) {
+		d.client = client
+	}
+}
+
+// NewDeliverer creates a deliverer sending deliveries queued in repo
+func NewDeliverer(repo domain.WebhookRepository, opts ...DelivererOption) *Deliverer {
+	d := &Deliverer{
+		repo:           repo,
+		pollInterval:   domain.DefaultPollInterval,
+		batchSize:      domain.DefaultBatchSize,
+		concurrency:    domain.DefaultConcurrency,
+		requestTimeout: domain.DefaultRequestTimeout,
+		maxAttempts:    domain.DefaultMaxAttempts,
+		disableAfter:   domain.DefaultDisableAfter,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.client == nil {
+		d.client = &http.Client{
+			// Redirects are reported as failures rather than followed
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+	}
+	if d.concurrency < 1 {
+		d.concurrency = 1
+	}
+	return d
+}
+
+// Run polls for due deliveries until ctx is cancelled
+func (d *Deliverer) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Keep going while full batches indicate a backlog
+		for ctx.Err() == nil {
+			attempted, err := d.DeliverOnce(ctx)
+			if err != nil || attempted < d.batchSize {
+				break
+			}
+		}
+	}
+}
+
+// DeliverOnce sends one batch of due deliveries and returns how many were attempted
+func (d *Deliverer) DeliverOnce(ctx context.Context) (int, error) {
+	now := time.Now()
+	deliveries, err := d.repo.ListDueDeliveries(ctx, now, d.batchSize)
+	if err != nil {
+		slog.Error("failed to list due webhook deliveries", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		attempted int
+	)
+	sem := make(chan struct{}, d.concurrency)
+	// The lease outlasts the request so a slow attempt is not picked up twice
+	leaseUntil := now.Add(2 * d.requestTimeout)
+
+	for _, delivery := range deliveries {
+		claimed, err := d.repo.ClaimDelivery(ctx, delivery.ID, now, leaseUntil)
+		if err != nil {
+			slog.Error("failed to claim webhook delivery", slog.String("error", err.Error()))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(delivery *domain.PendingDelivery) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			d.deliver(ctx, delivery)
+
+			mu.Lock()
+			attempted++
+			mu.Unlock()
+		}(delivery)
+	}
+	wg.Wait()
+
+	return attempted, nil
+}
+
+// deliver sends a delivery once and records the outcome
+func (d *Deliverer) deliver(ctx context.Context, delivery *domain.PendingDelivery) {
+	result := d.send(ctx, delivery)
+	now := time.Now()
+
+	if result.Succeeded() {
+		if err := d.repo.MarkDelivered(ctx, delivery.ID, result, now); err != nil {
+			slog.Error("failed to mark webhook delivered", slog.String("error", err.Error()))
+			return
+		}
+		if err := d.repo.RecordSuccess(ctx, delivery.SubscriptionID); err != nil {
+			slog.Error("failed to record webhook success", slog.String("error", err.Error()))
+		}
+		return
+	}
+
+	attempts := delivery.Attempts + 1
+	status := domain.DeliveryStatusPending
+	nextAttemptAt := now.Add(domain.RetryBackoff(attempts))
+	if attempts >= d.maxAttempts {
+		status = domain.DeliveryStatusFailed
+		nextAttemptAt = now
+	}
+
+	slog.Warn("webhook delivery attempt failed",
+		slog.String("delivery_id", delivery.ID),
+		slog.String("subscription_id", delivery.SubscriptionID),
+		slog.Int("attempts", attempts),
+		slog.Int("status_code", result.StatusCode),
+		slog.String("error", result.Error))
+
+	if err := d.repo.MarkAttemptFailed(ctx, delivery.ID, status, result, nextAttemptAt); err != nil {
+		slog.Error("failed to mark webhook attempt failed", slog.String("error", err.Error()))
+		return
+	}
+
+	disabled, err := d.repo.RecordFailure(ctx, delivery.SubscriptionID, d.disableAfter)
+	if err != nil {
+		slog.Error("failed to record webhook failure", slog.String("error", err.Error()))
+		return
+	}
+	if disabled {
+		slog.Warn("webhook subscription disabled after repeated failures",
+			slog.String("subscription_id", delivery.SubscriptionID))
+	}
+}
+
+// send posts the signed payload of a delivery to its endpoint
+func (d *Deliverer) send(ctx context.Context, delivery *domain.PendingDelivery) domain.AttemptResult {
+	ctx, cancel := context.WithTimeout(ctx, d.requestTimeout)
+	defer cancel()
+
+	timestamp := time.Now().Unix()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return domain.AttemptResult{Error: err.Error()}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", domain.UserAgent)
+	req.Header.Set(domain.HeaderWebhookID, delivery.ID)
+	req.Header.Set(domain.HeaderWebhookEvent, delivery.EventType)
+	req.Header.Set(domain.HeaderWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(domain.HeaderWebhookSignature, domain.Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	start := time.Now()
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return domain.AttemptResult{Error: err.Error(), Duration: time.Since(start)}
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, domain.MaxResponseLength))
+	// Drain the rest so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return domain.AttemptResult{
+		StatusCode: resp.StatusCode,
+		Response:   string(body),
+		Duration:   time.Since(start),
+	}
+}
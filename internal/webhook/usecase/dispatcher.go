@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	outboxdomain "github.com/zercle/template-go-echo/internal/outbox/domain"
+	"github.com/zercle/template-go-echo/internal/webhook/domain"
+)
+
+// Dispatcher implements the outbox Publisher by queueing a delivery for every
+// enabled subscription to the event type. Deliveries are unique per event, so
+// messages the relay publishes more than once are only sent once.
+type Dispatcher struct {
+	repo domain.WebhookRepository
+}
+
+// NewDispatcher creates a dispatcher queueing deliveries in repo
+func NewDispatcher(repo domain.WebhookRepository) *Dispatcher {
+	return &Dispatcher{repo: repo}
+}
+
+// Publish queues deliveries of message; an error makes the relay retry it
+func (d *Dispatcher) Publish(ctx context.Context, message *outboxdomain.Message) error {
+	subscriptions, err := d.repo.ListEnabledSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	var payload json.RawMessage
+	for _, subscription := range subscriptions {
+		if !subscription.Subscribes(message.EventType) {
+			continue
+		}
+
+		if payload == nil {
+			payload, err = json.Marshal(domain.Envelope{
+				ID:         message.EventID,
+				Type:       message.EventType,
+				OccurredAt: message.OccurredAt,
+				Data:       message.Payload,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		delivery := &domain.Delivery{
+			ID:             uuid.New().String(),
+			SubscriptionID: subscription.ID,
+			EventID:        message.EventID,
+			EventType:      message.EventType,
+			Payload:        payload,
+			Status:         domain.DeliveryStatusPending,
+			NextAttemptAt:  time.Now(),
+		}
+		created, err := d.repo.CreateDelivery(ctx, delivery)
+		if err != nil {
+			return err
+		}
+		if created {
+			slog.Debug("webhook delivery queued",
+				slog.String("subscription_id", subscription.ID),
+				slog.String("event_id", message.EventID))
+		}
+	}
+
+	return nil
+}
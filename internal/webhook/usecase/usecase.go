@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zercle/template-go-echo/internal/webhook/domain"
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+// WebhookUsecase implements domain.WebhookUsecase
+type WebhookUsecase struct {
+	repo domain.WebhookRepository
+}
+
+// New creates a new webhook usecase
+func New(repo domain.WebhookRepository) *WebhookUsecase {
+	return &WebhookUsecase{repo: repo}
+}
+
+// CreateSubscription validates and creates a subscription, generating a secret when none is given
+func (u *WebhookUsecase) CreateSubscription(ctx context.Context, input domain.SubscriptionInput) (*domain.Subscription, error) {
+	eventTypes, err := validateSubscription(input)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := input.Secret
+	if secret == "" {
+		secret, err = generateSecret()
+		if err != nil {
+			slog.Error("failed to generate webhook secret", slog.String("error", err.Error()))
+			return nil, pkg.ErrInternalError
+		}
+	}
+
+	now := time.Now()
+	subscription := &domain.Subscription{
+		ID:         uuid.New().String(),
+		URL:        input.URL,
+		EventTypes: eventTypes,
+		Secret:     secret,
+		Enabled:    input.Enabled == nil || *input.Enabled,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := u.repo.CreateSubscription(ctx, subscription); err != nil {
+		slog.Error("failed to create webhook subscription", slog.String("error", err.Error()))
+		return nil, pkg.ErrInternalError
+	}
+
+	slog.Info("webhook subscription created", slog.String("subscription_id", subscription.ID))
+	return subscription, nil
+}
+
+// GetSubscription retrieves a subscription by ID
+func (u *WebhookUsecase) GetSubscription(ctx context.Context, id string) (*domain.Subscription, error) {
+	subscription, err := u.repo.GetSubscription(ctx, id)
+	if err != nil {
+		slog.Error("failed to get webhook subscription", slog.String("error", err.Error()))
+		return nil, pkg.ErrInternalError
+	}
+	if subscription == nil {
+		return nil, domain.ErrSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+// ListSubscriptions retrieves all subscriptions
+func (u *WebhookUsecase) ListSubscriptions(ctx context.Context) ([]*domain.Subscription, error) {
+	subscriptions, err := u.repo.ListSubscriptions(ctx)
+	if err != nil {
+		slog.Error("failed to list webhook subscriptions", slog.String("error", err.Error()))
+		return nil, pkg.ErrInternalError
+	}
+	return subscriptions, nil
+}
+
+// UpdateSubscription replaces the URL, event types and enabled status of a subscription;
+// re-enabling clears its failure count
+func (u *WebhookUsecase) UpdateSubscription(ctx context.Context, id string, input domain.SubscriptionInput) (*domain.Subscription, error) {
+	eventTypes, err := validateSubscription(input)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := u.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.URL = input.URL
+	subscription.EventTypes = eventTypes
+	if input.Secret != "" {
+		subscription.Secret = input.Secret
+	}
+	if input.Enabled != nil {
+		if *input.Enabled && !subscription.Enabled {
+			subscription.ConsecutiveFailures = 0
+			subscription.DisabledAt = nil
+		}
+		subscription.Enabled = *input.Enabled
+	}
+	subscription.UpdatedAt = time.Now()
+
+	if err := u.repo.UpdateSubscription(ctx, subscription); err != nil {
+		slog.Error("failed to update webhook subscription", slog.String("error", err.Error()))
+		return nil, pkg.ErrInternalError
+	}
+
+	slog.Info("webhook subscription updated", slog.String("subscription_id", id), slog.Bool("enabled", subscription.Enabled))
+	return subscription, nil
+}
+
+// DeleteSubscription deletes a subscription and its delivery log
+func (u *WebhookUsecase) DeleteSubscription(ctx context.Context, id string) error {
+	deleted, err := u.repo.DeleteSubscription(ctx, id)
+	if err != nil {
+		slog.Error("failed to delete webhook subscription", slog.String("error", err.Error()))
+		return pkg.ErrInternalError
+	}
+	if !deleted {
+		return domain.ErrSubscriptionNotFound
+	}
+
+	slog.Info("webhook subscription deleted", slog.String("subscription_id", id))
+	return nil
+}
+
+// ListDeliveries retrieves the delivery log of a subscription, newest first
+func (u *WebhookUsecase) ListDeliveries(ctx context.Context, query domain.DeliveryQuery) (pkg.CursorPage[*domain.Delivery], error) {
+	if query.Status != "" && !domain.IsValidDeliveryStatus(query.Status) {
+		return pkg.CursorPage[*domain.Delivery]{}, domain.ErrInvalidStatus
+	}
+
+	if _, err := u.GetSubscription(ctx, query.SubscriptionID); err != nil {
+		return pkg.CursorPage[*domain.Delivery]{}, err
+	}
+
+	var after *domain.DeliveryCursor
+	if query.Cursor != "" {
+		cursor, err := pkg.DecodeCursor[domain.DeliveryCursor](query.Cursor)
+		if err != nil || cursor.ID == "" {
+			return pkg.CursorPage[*domain.Delivery]{}, pkg.ErrInvalidCursor
+		}
+		after = &cursor
+	}
+
+	// Fetch one extra row to detect whether another page exists
+	limit := pkg.ClampLimit(query.Limit, domain.DefaultPageSize, domain.MaxPageSize)
+	deliveries, err := u.repo.ListDeliveries(ctx, query.SubscriptionID, query.Status, after, limit+1)
+	if err != nil {
+		if domainErr, ok := err.(*pkg.DomainError); ok {
+			return pkg.CursorPage[*domain.Delivery]{}, domainErr
+		}
+		slog.Error("failed to list webhook deliveries", slog.String("error", err.Error()))
+		return pkg.CursorPage[*domain.Delivery]{}, pkg.ErrInternalError
+	}
+
+	return pkg.NewCursorPage(deliveries, limit, func(delivery *domain.Delivery) any {
+		return domain.NewDeliveryCursor(delivery)
+	}), nil
+}
+
+// Redeliver queues a delivery to be sent again immediately
+func (u *WebhookUsecase) Redeliver(ctx context.Context, subscriptionID, deliveryID string) (*domain.Delivery, error) {
+	if _, err := u.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	delivery, err := u.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		slog.Error("failed to get webhook delivery", slog.String("error", err.Error()))
+		return nil, pkg.ErrInternalError
+	}
+	if delivery == nil || delivery.SubscriptionID != subscriptionID {
+		return nil, domain.ErrDeliveryNotFound
+	}
+
+	now := time.Now()
+	if err := u.repo.ResetDelivery(ctx, deliveryID, now); err != nil {
+		slog.Error("failed to reset webhook delivery", slog.String("error", err.Error()))
+		return nil, pkg.ErrInternalError
+	}
+
+	delivery.Status = domain.DeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = now
+	delivery.DeliveredAt = nil
+
+	slog.Info("webhook delivery queued for redelivery", slog.String("delivery_id", deliveryID))
+	return delivery, nil
+}
+
+// validateSubscription checks the fields of a subscription and returns its
+// event types without duplicates
+func validateSubscription(input domain.SubscriptionInput) ([]string, error) {
+	if len(input.URL) > domain.MaxURLLength {
+		return nil, domain.ErrInvalidWebhookURL
+	}
+	parsed, err := url.Parse(input.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, domain.ErrInvalidWebhookURL
+	}
+
+	if input.Secret != "" && (len(input.Secret) < domain.MinSecretLength || len(input.Secret) > domain.MaxSecretLength) {
+		return nil, domain.ErrInvalidSecret
+	}
+
+	var eventTypes []string
+	seen := make(map[string]bool, len(input.EventTypes))
+	for _, eventType := range input.EventTypes {
+		if !domain.IsValidEventType(eventType) {
+			return nil, domain.ErrInvalidEventTypes
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	if len(eventTypes) == 0 {
+		return nil, domain.ErrInvalidEventTypes
+	}
+
+	return eventTypes, nil
+}
+
+// generateSecret returns a random signing secret
+func generateSecret() (string, error) {
+	b := make([]byte, domain.SecretByteLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return domain.SecretPrefix + hex.EncodeToString(b), nil
+}
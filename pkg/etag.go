@@ -0,0 +1,36 @@
+package pkg
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidETag is returned when an If-Match header is not a single strong version ETag or *
+var ErrInvalidETag = errors.New("invalid If-Match header: expected a single strong ETag or *")
+
+// VersionETag formats a row version as a strong entity tag
+func VersionETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ParseIfMatch returns the version required by an If-Match header. A missing
+// header or * yields 0, meaning the update is unconditional. Weak tags are
+// rejected because If-Match uses strong comparison.
+func ParseIfMatch(header string) (int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, ErrInvalidETag
+	}
+
+	version, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil || version < 1 {
+		return 0, ErrInvalidETag
+	}
+
+	return version, nil
+}
@@ -0,0 +1,19 @@
+package pkg
+
+import "time"
+
+// DomainEvent is a state change other services are notified of. Events are
+// serialized to JSON and delivered in order per aggregate.
+type DomainEvent interface {
+	// EventType returns the event name, such as user.registered
+	EventType() string
+
+	// AggregateType returns the kind of entity the event belongs to
+	AggregateType() string
+
+	// AggregateID returns the identifier of the entity the event belongs to
+	AggregateID() string
+
+	// OccurredAt returns when the state change happened
+	OccurredAt() time.Time
+}
@@ -0,0 +1,27 @@
+package pkg
+
+import (
+	"context"
+	"log/slog"
+)
+
+// loggerKey is the context key for the request-scoped logger
+type loggerKey struct{}
+
+// WithLogger returns a copy of ctx carrying logger
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// Logger returns the logger carried by ctx, enriched with the request ID,
+// route, tenant and user of the request behind it, or the default logger
+// when ctx carries none. Pass ctx to its Context methods too, so that records
+// also carry the trace and span IDs:
+//
+//	pkg.Logger(ctx).InfoContext(ctx, "user logged in", slog.String("session_id", id))
+func Logger(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,25 @@
+package pkg
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RegisterCollector registers collector with reg and returns it. When reg
+// already holds an equal collector, as when a component is created twice in
+// one process, the registered one is returned instead so that both share it.
+func RegisterCollector[T prometheus.Collector](reg prometheus.Registerer, collector T) T {
+	err := reg.Register(collector)
+	var registered prometheus.AlreadyRegisteredError
+	switch {
+	case errors.As(err, &registered):
+		if existing, ok := registered.ExistingCollector.(T); ok {
+			return existing
+		}
+	case err != nil:
+		slog.Error("failed to register metrics", slog.String("error", err.Error()))
+	}
+	return collector
+}
@@ -0,0 +1,110 @@
+package pkg
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// SortDirection represents the ordering direction of a sort field
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+// Pagination errors
+var (
+	ErrInvalidCursor = NewDomainError(ErrCodeBadRequest, "invalid pagination cursor")
+	ErrInvalidSort   = NewDomainError(ErrCodeBadRequest, "invalid sort field")
+)
+
+// Sort describes a whitelisted sort field and its direction
+type Sort struct {
+	Field     string
+	Direction SortDirection
+}
+
+// String returns the sort in query form, prefixing descending fields with "-"
+func (s Sort) String() string {
+	if s.Direction == SortDesc {
+		return "-" + s.Field
+	}
+	return s.Field
+}
+
+// ParseSort parses "field" or "-field" against an allow-list, returning fallback when raw is empty
+func ParseSort(raw string, allowed []string, fallback Sort) (Sort, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return fallback, nil
+	}
+
+	sort := Sort{Field: raw, Direction: SortAsc}
+	if strings.HasPrefix(raw, "-") {
+		sort = Sort{Field: raw[1:], Direction: SortDesc}
+	}
+
+	for _, field := range allowed {
+		if sort.Field == field {
+			return sort, nil
+		}
+	}
+	return Sort{}, ErrInvalidSort
+}
+
+// ClampLimit returns fallback for non-positive limits and caps limits at max
+func ClampLimit(limit, fallback, max int) int {
+	if limit <= 0 {
+		return fallback
+	}
+	if limit > max {
+		return max
+	}
+	return limit
+}
+
+// EncodeCursor encodes a keyset position as an opaque URL-safe cursor
+func EncodeCursor(position any) string {
+	data, err := json.Marshal(position)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes an opaque cursor produced by EncodeCursor
+func DecodeCursor[T any](cursor string) (T, error) {
+	var position T
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return position, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &position); err != nil {
+		return position, ErrInvalidCursor
+	}
+	return position, nil
+}
+
+// CursorPage is a page of keyset paginated results
+type CursorPage[T any] struct {
+	Items      []T
+	NextCursor string
+	HasMore    bool
+	Total      *int
+}
+
+// NewCursorPage builds a page from items fetched with limit+1 rows, using positionOf
+// to derive the cursor of the last returned item
+func NewCursorPage[T any](items []T, limit int, positionOf func(T) any) CursorPage[T] {
+	page := CursorPage[T]{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.HasMore = true
+	}
+	if page.HasMore && len(page.Items) > 0 {
+		page.NextCursor = EncodeCursor(positionOf(page.Items[len(page.Items)-1]))
+	}
+	return page
+}
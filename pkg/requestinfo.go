@@ -0,0 +1,29 @@
+package pkg
+
+import "context"
+
+// RequestInfo describes the HTTP request and authenticated user behind a context
+type RequestInfo struct {
+	RequestID string
+	// Route is the route template that matched, such as /api/v1/users/:id
+	Route     string
+	IPAddress string
+	UserAgent string
+	UserID    string
+	// TenantID is the tenant the request names in its X-Tenant-ID header, if any
+	TenantID string
+}
+
+// requestInfoKey is the context key for RequestInfo
+type requestInfoKey struct{}
+
+// WithRequestInfo returns a copy of ctx carrying info
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext returns the request info carried by ctx, or the zero value
+func RequestInfoFromContext(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info
+}
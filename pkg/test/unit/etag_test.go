@@ -0,0 +1,49 @@
+package unit_test
+
+import (
+	"testing"
+
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+func TestVersionETagRoundTrip(t *testing.T) {
+	etag := pkg.VersionETag(42)
+	if etag != `"42"` {
+		t.Fatalf(`expected "42", got %s`, etag)
+	}
+
+	version, err := pkg.ParseIfMatch(etag)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version != 42 {
+		t.Errorf("expected version 42, got %d", version)
+	}
+}
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		header  string
+		want    int64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"*", 0, false},
+		{` "7" `, 7, false},
+		{`W/"7"`, 0, true},
+		{`"7", "8"`, 0, true},
+		{`"abc"`, 0, true},
+		{`"0"`, 0, true},
+		{"7", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := pkg.ParseIfMatch(tt.header)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("header %q: unexpected error %v", tt.header, err)
+		}
+		if got != tt.want {
+			t.Errorf("header %q: expected %d, got %d", tt.header, tt.want, got)
+		}
+	}
+}
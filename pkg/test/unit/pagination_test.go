@@ -0,0 +1,84 @@
+package unit_test
+
+import (
+	"testing"
+
+	"github.com/zercle/template-go-echo/pkg"
+)
+
+func TestParseSort(t *testing.T) {
+	allowed := []string{"created_at", "email"}
+	fallback := pkg.Sort{Field: "created_at", Direction: pkg.SortDesc}
+
+	tests := []struct {
+		raw     string
+		want    pkg.Sort
+		wantErr bool
+	}{
+		{"", fallback, false},
+		{"email", pkg.Sort{Field: "email", Direction: pkg.SortAsc}, false},
+		{"-email", pkg.Sort{Field: "email", Direction: pkg.SortDesc}, false},
+		{"password_hash", pkg.Sort{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := pkg.ParseSort(tt.raw, allowed, fallback)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("sort %q: unexpected error %v", tt.raw, err)
+		}
+		if got != tt.want {
+			t.Errorf("sort %q: expected %v, got %v", tt.raw, tt.want, got)
+		}
+	}
+
+	if fallback.String() != "-created_at" {
+		t.Errorf("expected -created_at, got %s", fallback.String())
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	type position struct {
+		Value string `json:"v"`
+		ID    string `json:"id"`
+	}
+
+	cursor := pkg.EncodeCursor(position{Value: "2024-01-01T00:00:00Z", ID: "user-1"})
+	decoded, err := pkg.DecodeCursor[position](cursor)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decoded.ID != "user-1" || decoded.Value != "2024-01-01T00:00:00Z" {
+		t.Errorf("unexpected decoded cursor: %+v", decoded)
+	}
+
+	if _, err := pkg.DecodeCursor[position]("not a cursor!"); err != pkg.ErrInvalidCursor {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestNewCursorPage(t *testing.T) {
+	positionOf := func(i int) any { return i }
+
+	page := pkg.NewCursorPage([]int{1, 2, 3}, 2, positionOf)
+	if !page.HasMore || len(page.Items) != 2 || page.NextCursor == "" {
+		t.Errorf("expected truncated page with cursor, got %+v", page)
+	}
+
+	page = pkg.NewCursorPage([]int{1, 2}, 2, positionOf)
+	if page.HasMore || page.NextCursor != "" {
+		t.Errorf("expected last page without cursor, got %+v", page)
+	}
+}
+
+func TestClampLimit(t *testing.T) {
+	if got := pkg.ClampLimit(0, 10, 100); got != 10 {
+		t.Errorf("expected 10, got %d", got)
+	}
+	if got := pkg.ClampLimit(500, 10, 100); got != 100 {
+		t.Errorf("expected 100, got %d", got)
+	}
+	if got := pkg.ClampLimit(25, 10, 100); got != 25 {
+		t.Errorf("expected 25, got %d", got)
+	}
+}
@@ -0,0 +1,30 @@
+package pkg
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// EndSpan records the outcome of the operation traced by span and ends it.
+// Domain errors are expected outcomes, such as a wrong password, so they are
+// recorded with their code as error.type but only fail the span when
+// internal; any other error fails it.
+func EndSpan(span trace.Span, err error) {
+	defer span.End()
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err)
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		span.SetAttributes(attribute.String("error.type", domainErr.Code))
+		if domainErr.Code != ErrCodeInternalError {
+			return
+		}
+	}
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,21 @@
+package pkg
+
+import "context"
+
+// TxManager runs units of work spanning several repository calls. Repositories
+// called with the context passed to fn take part in the same transaction.
+type TxManager interface {
+	// WithinTx runs fn in a transaction, committing when fn returns nil and
+	// rolling back otherwise. Calls nested inside fn use savepoints, so an
+	// inner failure can be handled without abandoning the outer transaction.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// NoTx is a TxManager that runs fn directly, for stores without transactions
+// such as in-memory test doubles
+type NoTx struct{}
+
+// WithinTx runs fn without a transaction
+func (NoTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
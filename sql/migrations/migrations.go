@@ -0,0 +1,11 @@
+// Package migrations embeds the schema migrations of every database engine.
+// Each engine has its own directory of <version>_<name>.up.sql and
+// <version>_<name>.down.sql files named after its database.Dialect.
+package migrations
+
+import "embed"
+
+// FS holds the mysql, postgres and sqlite migration directories
+//
+//go:embed mysql/*.sql postgres/*.sql sqlite/*.sql
+var FS embed.FS
@@ -0,0 +1,3 @@
+-- Rollback account lifecycle changes
+
+ALTER TABLE users DROP COLUMN role;
@@ -0,0 +1,4 @@
+-- Account lifecycle: authorization roles and soft-delete grace period support
+
+ALTER TABLE users
+    ADD COLUMN role VARCHAR(32) NOT NULL DEFAULT 'user' COMMENT 'Authorization role';
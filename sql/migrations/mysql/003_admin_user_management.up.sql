@@ -0,0 +1,7 @@
+-- Admin user management: account suspension and forced password resets
+
+ALTER TABLE users
+    ADD COLUMN suspended_at TIMESTAMP NULL COMMENT 'Suspension timestamp',
+    ADD COLUMN suspended_until TIMESTAMP NULL COMMENT 'Suspension expiry, NULL for indefinite',
+    ADD COLUMN suspension_reason VARCHAR(500) NULL COMMENT 'Reason given for suspension',
+    ADD COLUMN password_reset_required BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'Password must be changed before further use';
@@ -0,0 +1,3 @@
+-- Rollback user listing indexes
+
+DROP INDEX idx_users_listing ON users;
@@ -0,0 +1,19 @@
+-- Persistent audit log of security and administrative actions
+
+CREATE TABLE IF NOT EXISTS audit_events (
+    id CHAR(36) PRIMARY KEY COMMENT 'UUID unique identifier',
+    actor_id CHAR(36) NULL COMMENT 'User who performed the action, NULL for anonymous or system actions',
+    action VARCHAR(64) NOT NULL COMMENT 'Action name such as user.login',
+    target_type VARCHAR(32) NULL COMMENT 'Kind of resource acted upon',
+    target_id VARCHAR(64) NULL COMMENT 'Identifier of the resource acted upon',
+    ip_address VARCHAR(45) NULL COMMENT 'Client IP address',
+    user_agent VARCHAR(500) NULL COMMENT 'Client user agent',
+    request_id VARCHAR(64) NULL COMMENT 'Request identifier',
+    metadata JSON NULL COMMENT 'Action specific details',
+    created_at TIMESTAMP(6) NOT NULL COMMENT 'Time the action happened',
+
+    INDEX idx_audit_events_created_at (created_at, id),
+    INDEX idx_audit_events_actor (actor_id, created_at),
+    INDEX idx_audit_events_target (target_id, created_at),
+    INDEX idx_audit_events_action (action, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Audit log of security and admin actions';
@@ -0,0 +1,19 @@
+-- Transactional outbox of domain events awaiting delivery
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY COMMENT 'Sequence number defining delivery order',
+    event_id CHAR(36) NOT NULL COMMENT 'UUID of the event, stable across redeliveries',
+    aggregate_type VARCHAR(64) NOT NULL COMMENT 'Kind of entity the event belongs to',
+    aggregate_id VARCHAR(64) NOT NULL COMMENT 'Identifier of the entity the event belongs to',
+    event_type VARCHAR(64) NOT NULL COMMENT 'Event name such as user.registered',
+    payload JSON NOT NULL COMMENT 'Event body',
+    occurred_at TIMESTAMP(6) NOT NULL COMMENT 'Time the state change happened',
+    attempts INT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'Number of failed delivery attempts',
+    last_error VARCHAR(1000) NULL COMMENT 'Error of the last failed delivery attempt',
+    next_attempt_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT 'Earliest time of the next delivery attempt',
+    published_at TIMESTAMP(6) NULL COMMENT 'Time the event was delivered, NULL while pending',
+
+    UNIQUE KEY uk_outbox_event_id (event_id),
+    INDEX idx_outbox_pending (published_at, id),
+    INDEX idx_outbox_aggregate (aggregate_type, aggregate_id, published_at, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Outbox of domain events';
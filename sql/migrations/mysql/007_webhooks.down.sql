@@ -0,0 +1,4 @@
+-- Rollback outbound webhooks
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
@@ -0,0 +1,35 @@
+-- Outbound webhook subscriptions and their delivery log
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id CHAR(36) PRIMARY KEY COMMENT 'UUID unique identifier',
+    url VARCHAR(2048) NOT NULL COMMENT 'Endpoint receiving deliveries',
+    event_types JSON NOT NULL COMMENT 'Subscribed event types, * for all',
+    secret VARCHAR(255) NOT NULL COMMENT 'Shared secret signing deliveries',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE COMMENT 'Whether deliveries are sent',
+    consecutive_failures INT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'Failed attempts since the last success',
+    disabled_at TIMESTAMP NULL COMMENT 'Time the subscription was disabled automatically',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation time',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update time'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Webhook subscriptions';
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id CHAR(36) PRIMARY KEY COMMENT 'UUID unique identifier',
+    subscription_id CHAR(36) NOT NULL COMMENT 'Foreign key to webhook_subscriptions',
+    event_id CHAR(36) NOT NULL COMMENT 'Outbox event delivered',
+    event_type VARCHAR(64) NOT NULL COMMENT 'Event name such as user.registered',
+    payload JSON NOT NULL COMMENT 'Request body sent to the endpoint',
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' COMMENT 'pending, succeeded or failed',
+    attempts INT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'Number of delivery attempts',
+    next_attempt_at TIMESTAMP(6) NOT NULL COMMENT 'Earliest time of the next attempt while pending',
+    last_status_code INT NULL COMMENT 'HTTP status of the last attempt',
+    last_response VARCHAR(1024) NULL COMMENT 'Truncated response body of the last attempt',
+    last_error VARCHAR(1024) NULL COMMENT 'Error of the last failed attempt',
+    last_duration_ms INT UNSIGNED NULL COMMENT 'Duration of the last attempt in milliseconds',
+    delivered_at TIMESTAMP(6) NULL COMMENT 'Time of the successful attempt',
+    created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT 'Record creation time',
+
+    FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    UNIQUE KEY uk_webhook_deliveries_event (subscription_id, event_id),
+    INDEX idx_webhook_deliveries_due (status, next_attempt_at),
+    INDEX idx_webhook_deliveries_log (subscription_id, created_at, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Webhook delivery log';
@@ -0,0 +1,3 @@
+-- Rollback scheduled job run history
+
+DROP TABLE IF EXISTS scheduler_runs;
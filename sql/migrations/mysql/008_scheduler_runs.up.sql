@@ -0,0 +1,15 @@
+-- Run history of scheduled maintenance jobs
+
+CREATE TABLE IF NOT EXISTS scheduler_runs (
+    id CHAR(36) PRIMARY KEY COMMENT 'UUID unique identifier',
+    job_name VARCHAR(64) NOT NULL COMMENT 'Name of the scheduled job',
+    instance VARCHAR(255) NOT NULL COMMENT 'Process that ran the job',
+    status VARCHAR(16) NOT NULL COMMENT 'running, succeeded or failed',
+    error TEXT NULL COMMENT 'Error of a failed run',
+    started_at TIMESTAMP(6) NOT NULL COMMENT 'Time the run started',
+    finished_at TIMESTAMP(6) NULL COMMENT 'Time the run finished, NULL while running',
+    duration_ms BIGINT UNSIGNED NULL COMMENT 'Run time in milliseconds',
+
+    INDEX idx_scheduler_runs_started_at (started_at, id),
+    INDEX idx_scheduler_runs_job (job_name, started_at, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Run history of scheduled jobs';
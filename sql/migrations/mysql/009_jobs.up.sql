@@ -0,0 +1,24 @@
+-- Durable background job queue
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id CHAR(36) PRIMARY KEY COMMENT 'UUID unique identifier',
+    job_type VARCHAR(100) NOT NULL COMMENT 'Handler name such as user.send_welcome_email',
+    payload JSON NOT NULL COMMENT 'Handler input',
+    priority INT NOT NULL DEFAULT 0 COMMENT 'Higher priorities are claimed first',
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' COMMENT 'pending, running, succeeded or dead',
+    attempts INT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'Number of attempts started',
+    max_attempts INT UNSIGNED NOT NULL COMMENT 'Attempts before the job is dead-lettered',
+    run_at TIMESTAMP(6) NOT NULL COMMENT 'Earliest time the job may run while pending',
+    locked_by VARCHAR(255) NULL COMMENT 'Worker running the job',
+    locked_until TIMESTAMP(6) NULL COMMENT 'Visibility timeout after which a running job is released',
+    last_error TEXT NULL COMMENT 'Error of the last failed attempt',
+    created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT 'Time the job was enqueued',
+    updated_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6) COMMENT 'Record update time',
+    completed_at TIMESTAMP(6) NULL COMMENT 'Time the job succeeded or was dead-lettered',
+
+    INDEX idx_jobs_claim (status, priority, run_at, id),
+    INDEX idx_jobs_locked (status, locked_until),
+    INDEX idx_jobs_created_at (created_at, id),
+    INDEX idx_jobs_type (job_type, created_at, id),
+    INDEX idx_jobs_completed_at (status, completed_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Background job queue';
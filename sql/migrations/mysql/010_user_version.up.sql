@@ -0,0 +1,4 @@
+-- Optimistic concurrency control: row version incremented by every user update
+
+ALTER TABLE users
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1 COMMENT 'Optimistic concurrency version, incremented on every update';
@@ -0,0 +1,15 @@
+-- Idempotency keys and the stored responses replayed to retried requests
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id CHAR(64) PRIMARY KEY COMMENT 'HMAC-SHA256 of the request scope and Idempotency-Key header',
+    request_hash CHAR(64) NOT NULL COMMENT 'HMAC-SHA256 fingerprint of the request method, path and body',
+    status VARCHAR(16) NOT NULL DEFAULT 'processing' COMMENT 'processing or completed',
+    response_status INT NOT NULL DEFAULT 0 COMMENT 'HTTP status of the stored response',
+    response_headers JSON NULL COMMENT 'Replayed response headers',
+    response_body MEDIUMBLOB NULL COMMENT 'Encrypted response body',
+    locked_until TIMESTAMP(6) NOT NULL COMMENT 'Time after which an unfinished request may be retried',
+    expires_at TIMESTAMP(6) NOT NULL COMMENT 'Time after which the key may be reused',
+    created_at TIMESTAMP(6) NOT NULL COMMENT 'Time the key was first used',
+
+    INDEX idx_idempotency_keys_expires_at (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Idempotency keys and stored responses';
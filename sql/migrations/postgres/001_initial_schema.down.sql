@@ -0,0 +1,5 @@
+-- Rollback initial schema
+
+DROP TABLE IF EXISTS user_sessions;
+DROP TABLE IF EXISTS users;
+DROP FUNCTION IF EXISTS set_updated_at();
@@ -0,0 +1,62 @@
+-- Initial schema for template-go-echo
+
+-- Keeps updated_at current on every update, as ON UPDATE CURRENT_TIMESTAMP does in MySQL
+CREATE OR REPLACE FUNCTION set_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Create users table as example domain
+CREATE TABLE IF NOT EXISTS users (
+    id CHAR(36) PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ NULL
+);
+
+-- Emails are unique regardless of case, as under the case-insensitive MySQL collation
+CREATE UNIQUE INDEX IF NOT EXISTS uk_users_email ON users (LOWER(email));
+CREATE INDEX IF NOT EXISTS idx_users_created_at ON users (created_at);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE TRIGGER users_set_updated_at BEFORE UPDATE ON users
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+COMMENT ON TABLE users IS 'User accounts';
+COMMENT ON COLUMN users.id IS 'UUIDv7 unique identifier';
+COMMENT ON COLUMN users.email IS 'User email address';
+COMMENT ON COLUMN users.name IS 'User full name';
+COMMENT ON COLUMN users.password_hash IS 'Bcrypt hashed password';
+COMMENT ON COLUMN users.is_active IS 'Account status';
+COMMENT ON COLUMN users.created_at IS 'Creation timestamp';
+COMMENT ON COLUMN users.updated_at IS 'Last update timestamp';
+COMMENT ON COLUMN users.deleted_at IS 'Soft delete timestamp';
+
+-- Create user sessions table for JWT refresh tokens
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    refresh_token_hash VARCHAR(255) NOT NULL UNIQUE,
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(500),
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_sessions_user_id ON user_sessions (user_id);
+CREATE INDEX IF NOT EXISTS idx_user_sessions_expires_at ON user_sessions (expires_at);
+
+COMMENT ON TABLE user_sessions IS 'User session tokens';
+COMMENT ON COLUMN user_sessions.id IS 'UUIDv7 unique identifier';
+COMMENT ON COLUMN user_sessions.user_id IS 'Foreign key to users';
+COMMENT ON COLUMN user_sessions.refresh_token_hash IS 'Hashed refresh token';
+COMMENT ON COLUMN user_sessions.ip_address IS 'Client IP address';
+COMMENT ON COLUMN user_sessions.user_agent IS 'Client user agent';
+COMMENT ON COLUMN user_sessions.expires_at IS 'Token expiration time';
+COMMENT ON COLUMN user_sessions.created_at IS 'Creation timestamp';
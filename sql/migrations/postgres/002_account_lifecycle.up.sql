@@ -0,0 +1,6 @@
+-- Account lifecycle: authorization roles and soft-delete grace period support
+
+ALTER TABLE users
+    ADD COLUMN role VARCHAR(32) NOT NULL DEFAULT 'user';
+
+COMMENT ON COLUMN users.role IS 'Authorization role';
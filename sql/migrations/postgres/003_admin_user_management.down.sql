@@ -0,0 +1,7 @@
+-- Rollback admin user management changes
+
+ALTER TABLE users
+    DROP COLUMN password_reset_required,
+    DROP COLUMN suspension_reason,
+    DROP COLUMN suspended_until,
+    DROP COLUMN suspended_at;
@@ -0,0 +1,12 @@
+-- Admin user management: account suspension and forced password resets
+
+ALTER TABLE users
+    ADD COLUMN suspended_at TIMESTAMPTZ NULL,
+    ADD COLUMN suspended_until TIMESTAMPTZ NULL,
+    ADD COLUMN suspension_reason VARCHAR(500) NULL,
+    ADD COLUMN password_reset_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN users.suspended_at IS 'Suspension timestamp';
+COMMENT ON COLUMN users.suspended_until IS 'Suspension expiry, NULL for indefinite';
+COMMENT ON COLUMN users.suspension_reason IS 'Reason given for suspension';
+COMMENT ON COLUMN users.password_reset_required IS 'Password must be changed before further use';
@@ -0,0 +1,3 @@
+-- Composite index backing keyset pagination of active users by (created_at, id)
+
+CREATE INDEX idx_users_listing ON users (deleted_at, created_at, id);
@@ -0,0 +1,3 @@
+-- Rollback audit log
+
+DROP TABLE IF EXISTS audit_events;
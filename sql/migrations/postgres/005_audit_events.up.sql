@@ -0,0 +1,31 @@
+-- Persistent audit log of security and administrative actions
+
+CREATE TABLE IF NOT EXISTS audit_events (
+    id CHAR(36) PRIMARY KEY,
+    actor_id CHAR(36) NULL,
+    action VARCHAR(64) NOT NULL,
+    target_type VARCHAR(32) NULL,
+    target_id VARCHAR(64) NULL,
+    ip_address VARCHAR(45) NULL,
+    user_agent VARCHAR(500) NULL,
+    request_id VARCHAR(64) NULL,
+    metadata JSONB NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor ON audit_events (actor_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_target ON audit_events (target_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_action ON audit_events (action, created_at);
+
+COMMENT ON TABLE audit_events IS 'Audit log of security and admin actions';
+COMMENT ON COLUMN audit_events.id IS 'UUID unique identifier';
+COMMENT ON COLUMN audit_events.actor_id IS 'User who performed the action, NULL for anonymous or system actions';
+COMMENT ON COLUMN audit_events.action IS 'Action name such as user.login';
+COMMENT ON COLUMN audit_events.target_type IS 'Kind of resource acted upon';
+COMMENT ON COLUMN audit_events.target_id IS 'Identifier of the resource acted upon';
+COMMENT ON COLUMN audit_events.ip_address IS 'Client IP address';
+COMMENT ON COLUMN audit_events.user_agent IS 'Client user agent';
+COMMENT ON COLUMN audit_events.request_id IS 'Request identifier';
+COMMENT ON COLUMN audit_events.metadata IS 'Action specific details';
+COMMENT ON COLUMN audit_events.created_at IS 'Time the action happened';
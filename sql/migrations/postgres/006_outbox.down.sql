@@ -0,0 +1,3 @@
+-- Rollback transactional outbox
+
+DROP TABLE IF EXISTS outbox;
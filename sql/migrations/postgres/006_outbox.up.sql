@@ -0,0 +1,33 @@
+-- Transactional outbox of domain events awaiting delivery
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    event_id CHAR(36) NOT NULL,
+    aggregate_type VARCHAR(64) NOT NULL,
+    aggregate_id VARCHAR(64) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error VARCHAR(1000) NULL,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMPTZ NULL,
+
+    CONSTRAINT uk_outbox_event_id UNIQUE (event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (published_at, id);
+CREATE INDEX IF NOT EXISTS idx_outbox_aggregate ON outbox (aggregate_type, aggregate_id, published_at, id);
+
+COMMENT ON TABLE outbox IS 'Outbox of domain events';
+COMMENT ON COLUMN outbox.id IS 'Sequence number defining delivery order';
+COMMENT ON COLUMN outbox.event_id IS 'UUID of the event, stable across redeliveries';
+COMMENT ON COLUMN outbox.aggregate_type IS 'Kind of entity the event belongs to';
+COMMENT ON COLUMN outbox.aggregate_id IS 'Identifier of the entity the event belongs to';
+COMMENT ON COLUMN outbox.event_type IS 'Event name such as user.registered';
+COMMENT ON COLUMN outbox.payload IS 'Event body';
+COMMENT ON COLUMN outbox.occurred_at IS 'Time the state change happened';
+COMMENT ON COLUMN outbox.attempts IS 'Number of failed delivery attempts';
+COMMENT ON COLUMN outbox.last_error IS 'Error of the last failed delivery attempt';
+COMMENT ON COLUMN outbox.next_attempt_at IS 'Earliest time of the next delivery attempt';
+COMMENT ON COLUMN outbox.published_at IS 'Time the event was delivered, NULL while pending';
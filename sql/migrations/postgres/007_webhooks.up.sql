@@ -0,0 +1,65 @@
+-- Outbound webhook subscriptions and their delivery log
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id CHAR(36) PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    event_types JSONB NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    disabled_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER webhook_subscriptions_set_updated_at BEFORE UPDATE ON webhook_subscriptions
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+COMMENT ON TABLE webhook_subscriptions IS 'Webhook subscriptions';
+COMMENT ON COLUMN webhook_subscriptions.id IS 'UUID unique identifier';
+COMMENT ON COLUMN webhook_subscriptions.url IS 'Endpoint receiving deliveries';
+COMMENT ON COLUMN webhook_subscriptions.event_types IS 'Subscribed event types, * for all';
+COMMENT ON COLUMN webhook_subscriptions.secret IS 'Shared secret signing deliveries';
+COMMENT ON COLUMN webhook_subscriptions.enabled IS 'Whether deliveries are sent';
+COMMENT ON COLUMN webhook_subscriptions.consecutive_failures IS 'Failed attempts since the last success';
+COMMENT ON COLUMN webhook_subscriptions.disabled_at IS 'Time the subscription was disabled automatically';
+COMMENT ON COLUMN webhook_subscriptions.created_at IS 'Record creation time';
+COMMENT ON COLUMN webhook_subscriptions.updated_at IS 'Record update time';
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id CHAR(36) PRIMARY KEY,
+    subscription_id CHAR(36) NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_id CHAR(36) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_status_code INTEGER NULL,
+    last_response VARCHAR(1024) NULL,
+    last_error VARCHAR(1024) NULL,
+    last_duration_ms INTEGER NULL,
+    delivered_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uk_webhook_deliveries_event UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_log ON webhook_deliveries (subscription_id, created_at, id);
+
+COMMENT ON TABLE webhook_deliveries IS 'Webhook delivery log';
+COMMENT ON COLUMN webhook_deliveries.id IS 'UUID unique identifier';
+COMMENT ON COLUMN webhook_deliveries.subscription_id IS 'Foreign key to webhook_subscriptions';
+COMMENT ON COLUMN webhook_deliveries.event_id IS 'Outbox event delivered';
+COMMENT ON COLUMN webhook_deliveries.event_type IS 'Event name such as user.registered';
+COMMENT ON COLUMN webhook_deliveries.payload IS 'Request body sent to the endpoint';
+COMMENT ON COLUMN webhook_deliveries.status IS 'pending, succeeded or failed';
+COMMENT ON COLUMN webhook_deliveries.attempts IS 'Number of delivery attempts';
+COMMENT ON COLUMN webhook_deliveries.next_attempt_at IS 'Earliest time of the next attempt while pending';
+COMMENT ON COLUMN webhook_deliveries.last_status_code IS 'HTTP status of the last attempt';
+COMMENT ON COLUMN webhook_deliveries.last_response IS 'Truncated response body of the last attempt';
+COMMENT ON COLUMN webhook_deliveries.last_error IS 'Error of the last failed attempt';
+COMMENT ON COLUMN webhook_deliveries.last_duration_ms IS 'Duration of the last attempt in milliseconds';
+COMMENT ON COLUMN webhook_deliveries.delivered_at IS 'Time of the successful attempt';
+COMMENT ON COLUMN webhook_deliveries.created_at IS 'Record creation time';
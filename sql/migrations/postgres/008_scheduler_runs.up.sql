@@ -0,0 +1,25 @@
+-- Run history of scheduled maintenance jobs
+
+CREATE TABLE IF NOT EXISTS scheduler_runs (
+    id CHAR(36) PRIMARY KEY,
+    job_name VARCHAR(64) NOT NULL,
+    instance VARCHAR(255) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    error TEXT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NULL,
+    duration_ms BIGINT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduler_runs_started_at ON scheduler_runs (started_at, id);
+CREATE INDEX IF NOT EXISTS idx_scheduler_runs_job ON scheduler_runs (job_name, started_at, id);
+
+COMMENT ON TABLE scheduler_runs IS 'Run history of scheduled jobs';
+COMMENT ON COLUMN scheduler_runs.id IS 'UUID unique identifier';
+COMMENT ON COLUMN scheduler_runs.job_name IS 'Name of the scheduled job';
+COMMENT ON COLUMN scheduler_runs.instance IS 'Process that ran the job';
+COMMENT ON COLUMN scheduler_runs.status IS 'running, succeeded or failed';
+COMMENT ON COLUMN scheduler_runs.error IS 'Error of a failed run';
+COMMENT ON COLUMN scheduler_runs.started_at IS 'Time the run started';
+COMMENT ON COLUMN scheduler_runs.finished_at IS 'Time the run finished, NULL while running';
+COMMENT ON COLUMN scheduler_runs.duration_ms IS 'Run time in milliseconds';
@@ -0,0 +1,43 @@
+-- Durable background job queue
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id CHAR(36) PRIMARY KEY,
+    job_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL,
+    run_at TIMESTAMPTZ NOT NULL,
+    locked_by VARCHAR(255) NULL,
+    locked_until TIMESTAMPTZ NULL,
+    last_error TEXT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_claim ON jobs (status, priority, run_at, id);
+CREATE INDEX IF NOT EXISTS idx_jobs_locked ON jobs (status, locked_until);
+CREATE INDEX IF NOT EXISTS idx_jobs_created_at ON jobs (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_jobs_type ON jobs (job_type, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_jobs_completed_at ON jobs (status, completed_at);
+
+CREATE TRIGGER jobs_set_updated_at BEFORE UPDATE ON jobs
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+COMMENT ON TABLE jobs IS 'Background job queue';
+COMMENT ON COLUMN jobs.id IS 'UUID unique identifier';
+COMMENT ON COLUMN jobs.job_type IS 'Handler name such as user.send_welcome_email';
+COMMENT ON COLUMN jobs.payload IS 'Handler input';
+COMMENT ON COLUMN jobs.priority IS 'Higher priorities are claimed first';
+COMMENT ON COLUMN jobs.status IS 'pending, running, succeeded or dead';
+COMMENT ON COLUMN jobs.attempts IS 'Number of attempts started';
+COMMENT ON COLUMN jobs.max_attempts IS 'Attempts before the job is dead-lettered';
+COMMENT ON COLUMN jobs.run_at IS 'Earliest time the job may run while pending';
+COMMENT ON COLUMN jobs.locked_by IS 'Worker running the job';
+COMMENT ON COLUMN jobs.locked_until IS 'Visibility timeout after which a running job is released';
+COMMENT ON COLUMN jobs.last_error IS 'Error of the last failed attempt';
+COMMENT ON COLUMN jobs.created_at IS 'Time the job was enqueued';
+COMMENT ON COLUMN jobs.updated_at IS 'Record update time';
+COMMENT ON COLUMN jobs.completed_at IS 'Time the job succeeded or was dead-lettered';
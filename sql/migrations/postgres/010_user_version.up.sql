@@ -0,0 +1,6 @@
+-- Optimistic concurrency control: row version incremented by every user update
+
+ALTER TABLE users
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN users.version IS 'Optimistic concurrency version, incremented on every update';
@@ -0,0 +1,3 @@
+-- Rollback idempotency keys
+
+DROP TABLE IF EXISTS idempotency_keys;
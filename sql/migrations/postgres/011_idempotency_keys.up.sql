@@ -0,0 +1,26 @@
+-- Idempotency keys and the stored responses replayed to retried requests
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id CHAR(64) PRIMARY KEY,
+    request_hash CHAR(64) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'processing',
+    response_status INTEGER NOT NULL DEFAULT 0,
+    response_headers JSONB NULL,
+    response_body TEXT NULL,
+    locked_until TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+COMMENT ON TABLE idempotency_keys IS 'Idempotency keys and stored responses';
+COMMENT ON COLUMN idempotency_keys.id IS 'HMAC-SHA256 of the request scope and Idempotency-Key header';
+COMMENT ON COLUMN idempotency_keys.request_hash IS 'HMAC-SHA256 fingerprint of the request method, path and body';
+COMMENT ON COLUMN idempotency_keys.status IS 'processing or completed';
+COMMENT ON COLUMN idempotency_keys.response_status IS 'HTTP status of the stored response';
+COMMENT ON COLUMN idempotency_keys.response_headers IS 'Replayed response headers';
+COMMENT ON COLUMN idempotency_keys.response_body IS 'Encrypted response body';
+COMMENT ON COLUMN idempotency_keys.locked_until IS 'Time after which an unfinished request may be retried';
+COMMENT ON COLUMN idempotency_keys.expires_at IS 'Time after which the key may be reused';
+COMMENT ON COLUMN idempotency_keys.created_at IS 'Time the key was first used';
@@ -0,0 +1,4 @@
+-- Rollback initial schema
+
+DROP TABLE IF EXISTS user_sessions;
+DROP TABLE IF EXISTS users;
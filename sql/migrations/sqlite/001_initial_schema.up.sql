@@ -0,0 +1,34 @@
+-- Initial schema for template-go-echo
+--
+-- Timestamps are stored as Unix microseconds; the application opens SQLite
+-- with _time_integer_format=unix_micro so the driver reads and writes them as
+-- time.Time.
+
+-- Create users table as example domain
+CREATE TABLE IF NOT EXISTS users (
+    id TEXT PRIMARY KEY,                                                           -- UUIDv7 unique identifier
+    email TEXT NOT NULL UNIQUE COLLATE NOCASE,                                     -- User email address
+    name TEXT NOT NULL,                                                            -- User full name
+    password_hash TEXT NOT NULL,                                                   -- Bcrypt hashed password
+    is_active BOOLEAN DEFAULT TRUE,                                                -- Account status
+    created_at TIMESTAMP DEFAULT (CAST(unixepoch('subsec') * 1000000 AS INTEGER)), -- Creation timestamp
+    updated_at TIMESTAMP DEFAULT (CAST(unixepoch('subsec') * 1000000 AS INTEGER)), -- Last update timestamp
+    deleted_at TIMESTAMP                                                           -- Soft delete timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_users_created_at ON users (created_at);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+-- Create user sessions table for JWT refresh tokens
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id TEXT PRIMARY KEY,                                                          -- UUIDv7 unique identifier
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,                 -- Foreign key to users
+    refresh_token_hash TEXT NOT NULL UNIQUE,                                      -- Hashed refresh token
+    ip_address TEXT,                                                              -- Client IP address
+    user_agent TEXT,                                                              -- Client user agent
+    expires_at TIMESTAMP NOT NULL,                                                -- Token expiration time
+    created_at TIMESTAMP DEFAULT (CAST(unixepoch('subsec') * 1000000 AS INTEGER)) -- Creation timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_sessions_user_id ON user_sessions (user_id);
+CREATE INDEX IF NOT EXISTS idx_user_sessions_expires_at ON user_sessions (expires_at);
@@ -0,0 +1,3 @@
+-- Account lifecycle: authorization roles and soft-delete grace period support
+
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'user'; -- Authorization role
@@ -0,0 +1,6 @@
+-- Admin user management: account suspension and forced password resets
+
+ALTER TABLE users ADD COLUMN suspended_at TIMESTAMP;                                 -- Suspension timestamp
+ALTER TABLE users ADD COLUMN suspended_until TIMESTAMP;                              -- Suspension expiry, NULL for indefinite
+ALTER TABLE users ADD COLUMN suspension_reason TEXT;                                 -- Reason given for suspension
+ALTER TABLE users ADD COLUMN password_reset_required BOOLEAN NOT NULL DEFAULT FALSE; -- Password must be changed before further use
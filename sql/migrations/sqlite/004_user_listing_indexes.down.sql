@@ -0,0 +1,3 @@
+-- Rollback user listing indexes
+
+DROP INDEX IF EXISTS idx_users_listing;
@@ -0,0 +1,19 @@
+-- Persistent audit log of security and administrative actions
+
+CREATE TABLE IF NOT EXISTS audit_events (
+    id TEXT PRIMARY KEY,          -- UUID unique identifier
+    actor_id TEXT,                -- User who performed the action, NULL for anonymous or system actions
+    action TEXT NOT NULL,         -- Action name such as user.login
+    target_type TEXT,             -- Kind of resource acted upon
+    target_id TEXT,               -- Identifier of the resource acted upon
+    ip_address TEXT,              -- Client IP address
+    user_agent TEXT,              -- Client user agent
+    request_id TEXT,              -- Request identifier
+    metadata JSON,                -- Action specific details
+    created_at TIMESTAMP NOT NULL -- Time the action happened
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor ON audit_events (actor_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_target ON audit_events (target_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_action ON audit_events (action, created_at);
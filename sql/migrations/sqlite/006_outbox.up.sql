@@ -0,0 +1,20 @@
+-- Transactional outbox of domain events awaiting delivery
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,                                                        -- Sequence number defining delivery order
+    event_id TEXT NOT NULL,                                                                      -- UUID of the event, stable across redeliveries
+    aggregate_type TEXT NOT NULL,                                                                -- Kind of entity the event belongs to
+    aggregate_id TEXT NOT NULL,                                                                  -- Identifier of the entity the event belongs to
+    event_type TEXT NOT NULL,                                                                    -- Event name such as user.registered
+    payload JSON NOT NULL,                                                                       -- Event body
+    occurred_at TIMESTAMP NOT NULL,                                                              -- Time the state change happened
+    attempts INTEGER NOT NULL DEFAULT 0,                                                         -- Number of failed delivery attempts
+    last_error TEXT,                                                                             -- Error of the last failed delivery attempt
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT (CAST(unixepoch('subsec') * 1000000 AS INTEGER)), -- Earliest time of the next delivery attempt
+    published_at TIMESTAMP,                                                                      -- Time the event was delivered, NULL while pending
+
+    CONSTRAINT uk_outbox_event_id UNIQUE (event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (published_at, id);
+CREATE INDEX IF NOT EXISTS idx_outbox_aggregate ON outbox (aggregate_type, aggregate_id, published_at, id);
@@ -0,0 +1,43 @@
+-- Outbound webhook subscriptions and their delivery log
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id TEXT PRIMARY KEY,                                                           -- UUID unique identifier
+    url TEXT NOT NULL,                                                             -- Endpoint receiving deliveries
+    event_types JSON NOT NULL,                                                     -- Subscribed event types, * for all
+    secret TEXT NOT NULL,                                                          -- Shared secret signing deliveries
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,                                         -- Whether deliveries are sent
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,                               -- Failed attempts since the last success
+    disabled_at TIMESTAMP,                                                         -- Time the subscription was disabled automatically
+    created_at TIMESTAMP DEFAULT (CAST(unixepoch('subsec') * 1000000 AS INTEGER)), -- Record creation time
+    updated_at TIMESTAMP DEFAULT (CAST(unixepoch('subsec') * 1000000 AS INTEGER))  -- Record update time
+);
+
+-- Keeps updated_at current on every update, as ON UPDATE CURRENT_TIMESTAMP does in MySQL
+CREATE TRIGGER IF NOT EXISTS webhook_subscriptions_set_updated_at
+AFTER UPDATE ON webhook_subscriptions
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE webhook_subscriptions SET updated_at = CAST(unixepoch('subsec') * 1000000 AS INTEGER) WHERE id = NEW.id;
+END;
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id TEXT PRIMARY KEY,                                                                    -- UUID unique identifier
+    subscription_id TEXT NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,   -- Foreign key to webhook_subscriptions
+    event_id TEXT NOT NULL,                                                                 -- Outbox event delivered
+    event_type TEXT NOT NULL,                                                               -- Event name such as user.registered
+    payload JSON NOT NULL,                                                                  -- Request body sent to the endpoint
+    status TEXT NOT NULL DEFAULT 'pending',                                                 -- pending, succeeded or failed
+    attempts INTEGER NOT NULL DEFAULT 0,                                                    -- Number of delivery attempts
+    next_attempt_at TIMESTAMP NOT NULL,                                                     -- Earliest time of the next attempt while pending
+    last_status_code INTEGER,                                                               -- HTTP status of the last attempt
+    last_response TEXT,                                                                     -- Truncated response body of the last attempt
+    last_error TEXT,                                                                        -- Error of the last failed attempt
+    last_duration_ms INTEGER,                                                               -- Duration of the last attempt in milliseconds
+    delivered_at TIMESTAMP,                                                                 -- Time of the successful attempt
+    created_at TIMESTAMP NOT NULL DEFAULT (CAST(unixepoch('subsec') * 1000000 AS INTEGER)), -- Record creation time
+
+    CONSTRAINT uk_webhook_deliveries_event UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_log ON webhook_deliveries (subscription_id, created_at, id);
@@ -0,0 +1,15 @@
+-- Run history of scheduled maintenance jobs
+
+CREATE TABLE IF NOT EXISTS scheduler_runs (
+    id TEXT PRIMARY KEY,           -- UUID unique identifier
+    job_name TEXT NOT NULL,        -- Name of the scheduled job
+    instance TEXT NOT NULL,        -- Process that ran the job
+    status TEXT NOT NULL,          -- running, succeeded or failed
+    error TEXT,                    -- Error of a failed run
+    started_at TIMESTAMP NOT NULL, -- Time the run started
+    finished_at TIMESTAMP,         -- Time the run finished, NULL while running
+    duration_ms BIGINT             -- Run time in milliseconds
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduler_runs_started_at ON scheduler_runs (started_at, id);
+CREATE INDEX IF NOT EXISTS idx_scheduler_runs_job ON scheduler_runs (job_name, started_at, id);
@@ -0,0 +1,32 @@
+-- Durable background job queue
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id TEXT PRIMARY KEY,                                                                    -- UUID unique identifier
+    job_type TEXT NOT NULL,                                                                 -- Handler name such as user.send_welcome_email
+    payload JSON NOT NULL,                                                                  -- Handler input
+    priority INTEGER NOT NULL DEFAULT 0,                                                    -- Higher priorities are claimed first
+    status TEXT NOT NULL DEFAULT 'pending',                                                 -- pending, running, succeeded or dead
+    attempts INTEGER NOT NULL DEFAULT 0,                                                    -- Number of attempts started
+    max_attempts INTEGER NOT NULL,                                                          -- Attempts before the job is dead-lettered
+    run_at TIMESTAMP NOT NULL,                                                              -- Earliest time the job may run while pending
+    locked_by TEXT,                                                                         -- Worker running the job
+    locked_until TIMESTAMP,                                                                 -- Visibility timeout after which a running job is released
+    last_error TEXT,                                                                        -- Error of the last failed attempt
+    created_at TIMESTAMP NOT NULL DEFAULT (CAST(unixepoch('subsec') * 1000000 AS INTEGER)), -- Time the job was enqueued
+    updated_at TIMESTAMP NOT NULL DEFAULT (CAST(unixepoch('subsec') * 1000000 AS INTEGER)), -- Record update time
+    completed_at TIMESTAMP                                                                  -- Time the job succeeded or was dead-lettered
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_claim ON jobs (status, priority, run_at, id);
+CREATE INDEX IF NOT EXISTS idx_jobs_locked ON jobs (status, locked_until);
+CREATE INDEX IF NOT EXISTS idx_jobs_created_at ON jobs (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_jobs_type ON jobs (job_type, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_jobs_completed_at ON jobs (status, completed_at);
+
+-- Keeps updated_at current on every update, as ON UPDATE CURRENT_TIMESTAMP does in MySQL
+CREATE TRIGGER IF NOT EXISTS jobs_set_updated_at
+AFTER UPDATE ON jobs
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE jobs SET updated_at = CAST(unixepoch('subsec') * 1000000 AS INTEGER) WHERE id = NEW.id;
+END;
@@ -0,0 +1,3 @@
+-- Rollback user row version
+
+ALTER TABLE users DROP COLUMN version;
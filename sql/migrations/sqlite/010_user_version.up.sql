@@ -0,0 +1,3 @@
+-- Optimistic concurrency control: row version incremented by every user update
+
+ALTER TABLE users ADD COLUMN version BIGINT NOT NULL DEFAULT 1; -- Optimistic concurrency version, incremented on every update
@@ -0,0 +1,15 @@
+-- Idempotency keys and the stored responses replayed to retried requests
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id TEXT PRIMARY KEY,                        -- HMAC-SHA256 of the request scope and Idempotency-Key header
+    request_hash TEXT NOT NULL,                 -- HMAC-SHA256 fingerprint of the request method, path and body
+    status TEXT NOT NULL DEFAULT 'processing',  -- processing or completed
+    response_status INTEGER NOT NULL DEFAULT 0, -- HTTP status of the stored response
+    response_headers JSON,                      -- Replayed response headers
+    response_body TEXT,                         -- Encrypted response body
+    locked_until TIMESTAMP NOT NULL,            -- Time after which an unfinished request may be retried
+    expires_at TIMESTAMP NOT NULL,              -- Time after which the key may be reused
+    created_at TIMESTAMP NOT NULL               -- Time the key was first used
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
@@ -0,0 +1,5 @@
+-- SQL queries for audit domain
+
+-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (id, actor_id, action, target_type, target_id, ip_address, user_agent, request_id, metadata, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
@@ -0,0 +1,32 @@
+-- SQL queries for idempotency domain
+
+-- name: CreateIdempotencyKey :exec
+-- Headers start as an empty object, as the JSON column cannot be scanned when NULL
+INSERT INTO idempotency_keys (id, request_hash, response_headers, locked_until, expires_at, created_at)
+VALUES (?, ?, '{}', ?, ?, ?);
+
+-- name: GetIdempotencyKey :one
+SELECT id, request_hash, status, response_status, response_headers, response_body, locked_until, expires_at, created_at
+FROM idempotency_keys
+WHERE id = ?;
+
+-- name: ReclaimIdempotencyKey :execrows
+-- Takes over a key whose record expired or whose request stopped responding
+UPDATE idempotency_keys
+SET request_hash = sqlc.arg(request_hash), status = 'processing', response_status = 0, response_headers = '{}', response_body = NULL,
+    locked_until = sqlc.arg(locked_until), expires_at = sqlc.arg(expires_at), created_at = sqlc.arg(now)
+WHERE id = sqlc.arg(id) AND (expires_at <= sqlc.arg(now) OR (status = 'processing' AND locked_until <= sqlc.arg(now)));
+
+-- name: CompleteIdempotencyKey :execrows
+UPDATE idempotency_keys
+SET status = 'completed', response_status = ?, response_headers = ?, response_body = ?
+WHERE id = ? AND status = 'processing';
+
+-- name: DeleteIdempotencyKey :exec
+-- Releases an unfinished key so that the request can be retried
+DELETE FROM idempotency_keys
+WHERE id = ? AND status = 'processing';
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at <= ?;
@@ -0,0 +1,36 @@
+-- SQL queries for outbox domain
+
+-- name: CreateOutboxMessage :exec
+INSERT INTO outbox (event_id, aggregate_type, aggregate_id, event_type, payload, occurred_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: ListPendingOutboxMessages :many
+-- Messages are held back while an earlier message of the same aggregate waits for a retry
+SELECT o.id, o.event_id, o.aggregate_type, o.aggregate_id, o.event_type, o.payload, o.occurred_at, o.attempts, o.last_error, o.next_attempt_at, o.published_at
+FROM outbox o
+WHERE o.published_at IS NULL
+  AND o.next_attempt_at <= sqlc.arg(now)
+  AND NOT EXISTS (
+    SELECT 1 FROM outbox b
+    WHERE b.aggregate_type = o.aggregate_type
+      AND b.aggregate_id = o.aggregate_id
+      AND b.published_at IS NULL
+      AND b.id < o.id
+      AND b.next_attempt_at > sqlc.arg(now)
+  )
+ORDER BY o.id
+LIMIT ?;
+
+-- name: MarkOutboxMessagePublished :exec
+UPDATE outbox
+SET published_at = ?
+WHERE id = ?;
+
+-- name: MarkOutboxMessageFailed :exec
+UPDATE outbox
+SET attempts = attempts + 1, last_error = ?, next_attempt_at = ?
+WHERE id = ?;
+
+-- name: DeletePublishedOutboxMessages :execrows
+DELETE FROM outbox
+WHERE published_at IS NOT NULL AND published_at < ?;
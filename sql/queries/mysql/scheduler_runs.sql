@@ -0,0 +1,14 @@
+-- SQL queries for scheduler domain
+
+-- name: CreateSchedulerRun :exec
+INSERT INTO scheduler_runs (id, job_name, instance, status, started_at)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: FinishSchedulerRun :exec
+UPDATE scheduler_runs
+SET status = ?, error = ?, finished_at = ?, duration_ms = ?
+WHERE id = ?;
+
+-- name: DeleteSchedulerRunsBefore :execrows
+DELETE FROM scheduler_runs
+WHERE started_at < ?;
@@ -19,7 +19,7 @@ ORDER BY created_at DESC;
 DELETE FROM user_sessions
 WHERE id = ?;
 
--- name: DeleteExpiredSessions :exec
+-- name: DeleteExpiredSessions :execrows
 DELETE FROM user_sessions
 WHERE expires_at <= NOW();
 
@@ -27,3 +27,7 @@ WHERE expires_at <= NOW();
 SELECT id, user_id, refresh_token_hash, ip_address, user_agent, expires_at, created_at
 FROM user_sessions
 WHERE refresh_token_hash = ? AND expires_at > NOW();
+
+-- name: DeleteSessionsByUserID :execrows
+DELETE FROM user_sessions
+WHERE user_id = ?;
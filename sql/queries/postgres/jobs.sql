@@ -0,0 +1,52 @@
+-- SQL queries for jobs domain
+
+-- name: CreateJob :exec
+INSERT INTO jobs (id, job_type, payload, priority, max_attempts, run_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetJob :one
+SELECT id, job_type, payload, priority, status, attempts, max_attempts, run_at, locked_by, locked_until, last_error, created_at, updated_at, completed_at
+FROM jobs
+WHERE id = $1;
+
+-- name: ClaimJob :exec
+-- Marks a job selected with FOR UPDATE SKIP LOCKED as running; must run in the same transaction
+UPDATE jobs
+SET status = 'running', attempts = attempts + 1, locked_by = $1, locked_until = $2
+WHERE id = $3;
+
+-- name: CompleteJob :execrows
+UPDATE jobs
+SET status = 'succeeded', locked_by = NULL, locked_until = NULL, last_error = NULL, completed_at = $1
+WHERE id = $2 AND status = 'running' AND locked_by = $3;
+
+-- name: RescheduleJob :execrows
+UPDATE jobs
+SET status = 'pending', run_at = $1, last_error = $2, locked_by = NULL, locked_until = NULL
+WHERE id = $3 AND status = 'running' AND locked_by = $4;
+
+-- name: DeadLetterJob :execrows
+UPDATE jobs
+SET status = 'dead', completed_at = $1, last_error = $2, locked_by = NULL, locked_until = NULL
+WHERE id = $3 AND status = 'running' AND locked_by = $4;
+
+-- name: ReleaseExpiredJobs :execrows
+-- Returns running jobs past their visibility timeout to the queue while attempts remain
+UPDATE jobs
+SET status = 'pending', last_error = 'visibility timeout expired', locked_by = NULL, locked_until = NULL
+WHERE status = 'running' AND locked_until <= $1 AND attempts < max_attempts;
+
+-- name: DeadLetterExpiredJobs :execrows
+-- Dead-letters running jobs past their visibility timeout that have no attempts left
+UPDATE jobs
+SET status = 'dead', completed_at = $1, last_error = 'visibility timeout expired', locked_by = NULL, locked_until = NULL
+WHERE status = 'running' AND locked_until <= $2 AND attempts >= max_attempts;
+
+-- name: RetryJob :execrows
+UPDATE jobs
+SET status = 'pending', attempts = 0, run_at = $1, last_error = NULL, completed_at = NULL
+WHERE id = $2 AND status = 'dead';
+
+-- name: DeleteSucceededJobs :execrows
+DELETE FROM jobs
+WHERE status = 'succeeded' AND completed_at < $1;
@@ -0,0 +1,14 @@
+-- SQL queries for scheduler domain
+
+-- name: CreateSchedulerRun :exec
+INSERT INTO scheduler_runs (id, job_name, instance, status, started_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: FinishSchedulerRun :exec
+UPDATE scheduler_runs
+SET status = $1, error = $2, finished_at = $3, duration_ms = $4
+WHERE id = $5;
+
+-- name: DeleteSchedulerRunsBefore :execrows
+DELETE FROM scheduler_runs
+WHERE started_at < $1;
@@ -0,0 +1,89 @@
+-- SQL queries for user domain
+
+-- name: CreateUser :exec
+INSERT INTO users (id, email, name, password_hash, is_active, role, password_reset_required, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW());
+
+-- name: GetUserByID :one
+SELECT id, email, name, password_hash, is_active, created_at, updated_at, deleted_at, role, suspended_at, suspended_until, suspension_reason, password_reset_required, version
+FROM users
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetUserByEmail :one
+SELECT id, email, name, password_hash, is_active, created_at, updated_at, deleted_at, role, suspended_at, suspended_until, suspension_reason, password_reset_required, version
+FROM users
+WHERE LOWER(email) = LOWER(sqlc.arg(email)) AND deleted_at IS NULL;
+
+-- name: UpdateUser :execrows
+UPDATE users
+SET name = $1, email = $2, version = version + 1, updated_at = NOW()
+WHERE id = $3 AND version = $4 AND deleted_at IS NULL;
+
+-- name: DeleteUser :exec
+UPDATE users
+SET deleted_at = NOW(), version = version + 1, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: ListUsers :many
+SELECT id, email, name, password_hash, is_active, created_at, updated_at, deleted_at, role, suspended_at, suspended_until, suspension_reason, password_reset_required, version
+FROM users
+WHERE deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: GetUserCount :one
+SELECT COUNT(*) as count
+FROM users
+WHERE deleted_at IS NULL;
+
+-- name: GetDeletedUserByEmail :one
+SELECT id, email, name, password_hash, is_active, created_at, updated_at, deleted_at, role, suspended_at, suspended_until, suspension_reason, password_reset_required, version
+FROM users
+WHERE LOWER(email) = LOWER(sqlc.arg(email)) AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC
+LIMIT 1;
+
+-- name: RestoreUser :exec
+UPDATE users
+SET deleted_at = NULL, version = version + 1, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: ListDeletedUsers :many
+SELECT id, email, name, password_hash, is_active, created_at, updated_at, deleted_at, role, suspended_at, suspended_until, suspension_reason, password_reset_required, version
+FROM users
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at ASC
+LIMIT $1 OFFSET $2;
+
+-- name: GetDeletedUserCount :one
+SELECT COUNT(*) as count
+FROM users
+WHERE deleted_at IS NOT NULL;
+
+-- name: PurgeDeletedUsers :execrows
+DELETE FROM users
+WHERE deleted_at IS NOT NULL AND deleted_at < $1;
+
+-- name: PurgeDeletedUser :execrows
+DELETE FROM users
+WHERE id = $1 AND deleted_at IS NOT NULL AND deleted_at < $2;
+
+-- name: UpdatePassword :exec
+UPDATE users
+SET password_hash = $1, password_reset_required = $2, version = version + 1, updated_at = NOW()
+WHERE id = $3 AND deleted_at IS NULL;
+
+-- name: UpdateUserRole :exec
+UPDATE users
+SET role = $1, version = version + 1, updated_at = NOW()
+WHERE id = $2 AND deleted_at IS NULL;
+
+-- name: SuspendUser :exec
+UPDATE users
+SET is_active = FALSE, suspended_at = NOW(), suspended_until = $1, suspension_reason = $2, version = version + 1, updated_at = NOW()
+WHERE id = $3 AND deleted_at IS NULL;
+
+-- name: ReactivateUser :exec
+UPDATE users
+SET is_active = TRUE, suspended_at = NULL, suspended_until = NULL, suspension_reason = NULL, version = version + 1, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
@@ -0,0 +1,52 @@
+-- SQL queries for jobs domain
+
+-- name: CreateJob :exec
+INSERT INTO jobs (id, job_type, payload, priority, max_attempts, run_at, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetJob :one
+SELECT id, job_type, payload, priority, status, attempts, max_attempts, run_at, locked_by, locked_until, last_error, created_at, updated_at, completed_at
+FROM jobs
+WHERE id = ?;
+
+-- name: ClaimJob :exec
+-- Marks a job selected by the claiming transaction, which holds the database write lock, as running; must run in the same transaction
+UPDATE jobs
+SET status = 'running', attempts = attempts + 1, locked_by = ?, locked_until = ?
+WHERE id = ?;
+
+-- name: CompleteJob :execrows
+UPDATE jobs
+SET status = 'succeeded', locked_by = NULL, locked_until = NULL, last_error = NULL, completed_at = ?
+WHERE id = ? AND status = 'running' AND locked_by = ?;
+
+-- name: RescheduleJob :execrows
+UPDATE jobs
+SET status = 'pending', run_at = ?, last_error = ?, locked_by = NULL, locked_until = NULL
+WHERE id = ? AND status = 'running' AND locked_by = ?;
+
+-- name: DeadLetterJob :execrows
+UPDATE jobs
+SET status = 'dead', completed_at = ?, last_error = ?, locked_by = NULL, locked_until = NULL
+WHERE id = ? AND status = 'running' AND locked_by = ?;
+
+-- name: ReleaseExpiredJobs :execrows
+-- Returns running jobs past their visibility timeout to the queue while attempts remain
+UPDATE jobs
+SET status = 'pending', last_error = 'visibility timeout expired', locked_by = NULL, locked_until = NULL
+WHERE status = 'running' AND locked_until <= ? AND attempts < max_attempts;
+
+-- name: DeadLetterExpiredJobs :execrows
+-- Dead-letters running jobs past their visibility timeout that have no attempts left
+UPDATE jobs
+SET status = 'dead', completed_at = ?, last_error = 'visibility timeout expired', locked_by = NULL, locked_until = NULL
+WHERE status = 'running' AND locked_until <= ? AND attempts >= max_attempts;
+
+-- name: RetryJob :execrows
+UPDATE jobs
+SET status = 'pending', attempts = 0, run_at = ?, last_error = NULL, completed_at = NULL
+WHERE id = ? AND status = 'dead';
+
+-- name: DeleteSucceededJobs :execrows
+DELETE FROM jobs
+WHERE status = 'succeeded' AND completed_at < ?;
@@ -0,0 +1,33 @@
+-- SQL queries for user session domain
+
+-- name: CreateSession :exec
+INSERT INTO user_sessions (id, user_id, refresh_token_hash, ip_address, user_agent, expires_at, created_at)
+VALUES (?, ?, ?, ?, ?, ?, CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+
+-- name: GetSessionByID :one
+SELECT id, user_id, refresh_token_hash, ip_address, user_agent, expires_at, created_at
+FROM user_sessions
+WHERE id = ? AND expires_at > CAST(unixepoch('subsec') * 1000000 AS INTEGER);
+
+-- name: GetSessionByUserID :many
+SELECT id, user_id, refresh_token_hash, ip_address, user_agent, expires_at, created_at
+FROM user_sessions
+WHERE user_id = ? AND expires_at > CAST(unixepoch('subsec') * 1000000 AS INTEGER)
+ORDER BY created_at DESC;
+
+-- name: DeleteSession :exec
+DELETE FROM user_sessions
+WHERE id = ?;
+
+-- name: DeleteExpiredSessions :execrows
+DELETE FROM user_sessions
+WHERE expires_at <= CAST(unixepoch('subsec') * 1000000 AS INTEGER);
+
+-- name: GetSessionByTokenHash :one
+SELECT id, user_id, refresh_token_hash, ip_address, user_agent, expires_at, created_at
+FROM user_sessions
+WHERE refresh_token_hash = ? AND expires_at > CAST(unixepoch('subsec') * 1000000 AS INTEGER);
+
+-- name: DeleteSessionsByUserID :execrows
+DELETE FROM user_sessions
+WHERE user_id = ?;
@@ -0,0 +1,89 @@
+-- SQL queries for user domain
+
+-- name: CreateUser :exec
+INSERT INTO users (id, email, name, password_hash, is_active, role, password_reset_required, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, CAST(unixepoch('subsec') * 1000000 AS INTEGER), CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+
+-- name: GetUserByID :one
+SELECT id, email, name, password_hash, is_active, created_at, updated_at, deleted_at, role, suspended_at, suspended_until, suspension_reason, password_reset_required, version
+FROM users
+WHERE id = ? AND deleted_at IS NULL;
+
+-- name: GetUserByEmail :one
+SELECT id, email, name, password_hash, is_active, created_at, updated_at, deleted_at, role, suspended_at, suspended_until, suspension_reason, password_reset_required, version
+FROM users
+WHERE email = ? AND deleted_at IS NULL;
+
+-- name: UpdateUser :execrows
+UPDATE users
+SET name = ?, email = ?, version = version + 1, updated_at = CAST(unixepoch('subsec') * 1000000 AS INTEGER)
+WHERE id = ? AND version = ? AND deleted_at IS NULL;
+
+-- name: DeleteUser :exec
+UPDATE users
+SET deleted_at = CAST(unixepoch('subsec') * 1000000 AS INTEGER), version = version + 1, updated_at = CAST(unixepoch('subsec') * 1000000 AS INTEGER)
+WHERE id = ? AND deleted_at IS NULL;
+
+-- name: ListUsers :many
+SELECT id, email, name, password_hash, is_active, created_at, updated_at, deleted_at, role, suspended_at, suspended_until, suspension_reason, password_reset_required, version
+FROM users
+WHERE deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT ? OFFSET ?;
+
+-- name: GetUserCount :one
+SELECT CAST(COUNT(*) AS BIGINT) AS count
+FROM users
+WHERE deleted_at IS NULL;
+
+-- name: GetDeletedUserByEmail :one
+SELECT id, email, name, password_hash, is_active, created_at, updated_at, deleted_at, role, suspended_at, suspended_until, suspension_reason, password_reset_required, version
+FROM users
+WHERE email = ? AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC
+LIMIT 1;
+
+-- name: RestoreUser :exec
+UPDATE users
+SET deleted_at = NULL, version = version + 1, updated_at = CAST(unixepoch('subsec') * 1000000 AS INTEGER)
+WHERE id = ? AND deleted_at IS NOT NULL;
+
+-- name: ListDeletedUsers :many
+SELECT id, email, name, password_hash, is_active, created_at, updated_at, deleted_at, role, suspended_at, suspended_until, suspension_reason, password_reset_required, version
+FROM users
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at ASC
+LIMIT ? OFFSET ?;
+
+-- name: GetDeletedUserCount :one
+SELECT CAST(COUNT(*) AS BIGINT) AS count
+FROM users
+WHERE deleted_at IS NOT NULL;
+
+-- name: PurgeDeletedUsers :execrows
+DELETE FROM users
+WHERE deleted_at IS NOT NULL AND deleted_at < ?;
+
+-- name: PurgeDeletedUser :execrows
+DELETE FROM users
+WHERE id = ? AND deleted_at IS NOT NULL AND deleted_at < ?;
+
+-- name: UpdatePassword :exec
+UPDATE users
+SET password_hash = ?, password_reset_required = ?, version = version + 1, updated_at = CAST(unixepoch('subsec') * 1000000 AS INTEGER)
+WHERE id = ? AND deleted_at IS NULL;
+
+-- name: UpdateUserRole :exec
+UPDATE users
+SET role = ?, version = version + 1, updated_at = CAST(unixepoch('subsec') * 1000000 AS INTEGER)
+WHERE id = ? AND deleted_at IS NULL;
+
+-- name: SuspendUser :exec
+UPDATE users
+SET is_active = FALSE, suspended_at = CAST(unixepoch('subsec') * 1000000 AS INTEGER), suspended_until = ?, suspension_reason = ?, version = version + 1, updated_at = CAST(unixepoch('subsec') * 1000000 AS INTEGER)
+WHERE id = ? AND deleted_at IS NULL;
+
+-- name: ReactivateUser :exec
+UPDATE users
+SET is_active = TRUE, suspended_at = NULL, suspended_until = NULL, suspension_reason = NULL, version = version + 1, updated_at = CAST(unixepoch('subsec') * 1000000 AS INTEGER)
+WHERE id = ? AND deleted_at IS NULL;
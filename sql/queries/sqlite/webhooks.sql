@@ -0,0 +1,83 @@
+-- SQL queries for webhook domain
+
+-- name: CreateWebhookSubscription :exec
+INSERT INTO webhook_subscriptions (id, url, event_types, secret, enabled)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: GetWebhookSubscription :one
+SELECT id, url, event_types, secret, enabled, consecutive_failures, disabled_at, created_at, updated_at
+FROM webhook_subscriptions
+WHERE id = ?;
+
+-- name: ListWebhookSubscriptions :many
+SELECT id, url, event_types, secret, enabled, consecutive_failures, disabled_at, created_at, updated_at
+FROM webhook_subscriptions
+ORDER BY created_at, id;
+
+-- name: ListEnabledWebhookSubscriptions :many
+SELECT id, url, event_types, secret, enabled, consecutive_failures, disabled_at, created_at, updated_at
+FROM webhook_subscriptions
+WHERE enabled = TRUE;
+
+-- name: UpdateWebhookSubscription :exec
+UPDATE webhook_subscriptions
+SET url = ?, event_types = ?, secret = ?, enabled = ?, consecutive_failures = ?, disabled_at = ?
+WHERE id = ?;
+
+-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscriptions
+WHERE id = ?;
+
+-- name: RecordWebhookSuccess :exec
+UPDATE webhook_subscriptions
+SET consecutive_failures = 0
+WHERE id = ?;
+
+-- name: RecordWebhookFailure :exec
+UPDATE webhook_subscriptions
+SET consecutive_failures = consecutive_failures + 1
+WHERE id = ?;
+
+-- name: DisableWebhookSubscription :execrows
+UPDATE webhook_subscriptions
+SET enabled = FALSE, disabled_at = CAST(unixepoch('subsec') * 1000000 AS INTEGER)
+WHERE id = ? AND enabled = TRUE AND consecutive_failures >= ?;
+
+-- name: CreateWebhookDelivery :execrows
+-- Deliveries are unique per subscription and event, so redelivered outbox events are ignored
+INSERT OR IGNORE INTO webhook_deliveries (id, subscription_id, event_id, event_type, payload, next_attempt_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: GetWebhookDelivery :one
+SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_response, last_error, last_duration_ms, delivered_at, created_at
+FROM webhook_deliveries
+WHERE id = ?;
+
+-- name: ListDueWebhookDeliveries :many
+SELECT d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.attempts, s.url, s.secret
+FROM webhook_deliveries d
+JOIN webhook_subscriptions s ON s.id = d.subscription_id
+WHERE d.status = 'pending' AND d.next_attempt_at <= ? AND s.enabled = TRUE
+ORDER BY d.next_attempt_at
+LIMIT ?;
+
+-- name: ClaimWebhookDelivery :execrows
+-- Pushes the next attempt past the lease so other workers skip the delivery while it is sent
+UPDATE webhook_deliveries
+SET next_attempt_at = sqlc.arg(lease_until)
+WHERE id = sqlc.arg(id) AND status = 'pending' AND next_attempt_at <= sqlc.arg(now);
+
+-- name: MarkWebhookDeliverySucceeded :exec
+UPDATE webhook_deliveries
+SET status = 'succeeded', attempts = attempts + 1, last_status_code = ?, last_response = ?, last_error = NULL, last_duration_ms = ?, delivered_at = ?
+WHERE id = ?;
+
+-- name: MarkWebhookDeliveryFailed :exec
+UPDATE webhook_deliveries
+SET status = ?, attempts = attempts + 1, next_attempt_at = ?, last_status_code = ?, last_response = ?, last_error = ?, last_duration_ms = ?
+WHERE id = ?;
+
+-- name: ResetWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET status = 'pending', attempts = 0, next_attempt_at = ?, delivered_at = NULL
+WHERE id = ?;